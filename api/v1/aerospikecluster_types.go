@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -49,6 +50,87 @@ const (
 	AerospikeClusterError AerospikeClusterPhase = "Error"
 )
 
+// +kubebuilder:validation:Enum=Passed;Failed;Skipped
+type WipeVerificationResult string
+
+const (
+	WipeVerificationPassed  WipeVerificationResult = "Passed"
+	WipeVerificationFailed  WipeVerificationResult = "Failed"
+	WipeVerificationSkipped WipeVerificationResult = "Skipped"
+)
+
+// VolumeWipeResult is the result of a volume wipe done by the init container.
+type VolumeWipeResult struct {
+	// Method is the wipe method used.
+	Method AerospikeVolumeMethod `json:"method"`
+
+	// Verification is the result of the verification pass. Skipped if wipe verification is disabled.
+	Verification WipeVerificationResult `json:"verification"`
+
+	// Time is the time at which the wipe completed.
+	Time metav1.Time `json:"time"`
+
+	// Message is a human-readable message with details about the wipe and its verification.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=init;wipe
+type VolumeOperationType string
+
+const (
+	VolumeOperationInit VolumeOperationType = "init"
+	VolumeOperationWipe VolumeOperationType = "wipe"
+)
+
+// +kubebuilder:validation:Enum=InProgress;Completed;Failed
+type VolumeOperationPhase string
+
+const (
+	VolumeOperationInProgress VolumeOperationPhase = "InProgress"
+	VolumeOperationCompleted  VolumeOperationPhase = "Completed"
+	VolumeOperationFailed     VolumeOperationPhase = "Failed"
+)
+
+// VolumeOperationProgress is the progress of a volume init or wipe operation reported by the init container.
+type VolumeOperationProgress struct {
+	// Operation is the type of operation running on the volume.
+	Operation VolumeOperationType `json:"operation"`
+
+	// Method is the init or wipe method used.
+	Method AerospikeVolumeMethod `json:"method"`
+
+	// Phase is the phase of the operation.
+	Phase VolumeOperationPhase `json:"phase"`
+
+	// BytesDone is the number of bytes processed so far.
+	// Only reported for methods that write the volume, like dd.
+	// +optional
+	BytesDone int64 `json:"bytesDone,omitempty"`
+
+	// TotalBytes is the number of bytes to process, accounting for all passes of the method.
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
+	// BytesPerSecond is the average throughput of the operation.
+	// +optional
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+
+	// StartTime is the time at which the operation started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// EstimatedCompletionTime is the estimated time at which the operation completes, based on its throughput.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// LastUpdateTime is the time at which the progress was last reported.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// Message is a human-readable message with details about the operation.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:validation:Enum=Failed;PartiallyFailed;""
 type DynamicConfigUpdateStatus string
 
@@ -92,6 +174,12 @@ type AerospikeClusterSpec struct { //nolint:govet // for readability
 	// +optional
 	Storage AerospikeStorageSpec `json:"storage,omitempty"`
 
+	// VolumeSnapshotRestore provisions the persistent volumes of a new cluster from a snapshot set
+	// taken by an AerospikeVolumeSnapshotBackup. It is only used while creating the racks of the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Snapshot Restore"
+	// +optional
+	VolumeSnapshotRestore *VolumeSnapshotRestoreSpec `json:"volumeSnapshotRestore,omitempty"`
+
 	// Has the Aerospike roles and users definitions. Required if aerospike cluster security is enabled.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Access Control"
 	// +optional
@@ -102,6 +190,20 @@ type AerospikeClusterSpec struct { //nolint:govet // for readability
 	// +kubebuilder:pruning:PreserveUnknownFields
 	AerospikeConfig *AerospikeConfigSpec `json:"aerospikeConfig"`
 
+	// AerospikeConfigVariables are the variables referenced in the aerospikeConfig string values as <var:name>.
+	// They are resolved for each pod by the init container, from the pod, its k8s node, Secrets and ConfigMaps,
+	// or computed from the container resources. The pod fields, the container resources, the Secrets and the
+	// ConfigMaps are set by k8s in the init container env vars. The values must not contain whitespace, '{', '}'
+	// or '#'. A change of the variables warm restarts the pods, or restarts them if a config using a changed
+	// variable needs it, like the storage-engine data-size.
+	// Requires an init image shipping the aerospike-pod-init binary, built with Dockerfile.init, so these are rejected
+	// with the default init image.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Aerospike Config Variables"
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AerospikeConfigVariables []AerospikeConfigVariable `json:"aerospikeConfigVariables,omitempty"`
+
 	// EnableDynamicConfigUpdate enables dynamic config update flow of the operator.
 	// If enabled, operator will try to update the Aerospike config dynamically.
 	// In case of inconsistent state during dynamic config update, operator falls back to rolling restart.
@@ -125,6 +227,36 @@ type AerospikeClusterSpec struct { //nolint:govet // for readability
 	// +optional
 	AerospikeNetworkPolicy AerospikeNetworkPolicy `json:"aerospikeNetworkPolicy,omitempty"`
 
+	// NetworkIsolation creates a Kubernetes NetworkPolicy restricting the traffic to the Aerospike pods.
+	// The NetworkPolicy is deleted if this is removed.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Isolation"
+	// +optional
+	NetworkIsolation *NetworkIsolationSpec `json:"networkIsolation,omitempty"`
+
+	// GatewayTLSRoute exposes the TLS service port of each pod through a Gateway API TLSRoute with TLS passthrough.
+	// Used with the 'gatewayTLSRoute' tlsAlternateAccess network type.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway TLSRoute"
+	// +optional
+	GatewayTLSRoute *GatewayTLSRouteSpec `json:"gatewayTLSRoute,omitempty"`
+
+	// ServiceIPFamily configures the IP families of the headless, LoadBalancer and pod services,
+	// e.g. for dual-stack clusters. The cluster defaults are used if not given.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service IP Family"
+	// +optional
+	ServiceIPFamily *ServiceIPFamilySpec `json:"serviceIPFamily,omitempty"`
+
+	// Federation stretches the Aerospike cluster across the AerospikeClusters in other Kubernetes clusters.
+	// Each member deploys only its own racks, and all the members form a single Aerospike cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Federation"
+	// +optional
+	Federation *FederationSpec `json:"federation,omitempty"`
+
+	// HeartbeatSeeds configures the heartbeat mesh seeds given to the Aerospike pods.
+	// Defaults to all the pods of the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Heartbeat Seeds"
+	// +optional
+	HeartbeatSeeds *HeartbeatSeedsSpec `json:"heartbeatSeeds,omitempty"`
+
 	// Certificates to connect to Aerospike.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Operator Client Cert"
 	// +optional
@@ -148,12 +280,13 @@ type AerospikeClusterSpec struct { //nolint:govet // for readability
 	HeadlessService ServiceSpec `json:"headlessService,omitempty"`
 
 	// PodService defines additional configuration parameters for the pod service created to expose the
-	// Aerospike Cluster nodes outside the Kubernetes cluster. This service is created only created when
+	// Aerospike Cluster nodes outside the Kubernetes cluster. This service is created only when
+	// `aerospikeNetworkPolicy` has one of the network types: 'podLoadBalancer', 'podNodePort', 'gatewayTLSRoute', or when
 	// `multiPodPerHost` is set to `true` and `aerospikeNetworkPolicy` has one of the network types:
 	// 'hostInternal', 'hostExternal', 'configuredIP'
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Service"
 	// +optional
-	PodService ServiceSpec `json:"podService,omitempty"`
+	PodService PodServiceSpec `json:"podService,omitempty"`
 
 	// RosterNodeBlockList is a list of blocked nodeIDs from roster in a strong-consistency setup
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Roster Node BlockList"
@@ -180,6 +313,15 @@ type AerospikeClusterSpec struct { //nolint:govet // for readability
 	Operations []OperationSpec `json:"operations,omitempty"`
 }
 
+// VolumeSnapshotRestoreSpec refers to a snapshot set of an AerospikeVolumeSnapshotBackup.
+type VolumeSnapshotRestoreSpec struct {
+	// BackupName is the name of the AerospikeVolumeSnapshotBackup in the cluster namespace.
+	BackupName string `json:"backupName"`
+
+	// SnapshotSetID is the ID of the completed snapshot set to restore.
+	SnapshotSetID string `json:"snapshotSetID"`
+}
+
 type OperationKind string
 
 const (
@@ -253,6 +395,35 @@ type ServiceSpec struct {
 	Metadata AerospikeObjectMeta `json:"metadata,omitempty"`
 }
 
+// PodServiceSpec contains specification customizations for the Kubernetes service created per pod.
+// +k8s:openapi-gen=true
+type PodServiceSpec struct { //nolint:govet // for readability
+	// The annotation values can use the variables {{.PodName}}, {{.RackID}}, {{.ClusterName}} and {{.Namespace}},
+	// which are expanded per pod, e.g. external-dns.alpha.kubernetes.io/hostname: {{.PodName}}.example.com
+	ServiceSpec `json:",inline"`
+
+	// ExternalTrafficPolicy of the pod service. Defaults to Local.
+	// +kubebuilder:validation:Enum=Local;Cluster
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation of the pod service.
+	// Used only with the 'podLoadBalancer' network type. It can not be updated.
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IPs allowed by the load balancer of the pod service.
+	// Used only with the 'podLoadBalancer' network type.
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// ExternalAddress is the address advertised with the 'podNodePort' network type.
+	// It can use the same variables as the annotations, e.g. {{.PodName}}.example.com
+	// Defaults to the external IP of the Kubernetes host of the pod.
+	// +optional
+	ExternalAddress string `json:"externalAddress,omitempty"`
+}
+
 type AerospikeOperatorClientCertSpec struct { //nolint:govet // for readability
 	// If specified, this name will be added to tls-authenticate-client list by the operator
 	// +optional
@@ -368,6 +539,12 @@ type AerospikePodSpec struct { //nolint:govet // for readability
 	// +optional
 	HostNetwork bool `json:"hostNetwork,omitempty"`
 
+	// ServiceMesh makes the pods compatible with the sidecar of the given service mesh.
+	// The heartbeat, fabric and admin ports are excluded from the sidecar traffic redirection,
+	// and the operator info calls use the admin port, if configured.
+	// +optional
+	ServiceMesh *ServiceMeshSpec `json:"serviceMesh,omitempty"`
+
 	// DnsPolicy same as https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-s-dns-policy.
 	// If hostNetwork is true and policy is not specified, it defaults to ClusterFirstWithHostNet
 	// +optional
@@ -394,6 +571,21 @@ type AerospikePodSpec struct { //nolint:govet // for readability
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
+// ServiceMeshProvider is the service mesh injecting the sidecar in the Aerospike pods.
+// +kubebuilder:validation:Enum=istio;linkerd
+type ServiceMeshProvider string
+
+const (
+	ServiceMeshIstio   ServiceMeshProvider = "istio"
+	ServiceMeshLinkerd ServiceMeshProvider = "linkerd"
+)
+
+// ServiceMeshSpec specifies the service mesh compatibility mode of the Aerospike pods.
+type ServiceMeshSpec struct {
+	// Provider is the service mesh injecting the sidecar in the Aerospike pods.
+	Provider ServiceMeshProvider `json:"provider"`
+}
+
 type AerospikeContainerSpec struct {
 	// SecurityContext that will be added to aerospike-server container created by operator.
 	// +optional
@@ -646,6 +838,20 @@ const (
 	// should be initialized by deleting files.
 	AerospikeVolumeMethodDeleteFiles AerospikeVolumeMethod = "deleteFiles"
 
+	// AerospikeVolumeMethodMultiPassOverwrite specifies the block volume should be overwritten twice with random data
+	// and then zeroed using the dd command.
+	AerospikeVolumeMethodMultiPassOverwrite AerospikeVolumeMethod = "multiPassOverwrite"
+
+	// AerospikeVolumeMethodNvmeSanitize specifies the block volume should be erased using the NVMe sanitize command.
+	// The block erase, overwrite or crypto erase sanitize action is used, in this order, as supported by the device.
+	// The device should be an NVMe namespace and the init container image should have nvme-cli.
+	AerospikeVolumeMethodNvmeSanitize AerospikeVolumeMethod = "nvmeSanitize"
+
+	// AerospikeVolumeMethodCryptoErase specifies the block volume should be erased by destroying its media
+	// encryption key using the NVMe format command with secure erase setting crypto erase.
+	// The device should be an NVMe namespace and the init container image should have nvme-cli.
+	AerospikeVolumeMethodCryptoErase AerospikeVolumeMethod = "cryptoErase"
+
 	// AerospikeVolumeSingleCleanupThread specifies the single thread
 	// for disks cleanup in init container.
 	AerospikeVolumeSingleCleanupThread int = 1
@@ -662,10 +868,17 @@ type AerospikePersistentVolumePolicySpec struct {
 
 	// WipeMethod determines how volumes attached to Aerospike server pods are wiped for dealing with storage format
 	// changes.
-	// +kubebuilder:validation:Enum=dd;blkdiscard;deleteFiles
+	// +kubebuilder:validation:Enum=dd;blkdiscard;deleteFiles;multiPassOverwrite;nvmeSanitize;cryptoErase
 	// +optional
 	InputWipeMethod *AerospikeVolumeMethod `json:"wipeMethod,omitempty"`
 
+	// WipeVerification enables a verification pass after wiping a volume.
+	// For block volumes, blocks sampled before the wipe are read again and checked to be zeroed or changed.
+	// For filesystem volumes, the deleted files are checked to be absent.
+	// The result is recorded in the pod status. Defaults to false.
+	// +optional
+	InputWipeVerification *bool `json:"wipeVerification,omitempty"`
+
 	// CascadeDelete determines if the persistent volumes are deleted after the pod this volume binds to is
 	// terminated and removed from the cluster.
 	// +optional
@@ -678,9 +891,13 @@ type AerospikePersistentVolumePolicySpec struct {
 
 	// Effective/operative value to use as the volume wipe method after applying defaults.
 	// +optional
-	// +kubebuilder:validation:Enum=dd;blkdiscard;deleteFiles
+	// +kubebuilder:validation:Enum=dd;blkdiscard;deleteFiles;multiPassOverwrite;nvmeSanitize;cryptoErase
 	WipeMethod AerospikeVolumeMethod `json:"effectiveWipeMethod,omitempty"`
 
+	// Effective/operative value to use for wipe verification after applying defaults.
+	// +optional
+	WipeVerification bool `json:"effectiveWipeVerification,omitempty"`
+
 	// Effective/operative value to use for cascade delete after applying defaults.
 	// +optional
 	CascadeDelete bool `json:"effectiveCascadeDelete,omitempty"`
@@ -840,6 +1057,14 @@ type AerospikeStorageSpec struct { //nolint:govet // for readability
 	// +optional
 	DeleteLocalStorageOnRestart *bool `json:"deleteLocalStorageOnRestart,omitempty"`
 
+	// SnapshotBootstrap enables provisioning of re-created persistent volumes from CSI VolumeSnapshots.
+	// When AKO deletes the PVCs of a pod (k8sNodeBlockList move or deleteLocalStorageOnRestart), the replacement
+	// PVCs are provisioned from a snapshot of a healthy replica in the same rack, so that the new node starts
+	// with warm data and only migrates the difference.
+	// The re-created pods are held by a scheduling gate until their PVCs are provisioned from the snapshots.
+	// +optional
+	SnapshotBootstrap *SnapshotBootstrapSpec `json:"snapshotBootstrap,omitempty"`
+
 	// Volumes list to attach to created pods.
 	// +patchMergeKey=name
 	// +patchStrategy=merge
@@ -849,6 +1074,24 @@ type AerospikeStorageSpec struct { //nolint:govet // for readability
 	Volumes []VolumeSpec `json:"volumes,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 }
 
+// SnapshotBootstrapSpec configures how re-created persistent volumes are bootstrapped from CSI VolumeSnapshots.
+type SnapshotBootstrapSpec struct {
+	// VolumeSnapshotClassName is the name of the VolumeSnapshotClass used for the snapshots taken by AKO.
+	// The CSI driver of this class must be the one provisioning the bootstrapped volumes.
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+
+	// MaxSnapshotAge is the maximum age of an existing ready VolumeSnapshot of the volume in the same rack
+	// (e.g. a scheduled snapshot) that can be used instead of taking a new one.
+	// If not specified, a new snapshot of a healthy replica is always taken.
+	// +optional
+	MaxSnapshotAge *metav1.Duration `json:"maxSnapshotAge,omitempty"`
+
+	// Volumes is the list of storage volume names to bootstrap from snapshots.
+	// Defaults to all persistent volumes attached to the Aerospike server container.
+	// +optional
+	Volumes []string `json:"volumes,omitempty"`
+}
+
 // AerospikeClusterStatusSpec captures the current status of the cluster.
 type AerospikeClusterStatusSpec struct { //nolint:govet // for readability
 	// Aerospike cluster size
@@ -898,6 +1141,10 @@ type AerospikeClusterStatusSpec struct { //nolint:govet // for readability
 	// +optional
 	AerospikeConfig *AerospikeConfigSpec `json:"aerospikeConfig,omitempty"`
 
+	// AerospikeConfigVariables are the variables referenced in the aerospikeConfig string values.
+	// +optional
+	AerospikeConfigVariables []AerospikeConfigVariable `json:"aerospikeConfigVariables,omitempty"`
+
 	// EnableDynamicConfigUpdate enables dynamic config update flow of the operator.
 	// If enabled, operator will try to update the Aerospike config dynamically.
 	// In case of inconsistent state during dynamic config update, operator falls back to rolling restart.
@@ -931,6 +1178,26 @@ type AerospikeClusterStatusSpec struct { //nolint:govet // for readability
 	// +optional
 	AerospikeNetworkPolicy AerospikeNetworkPolicy `json:"aerospikeNetworkPolicy,omitempty"`
 
+	// NetworkIsolation specifies the Kubernetes NetworkPolicy created for the Aerospike pods.
+	// +optional
+	NetworkIsolation *NetworkIsolationSpec `json:"networkIsolation,omitempty"`
+
+	// GatewayTLSRoute specifies the Gateway API TLSRoutes created for the pods.
+	// +optional
+	GatewayTLSRoute *GatewayTLSRouteSpec `json:"gatewayTLSRoute,omitempty"`
+
+	// ServiceIPFamily specifies the IP families of the services created for the cluster.
+	// +optional
+	ServiceIPFamily *ServiceIPFamilySpec `json:"serviceIPFamily,omitempty"`
+
+	// Federation specifies the AerospikeClusters in other Kubernetes clusters forming the same Aerospike cluster.
+	// +optional
+	Federation *FederationSpec `json:"federation,omitempty"`
+
+	// HeartbeatSeeds specifies the heartbeat mesh seeds given to the Aerospike pods.
+	// +optional
+	HeartbeatSeeds *HeartbeatSeedsSpec `json:"heartbeatSeeds,omitempty"`
+
 	// Certificates to connect to Aerospike. If omitted then certs are taken from the secret 'aerospike-secret'.
 	// +optional
 	OperatorClientCertSpec *AerospikeOperatorClientCertSpec `json:"operatorClientCertSpec,omitempty"`
@@ -953,7 +1220,7 @@ type AerospikeClusterStatusSpec struct { //nolint:govet // for readability
 	// `multiPodPerHost` is set to `true` and `aerospikeNetworkPolicy` has one of the network types:
 	// 'hostInternal', 'hostExternal', 'configuredIP'
 	// +optional
-	PodService ServiceSpec `json:"podService,omitempty"`
+	PodService PodServiceSpec `json:"podService,omitempty"`
 
 	// RosterNodeBlockList is a list of blocked nodeIDs from roster in a strong-consistency setup
 	// +optional
@@ -982,12 +1249,20 @@ type AerospikeClusterStatus struct { //nolint:govet // for readability
 	// Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
 
 	// Pods has Aerospike specific status of the pods.
-	// This is map instead of the conventional map as list convention to allow each pod to patch update its own
-	// status. The map key is the name of the pod.
+	// This is map instead of the conventional map as list convention to allow patch updating the status of each pod.
+	// Each pod publishes its status in its pod status ConfigMap, which the operator folds into this map.
+	// The map key is the name of the pod.
 	// +patchStrategy=strategic
 	// +optional
 	Pods map[string]AerospikePodStatus `json:"pods" patchStrategy:"strategic"`
 
+	// VolumeOperations has the progress of volume init and wipe operations running in the pod init containers.
+	// The map key is the name of the pod and the value is keyed by the volume name.
+	// Each pod publishes its progress in its pod status ConfigMap, which the operator folds into this map. The entry is
+	// removed once all the volume operations of the pod complete.
+	// +optional
+	VolumeOperations map[string]map[string]VolumeOperationProgress `json:"volumeOperations,omitempty"`
+
 	// Phase denotes the current phase of Aerospike cluster operation.
 	// +optional
 	Phase AerospikeClusterPhase `json:"phase,omitempty"`
@@ -1025,16 +1300,238 @@ const (
 
 	// AerospikeNetworkTypeCustomInterface specifies any other custom interface to be used with Aerospike
 	AerospikeNetworkTypeCustomInterface AerospikeNetworkType = "customInterface"
+
+	// AerospikeNetworkTypePodLoadBalancer specifies access using the ingress IP or hostname of the LoadBalancer
+	// service created per pod, and the actual Aerospike service port.
+	AerospikeNetworkTypePodLoadBalancer AerospikeNetworkType = "podLoadBalancer"
+
+	// AerospikeNetworkTypePodNodePort specifies access using the podService externalAddress, or the Kubernetes
+	// host's external IP, and the node port of the NodePort service created per pod.
+	AerospikeNetworkTypePodNodePort AerospikeNetworkType = "podNodePort"
+
+	// AerospikeNetworkTypeGatewayTLSRoute specifies access using the SNI hostname and the listener port of the
+	// Gateway API TLSRoute created per pod. Only allowed for the TLS alternate access.
+	AerospikeNetworkTypeGatewayTLSRoute AerospikeNetworkType = "gatewayTLSRoute"
 )
 
+// NetworkIsolationSpec specifies the ingress traffic allowed to the Aerospike pods by the Kubernetes NetworkPolicy
+// created by the operator. The ports are taken from the Aerospike config.
+// The heartbeat and fabric ports are open only to the pods of the cluster.
+type NetworkIsolationSpec struct {
+	// ClientPeers are allowed to access the service and TLS service ports.
+	// The service ports are open to all the clients if not given.
+	// +optional
+	ClientPeers []networkingv1.NetworkPolicyPeer `json:"clientPeers,omitempty"`
+
+	// OperatorNamespace is the namespace of the operator.
+	// It is allowed to access the service, TLS service, admin and TLS admin ports.
+	// +kubebuilder:validation:MinLength=1
+	OperatorNamespace string `json:"operatorNamespace"`
+
+	// MonitoringNamespaces are allowed to access the exporter ports.
+	// +optional
+	MonitoringNamespaces []string `json:"monitoringNamespaces,omitempty"`
+
+	// ExporterPorts are the ports of the exporter sidecars. Defaults to 9145.
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=65535
+	// +optional
+	ExporterPorts []int32 `json:"exporterPorts,omitempty"`
+}
+
+// GatewayTLSRouteSpec specifies the Gateway API TLSRoute created per pod to route the TLS connections by SNI
+// hostname to the TLS service port of the pod. The clients send the node tls-name as SNI, so the service tls-name
+// of each pod is set to its hostname, using a copy of the TLS config of the service. The TLS connections are passed
+// through to Aerospike, so the server certificate must be valid for the hostnames, as well as for the configured
+// service tls-name used by the operator. The clients must use the hostname as the TLS name of the seed hosts.
+type GatewayTLSRouteSpec struct {
+	// ParentRefs are the Gateways the TLSRoutes are attached to.
+	// The Gateways must have a TLS listener in Passthrough mode.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+
+	// Hostname is the SNI hostname of each pod, advertised as the tls-alternate-access-address and used as the
+	// service tls-name of the pod.
+	// It must be unique per pod and can use the variables {{.PodName}}, {{.RackID}}, {{.ClusterName}}
+	// and {{.Namespace}}, e.g. {{.PodName}}.aerospike.example.com
+	// +kubebuilder:validation:MinLength=1
+	Hostname string `json:"hostname"`
+
+	// Port is the port of the Gateway TLS listener, advertised as the tls-alternate-access-port. Defaults to 443.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// GatewayParentReference identifies a Gateway listener the TLSRoutes are attached to.
+type GatewayParentReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the namespace of the Aerospike cluster.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// HeartbeatSeedsStrategy is the strategy to select the heartbeat mesh seeds of the Aerospike pods.
+// +kubebuilder:validation:Enum=allPods;podsPerRack;headlessService
+type HeartbeatSeedsStrategy string
+
+const (
+	// HeartbeatSeedsAllPods seeds the FQDNs of all the pods of the cluster.
+	HeartbeatSeedsAllPods HeartbeatSeedsStrategy = "allPods"
+
+	// HeartbeatSeedsPodsPerRack seeds the FQDNs of the first pods of each rack.
+	HeartbeatSeedsPodsPerRack HeartbeatSeedsStrategy = "podsPerRack"
+
+	// HeartbeatSeedsHeadlessService seeds the DNS name of the headless service, resolved to all the pod IPs.
+	HeartbeatSeedsHeadlessService HeartbeatSeedsStrategy = "headlessService"
+)
+
+// DefaultHeartbeatSeedsPodsPerRack is the default number of seed pods per rack with the podsPerRack strategy.
+const DefaultHeartbeatSeedsPodsPerRack = 3
+
+// HeartbeatSeedsSpec specifies the heartbeat mesh seeds of the Aerospike pods. The seeds are only used to join the
+// cluster, so a change of the seeds does not restart the pods.
+type HeartbeatSeedsSpec struct {
+	// Strategy to select the seeds. Defaults to allPods.
+	// The podsPerRack and headlessService strategies keep the seeds bounded for large clusters,
+	// and not changed by the scale up and down of the racks.
+	// +optional
+	Strategy HeartbeatSeedsStrategy `json:"strategy,omitempty"`
+
+	// PodsPerRack is the number of pods of each rack used as seeds with the podsPerRack strategy.
+	// The pods with the lowest ordinals are used, as they are the last ones removed on scale down. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PodsPerRack int32 `json:"podsPerRack,omitempty"`
+}
+
+// AerospikeConfigVariable is a variable referenced in the aerospikeConfig string values as <var:name>, resolved
+// for each pod by the init container. Exactly one of value, expression and valueFrom must be set.
+type AerospikeConfigVariable struct {
+	// Name of the variable.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Value is the literal value of the variable.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Expression computes a size from the variables defined before this one, like "memoryLimit * 60%".
+	// Supports the +, -, *, / operators, parentheses, numbers, quantities like 4Gi, percentages and the min and
+	// max functions. The variables must resolve to numbers or quantities. The result is rounded down to an integer.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+
+	// ValueFrom is the source of the value of the variable.
+	// +optional
+	ValueFrom *AerospikeConfigVariableSource `json:"valueFrom,omitempty"`
+}
+
+// AerospikeConfigVariableSource is the source of the value of an AerospikeConfigVariable.
+// Exactly one of its fields must be set.
+type AerospikeConfigVariableSource struct {
+	// FieldRef selects a field of the pod: metadata.name, metadata.namespace, metadata.uid,
+	// metadata.labels['<key>'], metadata.annotations['<key>'], spec.nodeName, spec.serviceAccountName,
+	// status.hostIP and status.podIP.
+	// +optional
+	FieldRef *corev1.ObjectFieldSelector `json:"fieldRef,omitempty"`
+
+	// ResourceFieldRef selects a resource of a container of the pod, the aerospike-server container by default.
+	// The allocatable resource of the k8s node is used if the limit is not set.
+	// +optional
+	ResourceFieldRef *corev1.ResourceFieldSelector `json:"resourceFieldRef,omitempty"`
+
+	// NodeLabel selects a label of the k8s node of the pod, like node.kubernetes.io/instance-type.
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret in the AerospikeCluster namespace.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the AerospikeCluster namespace.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// FederationSpec specifies the members of an Aerospike cluster stretched across Kubernetes clusters.
+// The AerospikeClusters of all the members must have the same name, used as the Aerospike cluster-name.
+// The pod IPs must be routable across the Kubernetes clusters, and all the members must use the same
+// Aerospike network config. The pods of the other members are added as heartbeat mesh seeds, and are included
+// in the cluster wide operations like quiesce and the cluster stability checks.
+type FederationSpec struct {
+	// Members are the AerospikeClusters in the other Kubernetes clusters forming the same Aerospike cluster.
+	// +kubebuilder:validation:MinItems=1
+	Members []FederationMember `json:"members"`
+
+	// RosterManager must be true for exactly one member of the federation. Only this member sets the roster of the
+	// strong consistency namespaces, and only when all the members are ready, so that the nodes of a member being
+	// updated are not removed from the roster by another member.
+	// +optional
+	RosterManager bool `json:"rosterManager,omitempty"`
+}
+
+// FederationMember is a reference to the AerospikeCluster of a federation member, having the same name as
+// this AerospikeCluster.
+type FederationMember struct {
+	// Namespace is the namespace of the AerospikeCluster. Defaults to the namespace of this AerospikeCluster.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// KubeconfigSecretName is the name of a secret in the AerospikeCluster namespace having a kubeconfig
+	// in the "kubeconfig" key. It is used to access the AerospikeCluster in another Kubernetes cluster.
+	// If not set, the AerospikeCluster is in the same Kubernetes cluster as the operator.
+	// +optional
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+
+	// RackIDs are the IDs of the racks owned by the member. The racks of a federation must be owned by only
+	// one member.
+	// +kubebuilder:validation:MinItems=1
+	RackIDs []int `json:"rackIDs"`
+
+	// PodCIDRs are the CIDRs of the pod IPs of the member, and of the source IPs of its operator.
+	// They are allowed to access the heartbeat, fabric and service ports by the NetworkPolicy generated with
+	// networkIsolation. Required with networkIsolation for a member in another Kubernetes cluster.
+	// +optional
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
+}
+
+// ServiceIPFamilySpec specifies the IP families of the Kubernetes services created by the operator.
+type ServiceIPFamilySpec struct {
+	// IPFamilyPolicy of the services.
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies of the services. The first family is the primary family and cannot be changed.
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=IPv4;IPv6
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+}
+
 // AerospikeNetworkPolicy specifies how clients and tools access the Aerospike cluster.
 type AerospikeNetworkPolicy struct {
 	// AccessType is the type of network address to use for Aerospike access address.
 	// Defaults to hostInternal.
-	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface
+	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface;podLoadBalancer;podNodePort
 	// +optional
 	AccessType AerospikeNetworkType `json:"access,omitempty"`
 
+	// AccessIPFamily is the IP family of the pod or host IP used for Aerospike access address on dual-stack clusters.
+	// Defaults to the primary IP family of the pod or host.
+	// Only applicable to pod, hostInternal and hostExternal access types.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	AccessIPFamily corev1.IPFamily `json:"accessIPFamily,omitempty"`
+
 	// CustomAccessNetworkNames is the list of the pod's network interfaces used for Aerospike access address.
 	// Each element in the list is specified with a namespace and the name of a NetworkAttachmentDefinition,
 	// separated by a forward slash (/).
@@ -1047,10 +1544,17 @@ type AerospikeNetworkPolicy struct {
 
 	// AlternateAccessType is the type of network address to use for Aerospike alternate access address.
 	// Defaults to hostExternal.
-	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface
+	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface;podLoadBalancer;podNodePort
 	// +optional
 	AlternateAccessType AerospikeNetworkType `json:"alternateAccess,omitempty"`
 
+	// AlternateAccessIPFamily is the IP family of the pod or host IP used for Aerospike alternate access address on dual-stack clusters.
+	// Defaults to the primary IP family of the pod or host.
+	// Only applicable to pod, hostInternal and hostExternal alternate access types.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	AlternateAccessIPFamily corev1.IPFamily `json:"alternateAccessIPFamily,omitempty"`
+
 	// CustomAlternateAccessNetworkNames is the list of the pod's network interfaces used for Aerospike
 	// alternate access address.
 	// Each element in the list is specified with a namespace and the name of a NetworkAttachmentDefinition,
@@ -1064,10 +1568,17 @@ type AerospikeNetworkPolicy struct {
 
 	// TLSAccessType is the type of network address to use for Aerospike TLS access address.
 	// Defaults to hostInternal.
-	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface
+	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface;podLoadBalancer;podNodePort
 	// +optional
 	TLSAccessType AerospikeNetworkType `json:"tlsAccess,omitempty"`
 
+	// TLSAccessIPFamily is the IP family of the pod or host IP used for Aerospike TLS access address on dual-stack clusters.
+	// Defaults to the primary IP family of the pod or host.
+	// Only applicable to pod, hostInternal and hostExternal TLS access types.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	TLSAccessIPFamily corev1.IPFamily `json:"tlsAccessIPFamily,omitempty"`
+
 	// CustomTLSAccessNetworkNames is the list of the pod's network interfaces used for Aerospike TLS access address.
 	// Each element in the list is specified with a namespace and the name of a NetworkAttachmentDefinition,
 	// separated by a forward slash (/).
@@ -1080,10 +1591,18 @@ type AerospikeNetworkPolicy struct {
 
 	// TLSAlternateAccessType is the type of network address to use for Aerospike TLS alternate access address.
 	// Defaults to hostExternal.
-	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface
+	//nolint:lll // marker
+	// +kubebuilder:validation:Enum=pod;hostInternal;hostExternal;configuredIP;customInterface;podLoadBalancer;podNodePort;gatewayTLSRoute
 	// +optional
 	TLSAlternateAccessType AerospikeNetworkType `json:"tlsAlternateAccess,omitempty"`
 
+	// TLSAlternateAccessIPFamily is the IP family of the pod or host IP used for Aerospike TLS alternate access address on dual-stack clusters.
+	// Defaults to the primary IP family of the pod or host.
+	// Only applicable to pod, hostInternal and hostExternal TLS alternate access types.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	TLSAlternateAccessIPFamily corev1.IPFamily `json:"tlsAlternateAccessIPFamily,omitempty"`
+
 	// CustomTLSAlternateAccessNetworkNames is the list of the pod's network interfaces used for Aerospike TLS
 	// alternate access address.
 	// Each element in the list is specified with a namespace and the name of a NetworkAttachmentDefinition,
@@ -1184,6 +1703,18 @@ type AerospikePodStatus struct { //nolint:govet // for readability
 	// +optional
 	HostExternalIP string `json:"hostExternalIP,omitempty"`
 
+	// PodIPs are the IPs of all the IP families of the pod in the K8s network.
+	// +optional
+	PodIPs []string `json:"podIPs,omitempty"`
+
+	// HostInternalIPs are the internal IPs of all the IP families of the K8s host this pod is scheduled on.
+	// +optional
+	HostInternalIPs []string `json:"hostInternalIPs,omitempty"`
+
+	// HostExternalIPs are the external IPs of all the IP families of the K8s host this pod is scheduled on.
+	// +optional
+	HostExternalIPs []string `json:"hostExternalIPs,omitempty"`
+
 	// PodPort is the port K8s internal Aerospike clients can connect to.
 	PodPort int `json:"podPort"`
 
@@ -1218,6 +1749,10 @@ type AerospikePodStatus struct { //nolint:govet // for readability
 	// +optional
 	DirtyVolumes []string `json:"dirtyVolumes,omitempty"`
 
+	// WipeResults is the result of the last wipe of each volume, keyed by volume name.
+	// +optional
+	WipeResults map[string]VolumeWipeResult `json:"wipeResults,omitempty"`
+
 	// AerospikeConfigHash is ripemd160 hash of aerospikeConfig used by this pod
 	AerospikeConfigHash string `json:"aerospikeConfigHash"`
 
@@ -1341,6 +1876,32 @@ func CopySpecToStatus(spec *AerospikeClusterSpec) (*AerospikeClusterStatusSpec,
 		status.DisablePDB = &disablePDB
 	}
 
+	if spec.NetworkIsolation != nil {
+		status.NetworkIsolation = lib.DeepCopy(spec.NetworkIsolation).(*NetworkIsolationSpec)
+	}
+
+	if spec.ServiceIPFamily != nil {
+		status.ServiceIPFamily = lib.DeepCopy(spec.ServiceIPFamily).(*ServiceIPFamilySpec)
+	}
+
+	if spec.Federation != nil {
+		status.Federation = lib.DeepCopy(spec.Federation).(*FederationSpec)
+	}
+
+	if spec.HeartbeatSeeds != nil {
+		status.HeartbeatSeeds = lib.DeepCopy(spec.HeartbeatSeeds).(*HeartbeatSeedsSpec)
+	}
+
+	if len(spec.AerospikeConfigVariables) != 0 {
+		configVariables := lib.DeepCopy(&spec.AerospikeConfigVariables).(*[]AerospikeConfigVariable)
+
+		status.AerospikeConfigVariables = *configVariables
+	}
+
+	if spec.GatewayTLSRoute != nil {
+		status.GatewayTLSRoute = lib.DeepCopy(spec.GatewayTLSRoute).(*GatewayTLSRouteSpec)
+	}
+
 	// Storage
 	statusPodSpec := lib.DeepCopy(&spec.PodSpec).(*AerospikePodSpec)
 	status.PodSpec = *statusPodSpec
@@ -1360,7 +1921,7 @@ func CopySpecToStatus(spec *AerospikeClusterSpec) (*AerospikeClusterStatusSpec,
 
 	podService := lib.DeepCopy(
 		&spec.PodService,
-	).(*ServiceSpec)
+	).(*PodServiceSpec)
 
 	status.PodService = *podService
 
@@ -1459,6 +2020,32 @@ func CopyStatusToSpec(status *AerospikeClusterStatusSpec) (*AerospikeClusterSpec
 		spec.DisablePDB = &disablePDB
 	}
 
+	if status.NetworkIsolation != nil {
+		spec.NetworkIsolation = lib.DeepCopy(status.NetworkIsolation).(*NetworkIsolationSpec)
+	}
+
+	if status.ServiceIPFamily != nil {
+		spec.ServiceIPFamily = lib.DeepCopy(status.ServiceIPFamily).(*ServiceIPFamilySpec)
+	}
+
+	if status.Federation != nil {
+		spec.Federation = lib.DeepCopy(status.Federation).(*FederationSpec)
+	}
+
+	if status.HeartbeatSeeds != nil {
+		spec.HeartbeatSeeds = lib.DeepCopy(status.HeartbeatSeeds).(*HeartbeatSeedsSpec)
+	}
+
+	if len(status.AerospikeConfigVariables) != 0 {
+		configVariables := lib.DeepCopy(&status.AerospikeConfigVariables).(*[]AerospikeConfigVariable)
+
+		spec.AerospikeConfigVariables = *configVariables
+	}
+
+	if status.GatewayTLSRoute != nil {
+		spec.GatewayTLSRoute = lib.DeepCopy(status.GatewayTLSRoute).(*GatewayTLSRouteSpec)
+	}
+
 	// Storage
 	specPodSpec := lib.DeepCopy(&status.PodSpec).(*AerospikePodSpec)
 
@@ -1479,7 +2066,7 @@ func CopyStatusToSpec(status *AerospikeClusterStatusSpec) (*AerospikeClusterSpec
 
 	podService := lib.DeepCopy(
 		&status.PodService,
-	).(*ServiceSpec)
+	).(*PodServiceSpec)
 
 	spec.PodService = *podService
 
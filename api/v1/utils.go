@@ -6,8 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
@@ -16,6 +19,9 @@ import (
 
 var versionRegex = regexp.MustCompile(`(\d+(\.\d+)+)`)
 
+// configVariableRefRegex matches the references to the AerospikeConfigVariables in the aerospikeConfig values.
+var configVariableRefRegex = regexp.MustCompile(`<var:([A-Za-z_][A-Za-z0-9_]*)>`)
+
 const (
 	// DefaultRackID is the ID for the default rack created when no racks are specified.
 	DefaultRackID = 0
@@ -88,7 +94,52 @@ const (
 	AerospikeRackIDLabel                           = "aerospike.com/rack-id"
 	AerospikeRackRevisionLabel                     = "aerospike.com/rack-revision"
 	AerospikeAPIVersionLabel                       = "aerospike.com/api-version"
+	AerospikePodNameLabel                          = "aerospike.com/pod-name"
+	AerospikeSnapshotTypeLabel                     = "aerospike.com/snapshot-type"
+	AerospikeSnapshotBackupLabel                   = "aerospike.com/snapshot-backup"
+	AerospikeSnapshotSetLabel                      = "aerospike.com/snapshot-set"
+	AerospikeVolumeNameLabel                       = "aerospike.com/volume-name"
 	AerospikeAPIVersion                            = "v1"
+
+	// StorageVolumeAnnotationKey is set on the PVCs to the storage volume name they are created for.
+	StorageVolumeAnnotationKey = "storage-volume"
+
+	// StorageVolumeLegacyAnnotationKey is set on the PVCs created by older operator versions to the volume path.
+	StorageVolumeLegacyAnnotationKey = "storage-path"
+
+	// PodServiceExternalAddressAnnotation is set on the pod service with the expanded podService externalAddress.
+	PodServiceExternalAddressAnnotation = "aerospike.com/external-address"
+
+	// PodServiceAddressesFileName is the rack ConfigMap key with the published address of each pod service,
+	// one "<pod-name> <address>" line per pod. The pods read their access address from it.
+	PodServiceAddressesFileName = "podServiceAddresses"
+
+	// PodStatusConfigMapSuffix is appended to the pod name for the name of the pod status ConfigMap.
+	// The operator creates one per pod, and only allows the pods to patch these ConfigMaps.
+	PodStatusConfigMapSuffix = "-status"
+
+	// PodStatusConfigMapKey is set by the pod init container in its pod status ConfigMap with the JSON of its
+	// AerospikePodStatus. The operator folds it into status.pods and removes it.
+	PodStatusConfigMapKey = "podStatus"
+
+	// VolumeOperationsConfigMapKey is set by the pod init container in its pod status ConfigMap with the JSON of the
+	// progress of its volume operations. The operator folds it into status.volumeOperations.
+	VolumeOperationsConfigMapKey = "volumeOperations"
+
+	// AerospikeConfConfigMapKey is set by the pod init container in its pod status ConfigMap with the aerospike.conf
+	// template in use, to compute the dynamic config changes.
+	AerospikeConfConfigMapKey = "aerospikeConf"
+
+	// DefaultGatewayTLSRoutePort is the default port of the Gateway TLS listener.
+	DefaultGatewayTLSRoutePort int32 = 443
+
+	// ConfigVariableEnvVarPrefix is the prefix of the init container env vars set by k8s with the values of the
+	// AerospikeConfigVariables from pod fields, container resources, Secrets and ConfigMaps.
+	ConfigVariableEnvVarPrefix = "AEROSPIKE_CONFIG_VAR_"
+
+	// GatewayTLSRouteTLSNamePlaceholder is set by the operator as the service tls-name in the aerospike.conf
+	// template, and replaced with the TLSRoute hostname of the pod by the init container.
+	GatewayTLSRouteTLSNamePlaceholder = "<gateway-tls-route-hostname>"
 )
 
 // GetConfiguredWorkDirectory returns the Aerospike work directory configured in aerospikeConfig.
@@ -127,18 +178,28 @@ func getInitContainerImage(registry, namespace, repoAndTag string) string {
 	)
 }
 
+// GetPodStatusConfigMapName returns the name of the ConfigMap in which the pod publishes its status.
+func GetPodStatusConfigMapName(podName string) string {
+	return podName + PodStatusConfigMapSuffix
+}
+
 func GetAerospikeInitContainerImage(aeroCluster *AerospikeCluster) string {
 	registry := getInitContainerImageValue(
 		aeroCluster, AerospikeInitContainerRegistryEnvVar,
 		AerospikeInitContainerDefaultRegistry,
 	)
 	namespace := getInitContainerImageRegistryNamespace(aeroCluster)
-	repoAndTag := getInitContainerImageValue(
+	repoAndTag := GetAerospikeInitContainerNameAndTag(aeroCluster)
+
+	return getInitContainerImage(registry, namespace, repoAndTag)
+}
+
+// GetAerospikeInitContainerNameAndTag returns the name and tag of the init image, without its registry and namespace.
+func GetAerospikeInitContainerNameAndTag(aeroCluster *AerospikeCluster) string {
+	return getInitContainerImageValue(
 		aeroCluster, AerospikeInitContainerNameTagEnvVar,
 		AerospikeInitContainerDefaultNameAndTag,
 	)
-
-	return getInitContainerImage(registry, namespace, repoAndTag)
 }
 
 func getInitContainerImageRegistryNamespace(aeroCluster *AerospikeCluster) string {
@@ -622,3 +683,116 @@ func IsPathParentOrSame(dir1, dir2 string) bool {
 	// Paths are unrelated.
 	return false
 }
+
+// GetPodServiceType returns the type of the service to be created per pod for the given network policy.
+// An empty type is returned if pod services are not needed.
+func GetPodServiceType(multiPodPerHost *bool, networkPolicy *AerospikeNetworkPolicy) corev1.ServiceType {
+	if networkPolicy == nil {
+		return ""
+	}
+
+	accessTypes := []AerospikeNetworkType{
+		networkPolicy.AccessType,
+		networkPolicy.TLSAccessType,
+		networkPolicy.AlternateAccessType,
+		networkPolicy.TLSAlternateAccessType,
+	}
+
+	if slices.Contains(accessTypes, AerospikeNetworkTypePodLoadBalancer) {
+		return corev1.ServiceTypeLoadBalancer
+	}
+
+	if slices.Contains(accessTypes, AerospikeNetworkTypePodNodePort) {
+		return corev1.ServiceTypeNodePort
+	}
+
+	if GetBool(multiPodPerHost) {
+		// Network types other than "pod", "customInterface" and "gatewayTLSRoute" rely on the node port of
+		// the pod service.
+		for _, accessType := range accessTypes {
+			if accessType != AerospikeNetworkTypePod && accessType != AerospikeNetworkTypeCustomInterface &&
+				accessType != AerospikeNetworkTypeGatewayTLSRoute {
+				return corev1.ServiceTypeNodePort
+			}
+		}
+	}
+
+	// The TLSRoute of the pod is backed by the pod service.
+	if networkPolicy.TLSAlternateAccessType == AerospikeNetworkTypeGatewayTLSRoute {
+		return corev1.ServiceTypeClusterIP
+	}
+
+	return ""
+}
+
+// String returns the kubeconfig secret name and the namespace identifying the federation member.
+func (m *FederationMember) String() string {
+	if m.KubeconfigSecretName != "" {
+		return fmt.Sprintf("%s:%s", m.KubeconfigSecretName, m.Namespace)
+	}
+
+	return m.Namespace
+}
+
+// GetConfigVariableRefs returns the names of the AerospikeConfigVariables referenced in the text as <var:name>.
+func GetConfigVariableRefs(text string) []string {
+	var names []string
+
+	for _, match := range configVariableRefRegex.FindAllStringSubmatch(text, -1) {
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
+// ExpandConfigVariables replaces the references to the AerospikeConfigVariables in the text with their values.
+// The values are validated with ValidateConfigVariableValue.
+func ExpandConfigVariables(text string, values map[string]string) (string, error) {
+	var err error
+
+	expanded := configVariableRefRegex.ReplaceAllStringFunc(text, func(ref string) string {
+		name := configVariableRefRegex.FindStringSubmatch(ref)[1]
+
+		value, ok := values[name]
+		if !ok {
+			err = fmt.Errorf("aerospikeConfig variable %s not defined", name)
+			return ref
+		}
+
+		if vErr := ValidateConfigVariableValue(value); vErr != nil {
+			err = fmt.Errorf("aerospikeConfig variable %s: %v", name, vErr)
+			return ref
+		}
+
+		return value
+	})
+
+	return expanded, err
+}
+
+// ValidateConfigVariableValue returns an error if the value of an AerospikeConfigVariable would change the structure
+// of the aerospike.conf once expanded: a whitespace, a newline or one of the {, } and # conf metacharacters.
+func ValidateConfigVariableValue(value string) error {
+	for _, r := range value {
+		if unicode.IsSpace(r) || strings.ContainsRune("{}#", r) {
+			return fmt.Errorf("value contains %q, not allowed in the aerospike.conf", r)
+		}
+	}
+
+	return nil
+}
+
+// IsConfigVariableFromEnv returns true if the value of the AerospikeConfigVariable is set by k8s in an init container
+// env var, named with GetConfigVariableEnvVarName.
+func IsConfigVariableFromEnv(variable *AerospikeConfigVariable) bool {
+	source := variable.ValueFrom
+
+	return source != nil && (source.FieldRef != nil || source.ResourceFieldRef != nil || source.SecretKeyRef != nil ||
+		source.ConfigMapKeyRef != nil)
+}
+
+// GetConfigVariableEnvVarName returns the name of the init container env var with the value of the
+// AerospikeConfigVariable.
+func GetConfigVariableEnvVarName(name string) string {
+	return ConfigVariableEnvVarPrefix + name
+}
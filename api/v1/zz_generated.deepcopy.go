@@ -22,6 +22,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -164,6 +165,11 @@ func (in *AerospikeClusterSpec) DeepCopyInto(out *AerospikeClusterSpec) {
 		**out = **in
 	}
 	in.Storage.DeepCopyInto(&out.Storage)
+	if in.VolumeSnapshotRestore != nil {
+		in, out := &in.VolumeSnapshotRestore, &out.VolumeSnapshotRestore
+		*out = new(VolumeSnapshotRestoreSpec)
+		**out = **in
+	}
 	if in.AerospikeAccessControl != nil {
 		in, out := &in.AerospikeAccessControl, &out.AerospikeAccessControl
 		*out = new(AerospikeAccessControlSpec)
@@ -173,6 +179,13 @@ func (in *AerospikeClusterSpec) DeepCopyInto(out *AerospikeClusterSpec) {
 		in, out := &in.AerospikeConfig, &out.AerospikeConfig
 		*out = (*in).DeepCopy()
 	}
+	if in.AerospikeConfigVariables != nil {
+		in, out := &in.AerospikeConfigVariables, &out.AerospikeConfigVariables
+		*out = make([]AerospikeConfigVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.EnableDynamicConfigUpdate != nil {
 		in, out := &in.EnableDynamicConfigUpdate, &out.EnableDynamicConfigUpdate
 		*out = new(bool)
@@ -185,6 +198,31 @@ func (in *AerospikeClusterSpec) DeepCopyInto(out *AerospikeClusterSpec) {
 	}
 	in.RackConfig.DeepCopyInto(&out.RackConfig)
 	in.AerospikeNetworkPolicy.DeepCopyInto(&out.AerospikeNetworkPolicy)
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayTLSRoute != nil {
+		in, out := &in.GatewayTLSRoute, &out.GatewayTLSRoute
+		*out = new(GatewayTLSRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceIPFamily != nil {
+		in, out := &in.ServiceIPFamily, &out.ServiceIPFamily
+		*out = new(ServiceIPFamilySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Federation != nil {
+		in, out := &in.Federation, &out.Federation
+		*out = new(FederationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeartbeatSeeds != nil {
+		in, out := &in.HeartbeatSeeds, &out.HeartbeatSeeds
+		*out = new(HeartbeatSeedsSpec)
+		**out = **in
+	}
 	if in.OperatorClientCertSpec != nil {
 		in, out := &in.OperatorClientCertSpec, &out.OperatorClientCertSpec
 		*out = new(AerospikeOperatorClientCertSpec)
@@ -239,6 +277,24 @@ func (in *AerospikeClusterStatus) DeepCopyInto(out *AerospikeClusterStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.VolumeOperations != nil {
+		in, out := &in.VolumeOperations, &out.VolumeOperations
+		*out = make(map[string]map[string]VolumeOperationProgress, len(*in))
+		for key, val := range *in {
+			var outVal map[string]VolumeOperationProgress
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]VolumeOperationProgress, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeClusterStatus.
@@ -279,6 +335,13 @@ func (in *AerospikeClusterStatusSpec) DeepCopyInto(out *AerospikeClusterStatusSp
 		in, out := &in.AerospikeConfig, &out.AerospikeConfig
 		*out = (*in).DeepCopy()
 	}
+	if in.AerospikeConfigVariables != nil {
+		in, out := &in.AerospikeConfigVariables, &out.AerospikeConfigVariables
+		*out = make([]AerospikeConfigVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.EnableDynamicConfigUpdate != nil {
 		in, out := &in.EnableDynamicConfigUpdate, &out.EnableDynamicConfigUpdate
 		*out = new(bool)
@@ -296,6 +359,31 @@ func (in *AerospikeClusterStatusSpec) DeepCopyInto(out *AerospikeClusterStatusSp
 	}
 	in.RackConfig.DeepCopyInto(&out.RackConfig)
 	in.AerospikeNetworkPolicy.DeepCopyInto(&out.AerospikeNetworkPolicy)
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayTLSRoute != nil {
+		in, out := &in.GatewayTLSRoute, &out.GatewayTLSRoute
+		*out = new(GatewayTLSRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceIPFamily != nil {
+		in, out := &in.ServiceIPFamily, &out.ServiceIPFamily
+		*out = new(ServiceIPFamilySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Federation != nil {
+		in, out := &in.Federation, &out.Federation
+		*out = new(FederationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeartbeatSeeds != nil {
+		in, out := &in.HeartbeatSeeds, &out.HeartbeatSeeds
+		*out = new(HeartbeatSeedsSpec)
+		**out = **in
+	}
 	if in.OperatorClientCertSpec != nil {
 		in, out := &in.OperatorClientCertSpec, &out.OperatorClientCertSpec
 		*out = new(AerospikeOperatorClientCertSpec)
@@ -340,6 +428,61 @@ func (in *AerospikeConfigSpec) DeepCopyInto(out *AerospikeConfigSpec) {
 	*out = *clone
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeConfigVariable) DeepCopyInto(out *AerospikeConfigVariable) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(AerospikeConfigVariableSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeConfigVariable.
+func (in *AerospikeConfigVariable) DeepCopy() *AerospikeConfigVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeConfigVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeConfigVariableSource) DeepCopyInto(out *AerospikeConfigVariableSource) {
+	*out = *in
+	if in.FieldRef != nil {
+		in, out := &in.FieldRef, &out.FieldRef
+		*out = new(corev1.ObjectFieldSelector)
+		**out = **in
+	}
+	if in.ResourceFieldRef != nil {
+		in, out := &in.ResourceFieldRef, &out.ResourceFieldRef
+		*out = new(corev1.ResourceFieldSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeConfigVariableSource.
+func (in *AerospikeConfigVariableSource) DeepCopy() *AerospikeConfigVariableSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeConfigVariableSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeContainerSpec) DeepCopyInto(out *AerospikeContainerSpec) {
 	*out = *in
@@ -558,6 +701,11 @@ func (in *AerospikePersistentVolumePolicySpec) DeepCopyInto(out *AerospikePersis
 		*out = new(AerospikeVolumeMethod)
 		**out = **in
 	}
+	if in.InputWipeVerification != nil {
+		in, out := &in.InputWipeVerification, &out.InputWipeVerification
+		*out = new(bool)
+		**out = **in
+	}
 	if in.InputCascadeDelete != nil {
 		in, out := &in.InputCascadeDelete, &out.InputCascadeDelete
 		*out = new(bool)
@@ -605,6 +753,11 @@ func (in *AerospikePodSpec) DeepCopyInto(out *AerospikePodSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMeshSpec)
+		**out = **in
+	}
 	if in.InputDNSPolicy != nil {
 		in, out := &in.InputDNSPolicy, &out.InputDNSPolicy
 		*out = new(corev1.DNSPolicy)
@@ -640,6 +793,21 @@ func (in *AerospikePodSpec) DeepCopy() *AerospikePodSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikePodStatus) DeepCopyInto(out *AerospikePodStatus) {
 	*out = *in
+	if in.PodIPs != nil {
+		in, out := &in.PodIPs, &out.PodIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostInternalIPs != nil {
+		in, out := &in.HostInternalIPs, &out.HostInternalIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostExternalIPs != nil {
+		in, out := &in.HostExternalIPs, &out.HostExternalIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Aerospike.DeepCopyInto(&out.Aerospike)
 	if in.InitializedVolumes != nil {
 		in, out := &in.InitializedVolumes, &out.InitializedVolumes
@@ -651,6 +819,13 @@ func (in *AerospikePodStatus) DeepCopyInto(out *AerospikePodStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.WipeResults != nil {
+		in, out := &in.WipeResults, &out.WipeResults
+		*out = make(map[string]VolumeWipeResult, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikePodStatus.
@@ -739,6 +914,11 @@ func (in *AerospikeStorageSpec) DeepCopyInto(out *AerospikeStorageSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SnapshotBootstrap != nil {
+		in, out := &in.SnapshotBootstrap, &out.SnapshotBootstrap
+		*out = new(SnapshotBootstrapSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]VolumeSpec, len(*in))
@@ -809,6 +989,103 @@ func (in *CaCertsSource) DeepCopy() *CaCertsSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederationMember) DeepCopyInto(out *FederationMember) {
+	*out = *in
+	if in.RackIDs != nil {
+		in, out := &in.RackIDs, &out.RackIDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederationMember.
+func (in *FederationMember) DeepCopy() *FederationMember {
+	if in == nil {
+		return nil
+	}
+	out := new(FederationMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederationSpec) DeepCopyInto(out *FederationSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]FederationMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederationSpec.
+func (in *FederationSpec) DeepCopy() *FederationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FederationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTLSRouteSpec) DeepCopyInto(out *GatewayTLSRouteSpec) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSRouteSpec.
+func (in *GatewayTLSRouteSpec) DeepCopy() *GatewayTLSRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTLSRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatSeedsSpec) DeepCopyInto(out *HeartbeatSeedsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatSeedsSpec.
+func (in *HeartbeatSeedsSpec) DeepCopy() *HeartbeatSeedsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatSeedsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 	*out = *in
@@ -861,6 +1138,38 @@ func (in *MountOptions) DeepCopy() *MountOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkIsolationSpec) DeepCopyInto(out *NetworkIsolationSpec) {
+	*out = *in
+	if in.ClientPeers != nil {
+		in, out := &in.ClientPeers, &out.ClientPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MonitoringNamespaces != nil {
+		in, out := &in.MonitoringNamespaces, &out.MonitoringNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExporterPorts != nil {
+		in, out := &in.ExporterPorts, &out.ExporterPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIsolationSpec.
+func (in *NetworkIsolationSpec) DeepCopy() *NetworkIsolationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkIsolationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperationSpec) DeepCopyInto(out *OperationSpec) {
 	*out = *in
@@ -908,6 +1217,32 @@ func (in *PersistentVolumeSpec) DeepCopy() *PersistentVolumeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodServiceSpec) DeepCopyInto(out *PodServiceSpec) {
+	*out = *in
+	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	if in.LoadBalancerClass != nil {
+		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodServiceSpec.
+func (in *PodServiceSpec) DeepCopy() *PodServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rack) DeepCopyInto(out *Rack) {
 	*out = *in
@@ -1052,6 +1387,46 @@ func (in *SeedsFinderServices) DeepCopy() *SeedsFinderServices {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceIPFamilySpec) DeepCopyInto(out *ServiceIPFamilySpec) {
+	*out = *in
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(corev1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]corev1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceIPFamilySpec.
+func (in *ServiceIPFamilySpec) DeepCopy() *ServiceIPFamilySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceIPFamilySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMeshSpec) DeepCopyInto(out *ServiceMeshSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshSpec.
+func (in *ServiceMeshSpec) DeepCopy() *ServiceMeshSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMeshSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
@@ -1068,6 +1443,31 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotBootstrapSpec) DeepCopyInto(out *SnapshotBootstrapSpec) {
+	*out = *in
+	if in.MaxSnapshotAge != nil {
+		in, out := &in.MaxSnapshotAge, &out.MaxSnapshotAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotBootstrapSpec.
+func (in *SnapshotBootstrapSpec) DeepCopy() *SnapshotBootstrapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotBootstrapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidationPolicySpec) DeepCopyInto(out *ValidationPolicySpec) {
 	*out = *in
@@ -1099,6 +1499,42 @@ func (in *VolumeAttachment) DeepCopy() *VolumeAttachment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeOperationProgress) DeepCopyInto(out *VolumeOperationProgress) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeOperationProgress.
+func (in *VolumeOperationProgress) DeepCopy() *VolumeOperationProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeOperationProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotRestoreSpec) DeepCopyInto(out *VolumeSnapshotRestoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotRestoreSpec.
+func (in *VolumeSnapshotRestoreSpec) DeepCopy() *VolumeSnapshotRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 	*out = *in
@@ -1174,3 +1610,19 @@ func (in *VolumeSpec) DeepCopy() *VolumeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeWipeResult) DeepCopyInto(out *VolumeWipeResult) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeWipeResult.
+func (in *VolumeWipeResult) DeepCopy() *VolumeWipeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeWipeResult)
+	in.DeepCopyInto(out)
+	return out
+}
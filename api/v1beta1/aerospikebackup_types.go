@@ -37,10 +37,26 @@ type AerospikeBackupSpec struct {
 	Config runtime.RawExtension `json:"config"`
 
 	// OnDemandBackups is the configuration for on-demand backups.
+	// Each on-demand backup is scheduled once and tracked in status by its ID.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="On Demand Backups"
-	// +kubebuilder:validation:MaxItems:=1
+	// +listType=map
+	// +listMapKey=id
 	// +optional
 	OnDemandBackups []OnDemandBackupSpec `json:"onDemandBackups,omitempty"`
+
+	// OnDemandBackupRetention is the number of finished on-demand backups, removed from spec,
+	// which are kept in status. Default is 10.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="On Demand Backup Retention"
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OnDemandBackupRetention *int32 `json:"onDemandBackupRetention,omitempty"`
+
+	// InventoryPollingPeriod is the polling period for the backup inventory.
+	// It is used to poll the backup service to sync the backups of the routines into status
+	// and AerospikeBackupSnapshot objects. Default is 300 seconds.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Inventory Polling Period"
+	// +optional
+	InventoryPollingPeriod metav1.Duration `json:"inventoryPollingPeriod,omitempty"`
 }
 
 type BackupService struct {
@@ -49,6 +65,90 @@ type BackupService struct {
 
 	// Backup service namespace
 	Namespace string `json:"namespace"`
+
+	// Connection overrides how the operator connects to the backup service API.
+	// By default, the operator connects over HTTP to the Kubernetes service of the AerospikeBackupService.
+	// +optional
+	Connection *BackupServiceConnection `json:"connection,omitempty"`
+}
+
+// BackupServiceConnection specifies how the operator connects to the backup service API.
+type BackupServiceConnection struct {
+	// Address is the host name or IP address of the backup service API.
+	// Defaults to the Kubernetes service address of the AerospikeBackupService.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port is the port of the backup service API.
+	// Defaults to the port in the AerospikeBackupService status.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// ContextPath is the backup service API context path.
+	// Defaults to the context path in the AerospikeBackupService status.
+	// +optional
+	ContextPath string `json:"contextPath,omitempty"`
+
+	// Timeout is the timeout of a single request to the backup service API. Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// TLS enables HTTPS for the backup service API.
+	// +optional
+	TLS *BackupServiceTLS `json:"tls,omitempty"`
+
+	// Auth is the authentication used for the backup service API.
+	// +optional
+	Auth *BackupServiceAuth `json:"auth,omitempty"`
+}
+
+// BackupServiceTLS specifies the TLS configuration of the backup service API.
+type BackupServiceTLS struct {
+	// CASecretName is the name of the secret, in the backup service namespace, having the CA certificate
+	// used to verify the backup service certificate. The system CA pool is used if not given.
+	// +optional
+	CASecretName string `json:"caSecretName,omitempty"`
+
+	// CAKey is the key of the CA certificate in the CA secret. Defaults to ca.crt.
+	// +optional
+	CAKey string `json:"caKey,omitempty"`
+
+	// ServerName is the name used to verify the backup service certificate. Defaults to the address.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// BackupServiceAuthType is the authentication type of the backup service API.
+// +kubebuilder:validation:Enum=Bearer;Basic
+type BackupServiceAuthType string
+
+const (
+	// BackupServiceAuthBearer sends the token from the auth secret as a bearer token.
+	BackupServiceAuthBearer BackupServiceAuthType = "Bearer"
+
+	// BackupServiceAuthBasic sends the username and password from the auth secret as basic auth.
+	BackupServiceAuthBasic BackupServiceAuthType = "Basic"
+)
+
+// Keys of the backup service auth secret.
+const (
+	BackupServiceAuthTokenKey    = "token"
+	BackupServiceAuthUsernameKey = "username"
+	BackupServiceAuthPasswordKey = "password"
+	BackupServiceDefaultCAKey    = "ca.crt"
+)
+
+// BackupServiceAuth specifies the authentication of the backup service API.
+type BackupServiceAuth struct {
+	// Type is the authentication type.
+	Type BackupServiceAuthType `json:"type"`
+
+	// SecretName is the name of the secret, in the backup service namespace, having the credentials.
+	// The secret must have the token key for Bearer auth and the username and password keys for Basic auth.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
 }
 
 func (b *BackupService) String() string {
@@ -68,6 +168,79 @@ type OnDemandBackupSpec struct {
 	Delay metav1.Duration `json:"delay,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Queued;Running;Completed;Failed;Unknown
+type OnDemandBackupPhase string
+
+// These are the valid phases of an on-demand backup.
+const (
+	// OnDemandBackupQueued means the on-demand backup is scheduled in the backup service but not yet started.
+	OnDemandBackupQueued OnDemandBackupPhase = "Queued"
+
+	// OnDemandBackupRunning means the on-demand backup is running.
+	OnDemandBackupRunning OnDemandBackupPhase = "Running"
+
+	// OnDemandBackupCompleted means the on-demand backup is completed.
+	OnDemandBackupCompleted OnDemandBackupPhase = "Completed"
+
+	// OnDemandBackupFailed means the on-demand backup could not be scheduled or did not complete.
+	OnDemandBackupFailed OnDemandBackupPhase = "Failed"
+
+	// OnDemandBackupUnknown means the on-demand backup was scheduled by an operator version which did not track
+	// its progress.
+	OnDemandBackupUnknown OnDemandBackupPhase = "Unknown"
+)
+
+// OnDemandBackupStatus is the status of an on-demand backup.
+type OnDemandBackupStatus struct {
+	OnDemandBackupSpec `json:",inline"`
+
+	// Phase is the phase of the on-demand backup.
+	// +optional
+	Phase OnDemandBackupPhase `json:"phase,omitempty"`
+
+	// ScheduledTime is the time the on-demand backup was scheduled in the backup service.
+	// +optional
+	ScheduledTime *metav1.Time `json:"scheduledTime,omitempty"`
+
+	// PreviousFullBackupTime is the time of the last full backup of the routine, as reported by the backup service,
+	// when the on-demand backup was scheduled. Only the full backups taken after it are attributed to the
+	// on-demand backup.
+	// +optional
+	PreviousFullBackupTime *metav1.Time `json:"previousFullBackupTime,omitempty"`
+
+	// StartTime is the time the on-demand backup was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the on-demand backup was completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// PercentageDone is the progress of the running on-demand backup.
+	// +optional
+	PercentageDone int32 `json:"percentageDone,omitempty"`
+
+	// Backups are the backups taken by the on-demand backup, one per Aerospike namespace.
+	// +optional
+	Backups []BackupInfo `json:"backups,omitempty"`
+
+	// Message is the reason of the failure of the on-demand backup.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsFinished returns true if the on-demand backup is completed or failed, or its progress is unknown.
+func (s *OnDemandBackupStatus) IsFinished() bool {
+	switch s.Phase {
+	case OnDemandBackupCompleted, OnDemandBackupFailed, OnDemandBackupUnknown:
+		return true
+	case OnDemandBackupQueued, OnDemandBackupRunning:
+		return false
+	}
+
+	return false
+}
+
 // AerospikeBackupStatus defines the observed state of AerospikeBackup
 type AerospikeBackupStatus struct {
 	// BackupService is the backup service reference i.e. name and namespace.
@@ -77,13 +250,80 @@ type AerospikeBackupStatus struct {
 	// This config is used to trigger backups. It includes: aerospike-cluster, backup-routines.
 	Config runtime.RawExtension `json:"config"`
 
-	// OnDemandBackups is the configuration for on-demand backups.
+	// OnDemandBackups is the status of the on-demand backups.
 	// +optional
-	OnDemandBackups []OnDemandBackupSpec `json:"onDemandBackups,omitempty"`
+	OnDemandBackups []OnDemandBackupStatus `json:"onDemandBackups,omitempty"`
+
+	// Routines is the backup inventory of the backup routines, synced from the backup service.
+	// +optional
+	Routines map[string]BackupRoutineStatus `json:"routines,omitempty"`
+
+	// InventorySyncTime is the last time the backup inventory was synced from the backup service.
+	// +optional
+	InventorySyncTime *metav1.Time `json:"inventorySyncTime,omitempty"`
 
 	// TODO: finalize the status and phase
 }
 
+// BackupRoutineStatus is the backup inventory of a backup routine.
+type BackupRoutineStatus struct {
+	// LastFullBackup is the latest successful full backup of the routine.
+	// +optional
+	LastFullBackup *BackupInfo `json:"lastFullBackup,omitempty"`
+
+	// LastIncrementalBackup is the latest successful incremental backup of the routine.
+	// +optional
+	LastIncrementalBackup *BackupInfo `json:"lastIncrementalBackup,omitempty"`
+
+	// FullBackupCount is the number of full backups of the routine in the storage.
+	FullBackupCount int32 `json:"fullBackupCount"`
+
+	// IncrementalBackupCount is the number of incremental backups of the routine in the storage.
+	IncrementalBackupCount int32 `json:"incrementalBackupCount"`
+
+	// NextFullBackupDeadline is the time by which the next full backup is expected to succeed
+	// as per the interval-cron of the routine.
+	// +optional
+	NextFullBackupDeadline *metav1.Time `json:"nextFullBackupDeadline,omitempty"`
+
+	// Overdue is true if no full backup of the routine has succeeded within its interval
+	// i.e. NextFullBackupDeadline has passed.
+	// +optional
+	Overdue bool `json:"overdue,omitempty"`
+}
+
+// BackupInfo describes a backup taken by the backup service.
+type BackupInfo struct {
+	// SnapshotName is the name of the AerospikeBackupSnapshot of the backup.
+	// +optional
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// AerospikeNamespace is the Aerospike namespace of the backup.
+	AerospikeNamespace string `json:"aerospikeNamespace"`
+
+	// Key is the path to the backup files within the storage.
+	Key string `json:"key"`
+
+	// StoragePath is the full path to the backup files including the storage location.
+	// +optional
+	StoragePath string `json:"storagePath,omitempty"`
+
+	// Created is the time the backup was started.
+	Created metav1.Time `json:"created"`
+
+	// Finished is the time the backup was completed.
+	// +optional
+	Finished metav1.Time `json:"finished,omitempty"`
+
+	// RecordCount is the number of records in the backup.
+	// +optional
+	RecordCount int64 `json:"recordCount,omitempty"`
+
+	// ByteCount is the size of the backup in bytes.
+	// +optional
+	ByteCount int64 `json:"byteCount,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:metadata:annotations="aerospike-kubernetes-operator/version=4.2.0-dev1"
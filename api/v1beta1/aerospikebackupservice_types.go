@@ -42,6 +42,33 @@ const (
 	AerospikeBackupServiceError AerospikeBackupServicePhase = "Error"
 )
 
+// +kubebuilder:validation:Enum=Leader;Standby
+type BackupServiceRole string
+
+// These are the roles of the backup service pods.
+const (
+	// BackupServiceLeader is the role of the pod which runs the scheduled backups.
+	BackupServiceLeader BackupServiceRole = "Leader"
+
+	// BackupServiceStandby is the role of the pods which run with all the backup routines disabled.
+	BackupServiceStandby BackupServiceRole = "Standby"
+)
+
+// +kubebuilder:validation:Enum=InProgress;Completed;Failed
+type BackupServiceConfigReloadPhase string
+
+// These are the valid phases of the backup service config reload.
+const (
+	// BackupServiceConfigReloadInProgress means the config is being applied in the backup service.
+	BackupServiceConfigReloadInProgress BackupServiceConfigReloadPhase = "InProgress"
+
+	// BackupServiceConfigReloadCompleted means the backup service is serving the config in the ConfigMap.
+	BackupServiceConfigReloadCompleted BackupServiceConfigReloadPhase = "Completed"
+
+	// BackupServiceConfigReloadFailed means the config could not be applied in the backup service.
+	BackupServiceConfigReloadFailed BackupServiceConfigReloadPhase = "Failed"
+)
+
 // AerospikeBackupServiceSpec defines the desired state of AerospikeBackupService
 // +k8s:openapi-gen=true
 //
@@ -57,6 +84,19 @@ type AerospikeBackupServiceSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup Service Config"
 	Config runtime.RawExtension `json:"config"`
 
+	// Replicas is the number of backup service pods, 1 or 2. Default is 1.
+	// With 2 replicas, a leader pod runs the scheduled backups and serves the API, and a standby pod,
+	// deployed as the <name>-standby Deployment, runs with all the backup routines disabled. If the leader is not
+	// healthy, the standby pod is promoted: the backup routines are enabled in it and the Kubernetes service is
+	// switched to it, until the leader recovers and the standby pod is demoted again. A single standby pod is allowed
+	// so that only one pod runs the scheduled backups and knows the backup jobs the API is queried for.
+	// A PodDisruptionBudget keeps one of the pods available.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replicas"
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// Specify additional configuration for the AerospikeBackupService pods
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Configuration"
 	// +optional
@@ -79,6 +119,24 @@ type AerospikeBackupServiceSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="K8s Service"
 	// +optional
 	Service *Service `json:"service,omitempty"`
+
+	// WorkloadIdentity configures the backup service pods to access the cloud storage with the cloud workload
+	// identity of the pod ServiceAccount, instead of the long-lived access keys.
+	// Unless podSpec.serviceAccountName is given, the operator creates a ServiceAccount named after the
+	// AerospikeBackupService and annotates it for the provider. A given ServiceAccount is used as is,
+	// it must be annotated for the provider, e.g. for GCP, by the user.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Workload Identity"
+	// +optional
+	WorkloadIdentity *WorkloadIdentity `json:"workloadIdentity,omitempty"`
+
+	// Connection specifies how the operator connects to the backup service API, when the API is served with TLS
+	// or authentication, for example by a proxy in the backup service pod.
+	// The address is ignored, the Kubernetes service and the pods are always used. The port and the context path
+	// default to the ones in the backup service config. The readiness probe of the pods uses the same port,
+	// context path and, if TLS is given, the HTTPS scheme.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Connection"
+	// +optional
+	Connection *BackupServiceConnection `json:"connection,omitempty"`
 }
 
 // AerospikeBackupServiceStatus defines the observed state of AerospikeBackupService
@@ -114,6 +172,10 @@ type AerospikeBackupServiceStatus struct {
 	// +optional
 	Service *Service `json:"service,omitempty"`
 
+	// WorkloadIdentity is the cloud workload identity configuration of the backup service pods.
+	// +optional
+	WorkloadIdentity *WorkloadIdentity `json:"workloadIdentity,omitempty"`
+
 	// ContextPath is the backup service API context path
 	// +optional
 	ContextPath string `json:"contextPath,omitempty"`
@@ -124,6 +186,41 @@ type AerospikeBackupServiceStatus struct {
 	// Port is the listening port of backup service
 	// +optional
 	Port int32 `json:"port,omitempty"`
+
+	// Replicas is the number of backup service pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ServingRole is the role of the pods the Kubernetes service is serving the API from.
+	// It is Standby while the leader is not healthy.
+	// +optional
+	ServingRole BackupServiceRole `json:"servingRole,omitempty"`
+
+	// ConfigReload is the status of the last hot reload of the backup service config.
+	// +optional
+	ConfigReload *BackupServiceConfigReloadStatus `json:"configReload,omitempty"`
+}
+
+// BackupServiceConfigReloadStatus is the status of the hot reload of the backup service config.
+type BackupServiceConfigReloadStatus struct {
+	// Phase is the phase of the config reload.
+	Phase BackupServiceConfigReloadPhase `json:"phase"`
+
+	// DesiredConfigHash is the hash of the config in the ConfigMap being reloaded.
+	// +optional
+	DesiredConfigHash string `json:"desiredConfigHash,omitempty"`
+
+	// AppliedConfigHash is the hash of the config served by the backup service config API.
+	// +optional
+	AppliedConfigHash string `json:"appliedConfigHash,omitempty"`
+
+	// Message is the reason of the config reload failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastReloadTime is the time the config reload last changed phase.
+	// +optional
+	LastReloadTime *metav1.Time `json:"lastReloadTime,omitempty"`
 }
 
 type ServicePodSpec struct {
@@ -140,7 +237,8 @@ type ServicePodSpec struct {
 	SchedulingPolicy `json:",inline"`
 
 	// ServiceAccountName is the name of the ServiceAccount to use to run the backup service pod.
-	// Defaults to "aerospike-backup-service" if not provided.
+	// Defaults to "aerospike-backup-service" if not provided, or to the ServiceAccount created by the operator
+	// if workloadIdentity is given.
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
@@ -165,6 +263,7 @@ type ServiceContainerSpec struct {
 // +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
 // +kubebuilder:printcolumn:name="Service Type",type=string,JSONPath=`.spec.service.type`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Serving",type=string,JSONPath=`.status.servingRole`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // AerospikeBackupService is the Schema for the aerospikebackupservices API
@@ -198,6 +297,56 @@ type SecretMount struct {
 	VolumeMount corev1.VolumeMount `json:"volumeMount"`
 }
 
+// +kubebuilder:validation:Enum=AWS;GCP;Azure
+type WorkloadIdentityProvider string
+
+// These are the supported cloud workload identity providers.
+const (
+	// WorkloadIdentityAWS is the IAM Roles for Service Accounts (IRSA) on EKS, used by s3-storage.
+	WorkloadIdentityAWS WorkloadIdentityProvider = "AWS"
+
+	// WorkloadIdentityGCP is the GKE Workload Identity, used by gcp-storage.
+	WorkloadIdentityGCP WorkloadIdentityProvider = "GCP"
+
+	// WorkloadIdentityAzure is the Microsoft Entra Workload ID on AKS, used by azure-storage.
+	WorkloadIdentityAzure WorkloadIdentityProvider = "Azure"
+)
+
+// WorkloadIdentity specifies the cloud identity the backup service pods assume to access the storage.
+type WorkloadIdentity struct {
+	// Provider is the cloud provider of the workload identity.
+	Provider WorkloadIdentityProvider `json:"provider"`
+
+	// RoleARN is the ARN of the AWS IAM role to assume. Required for the AWS provider.
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// GCPServiceAccount is the email of the GCP IAM service account to impersonate.
+	// Required for the GCP provider.
+	// +optional
+	GCPServiceAccount string `json:"gcpServiceAccount,omitempty"`
+
+	// ClientID is the client ID of the Azure managed identity or application. Required for the Azure provider.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// TenantID is the Azure tenant ID. Required for the Azure provider.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// Audience is the audience of the projected ServiceAccount token.
+	// Defaults to sts.amazonaws.com for AWS and api://AzureADTokenExchange for Azure.
+	// It is not used for GCP, which gets the token from the GKE metadata server.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// ExpirationSeconds is the validity of the projected ServiceAccount token. Default is 86400.
+	// The token is refreshed by the kubelet before it expires.
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
 // Service specifies the Kubernetes service related configuration.
 type Service struct {
 	// Type is the Kubernetes service type.
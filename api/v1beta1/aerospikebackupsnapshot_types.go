@@ -0,0 +1,130 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +kubebuilder:validation:Enum=Full;Incremental
+type BackupSnapshotType string
+
+const (
+	BackupSnapshotFull        BackupSnapshotType = "Full"
+	BackupSnapshotIncremental BackupSnapshotType = "Incremental"
+)
+
+// AerospikeBackupSnapshotSpec describes a backup taken by the backup service.
+// It is populated by the operator from the backup service backup list and is immutable.
+// +k8s:openapi-gen=true
+//
+//nolint:govet // for readability
+type AerospikeBackupSnapshotSpec struct {
+	// Backup is the name of the AerospikeBackup, in the same namespace, which took the backup.
+	Backup string `json:"backup"`
+
+	// RoutineName is the backup routine which took the backup.
+	RoutineName string `json:"routineName"`
+
+	// Type is the type of the backup.
+	Type BackupSnapshotType `json:"type"`
+
+	// AerospikeNamespace is the Aerospike namespace of the backup.
+	AerospikeNamespace string `json:"aerospikeNamespace"`
+
+	// Key is the path to the backup files within the storage.
+	// It is used as backup-data-path in the restore config.
+	Key string `json:"key"`
+
+	// StoragePath is the full path to the backup files including the storage location.
+	// +optional
+	StoragePath string `json:"storagePath,omitempty"`
+
+	// Storage is the storage of the backup in the backup service format.
+	// It is used as source in the restore config.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Storage runtime.RawExtension `json:"storage,omitempty"`
+
+	// Created is the time the backup was started.
+	Created metav1.Time `json:"created"`
+
+	// Finished is the time the backup was completed.
+	// +optional
+	Finished metav1.Time `json:"finished,omitempty"`
+
+	// RecordCount is the number of records in the backup.
+	// +optional
+	RecordCount int64 `json:"recordCount,omitempty"`
+
+	// ByteCount is the size of the backup in bytes.
+	// +optional
+	ByteCount int64 `json:"byteCount,omitempty"`
+
+	// FileCount is the number of backup files.
+	// +optional
+	FileCount int64 `json:"fileCount,omitempty"`
+
+	// SecondaryIndexCount is the number of secondary indexes in the backup.
+	// +optional
+	SecondaryIndexCount int64 `json:"secondaryIndexCount,omitempty"`
+
+	// UDFCount is the number of UDF files in the backup.
+	// +optional
+	UDFCount int64 `json:"udfCount,omitempty"`
+
+	// Compression is the compression mode of the backup.
+	// +optional
+	Compression string `json:"compression,omitempty"`
+
+	// Encryption is the encryption mode of the backup.
+	// +optional
+	Encryption string `json:"encryption,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:metadata:annotations="aerospike-kubernetes-operator/version=4.2.0-dev1"
+// +kubebuilder:printcolumn:name="Backup",type=string,JSONPath=`.spec.backup`
+// +kubebuilder:printcolumn:name="Routine",type=string,JSONPath=`.spec.routineName`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.aerospikeNamespace`
+// +kubebuilder:printcolumn:name="Records",type=integer,JSONPath=`.spec.recordCount`
+// +kubebuilder:printcolumn:name="Bytes",type=integer,JSONPath=`.spec.byteCount`
+// +kubebuilder:printcolumn:name="Created",type="date",JSONPath=".spec.created"
+
+// AerospikeBackupSnapshot is the Schema for the aerospikebackupsnapshots API.
+// It is a read-only view of a backup taken by the backup service for an AerospikeBackup.
+type AerospikeBackupSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AerospikeBackupSnapshotSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AerospikeBackupSnapshotList contains a list of AerospikeBackupSnapshot
+type AerospikeBackupSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AerospikeBackupSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AerospikeBackupSnapshot{}, &AerospikeBackupSnapshotList{})
+}
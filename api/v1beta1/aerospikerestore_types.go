@@ -21,7 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// +kubebuilder:validation:Enum=InProgress;Completed;Failed
+// +kubebuilder:validation:Enum=InProgress;Completed;Failed;Cancelled
 type AerospikeRestorePhase string
 
 // These are the valid phases of Aerospike restore operation.
@@ -34,6 +34,9 @@ const (
 
 	// AerospikeRestoreFailed means the AerospikeRestore CR has been reconciled and restore operation is failed.
 	AerospikeRestoreFailed AerospikeRestorePhase = "Failed"
+
+	// AerospikeRestoreCancelled means the restore operation is cancelled on request.
+	AerospikeRestoreCancelled AerospikeRestorePhase = "Cancelled"
 )
 
 type RestoreType string
@@ -65,6 +68,47 @@ type AerospikeRestoreSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Restore Config"
 	Config runtime.RawExtension `json:"config"`
 
+	// BackupSnapshotName is the name of the AerospikeBackupSnapshot, in the same namespace, to restore from.
+	// It is only supported for the Full and Incremental restore types. The backup-data-path and source
+	// of the restore config are set from the snapshot if not given.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup Snapshot Name"
+	// +optional
+	BackupSnapshotName string `json:"backupSnapshotName,omitempty"`
+
+	// PointInTime restores the data as of the given time from the backups of the given routine.
+	// The full backup and the chain of incremental backups before the time are found from the backup service,
+	// and restored one by one from the storage of the routine, each namespace backup by a restore job of the Full or
+	// Incremental type. It is only supported for the Timestamp restore type, and the routine, time and
+	// disable-reordering fields are not allowed in the restore config along with it.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Point In Time"
+	// +optional
+	PointInTime *RestorePointInTime `json:"pointInTime,omitempty"`
+
+	// DestinationCluster is an AerospikeCluster provisioned by the operator to restore into.
+	// The cluster is created and the restore is triggered once the cluster is Completed.
+	// The destination seed-nodes, credentials and TLS name of the restore config are set from the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Destination Cluster"
+	// +optional
+	DestinationCluster *RestoreDestinationCluster `json:"destinationCluster,omitempty"`
+
+	// Verification verifies the restored data in the destination cluster once the restore is completed.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Verification"
+	// +optional
+	Verification *RestoreVerification `json:"verification,omitempty"`
+
+	// RetryPolicy retries the restore when the restore job fails.
+	// If not given, a failed restore is not retried.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retry Policy"
+	// +optional
+	RetryPolicy *RestoreRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Cancel cancels the restore. The running restore job is cancelled, and no further attempt is made.
+	// The AerospikeRestore is kept in the Cancelled phase for audit. This is the only field which can be updated,
+	// and it can not be unset once set.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cancel"
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+
 	// PollingPeriod is the polling period for restore operation status.
 	// It is used to poll the restore service to fetch restore operation status.
 	// Default is 60 seconds.
@@ -73,6 +117,259 @@ type AerospikeRestoreSpec struct {
 	PollingPeriod metav1.Duration `json:"pollingPeriod,omitempty"`
 }
 
+// RestorePointInTime defines the point in time to restore the data as of.
+type RestorePointInTime struct {
+	// Routine is the name of the backup routine to restore from.
+	// +kubebuilder:validation:MinLength=1
+	Routine string `json:"routine"`
+
+	// Time is the point in time to restore the data as of.
+	// The latest full backup before the time and the incremental backups after it, before the time, are restored.
+	Time metav1.Time `json:"time"`
+}
+
+// RestoreChain is the chain of backups restored for a point in time restore.
+type RestoreChain struct {
+	// FullBackup is the full backup the chain starts from.
+	FullBackup RestoreChainBackup `json:"fullBackup"`
+
+	// IncrementalBackups are the incremental backups applied over the full backup, the oldest first.
+	// +optional
+	IncrementalBackups []RestoreChainBackup `json:"incrementalBackups,omitempty"`
+
+	// Storage is the name of the backup service storage of the routine the backups are read from.
+	// +optional
+	Storage string `json:"storage,omitempty"`
+
+	// RestoredKeys are the keys of the backups of the chain restored so far, in the restore order.
+	// +optional
+	RestoredKeys []string `json:"restoredKeys,omitempty"`
+
+	// RestoredResult is the result of the restore jobs of the restored keys, with the record counts summed.
+	// +optional
+	RestoredResult runtime.RawExtension `json:"restoredResult,omitempty"`
+}
+
+// RestoreChainBackup is a backup in the restore chain.
+type RestoreChainBackup struct {
+	// Created is the creation time of the backup.
+	Created metav1.Time `json:"created"`
+
+	// Keys are the storage keys of the backup, one per Aerospike namespace.
+	Keys []string `json:"keys"`
+
+	// RecordCount is the number of records in the backup.
+	// +optional
+	RecordCount int64 `json:"recordCount,omitempty"`
+}
+
+// RestoreRetryPolicy defines the retries of a failed restore job.
+type RestoreRetryPolicy struct {
+	// MaxAttempts is the maximum number of restore attempts, including the first one.
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int32 `json:"maxAttempts"`
+
+	// Backoff is the wait before the first retry. It is doubled after every failed attempt, up to 30 minutes.
+	// Default is 60 seconds.
+	// +optional
+	Backoff metav1.Duration `json:"backoff,omitempty"`
+
+	// SkipExistingRecords restores only the records not already in the destination cluster on a retry,
+	// so that the records written by the failed attempts are not rewritten. The backup retried is read whole,
+	// only the writes of the existing records are skipped. The records restored by a failed attempt and updated
+	// in the destination since are kept as is.
+	// It sets the unique restore policy for the retries, hence the replace and no-generation
+	// restore policies are not allowed along with it.
+	// +optional
+	SkipExistingRecords bool `json:"skipExistingRecords,omitempty"`
+
+	// Resume resumes a retry of a pointInTime restore from the backup of the restore chain the failed attempt
+	// stopped at. The backups of the chain already restored, listed in the restoredKeys of the restoreChain status,
+	// are not restored again. Without it, every retry restores the chain again from the full backup.
+	// Only allowed along with pointInTime.
+	// +optional
+	Resume bool `json:"resume,omitempty"`
+}
+
+// RestoreAttempt is the record of a restore attempt.
+type RestoreAttempt struct {
+	// JobID is the restore job id of the attempt.
+	JobID int64 `json:"job-id"`
+
+	// Phase is the phase of the attempt.
+	Phase AerospikeRestorePhase `json:"phase"`
+
+	// SkippedExistingRecords is true if the attempt restored only the records not already in the destination
+	// cluster.
+	// +optional
+	SkippedExistingRecords bool `json:"skippedExistingRecords,omitempty"`
+
+	// ResumedFromKey is the key of the backup of the restore chain the attempt resumed from.
+	// Empty if the attempt restored the chain from the full backup.
+	// +optional
+	ResumedFromKey string `json:"resumedFromKey,omitempty"`
+
+	// StartTime is the time the attempt was triggered.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time the attempt completed, failed or was cancelled.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ReadRecords is the number of records read from the backup by the attempt.
+	// For a pointInTime restore, the record counts are summed over the backups of the restore chain restored so far,
+	// including the backups restored by the previous attempts if resumed.
+	// +optional
+	ReadRecords int64 `json:"readRecords,omitempty"`
+
+	// InsertedRecords is the number of records inserted in the destination cluster by the attempt.
+	// +optional
+	InsertedRecords int64 `json:"insertedRecords,omitempty"`
+
+	// Error is the error of the failed attempt.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// RestoreDestinationCluster is an AerospikeCluster provisioned by the operator to restore into.
+type RestoreDestinationCluster struct {
+	// Name is the name of the AerospikeCluster created in the AerospikeRestore namespace.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Spec is the AerospikeCluster spec in YAML format used as the template of the destination cluster.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
+
+	// User is the Aerospike user used for the restore if security is enabled in the spec.
+	// The password is read by the backup service from the secret of the user in the aerospikeAccessControl of the
+	// spec, which must be mounted in the backup service through its secrets. The TLS certificates are read the same
+	// way from the secretCertSource of the operatorClientCert of the spec.
+	// Defaults to admin.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// DeleteAfterRestore deletes the destination cluster once the restore is completed and verified, if verification
+	// is given. The cluster is also deleted along with the AerospikeRestore. A failed restore keeps the cluster.
+	// +optional
+	DeleteAfterRestore bool `json:"deleteAfterRestore,omitempty"`
+}
+
+// RestoreVerification defines the verification of the restored data.
+// The object counts of the namespaces in the destination cluster are compared against the records in the backup,
+// excluding the records expired or skipped by the restore.
+type RestoreVerification struct {
+	// ClusterName is the name of the AerospikeCluster, in the AerospikeRestore namespace, the data is restored into.
+	// Defaults to the destinationCluster name.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Namespaces is the list of Aerospike namespaces the data is restored into.
+	// Defaults to the namespace of the backup, derived from the restore policy namespace mapping,
+	// the backup snapshot or the backup-data-path. Required for the Timestamp restore type.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Sets is the list of minimum object counts expected in the sets of the destination cluster.
+	// +optional
+	Sets []RestoreVerificationSet `json:"sets,omitempty"`
+
+	// MaxFailedRecords is the number of records, ignored due to errors or in doubt, tolerated by the verification.
+	// Default is 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailedRecords int64 `json:"maxFailedRecords,omitempty"`
+}
+
+// RestoreVerificationSet is the minimum object count expected in a set.
+type RestoreVerificationSet struct {
+	// Namespace is the Aerospike namespace of the set.
+	Namespace string `json:"namespace"`
+
+	// Set is the name of the set.
+	Set string `json:"set"`
+
+	// MinObjects is the minimum number of objects expected in the set.
+	// +kubebuilder:validation:Minimum=0
+	MinObjects int64 `json:"minObjects"`
+}
+
+// +kubebuilder:validation:Enum=Verified;VerificationFailed
+type RestoreVerificationPhase string
+
+// These are the valid phases of the verification of a restore.
+const (
+	// RestoreVerified means the restored data matches the backup.
+	RestoreVerified RestoreVerificationPhase = "Verified"
+
+	// RestoreVerificationFailed means the restored data does not match the backup.
+	RestoreVerificationFailed RestoreVerificationPhase = "VerificationFailed"
+)
+
+// RestoreVerificationStatus is the result of the verification of the restored data.
+type RestoreVerificationStatus struct {
+	// Phase is the outcome of the verification.
+	Phase RestoreVerificationPhase `json:"phase"`
+
+	// ExpectedObjects is the minimum number of objects expected in the namespaces.
+	// +optional
+	ExpectedObjects int64 `json:"expectedObjects,omitempty"`
+
+	// Namespaces are the object counts of the namespaces in the destination cluster.
+	// +optional
+	Namespaces []NamespaceObjectCount `json:"namespaces,omitempty"`
+
+	// Message is a human-readable message indicating why the verification failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// VerificationTime is the time the verification was done.
+	// +optional
+	VerificationTime *metav1.Time `json:"verificationTime,omitempty"`
+}
+
+// NamespaceObjectCount is the object count of a namespace and its sets.
+type NamespaceObjectCount struct {
+	// Name is the name of the namespace.
+	Name string `json:"name"`
+
+	// Objects is the number of master objects in the namespace.
+	Objects int64 `json:"objects"`
+
+	// Sets are the object counts of the sets in the namespace.
+	// +optional
+	Sets map[string]int64 `json:"sets,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Provisioning;Ready;Deleted
+type RestoreDestinationClusterPhase string
+
+// These are the valid phases of the destination cluster of a restore.
+const (
+	// RestoreDestinationClusterProvisioning means the destination cluster is created and not yet Completed.
+	RestoreDestinationClusterProvisioning RestoreDestinationClusterPhase = "Provisioning"
+
+	// RestoreDestinationClusterReady means the destination cluster is Completed and ready to restore into.
+	RestoreDestinationClusterReady RestoreDestinationClusterPhase = "Ready"
+
+	// RestoreDestinationClusterDeleted means the destination cluster is deleted after the restore.
+	RestoreDestinationClusterDeleted RestoreDestinationClusterPhase = "Deleted"
+)
+
+// RestoreDestinationClusterStatus is the status of the destination cluster of a restore.
+type RestoreDestinationClusterStatus struct {
+	// Name is the name of the destination AerospikeCluster.
+	Name string `json:"name"`
+
+	// Phase is the phase of the destination cluster.
+	// +optional
+	Phase RestoreDestinationClusterPhase `json:"phase,omitempty"`
+
+	// SeedNodes is the list of seeds, in host:port format, the restore is run against.
+	// +optional
+	SeedNodes []string `json:"seedNodes,omitempty"`
+}
+
 // AerospikeRestoreStatus defines the observed state of AerospikeRestore
 type AerospikeRestoreStatus struct {
 	// JobID is the restore operation job id.
@@ -83,6 +380,27 @@ type AerospikeRestoreStatus struct {
 	// +optional
 	RestoreResult runtime.RawExtension `json:"restoreResult,omitempty"`
 
+	// Attempts is the history of the restore attempts, the latest one last.
+	// +optional
+	Attempts []RestoreAttempt `json:"attempts,omitempty"`
+
+	// NextAttemptTime is the time the failed restore is retried at, as per the retryPolicy.
+	// +optional
+	NextAttemptTime *metav1.Time `json:"nextAttemptTime,omitempty"`
+
+	// RestoreChain is the chain of backups planned for the point in time restore.
+	// It is set before the restore jobs are triggered, and tracks the backups restored so far.
+	// +optional
+	RestoreChain *RestoreChain `json:"restoreChain,omitempty"`
+
+	// DestinationCluster is the status of the destination cluster provisioned for the restore.
+	// +optional
+	DestinationCluster *RestoreDestinationClusterStatus `json:"destinationCluster,omitempty"`
+
+	// Verification is the result of the verification of the restored data.
+	// +optional
+	Verification *RestoreVerificationStatus `json:"verification,omitempty"`
+
 	// Phase denotes the current phase of Aerospike restore operation.
 	Phase AerospikeRestorePhase `json:"phase"`
 }
@@ -93,6 +411,7 @@ type AerospikeRestoreStatus struct {
 // +kubebuilder:printcolumn:name="Backup Service Name",type=string,JSONPath=`.spec.backupService.name`
 // +kubebuilder:printcolumn:name="Backup Service Namespace",type=string,JSONPath=`.spec.backupService.namespace`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Verification",type=string,JSONPath=`.status.verification.phase`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // AerospikeRestore is the Schema for the aerospikerestores API
@@ -0,0 +1,189 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=InProgress;Completed;Failed
+type VolumeSnapshotSetPhase string
+
+// These are the valid phases of a VolumeSnapshot set.
+const (
+	// VolumeSnapshotSetInProgress means the VolumeSnapshots of the set are being taken.
+	VolumeSnapshotSetInProgress VolumeSnapshotSetPhase = "InProgress"
+
+	// VolumeSnapshotSetCompleted means all VolumeSnapshots of the set are ready to use.
+	VolumeSnapshotSetCompleted VolumeSnapshotSetPhase = "Completed"
+
+	// VolumeSnapshotSetFailed means some VolumeSnapshot of the set failed or
+	// was not cut within the snapshot window.
+	VolumeSnapshotSetFailed VolumeSnapshotSetPhase = "Failed"
+)
+
+// AerospikeVolumeSnapshotBackupSpec defines the desired state of AerospikeVolumeSnapshotBackup
+// +k8s:openapi-gen=true
+type AerospikeVolumeSnapshotBackupSpec struct {
+	// ClusterName is the name of the AerospikeCluster to back up.
+	// The AerospikeCluster should be in the same namespace. This field is immutable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Name"
+	ClusterName string `json:"clusterName"`
+
+	// VolumeSnapshotClassName is the CSI VolumeSnapshotClass used to take snapshots of the cluster volumes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volume Snapshot Class Name"
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+
+	// Volumes is the list of storage volume names to snapshot.
+	// Default is all persistent volumes attached to the Aerospike server container.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Volumes"
+	// +optional
+	Volumes []string `json:"volumes,omitempty"`
+
+	// Interval is the period between two scheduled snapshot sets.
+	// If not set, a single snapshot set is taken.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Interval"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// SnapshotWindow is the maximum time allowed for all snapshots of a set to be cut.
+	// A set which is not ready within this window is marked Failed. Default is 5 minutes.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Snapshot Window"
+	// +optional
+	SnapshotWindow metav1.Duration `json:"snapshotWindow,omitempty"`
+
+	// AllowMultiVolumePods allows snapshot sets of pods with more than one volume to snapshot.
+	// The volumes of a pod are snapshotted independently, without pausing the Aerospike server, so each snapshot
+	// is only crash consistent on its own, and the namespaces and devices of a pod are not consistent with each
+	// other. A pod restored from such snapshots relies on migrations to reconcile them.
+	// If not set, a snapshot set fails when any pod has more than one volume to snapshot.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Allow Multi Volume Pods"
+	// +optional
+	AllowMultiVolumePods bool `json:"allowMultiVolumePods,omitempty"`
+
+	// InfoHooks are the Aerospike info commands run on all the cluster pods around the snapshot window, e.g. to
+	// quiesce the writes, or to flush them to the devices, before the VolumeSnapshots are cut, so that the
+	// snapshots are more than crash consistent.
+	// If not set, the snapshots are taken without pausing the Aerospike server.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Info Hooks"
+	// +optional
+	InfoHooks *VolumeSnapshotInfoHooks `json:"infoHooks,omitempty"`
+
+	// Retention defines which snapshot sets are deleted.
+	// If not set, snapshot sets are kept until the AerospikeVolumeSnapshotBackup is deleted.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention"
+	// +optional
+	Retention *VolumeSnapshotRetention `json:"retention,omitempty"`
+}
+
+// VolumeSnapshotRetention defines the retention of snapshot sets.
+// The latest completed snapshot set is never deleted.
+type VolumeSnapshotRetention struct {
+	// MaxSets is the maximum number of snapshot sets to keep.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSets *int32 `json:"maxSets,omitempty"`
+
+	// MaxAge is the maximum age of a snapshot set.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// VolumeSnapshotInfoHooks defines the info commands run on all the cluster pods around the snapshot window.
+type VolumeSnapshotInfoHooks struct {
+	// PreSnapshot are the info commands run before the VolumeSnapshots of a set are created.
+	// The snapshot set fails without taking any VolumeSnapshot if any of them fails.
+	// +optional
+	PreSnapshot []string `json:"preSnapshot,omitempty"`
+
+	// PostSnapshot are the info commands run once all the VolumeSnapshots of a set are cut, or the set failed,
+	// e.g. to undo the preSnapshot commands. They are retried until they succeed, and should be idempotent as they
+	// are run even if the preSnapshot commands were interrupted.
+	// +optional
+	PostSnapshot []string `json:"postSnapshot,omitempty"`
+}
+
+// VolumeSnapshotSet is a set of VolumeSnapshots of all the cluster pods taken together.
+type VolumeSnapshotSet struct {
+	// ID is the unique identifier of the snapshot set.
+	// VolumeSnapshots of the set are labelled with it.
+	ID string `json:"id"`
+
+	// Phase denotes the current phase of the snapshot set.
+	Phase VolumeSnapshotSetPhase `json:"phase"`
+
+	// StartTime is the time at which the snapshot set was started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time at which all snapshots of the set were ready to use.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// SnapshotCount is the number of VolumeSnapshots in the set.
+	SnapshotCount int32 `json:"snapshotCount"`
+
+	// Message is a human-readable message indicating details about the snapshot set phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PostSnapshotPending is true from before the preSnapshot info hooks are run until the postSnapshot info hooks
+	// succeed.
+	// +optional
+	PostSnapshotPending bool `json:"postSnapshotPending,omitempty"`
+}
+
+// AerospikeVolumeSnapshotBackupStatus defines the observed state of AerospikeVolumeSnapshotBackup
+type AerospikeVolumeSnapshotBackupStatus struct {
+	// LastScheduleTime is the time at which the last snapshot set was started.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// SnapshotSets is the list of snapshot sets which are not deleted by retention, oldest first.
+	// A snapshot set is recorded before its VolumeSnapshots are created.
+	// +optional
+	SnapshotSets []VolumeSnapshotSet `json:"snapshotSets,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:annotations="aerospike-kubernetes-operator/version=4.2.0-dev1"
+// +kubebuilder:printcolumn:name="Cluster Name",type=string,JSONPath=`.spec.clusterName`
+// +kubebuilder:printcolumn:name="Interval",type=string,JSONPath=`.spec.interval`
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AerospikeVolumeSnapshotBackup is the Schema for the aerospikevolumesnapshotbackups API
+type AerospikeVolumeSnapshotBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AerospikeVolumeSnapshotBackupSpec   `json:"spec,omitempty"`
+	Status AerospikeVolumeSnapshotBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AerospikeVolumeSnapshotBackupList contains a list of AerospikeVolumeSnapshotBackup
+type AerospikeVolumeSnapshotBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AerospikeVolumeSnapshotBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AerospikeVolumeSnapshotBackup{}, &AerospikeVolumeSnapshotBackupList{})
+}
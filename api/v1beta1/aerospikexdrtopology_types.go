@@ -0,0 +1,181 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +kubebuilder:validation:Enum=InProgress;Completed;Error
+type AerospikeXDRTopologyPhase string
+
+// These are the valid phases of AerospikeXDRTopology.
+const (
+	// AerospikeXDRTopologyInProgress means the XDR DC config is being applied to the source clusters.
+	AerospikeXDRTopologyInProgress AerospikeXDRTopologyPhase = "InProgress"
+
+	// AerospikeXDRTopologyCompleted means the XDR DC config is applied to all the source clusters.
+	AerospikeXDRTopologyCompleted AerospikeXDRTopologyPhase = "Completed"
+
+	// AerospikeXDRTopologyError means the seeds of a destination could not be resolved or
+	// the XDR DC config could not be applied to a source cluster.
+	AerospikeXDRTopologyError AerospikeXDRTopologyPhase = "Error"
+)
+
+// +kubebuilder:validation:Enum=pod;loadBalancer
+type XDRSeedSource string
+
+const (
+	// XDRSeedSourcePod resolves the seeds from the access endpoints in the destination cluster pods status.
+	XDRSeedSourcePod XDRSeedSource = "pod"
+
+	// XDRSeedSourceLoadBalancer resolves the seed from the destination cluster LoadBalancer service,
+	// created using spec.seedsFinderServices.loadBalancer of the destination cluster.
+	XDRSeedSourceLoadBalancer XDRSeedSource = "loadBalancer"
+)
+
+// AerospikeXDRTopologySpec defines the desired state of AerospikeXDRTopology
+// +k8s:openapi-gen=true
+type AerospikeXDRTopologySpec struct {
+	// Sources is the list of AerospikeClusters shipping records to the destinations.
+	// The XDR DC config of every destination is generated in the aerospikeConfig of each source.
+	// A destination which is also a source is skipped for that source.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sources"
+	// +kubebuilder:validation:MinItems:=1
+	Sources []XDRClusterReference `json:"sources"`
+
+	// Destinations is the list of AerospikeClusters receiving records from the sources, one XDR DC each.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Destinations"
+	// +kubebuilder:validation:MinItems:=1
+	Destinations []XDRDestinationSpec `json:"destinations"`
+}
+
+// XDRClusterReference is a reference to an AerospikeCluster.
+type XDRClusterReference struct {
+	// Name is the name of the AerospikeCluster.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the AerospikeCluster. Defaults to the AerospikeXDRTopology namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// KubeconfigSecretName is the name of a secret in the AerospikeXDRTopology namespace having a kubeconfig
+	// in the "kubeconfig" key. It is used to access an AerospikeCluster in another Kubernetes cluster.
+	// If not set, the AerospikeCluster is in the same Kubernetes cluster as the operator.
+	// +optional
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+}
+
+func (c *XDRClusterReference) String() string {
+	if c.KubeconfigSecretName != "" {
+		return fmt.Sprintf("%s:%s/%s", c.KubeconfigSecretName, c.Namespace, c.Name)
+	}
+
+	return fmt.Sprintf("%s/%s", c.Namespace, c.Name)
+}
+
+// XDRDestinationSpec defines an XDR destination and its DC config in the source clusters.
+type XDRDestinationSpec struct {
+	// DCName is the name of the XDR DC generated in the source clusters for this destination.
+	// An existing DC with the same name in a source cluster aerospikeConfig is overwritten.
+	DCName string `json:"dcName"`
+
+	// Cluster is the destination AerospikeCluster.
+	Cluster XDRClusterReference `json:"cluster"`
+
+	// SeedSource is where the seeds of the destination cluster are resolved from. Defaults to pod.
+	// +optional
+	SeedSource XDRSeedSource `json:"seedSource,omitempty"`
+
+	// TLSName is the TLS name used to connect to the destination cluster.
+	// If set, the TLS access endpoints of the destination cluster are used as seeds.
+	// +optional
+	TLSName string `json:"tlsName,omitempty"`
+
+	// DCConfig is the free form XDR DC config in YAML format, like namespaces and auth-user.
+	// The name and node-address-ports fields are generated and should not be set.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	DCConfig *runtime.RawExtension `json:"dcConfig,omitempty"`
+}
+
+// XDRDCStatus is the status of the XDR DC generated for a destination.
+type XDRDCStatus struct {
+	// Name is the name of the XDR DC.
+	Name string `json:"name"`
+
+	// Cluster is the destination AerospikeCluster.
+	Cluster XDRClusterReference `json:"cluster"`
+
+	// Seeds is the list of resolved seeds in the node-address-ports format.
+	// +optional
+	Seeds []string `json:"seeds,omitempty"`
+
+	// Message is a human-readable message indicating why the seeds could not be resolved.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AerospikeXDRTopologyStatus defines the observed state of AerospikeXDRTopology
+type AerospikeXDRTopologyStatus struct {
+	// Phase denotes the current phase of AerospikeXDRTopology.
+	// +optional
+	Phase AerospikeXDRTopologyPhase `json:"phase,omitempty"`
+
+	// Sources is the list of source AerospikeClusters the XDR DC config is applied to.
+	// +optional
+	Sources []XDRClusterReference `json:"sources,omitempty"`
+
+	// DCs is the list of XDR DCs applied to the source clusters.
+	// +optional
+	DCs []XDRDCStatus `json:"dcs,omitempty"`
+
+	// Message is a human-readable message indicating details about the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:annotations="aerospike-kubernetes-operator/version=4.2.0-dev1"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AerospikeXDRTopology is the Schema for the aerospikexdrtopologies API
+type AerospikeXDRTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AerospikeXDRTopologySpec   `json:"spec,omitempty"`
+	Status AerospikeXDRTopologyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AerospikeXDRTopologyList contains a list of AerospikeXDRTopology
+type AerospikeXDRTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AerospikeXDRTopology `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AerospikeXDRTopology{}, &AerospikeXDRTopologyList{})
+}
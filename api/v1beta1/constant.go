@@ -15,14 +15,24 @@ const (
 
 // Restore config fields
 const (
-	RoutineKey        = "routine"
-	TimeKey           = "time"
-	SourceKey         = "source"
-	BackupDataPathKey = "backup-data-path"
+	RoutineKey         = "routine"
+	TimeKey            = "time"
+	SourceKey            = "source"
+	SourceNameKey        = "source-name"
+	BackupDataPathKey    = "backup-data-path"
+	DestinationKey       = "destination"
+	DestinationNameKey   = "destination-name"
+	PolicyKey            = "policy"
+	DisableReorderingKey = "disable-reordering"
 )
 
 const (
 	HTTPKey                   = "http"
 	AerospikeBackupServiceKey = "aerospike-backup-service"
-	RefreshTimeKey            = AerospikeBackupServiceKey + "/last-refresh"
+)
+
+// Volumes of the projected ServiceAccount token for the workload identity.
+const (
+	AWSTokenVolumeName   = "aws-iam-token"
+	AzureTokenVolumeName = "azure-identity-token"
 )
@@ -21,7 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -147,10 +148,15 @@ func (in *AerospikeBackupServiceList) DeepCopyObject() runtime.Object {
 func (in *AerospikeBackupServiceSpec) DeepCopyInto(out *AerospikeBackupServiceSpec) {
 	*out = *in
 	in.Config.DeepCopyInto(&out.Config)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	in.PodSpec.DeepCopyInto(&out.PodSpec)
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretMounts != nil {
@@ -165,6 +171,16 @@ func (in *AerospikeBackupServiceSpec) DeepCopyInto(out *AerospikeBackupServiceSp
 		*out = new(Service)
 		**out = **in
 	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(BackupServiceConnection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeBackupServiceSpec.
@@ -184,7 +200,7 @@ func (in *AerospikeBackupServiceStatus) DeepCopyInto(out *AerospikeBackupService
 	in.PodSpec.DeepCopyInto(&out.PodSpec)
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretMounts != nil {
@@ -199,6 +215,16 @@ func (in *AerospikeBackupServiceStatus) DeepCopyInto(out *AerospikeBackupService
 		*out = new(Service)
 		**out = **in
 	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigReload != nil {
+		in, out := &in.ConfigReload, &out.ConfigReload
+		*out = new(BackupServiceConfigReloadStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeBackupServiceStatus.
@@ -211,16 +237,98 @@ func (in *AerospikeBackupServiceStatus) DeepCopy() *AerospikeBackupServiceStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeBackupSnapshot) DeepCopyInto(out *AerospikeBackupSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeBackupSnapshot.
+func (in *AerospikeBackupSnapshot) DeepCopy() *AerospikeBackupSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeBackupSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeBackupSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeBackupSnapshotList) DeepCopyInto(out *AerospikeBackupSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AerospikeBackupSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeBackupSnapshotList.
+func (in *AerospikeBackupSnapshotList) DeepCopy() *AerospikeBackupSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeBackupSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeBackupSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeBackupSnapshotSpec) DeepCopyInto(out *AerospikeBackupSnapshotSpec) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.Created.DeepCopyInto(&out.Created)
+	in.Finished.DeepCopyInto(&out.Finished)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeBackupSnapshotSpec.
+func (in *AerospikeBackupSnapshotSpec) DeepCopy() *AerospikeBackupSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeBackupSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeBackupSpec) DeepCopyInto(out *AerospikeBackupSpec) {
 	*out = *in
-	out.BackupService = in.BackupService
+	in.BackupService.DeepCopyInto(&out.BackupService)
 	in.Config.DeepCopyInto(&out.Config)
 	if in.OnDemandBackups != nil {
 		in, out := &in.OnDemandBackups, &out.OnDemandBackups
 		*out = make([]OnDemandBackupSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.OnDemandBackupRetention != nil {
+		in, out := &in.OnDemandBackupRetention, &out.OnDemandBackupRetention
+		*out = new(int32)
+		**out = **in
+	}
+	out.InventoryPollingPeriod = in.InventoryPollingPeriod
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeBackupSpec.
@@ -236,12 +344,25 @@ func (in *AerospikeBackupSpec) DeepCopy() *AerospikeBackupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeBackupStatus) DeepCopyInto(out *AerospikeBackupStatus) {
 	*out = *in
-	out.BackupService = in.BackupService
+	in.BackupService.DeepCopyInto(&out.BackupService)
 	in.Config.DeepCopyInto(&out.Config)
 	if in.OnDemandBackups != nil {
 		in, out := &in.OnDemandBackups, &out.OnDemandBackups
-		*out = make([]OnDemandBackupSpec, len(*in))
-		copy(*out, *in)
+		*out = make([]OnDemandBackupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Routines != nil {
+		in, out := &in.Routines, &out.Routines
+		*out = make(map[string]BackupRoutineStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.InventorySyncTime != nil {
+		in, out := &in.InventorySyncTime, &out.InventorySyncTime
+		*out = (*in).DeepCopy()
 	}
 }
 
@@ -346,8 +467,28 @@ func (in *AerospikeRestoreList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AerospikeRestoreSpec) DeepCopyInto(out *AerospikeRestoreSpec) {
 	*out = *in
-	out.BackupService = in.BackupService
+	in.BackupService.DeepCopyInto(&out.BackupService)
 	in.Config.DeepCopyInto(&out.Config)
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = new(RestorePointInTime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DestinationCluster != nil {
+		in, out := &in.DestinationCluster, &out.DestinationCluster
+		*out = new(RestoreDestinationCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RestoreRetryPolicy)
+		**out = **in
+	}
 	out.PollingPeriod = in.PollingPeriod
 }
 
@@ -370,6 +511,32 @@ func (in *AerospikeRestoreStatus) DeepCopyInto(out *AerospikeRestoreStatus) {
 		**out = **in
 	}
 	in.RestoreResult.DeepCopyInto(&out.RestoreResult)
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]RestoreAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextAttemptTime != nil {
+		in, out := &in.NextAttemptTime, &out.NextAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreChain != nil {
+		in, out := &in.RestoreChain, &out.RestoreChain
+		*out = new(RestoreChain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DestinationCluster != nil {
+		in, out := &in.DestinationCluster, &out.DestinationCluster
+		*out = new(RestoreDestinationClusterStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeRestoreStatus.
@@ -383,145 +550,921 @@ func (in *AerospikeRestoreStatus) DeepCopy() *AerospikeRestoreStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupService) DeepCopyInto(out *BackupService) {
+func (in *AerospikeVolumeSnapshotBackup) DeepCopyInto(out *AerospikeVolumeSnapshotBackup) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupService.
-func (in *BackupService) DeepCopy() *BackupService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeVolumeSnapshotBackup.
+func (in *AerospikeVolumeSnapshotBackup) DeepCopy() *AerospikeVolumeSnapshotBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupService)
+	out := new(AerospikeVolumeSnapshotBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeVolumeSnapshotBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OnDemandBackupSpec) DeepCopyInto(out *OnDemandBackupSpec) {
+func (in *AerospikeVolumeSnapshotBackupList) DeepCopyInto(out *AerospikeVolumeSnapshotBackupList) {
 	*out = *in
-	out.Delay = in.Delay
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AerospikeVolumeSnapshotBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnDemandBackupSpec.
-func (in *OnDemandBackupSpec) DeepCopy() *OnDemandBackupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeVolumeSnapshotBackupList.
+func (in *AerospikeVolumeSnapshotBackupList) DeepCopy() *AerospikeVolumeSnapshotBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(OnDemandBackupSpec)
+	out := new(AerospikeVolumeSnapshotBackupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeVolumeSnapshotBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchedulingPolicy) DeepCopyInto(out *SchedulingPolicy) {
+func (in *AerospikeVolumeSnapshotBackupSpec) DeepCopyInto(out *AerospikeVolumeSnapshotBackupSpec) {
 	*out = *in
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.SnapshotWindow = in.SnapshotWindow
+	if in.InfoHooks != nil {
+		in, out := &in.InfoHooks, &out.InfoHooks
+		*out = new(VolumeSnapshotInfoHooks)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(VolumeSnapshotRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeVolumeSnapshotBackupSpec.
+func (in *AerospikeVolumeSnapshotBackupSpec) DeepCopy() *AerospikeVolumeSnapshotBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AerospikeVolumeSnapshotBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AerospikeVolumeSnapshotBackupStatus) DeepCopyInto(out *AerospikeVolumeSnapshotBackupStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SnapshotSets != nil {
+		in, out := &in.SnapshotSets, &out.SnapshotSets
+		*out = make([]VolumeSnapshotSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicy.
-func (in *SchedulingPolicy) DeepCopy() *SchedulingPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeVolumeSnapshotBackupStatus.
+func (in *AerospikeVolumeSnapshotBackupStatus) DeepCopy() *AerospikeVolumeSnapshotBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SchedulingPolicy)
+	out := new(AerospikeVolumeSnapshotBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretMount) DeepCopyInto(out *SecretMount) {
+func (in *AerospikeXDRTopology) DeepCopyInto(out *AerospikeXDRTopology) {
 	*out = *in
-	in.VolumeMount.DeepCopyInto(&out.VolumeMount)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretMount.
-func (in *SecretMount) DeepCopy() *SecretMount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeXDRTopology.
+func (in *AerospikeXDRTopology) DeepCopy() *AerospikeXDRTopology {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretMount)
+	out := new(AerospikeXDRTopology)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeXDRTopology) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Service) DeepCopyInto(out *Service) {
+func (in *AerospikeXDRTopologyList) DeepCopyInto(out *AerospikeXDRTopologyList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AerospikeXDRTopology, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
-func (in *Service) DeepCopy() *Service {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeXDRTopologyList.
+func (in *AerospikeXDRTopologyList) DeepCopy() *AerospikeXDRTopologyList {
 	if in == nil {
 		return nil
 	}
-	out := new(Service)
+	out := new(AerospikeXDRTopologyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AerospikeXDRTopologyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceContainerSpec) DeepCopyInto(out *ServiceContainerSpec) {
+func (in *AerospikeXDRTopologySpec) DeepCopyInto(out *AerospikeXDRTopologySpec) {
 	*out = *in
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
-		(*in).DeepCopyInto(*out)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]XDRClusterReference, len(*in))
+		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]XDRDestinationSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceContainerSpec.
-func (in *ServiceContainerSpec) DeepCopy() *ServiceContainerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeXDRTopologySpec.
+func (in *AerospikeXDRTopologySpec) DeepCopy() *AerospikeXDRTopologySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceContainerSpec)
+	out := new(AerospikeXDRTopologySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServicePodSpec) DeepCopyInto(out *ServicePodSpec) {
+func (in *AerospikeXDRTopologyStatus) DeepCopyInto(out *AerospikeXDRTopologyStatus) {
 	*out = *in
-	in.ServiceContainerSpec.DeepCopyInto(&out.ServiceContainerSpec)
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.SchedulingPolicy.DeepCopyInto(&out.SchedulingPolicy)
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]XDRClusterReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.DCs != nil {
+		in, out := &in.DCs, &out.DCs
+		*out = make([]XDRDCStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePodSpec.
-func (in *ServicePodSpec) DeepCopy() *ServicePodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AerospikeXDRTopologyStatus.
+func (in *AerospikeXDRTopologyStatus) DeepCopy() *AerospikeXDRTopologyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServicePodSpec)
+	out := new(AerospikeXDRTopologyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupInfo) DeepCopyInto(out *BackupInfo) {
+	*out = *in
+	in.Created.DeepCopyInto(&out.Created)
+	in.Finished.DeepCopyInto(&out.Finished)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupInfo.
+func (in *BackupInfo) DeepCopy() *BackupInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRoutineStatus) DeepCopyInto(out *BackupRoutineStatus) {
+	*out = *in
+	if in.LastFullBackup != nil {
+		in, out := &in.LastFullBackup, &out.LastFullBackup
+		*out = new(BackupInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastIncrementalBackup != nil {
+		in, out := &in.LastIncrementalBackup, &out.LastIncrementalBackup
+		*out = new(BackupInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextFullBackupDeadline != nil {
+		in, out := &in.NextFullBackupDeadline, &out.NextFullBackupDeadline
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRoutineStatus.
+func (in *BackupRoutineStatus) DeepCopy() *BackupRoutineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRoutineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupService) DeepCopyInto(out *BackupService) {
+	*out = *in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(BackupServiceConnection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupService.
+func (in *BackupService) DeepCopy() *BackupService {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupServiceAuth) DeepCopyInto(out *BackupServiceAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupServiceAuth.
+func (in *BackupServiceAuth) DeepCopy() *BackupServiceAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupServiceAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupServiceConfigReloadStatus) DeepCopyInto(out *BackupServiceConfigReloadStatus) {
+	*out = *in
+	if in.LastReloadTime != nil {
+		in, out := &in.LastReloadTime, &out.LastReloadTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupServiceConfigReloadStatus.
+func (in *BackupServiceConfigReloadStatus) DeepCopy() *BackupServiceConfigReloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupServiceConfigReloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupServiceConnection) DeepCopyInto(out *BackupServiceConnection) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(BackupServiceTLS)
+		**out = **in
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(BackupServiceAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupServiceConnection.
+func (in *BackupServiceConnection) DeepCopy() *BackupServiceConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupServiceConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupServiceTLS) DeepCopyInto(out *BackupServiceTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupServiceTLS.
+func (in *BackupServiceTLS) DeepCopy() *BackupServiceTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupServiceTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceObjectCount) DeepCopyInto(out *NamespaceObjectCount) {
+	*out = *in
+	if in.Sets != nil {
+		in, out := &in.Sets, &out.Sets
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceObjectCount.
+func (in *NamespaceObjectCount) DeepCopy() *NamespaceObjectCount {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceObjectCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnDemandBackupSpec) DeepCopyInto(out *OnDemandBackupSpec) {
+	*out = *in
+	out.Delay = in.Delay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnDemandBackupSpec.
+func (in *OnDemandBackupSpec) DeepCopy() *OnDemandBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OnDemandBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnDemandBackupStatus) DeepCopyInto(out *OnDemandBackupStatus) {
+	*out = *in
+	out.OnDemandBackupSpec = in.OnDemandBackupSpec
+	if in.ScheduledTime != nil {
+		in, out := &in.ScheduledTime, &out.ScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousFullBackupTime != nil {
+		in, out := &in.PreviousFullBackupTime, &out.PreviousFullBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]BackupInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnDemandBackupStatus.
+func (in *OnDemandBackupStatus) DeepCopy() *OnDemandBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OnDemandBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreAttempt) DeepCopyInto(out *RestoreAttempt) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreAttempt.
+func (in *RestoreAttempt) DeepCopy() *RestoreAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreChain) DeepCopyInto(out *RestoreChain) {
+	*out = *in
+	in.FullBackup.DeepCopyInto(&out.FullBackup)
+	if in.IncrementalBackups != nil {
+		in, out := &in.IncrementalBackups, &out.IncrementalBackups
+		*out = make([]RestoreChainBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestoredKeys != nil {
+		in, out := &in.RestoredKeys, &out.RestoredKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.RestoredResult.DeepCopyInto(&out.RestoredResult)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreChain.
+func (in *RestoreChain) DeepCopy() *RestoreChain {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreChainBackup) DeepCopyInto(out *RestoreChainBackup) {
+	*out = *in
+	in.Created.DeepCopyInto(&out.Created)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreChainBackup.
+func (in *RestoreChainBackup) DeepCopy() *RestoreChainBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreChainBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreDestinationCluster) DeepCopyInto(out *RestoreDestinationCluster) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreDestinationCluster.
+func (in *RestoreDestinationCluster) DeepCopy() *RestoreDestinationCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreDestinationCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreDestinationClusterStatus) DeepCopyInto(out *RestoreDestinationClusterStatus) {
+	*out = *in
+	if in.SeedNodes != nil {
+		in, out := &in.SeedNodes, &out.SeedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreDestinationClusterStatus.
+func (in *RestoreDestinationClusterStatus) DeepCopy() *RestoreDestinationClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreDestinationClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePointInTime) DeepCopyInto(out *RestorePointInTime) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePointInTime.
+func (in *RestorePointInTime) DeepCopy() *RestorePointInTime {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePointInTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRetryPolicy) DeepCopyInto(out *RestoreRetryPolicy) {
+	*out = *in
+	out.Backoff = in.Backoff
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRetryPolicy.
+func (in *RestoreRetryPolicy) DeepCopy() *RestoreRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerification) DeepCopyInto(out *RestoreVerification) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sets != nil {
+		in, out := &in.Sets, &out.Sets
+		*out = make([]RestoreVerificationSet, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerification.
+func (in *RestoreVerification) DeepCopy() *RestoreVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationSet) DeepCopyInto(out *RestoreVerificationSet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationSet.
+func (in *RestoreVerificationSet) DeepCopy() *RestoreVerificationSet {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationStatus) DeepCopyInto(out *RestoreVerificationStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceObjectCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VerificationTime != nil {
+		in, out := &in.VerificationTime, &out.VerificationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationStatus.
+func (in *RestoreVerificationStatus) DeepCopy() *RestoreVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingPolicy) DeepCopyInto(out *SchedulingPolicy) {
+	*out = *in
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicy.
+func (in *SchedulingPolicy) DeepCopy() *SchedulingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretMount) DeepCopyInto(out *SecretMount) {
+	*out = *in
+	in.VolumeMount.DeepCopyInto(&out.VolumeMount)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretMount.
+func (in *SecretMount) DeepCopy() *SecretMount {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Service) DeepCopyInto(out *Service) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
+func (in *Service) DeepCopy() *Service {
+	if in == nil {
+		return nil
+	}
+	out := new(Service)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceContainerSpec) DeepCopyInto(out *ServiceContainerSpec) {
+	*out = *in
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceContainerSpec.
+func (in *ServiceContainerSpec) DeepCopy() *ServiceContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePodSpec) DeepCopyInto(out *ServicePodSpec) {
+	*out = *in
+	in.ServiceContainerSpec.DeepCopyInto(&out.ServiceContainerSpec)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.SchedulingPolicy.DeepCopyInto(&out.SchedulingPolicy)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePodSpec.
+func (in *ServicePodSpec) DeepCopy() *ServicePodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotInfoHooks) DeepCopyInto(out *VolumeSnapshotInfoHooks) {
+	*out = *in
+	if in.PreSnapshot != nil {
+		in, out := &in.PreSnapshot, &out.PreSnapshot
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostSnapshot != nil {
+		in, out := &in.PostSnapshot, &out.PostSnapshot
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotInfoHooks.
+func (in *VolumeSnapshotInfoHooks) DeepCopy() *VolumeSnapshotInfoHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotInfoHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotRetention) DeepCopyInto(out *VolumeSnapshotRetention) {
+	*out = *in
+	if in.MaxSets != nil {
+		in, out := &in.MaxSets, &out.MaxSets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotRetention.
+func (in *VolumeSnapshotRetention) DeepCopy() *VolumeSnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotSet) DeepCopyInto(out *VolumeSnapshotSet) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotSet.
+func (in *VolumeSnapshotSet) DeepCopy() *VolumeSnapshotSet {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentity) DeepCopyInto(out *WorkloadIdentity) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentity.
+func (in *WorkloadIdentity) DeepCopy() *WorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XDRClusterReference) DeepCopyInto(out *XDRClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XDRClusterReference.
+func (in *XDRClusterReference) DeepCopy() *XDRClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(XDRClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XDRDCStatus) DeepCopyInto(out *XDRDCStatus) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.Seeds != nil {
+		in, out := &in.Seeds, &out.Seeds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XDRDCStatus.
+func (in *XDRDCStatus) DeepCopy() *XDRDCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(XDRDCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XDRDestinationSpec) DeepCopyInto(out *XDRDestinationSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.DCConfig != nil {
+		in, out := &in.DCConfig, &out.DCConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XDRDestinationSpec.
+func (in *XDRDestinationSpec) DeepCopy() *XDRDestinationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(XDRDestinationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,123 @@
+// aerospike-pod-init runs the init steps of the Aerospike pods. The init container runs it with the init sub-command, the
+// operator runs it in the Aerospike server container with the quick-restart and update-conf sub-commands.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	k8Runtime "k8s.io/apimachinery/pkg/runtime"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientGoScheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	crClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/podinit"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const usage = `Usage: aerospike-pod-init <command> [flags]
+
+Commands:
+  init           Write the aerospike.conf, initialize the volumes and publish the pod status
+  quick-restart  Refresh the aerospike.conf from the ConfigMap and warm restart the Aerospike server
+  update-conf    Refresh the aerospike.conf from the ConfigMap after a dynamic config update
+`
+
+var scheme = k8Runtime.NewScheme()
+
+func init() {
+	utilRuntime.Must(clientGoScheme.AddToScheme(scheme))
+	utilRuntime.Must(asdbv1.AddToScheme(scheme))
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+
+	var configDir, configMapDir, configMapName, configMapNamespace string
+
+	flags.StringVar(&configDir, "config-dir", podinit.DefaultConfigDir,
+		"The directory where the aerospike.conf template is written.")
+
+	switch command {
+	case "init":
+		flags.StringVar(&configMapDir, "configmap-dir", podinit.DefaultConfigMapDir,
+			"The directory where the rack ConfigMap is mounted.")
+	case "quick-restart", "update-conf":
+		flags.StringVar(&configMapName, "cm-name", "", "The name of the rack ConfigMap.")
+		flags.StringVar(&configMapNamespace, "cm-namespace", "", "The namespace of the rack ConfigMap.")
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// The logs are structured as JSON by default.
+	opts := zap.Options{}
+	opts.BindFlags(flags)
+
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	log := ctrl.Log.WithName(utils.InitBinaryName).WithValues("command", command)
+
+	if err := run(command, configDir, configMapDir, configMapName, configMapNamespace); err != nil {
+		log.Error(err, "Failed")
+		os.Exit(1)
+	}
+
+	log.Info("Completed")
+}
+
+func run(command, configDir, configMapDir, configMapName, configMapNamespace string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	env, err := podinit.GetPodEnv()
+	if err != nil {
+		return err
+	}
+
+	if configMapNamespace != "" && configMapNamespace != env.Namespace {
+		return fmt.Errorf("ConfigMap namespace %s is not the pod namespace %s", configMapNamespace, env.Namespace)
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := crClient.New(config, crClient.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	initializer := &podinit.Initializer{
+		Client:    client,
+		Log:       ctrl.Log.WithName(utils.InitBinaryName).WithValues("podName", env.PodName),
+		Env:       env,
+		ConfigDir: configDir,
+	}
+
+	switch command {
+	case "init":
+		return initializer.Init(ctx, configMapDir)
+	case "quick-restart":
+		return initializer.QuickRestart(ctx, configMapName)
+	default:
+		return initializer.UpdateConf(ctx, configMapName)
+	}
+}
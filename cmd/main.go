@@ -35,6 +35,8 @@ import (
 	backupservice "github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/backup-service"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/cluster"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/restore"
+	volumesnapshotbackup "github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/volume-snapshot-backup"
+	xdrtopology "github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/xdr-topology"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/configschema"
 
 	webhookv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/internal/webhook/v1beta1"
@@ -354,6 +356,45 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = webhookv1beta1.SetupAerospikeBackupSnapshotWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AerospikeBackupSnapshot")
+		os.Exit(1)
+	}
+
+	if err = (&volumesnapshotbackup.AerospikeVolumeSnapshotBackupReconciler{
+		Client: client,
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("controller").WithName("AerospikeVolumeSnapshotBackup"),
+		Recorder: eventBroadcaster.NewRecorder(
+			mgr.GetScheme(), v1.EventSource{Component: "aerospikeVolumeSnapshotBackup-controller"},
+		),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AerospikeVolumeSnapshotBackup")
+		os.Exit(1)
+	}
+
+	if err = webhookv1beta1.SetupAerospikeVolumeSnapshotBackupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AerospikeVolumeSnapshotBackup")
+		os.Exit(1)
+	}
+
+	if err = (&xdrtopology.AerospikeXDRTopologyReconciler{
+		Client: client,
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("controller").WithName("AerospikeXDRTopology"),
+		Recorder: eventBroadcaster.NewRecorder(
+			mgr.GetScheme(), v1.EventSource{Component: "aerospikeXDRTopology-controller"},
+		),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AerospikeXDRTopology")
+		os.Exit(1)
+	}
+
+	if err = webhookv1beta1.SetupAerospikeXDRTopologyWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AerospikeXDRTopology")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
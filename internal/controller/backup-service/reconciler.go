@@ -3,15 +3,18 @@ package backupservice
 import (
 	"context"
 	"fmt"
+	"path"
 	"time"
 
 	"github.com/go-logr/logr"
 	app "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -25,7 +28,6 @@ import (
 	"github.com/aerospike/aerospike-backup-service/v3/pkg/validation"
 	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
-	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
 	lib "github.com/aerospike/aerospike-management-lib"
 )
@@ -115,7 +117,46 @@ func (r *SingleBackupServiceReconciler) Reconcile() (result ctrl.Result, recErr
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcileServiceAccount(); err != nil {
+		r.Log.Error(err, "Failed to reconcile service account", "serviceAccount", r.getServiceAccount())
+		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeWarning,
+			"ServiceAccountReconcileFailed", "Failed to reconcile service account %s/%s",
+			r.aeroBackupService.Namespace, r.getServiceAccount())
+
+		recErr = err
+
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileStandby(); err != nil {
+		r.Log.Error(err, "Failed to reconcile standby",
+			"deployment", getStandbyName(r.aeroBackupService.Name))
+		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeWarning,
+			"StandbyReconcileFailed", "Failed to reconcile standby %s/%s",
+			r.aeroBackupService.Namespace, r.aeroBackupService.Name)
+
+		recErr = err
+
+		return ctrl.Result{}, err
+	}
+
+	// Serve from the standby pods if the leader pods are not healthy, before the leader pods are updated, as the
+	// leader update waits for the leader pods to be ready. Switching back to the leader happens in the next
+	// periodic reconcile once it is healthy.
+	if err := r.reconcileServingRole(); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.reconcileDeployment(); err != nil {
+		if isBackupInProgress(err) {
+			r.Log.Info("Deferring backup service restart as backups are in progress", "reason", err.Error())
+			r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeNormal,
+				"RestartDeferred", "Deferred restart of Backup Service %s/%s as backups are in progress",
+				r.aeroBackupService.Namespace, r.aeroBackupService.Name)
+
+			return ctrl.Result{RequeueAfter: backupInProgressRequeuePeriod * time.Second}, nil
+		}
+
 		r.Log.Error(err, "Failed to reconcile deployment",
 			"deployment", getBackupServiceName(r.aeroBackupService))
 		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeWarning,
@@ -127,6 +168,18 @@ func (r *SingleBackupServiceReconciler) Reconcile() (result ctrl.Result, recErr
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcilePDB(); err != nil {
+		r.Log.Error(err, "Failed to reconcile PodDisruptionBudget",
+			"pdb", getBackupServiceName(r.aeroBackupService))
+		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeWarning,
+			"PDBReconcileFailed", "Failed to reconcile PodDisruptionBudget %s/%s",
+			r.aeroBackupService.Namespace, r.aeroBackupService.Name)
+
+		recErr = err
+
+		return ctrl.Result{}, err
+	}
+
 	if err := r.updateStatus(); err != nil {
 		r.Log.Error(err, "Failed to update status")
 		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeWarning,
@@ -138,6 +191,11 @@ func (r *SingleBackupServiceReconciler) Reconcile() (result ctrl.Result, recErr
 
 	r.Log.Info("Reconcile completed successfully")
 
+	// The leader health is checked periodically to fail over the service to the standby pods.
+	if r.isReplicated() {
+		return ctrl.Result{RequeueAfter: leaderHealthCheckPeriod * time.Second}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -261,7 +319,7 @@ func (r *SingleBackupServiceReconciler) getConfigMapData() map[string]string {
 }
 
 func (r *SingleBackupServiceReconciler) reconcileDeployment() error {
-	deployment, err := r.getBackupSvcDeployment()
+	deployment, err := r.getBackupSvcDeployment(r.aeroBackupService.Name)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return err
@@ -293,7 +351,7 @@ func (r *SingleBackupServiceReconciler) reconcileDeployment() error {
 		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeNormal, "DeploymentCreated",
 			"Created Backup Service Deployment %s/%s", r.aeroBackupService.Namespace, r.aeroBackupService.Name)
 
-		return r.waitForDeploymentToBeReady()
+		return r.waitForDeploymentToBeReady(r.aeroBackupService.Name)
 	}
 
 	r.Log.Info(
@@ -308,6 +366,13 @@ func (r *SingleBackupServiceReconciler) reconcileDeployment() error {
 		return err
 	}
 
+	if !equality.Semantic.DeepDerivative(desiredDeployObj.Spec, deployment.Spec) {
+		// Deployment spec change restarts the backup service pod, wait for the running backups to finish.
+		if err = r.checkBackupsInProgress(); err != nil {
+			return err
+		}
+	}
+
 	deployment.Spec = desiredDeployObj.Spec
 
 	if err = r.Update(context.TODO(), deployment, common.UpdateOption); err != nil {
@@ -323,24 +388,24 @@ func (r *SingleBackupServiceReconciler) reconcileDeployment() error {
 		r.Log.Info("Deployment spec is updated, will result in rolling restart of Backup service pod",
 			"deployment", getBackupServiceName(r.aeroBackupService))
 
-		return r.waitForDeploymentToBeReady()
+		return r.waitForDeploymentToBeReady(r.aeroBackupService.Name)
 	}
 
 	// Wait for deployment pods to be ready before doing any operation related to the backup service
-	if err := r.waitForDeploymentToBeReady(); err != nil {
+	if err := r.waitForDeploymentToBeReady(r.aeroBackupService.Name); err != nil {
 		return err
 	}
 
 	return r.updateBackupSvcConfig()
 }
 
-func (r *SingleBackupServiceReconciler) getBackupSvcDeployment() (*app.Deployment, error) {
+func (r *SingleBackupServiceReconciler) getBackupSvcDeployment(name string) (*app.Deployment, error) {
 	var deployment app.Deployment
 
 	if err := r.Get(context.TODO(),
 		types.NamespacedName{
 			Namespace: r.aeroBackupService.Namespace,
-			Name:      r.aeroBackupService.Name,
+			Name:      name,
 		}, &deployment,
 	); err != nil {
 		return nil, err
@@ -368,13 +433,14 @@ func (r *SingleBackupServiceReconciler) updateBackupSvcConfig() error {
 	}
 
 	// Always create client with the latest config in spec
-	backupServiceClient := backup_service.NewClient(
-		fmt.Sprintf("%s.%s.svc", backupSvc.Name, backupSvc.Namespace),
-		svcConfig.portInfo[asdbv1beta1.HTTPKey],
-		svcConfig.contextPath,
+	backupServiceClient, err := r.newBackupServiceClient(
+		fmt.Sprintf("%s.%s.svc", backupSvc.Name, backupSvc.Namespace), svcConfig,
 	)
+	if err != nil {
+		return err
+	}
 
-	apiBackupSvcConfig, err := backupServiceClient.GetBackupServiceConfig()
+	apiBackupSvcConfig, err := backupServiceClient.GetBackupServiceConfig(context.TODO())
 	if err != nil {
 		return err
 	}
@@ -411,15 +477,48 @@ func (r *SingleBackupServiceReconciler) updateBackupSvcConfig() error {
 
 	if err := validation.ValidateStaticFieldChanges(&currentConfig, &desiredConfig); err != nil {
 		r.Log.Info("Static config change detected, will result in rolling restart of Backup service pod")
+
+		if err := r.checkBackupsInProgress(); err != nil {
+			return err
+		}
+
 		// In case of static config change restart the backup service pod
 		return r.restartBackupSvcPod()
 	}
 
-	return common.ReloadBackupServiceConfigInPods(r.Client, backupServiceClient, r.Log, backupSvc)
+	if err := common.ReloadBackupServiceConfigInPods(r.Client, backupServiceClient, r.Log, backupSvc); err != nil {
+		return err
+	}
+
+	// Reload progress is updated in the status, refresh the object to avoid conflicts in the later status updates.
+	return r.Get(context.TODO(), r.getNamespacedName(r.aeroBackupService.Name), r.aeroBackupService)
 }
 
+// restartBackupSvcPod restarts the backup service pods.
+// With more than one replica, the standby pods are restarted first, and they serve the API while the leader
+// is restarted.
 func (r *SingleBackupServiceReconciler) restartBackupSvcPod() error {
-	podList, err := common.GetBackupServicePodList(r.Client, r.aeroBackupService.Name, r.aeroBackupService.Namespace)
+	if !r.isReplicated() {
+		return r.restartPods(r.aeroBackupService.Name)
+	}
+
+	if err := r.restartPods(getStandbyName(r.aeroBackupService.Name)); err != nil {
+		return err
+	}
+
+	if err := r.setServingRole(asdbv1beta1.BackupServiceStandby); err != nil {
+		return err
+	}
+
+	if err := r.restartPods(r.aeroBackupService.Name); err != nil {
+		return err
+	}
+
+	return r.setServingRole(asdbv1beta1.BackupServiceLeader)
+}
+
+func (r *SingleBackupServiceReconciler) restartPods(name string) error {
+	podList, err := common.GetBackupServicePodList(r.Client, name, r.aeroBackupService.Namespace)
 	if err != nil {
 		return err
 	}
@@ -433,7 +532,7 @@ func (r *SingleBackupServiceReconciler) restartBackupSvcPod() error {
 		}
 	}
 
-	return r.waitForDeploymentToBeReady()
+	return r.waitForDeploymentToBeReady(name)
 }
 
 func getBackupServiceName(aeroBackupService *asdbv1beta1.AerospikeBackupService) types.NamespacedName {
@@ -482,6 +581,7 @@ func (r *SingleBackupServiceReconciler) getDeploymentObject() (*app.Deployment,
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							VolumeMounts:    volumeMounts,
 							Ports:           containerPorts,
+							ReadinessProbe:  r.getReadinessProbe(svcConf.contextPath),
 						},
 					},
 					Volumes: volumes,
@@ -490,16 +590,59 @@ func (r *SingleBackupServiceReconciler) getDeploymentObject() (*app.Deployment,
 		},
 	}
 
+	// A single leader pod runs the scheduled backups, hence the old pod is stopped before starting the new one.
+	// The standby pods serve the API meanwhile.
+	if r.isReplicated() {
+		deploy.Spec.Strategy = app.DeploymentStrategy{Type: app.RecreateDeploymentStrategyType}
+	}
+
 	r.updateDeploymentFromPodSpec(deploy)
 
 	return deploy, nil
 }
 
+// getReadinessProbe returns the readiness probe of the backup service container on the health API.
+// The port, context path and HTTPS scheme of the connection are used if given, as for the API clients.
+func (r *SingleBackupServiceReconciler) getReadinessProbe(contextPath string) *corev1.Probe {
+	httpGet := &corev1.HTTPGetAction{
+		Port: intstr.FromString(asdbv1beta1.HTTPKey),
+	}
+
+	if conn := r.aeroBackupService.Spec.Connection; conn != nil {
+		if conn.Port != 0 {
+			httpGet.Port = intstr.FromInt32(conn.Port)
+		}
+
+		if conn.ContextPath != "" {
+			contextPath = conn.ContextPath
+		}
+
+		if conn.TLS != nil {
+			httpGet.Scheme = corev1.URISchemeHTTPS
+		}
+	}
+
+	httpGet.Path = path.Join("/", contextPath, "v1", "health")
+
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: httpGet,
+		},
+		PeriodSeconds:    10,
+		FailureThreshold: 3,
+	}
+}
+
 func (r *SingleBackupServiceReconciler) getServiceAccount() string {
 	if r.aeroBackupService.Spec.PodSpec.ServiceAccountName != "" {
 		return r.aeroBackupService.Spec.PodSpec.ServiceAccountName
 	}
 
+	// The default ServiceAccount is shared by the backup services, a dedicated one is used for the workload identity.
+	if r.aeroBackupService.Spec.WorkloadIdentity != nil {
+		return r.getWorkloadIdentityServiceAccount()
+	}
+
 	return asdbv1beta1.AerospikeBackupServiceKey
 }
 
@@ -516,6 +659,7 @@ func (r *SingleBackupServiceReconciler) updateDeploymentFromPodSpec(deploy *app.
 	deploy.Spec.Template.Spec.ImagePullSecrets = r.aeroBackupService.Spec.PodSpec.ImagePullSecrets
 
 	r.updateBackupServiceContainer(deploy)
+	r.updateDeploymentWorkloadIdentity(deploy)
 }
 
 func (r *SingleBackupServiceReconciler) updateDeploymentSchedulingPolicy(deploy *app.Deployment) {
@@ -662,7 +806,7 @@ func (r *SingleBackupServiceReconciler) getServiceObject() (*corev1.Service, err
 			Labels:    utils.LabelsForAerospikeBackupService(r.aeroBackupService.Name),
 		},
 		Spec: corev1.ServiceSpec{
-			Selector: utils.LabelsForAerospikeBackupService(r.aeroBackupService.Name),
+			Selector: utils.LabelsForAerospikeBackupService(r.getServingName()),
 			Ports:    servicePort,
 		},
 	}
@@ -720,20 +864,22 @@ func (r *SingleBackupServiceReconciler) getBackupServiceConfig() (*serviceConfig
 	return &svcConfig, nil
 }
 
-func (r *SingleBackupServiceReconciler) waitForDeploymentToBeReady() error {
+// waitForDeploymentToBeReady waits for the pods of the deployment to be ready and the backup service in them to be
+// healthy.
+func (r *SingleBackupServiceReconciler) waitForDeploymentToBeReady(name string) error {
 	const (
 		podStatusTimeout       = 2 * time.Minute
 		podStatusRetryInterval = 5 * time.Second
 	)
 
 	r.Log.Info(
-		"Waiting for deployment to be ready", "deployment", getBackupServiceName(r.aeroBackupService),
+		"Waiting for deployment to be ready", "deployment", r.getNamespacedName(name),
 		"WaitTimePerPod", podStatusTimeout,
 	)
 
 	if err := wait.PollUntilContextTimeout(context.TODO(),
 		podStatusRetryInterval, podStatusTimeout, true, func(ctx context.Context) (done bool, err error) {
-			deployment, err := r.getBackupSvcDeployment()
+			deployment, err := r.getBackupSvcDeployment(name)
 			if err != nil {
 				return false, err
 			}
@@ -742,18 +888,18 @@ func (r *SingleBackupServiceReconciler) waitForDeploymentToBeReady() error {
 			// pods with new spec are yet to be created.
 			if deployment.Generation > deployment.Status.ObservedGeneration {
 				r.Log.Info("Waiting for deployment to be ready",
-					"deployment", getBackupServiceName(r.aeroBackupService))
+					"deployment", r.getNamespacedName(name))
 				return false, nil
 			}
 
-			podList, err := common.GetBackupServicePodList(r.Client, r.aeroBackupService.Name, r.aeroBackupService.Namespace)
+			podList, err := common.GetBackupServicePodList(r.Client, name, r.aeroBackupService.Namespace)
 			if err != nil {
 				return false, err
 			}
 
 			if len(podList.Items) == 0 {
 				r.Log.Info("No pod found for deployment",
-					"deployment", getBackupServiceName(r.aeroBackupService))
+					"deployment", r.getNamespacedName(name))
 				return false, nil
 			}
 
@@ -768,6 +914,12 @@ func (r *SingleBackupServiceReconciler) waitForDeploymentToBeReady() error {
 					r.Log.Info("Pod is not ready", "pod", utils.GetNamespacedName(pod))
 					return false, nil
 				}
+
+				if err := r.checkPodHealth(pod); err != nil {
+					r.Log.Info("Backup service is not healthy", "pod", utils.GetNamespacedName(pod),
+						"err", err.Error())
+					return false, nil
+				}
 			}
 
 			if deployment.Status.Replicas != *deployment.Spec.Replicas {
@@ -780,7 +932,7 @@ func (r *SingleBackupServiceReconciler) waitForDeploymentToBeReady() error {
 		return err
 	}
 
-	r.Log.Info("Deployment is ready", "deployment", getBackupServiceName(r.aeroBackupService))
+	r.Log.Info("Deployment is ready", "deployment", r.getNamespacedName(name))
 
 	return nil
 }
@@ -808,6 +960,9 @@ func (r *SingleBackupServiceReconciler) updateStatus() error {
 	status.ContextPath = svcConfig.contextPath
 	status.Port = svcConfig.portInfo[asdbv1beta1.HTTPKey]
 	status.Phase = asdbv1beta1.AerospikeBackupServiceCompleted
+	status.Replicas = r.getReplicas()
+	status.ServingRole = r.aeroBackupService.Status.ServingRole
+	status.ConfigReload = r.aeroBackupService.Status.ConfigReload
 
 	r.aeroBackupService.Status = *status
 
@@ -823,6 +978,7 @@ func (r *SingleBackupServiceReconciler) CopySpecToStatus() *asdbv1beta1.Aerospik
 	status.Resources = r.aeroBackupService.Spec.Resources
 	status.SecretMounts = r.aeroBackupService.Spec.SecretMounts
 	status.Service = r.aeroBackupService.Spec.Service
+	status.WorkloadIdentity = r.aeroBackupService.Spec.WorkloadIdentity
 
 	return &status
 }
@@ -0,0 +1,615 @@
+package backupservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	app "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/validation"
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const (
+	// leaderHealthCheckPeriod is the period, in seconds, the leader health is checked at with more than one replica.
+	leaderHealthCheckPeriod = 30
+
+	// backupInProgressRequeuePeriod is the period, in seconds, the restart of the leader is retried at
+	// while backups are running.
+	backupInProgressRequeuePeriod = 30
+)
+
+// errBackupInProgress is returned if the leader can not be restarted as backups are running in it.
+var errBackupInProgress = errors.New("backup in progress")
+
+func isBackupInProgress(err error) bool {
+	return errors.Is(err, errBackupInProgress)
+}
+
+func getStandbyName(name string) string {
+	return common.GetBackupServiceStandbyName(name)
+}
+
+func (r *SingleBackupServiceReconciler) getReplicas() int32 {
+	if r.aeroBackupService.Spec.Replicas != nil {
+		return *r.aeroBackupService.Spec.Replicas
+	}
+
+	return 1
+}
+
+func (r *SingleBackupServiceReconciler) isReplicated() bool {
+	return r.getReplicas() > 1
+}
+
+// getServingName returns the name of the Deployment the Kubernetes service is serving the API from.
+func (r *SingleBackupServiceReconciler) getServingName() string {
+	if r.aeroBackupService.Status.ServingRole == asdbv1beta1.BackupServiceStandby {
+		return getStandbyName(r.aeroBackupService.Name)
+	}
+
+	return r.aeroBackupService.Name
+}
+
+func (r *SingleBackupServiceReconciler) getNamespacedName(name string) types.NamespacedName {
+	return types.NamespacedName{Name: name, Namespace: r.aeroBackupService.Namespace}
+}
+
+// getPodClient returns the backup service client connecting directly to the pod.
+func (r *SingleBackupServiceReconciler) getPodClient(pod *corev1.Pod) (*backup_service.Client, error) {
+	svcConfig, err := r.getBackupServiceConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newBackupServiceClient(pod.Status.PodIP, svcConfig)
+}
+
+// newBackupServiceClient returns the backup service client for the given address, with the connection options of
+// the AerospikeBackupService, like the backup and restore controllers.
+func (r *SingleBackupServiceReconciler) newBackupServiceClient(
+	address string, svcConfig *serviceConfig,
+) (*backup_service.Client, error) {
+	port := svcConfig.portInfo[asdbv1beta1.HTTPKey]
+	contextPath := svcConfig.contextPath
+
+	conn := r.aeroBackupService.Spec.Connection
+	if conn != nil {
+		if conn.Port != 0 {
+			port = conn.Port
+		}
+
+		if conn.ContextPath != "" {
+			contextPath = conn.ContextPath
+		}
+	}
+
+	return backup_service.NewClientWithConnection(
+		r.Client, r.aeroBackupService.Namespace, address, port, contextPath, conn,
+	)
+}
+
+// checkPodHealth checks the health API of the backup service in the pod.
+func (r *SingleBackupServiceReconciler) checkPodHealth(pod *corev1.Pod) error {
+	podClient, err := r.getPodClient(pod)
+	if err != nil {
+		return err
+	}
+
+	return podClient.CheckBackupServiceHealth(context.TODO())
+}
+
+// checkDeploymentHealth returns an error if no pod of the deployment is ready with a healthy backup service.
+func (r *SingleBackupServiceReconciler) checkDeploymentHealth(name string) error {
+	podList, err := common.GetBackupServicePodList(r.Client, name, r.aeroBackupService.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+
+		if !utils.IsPodRunningAndReady(pod) {
+			lastErr = fmt.Errorf("pod %s is not ready", pod.Name)
+			continue
+		}
+
+		if lastErr = r.checkPodHealth(pod); lastErr == nil {
+			return nil
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no pod found for deployment %s", name)
+	}
+
+	return lastErr
+}
+
+// checkBackupsInProgress returns errBackupInProgress if a backup is running in the leader.
+// Backups can not be checked if the leader is not reachable, in which case nothing is running.
+func (r *SingleBackupServiceReconciler) checkBackupsInProgress() error {
+	podList, err := common.GetBackupServicePodList(r.Client, r.aeroBackupService.Name, r.aeroBackupService.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+
+		if !utils.IsPodRunningAndReady(pod) {
+			continue
+		}
+
+		podClient, err := r.getPodClient(pod)
+		if err != nil {
+			return err
+		}
+
+		routines, err := podClient.GetBackupRoutines(context.TODO())
+		if err != nil {
+			r.Log.Info("Failed to get backup routines, skipping running backups check",
+				"pod", utils.GetNamespacedName(pod), "err", err.Error())
+
+			continue
+		}
+
+		for routineName := range routines {
+			state, err := podClient.GetCurrentBackup(context.TODO(), routineName)
+			if err != nil {
+				r.Log.Info("Failed to get current backup, skipping running backups check",
+					"routine", routineName, "err", err.Error())
+
+				continue
+			}
+
+			if state.Full != nil || state.Incremental != nil {
+				return fmt.Errorf("%w, routine %s in pod %s", errBackupInProgress, routineName, pod.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcileServingRole switches the Kubernetes service to the standby pods if the leader is not healthy,
+// and back to the leader once it is healthy. The standby pods run the backup routines while they serve.
+func (r *SingleBackupServiceReconciler) reconcileServingRole() error {
+	if !r.isReplicated() {
+		return r.setServingRole(asdbv1beta1.BackupServiceLeader)
+	}
+
+	leaderErr := r.checkDeploymentHealth(r.aeroBackupService.Name)
+	if leaderErr == nil {
+		return r.setServingRole(asdbv1beta1.BackupServiceLeader)
+	}
+
+	if err := r.checkDeploymentHealth(getStandbyName(r.aeroBackupService.Name)); err != nil {
+		r.Log.Info("Leader and standby backup service pods are not healthy", "leader", leaderErr.Error(),
+			"standby", err.Error())
+
+		return nil
+	}
+
+	r.Log.Info("Leader backup service pod is not healthy, serving from standby pods", "reason", leaderErr.Error())
+
+	return r.setServingRole(asdbv1beta1.BackupServiceStandby)
+}
+
+// setServingRole points the Kubernetes service to the pods of the given role.
+// The standby pods are promoted to run the backup routines before serving, and demoted after the leader pods
+// are reloaded with the latest config, as the config may have changed while the standby pods were serving.
+func (r *SingleBackupServiceReconciler) setServingRole(role asdbv1beta1.BackupServiceRole) error {
+	if r.aeroBackupService.Status.ServingRole == role {
+		return nil
+	}
+
+	oldRole := r.aeroBackupService.Status.ServingRole
+
+	if r.isReplicated() {
+		if oldRole == asdbv1beta1.BackupServiceStandby {
+			if err := r.reloadConfigInPods(r.aeroBackupService.Name); err != nil {
+				return err
+			}
+		}
+
+		configChanged, err := r.reconcileStandbyConfigMap(role == asdbv1beta1.BackupServiceStandby)
+		if err != nil {
+			return err
+		}
+
+		if configChanged {
+			if err := r.reloadConfigInPods(getStandbyName(r.aeroBackupService.Name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.aeroBackupService.Status.ServingRole = role
+
+	if err := r.Client.Status().Update(context.TODO(), r.aeroBackupService); err != nil {
+		return err
+	}
+
+	if err := r.reconcileService(); err != nil {
+		return err
+	}
+
+	if oldRole != "" {
+		r.Log.Info("Switched backup service serving role", "from", oldRole, "to", role)
+		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeNormal, "ServingRoleSwitched",
+			"Switched Backup Service %s/%s serving role from %s to %s", r.aeroBackupService.Namespace,
+			r.aeroBackupService.Name, oldRole, role)
+	}
+
+	return nil
+}
+
+// reconcileStandby creates or updates the standby ConfigMap and Deployment with more than one replica,
+// and deletes them otherwise.
+func (r *SingleBackupServiceReconciler) reconcileStandby() error {
+	standbyName := getStandbyName(r.aeroBackupService.Name)
+
+	if !r.isReplicated() {
+		return r.deleteStandby()
+	}
+
+	configChanged, err := r.reconcileStandbyConfigMap(
+		r.aeroBackupService.Status.ServingRole == asdbv1beta1.BackupServiceStandby,
+	)
+	if err != nil {
+		return err
+	}
+
+	desiredDeployObj, err := r.getStandbyDeploymentObject()
+	if err != nil {
+		return err
+	}
+
+	deployment, err := r.getBackupSvcDeployment(standbyName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		r.Log.Info("Creating Backup Service standby deployment", "deployment", r.getNamespacedName(standbyName))
+
+		if err = controllerutil.SetControllerReference(r.aeroBackupService, desiredDeployObj, r.Scheme); err != nil {
+			return err
+		}
+
+		if err = r.Create(context.TODO(), desiredDeployObj, common.CreateOption); err != nil {
+			return fmt.Errorf("failed to deploy Backup service standby deployment: %v", err)
+		}
+
+		r.Recorder.Eventf(r.aeroBackupService, corev1.EventTypeNormal, "StandbyDeploymentCreated",
+			"Created Backup Service standby Deployment %s/%s", r.aeroBackupService.Namespace, standbyName)
+
+		return r.waitForDeploymentToBeReady(standbyName)
+	}
+
+	oldResourceVersion := deployment.ResourceVersion
+	deployment.Spec = desiredDeployObj.Spec
+
+	if err = r.Update(context.TODO(), deployment, common.UpdateOption); err != nil {
+		return fmt.Errorf("failed to update Backup service standby deployment: %v", err)
+	}
+
+	if oldResourceVersion != deployment.ResourceVersion {
+		r.Log.Info("Standby deployment spec is updated, will result in rolling restart of standby pods",
+			"deployment", r.getNamespacedName(standbyName))
+
+		return r.waitForDeploymentToBeReady(standbyName)
+	}
+
+	if configChanged {
+		return r.reloadConfigInPods(standbyName)
+	}
+
+	return nil
+}
+
+// reconcileStandbyConfigMap creates or updates the standby ConfigMap and returns true if the config is updated.
+// The backup routines are enabled in the config only if the standby pods are promoted to serve in place of the
+// leader.
+func (r *SingleBackupServiceReconciler) reconcileStandbyConfigMap(promoted bool) (bool, error) {
+	standbyName := getStandbyName(r.aeroBackupService.Name)
+
+	standbyConfig, err := r.getStandbyConfig(promoted)
+	if err != nil {
+		return false, err
+	}
+
+	cm := &corev1.ConfigMap{}
+
+	if err = r.Get(context.TODO(), r.getNamespacedName(standbyName), cm); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      standbyName,
+				Namespace: r.aeroBackupService.Namespace,
+				Labels:    utils.LabelsForAerospikeBackupService(standbyName),
+			},
+			Data: map[string]string{asdbv1beta1.BackupServiceConfigYAML: standbyConfig},
+		}
+
+		if err = controllerutil.SetControllerReference(r.aeroBackupService, cm, r.Scheme); err != nil {
+			return false, err
+		}
+
+		if err = r.Create(context.TODO(), cm, common.CreateOption); err != nil {
+			return false, fmt.Errorf("failed to create standby ConfigMap: %v", err)
+		}
+
+		r.Log.Info("Created Backup Service standby ConfigMap", "configmap", r.getNamespacedName(standbyName))
+
+		return false, nil
+	}
+
+	if cm.Data[asdbv1beta1.BackupServiceConfigYAML] == standbyConfig {
+		return false, nil
+	}
+
+	cm.Data = map[string]string{asdbv1beta1.BackupServiceConfigYAML: standbyConfig}
+
+	if err = r.Update(context.TODO(), cm, common.UpdateOption); err != nil {
+		return false, fmt.Errorf("failed to update standby ConfigMap: %v", err)
+	}
+
+	r.Log.Info("Updated Backup Service standby ConfigMap", "configmap", r.getNamespacedName(standbyName))
+
+	return true, nil
+}
+
+// getStandbyConfig returns the leader config, including the routines added by the AerospikeBackups,
+// with all the backup routines disabled unless the standby pods are promoted.
+func (r *SingleBackupServiceReconciler) getStandbyConfig(promoted bool) (string, error) {
+	config, err := common.GetBackupSvcConfigFromCM(r.Client, &asdbv1beta1.BackupService{
+		Name:      r.aeroBackupService.Name,
+		Namespace: r.aeroBackupService.Namespace,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if promoted {
+		return config, nil
+	}
+
+	configMap := make(map[string]interface{})
+
+	if err = yaml.Unmarshal([]byte(config), &configMap); err != nil {
+		return "", err
+	}
+
+	routines, err := common.GetConfigSection(configMap, asdbv1beta1.BackupRoutinesKey)
+	if err != nil {
+		return "", err
+	}
+
+	for name, routine := range routines {
+		routineMap, ok := routine.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("backup routine %s is not a map", name)
+		}
+
+		routineMap["disabled"] = true
+	}
+
+	standbyConfig, err := yaml.Marshal(configMap)
+	if err != nil {
+		return "", err
+	}
+
+	return string(standbyConfig), nil
+}
+
+func (r *SingleBackupServiceReconciler) getStandbyDeploymentObject() (*app.Deployment, error) {
+	standbyName := getStandbyName(r.aeroBackupService.Name)
+
+	deploy, err := r.getDeploymentObject()
+	if err != nil {
+		return nil, err
+	}
+
+	standbyLabels := utils.LabelsForAerospikeBackupService(standbyName)
+
+	deploy.Name = standbyName
+	deploy.Labels = standbyLabels
+	deploy.Spec.Replicas = func(replica int32) *int32 { return &replica }(r.getReplicas() - 1)
+	deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: standbyLabels}
+	// The old standby pod is stopped before starting the new one, as a promoted standby pod runs the backup routines.
+	deploy.Spec.Strategy = app.DeploymentStrategy{Type: app.RecreateDeploymentStrategyType}
+	deploy.Spec.Template.Labels = utils.MergeLabels(standbyLabels, r.aeroBackupService.Spec.PodSpec.ObjectMeta.Labels)
+
+	for idx := range deploy.Spec.Template.Spec.Volumes {
+		if cm := deploy.Spec.Template.Spec.Volumes[idx].ConfigMap; cm != nil && cm.Name == r.aeroBackupService.Name {
+			cm.Name = standbyName
+		}
+	}
+
+	return deploy, nil
+}
+
+// reloadConfigInPods reloads the config of the ConfigMap of the given Deployment in its pods.
+// The pods are restarted in case of static config change. Only the backup routines differ between the leader and
+// the standby config, so serving pods are never restarted by a promotion or demotion.
+func (r *SingleBackupServiceReconciler) reloadConfigInPods(name string) error {
+	backupSvc := &asdbv1beta1.BackupService{
+		Name:      name,
+		Namespace: r.aeroBackupService.Namespace,
+	}
+
+	desiredData, err := common.GetBackupSvcConfigFromCM(r.Client, backupSvc)
+	if err != nil {
+		return err
+	}
+
+	podList, err := common.GetBackupServicePodList(r.Client, backupSvc.Name, backupSvc.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+
+		// The pods not running yet read the latest config when they start.
+		if !utils.IsPodRunningAndReady(pod) {
+			continue
+		}
+
+		podClient, err := r.getPodClient(pod)
+		if err != nil {
+			return err
+		}
+
+		apiBackupSvcConfig, err := podClient.GetBackupServiceConfig(context.TODO())
+		if err != nil {
+			return err
+		}
+
+		var currentConfig, desiredConfig dto.Config
+
+		apiBackupSvcConfigData, err := yaml.Marshal(apiBackupSvcConfig)
+		if err != nil {
+			return err
+		}
+
+		if err := yaml.Unmarshal(apiBackupSvcConfigData, &currentConfig); err != nil {
+			return err
+		}
+
+		if err := yaml.Unmarshal([]byte(desiredData), &desiredConfig); err != nil {
+			return err
+		}
+
+		if err := validation.ValidateStaticFieldChanges(&currentConfig, &desiredConfig); err != nil {
+			r.Log.Info("Static config change detected, will result in restart of pods", "deployment", name)
+
+			return r.restartPods(backupSvc.Name)
+		}
+
+		if _, err := common.ReloadBackupServiceConfig(r.Client, podClient, r.Log, backupSvc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *SingleBackupServiceReconciler) deleteStandby() error {
+	standbyName := getStandbyName(r.aeroBackupService.Name)
+
+	for _, obj := range []client.Object{&app.Deployment{}, &corev1.ConfigMap{}} {
+		if err := r.Get(context.TODO(), r.getNamespacedName(standbyName), obj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if !utils.IsOwnedBy(obj, r.aeroBackupService) {
+			continue
+		}
+
+		r.Log.Info("Deleting Backup Service standby resource", "name", r.getNamespacedName(standbyName),
+			"kind", fmt.Sprintf("%T", obj))
+
+		if err := r.Delete(context.TODO(), obj); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcilePDB keeps one of the leader and standby pods available with more than one replica.
+func (r *SingleBackupServiceReconciler) reconcilePDB() error {
+	pdb := &policyv1.PodDisruptionBudget{}
+
+	err := r.Get(context.TODO(), r.getNamespacedName(r.aeroBackupService.Name), pdb)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	exists := err == nil
+
+	if !r.isReplicated() {
+		if exists && utils.IsOwnedBy(pdb, r.aeroBackupService) {
+			r.Log.Info("Deleting Backup Service PodDisruptionBudget",
+				"name", getBackupServiceName(r.aeroBackupService))
+
+			if err := r.Delete(context.TODO(), pdb); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{asdbv1.AerospikeAppLabel: asdbv1beta1.AerospikeBackupServiceKey},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      asdbv1.AerospikeCustomResourceLabel,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{r.aeroBackupService.Name, getStandbyName(r.aeroBackupService.Name)},
+			},
+		},
+	}
+
+	if exists {
+		if !utils.IsOwnedBy(pdb, r.aeroBackupService) {
+			return fmt.Errorf("failed to update PodDisruptionBudget, PodDisruptionBudget is not "+
+				"created/owned by operator. name: %s", getBackupServiceName(r.aeroBackupService))
+		}
+
+		return nil
+	}
+
+	pdb = &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.aeroBackupService.Name,
+			Namespace: r.aeroBackupService.Namespace,
+			Labels:    utils.LabelsForAerospikeBackupService(r.aeroBackupService.Name),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       selector,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(r.aeroBackupService, pdb, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(context.TODO(), pdb, common.CreateOption); err != nil {
+		return fmt.Errorf("failed to create PodDisruptionBudget: %v", err)
+	}
+
+	r.Log.Info("Created Backup Service PodDisruptionBudget", "name", getBackupServiceName(r.aeroBackupService))
+
+	return nil
+}
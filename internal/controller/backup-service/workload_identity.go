@@ -0,0 +1,252 @@
+package backupservice
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	app "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const (
+	// Same token paths as used by the EKS pod identity webhook and the Azure workload identity webhook.
+	awsTokenMountPath    = "/var/run/secrets/eks.amazonaws.com/serviceaccount"
+	awsDefaultAudience   = "sts.amazonaws.com"
+	azureTokenMountPath  = "/var/run/secrets/azure/tokens"
+	azureDefaultAudience = "api://AzureADTokenExchange"
+	azureAuthorityHost   = "https://login.microsoftonline.com/"
+	tokenFileName        = "token"
+
+	defaultTokenExpirationSeconds int64 = 86400
+
+	awsRoleARNAnnotation           = "eks.amazonaws.com/role-arn"
+	awsAudienceAnnotation          = "eks.amazonaws.com/audience"
+	gcpServiceAccountAnnotation    = "iam.gke.io/gcp-service-account"
+	azureClientIDAnnotation        = "azure.workload.identity/client-id"
+	azureTenantIDAnnotation        = "azure.workload.identity/tenant-id"
+	azureTokenExpirationAnnotation = "azure.workload.identity/service-account-token-expiration"
+)
+
+// workloadIdentityAnnotations are the ServiceAccount annotations set for the workload identity of any provider.
+var workloadIdentityAnnotations = []string{
+	awsRoleARNAnnotation, awsAudienceAnnotation, gcpServiceAccountAnnotation, azureClientIDAnnotation,
+	azureTenantIDAnnotation, azureTokenExpirationAnnotation,
+}
+
+// reconcileServiceAccount creates and annotates the per AerospikeBackupService ServiceAccount for the workload
+// identity, and deletes it once the workload identity is removed. A ServiceAccount not created by the operator for
+// this AerospikeBackupService is never modified, the ServiceAccount given in the podSpec is used as is.
+func (r *SingleBackupServiceReconciler) reconcileServiceAccount() error {
+	workloadIdentity := r.aeroBackupService.Spec.WorkloadIdentity
+
+	if r.aeroBackupService.Spec.PodSpec.ServiceAccountName != "" {
+		return nil
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+
+	if err := r.Get(context.TODO(), r.getNamespacedName(r.getWorkloadIdentityServiceAccount()),
+		serviceAccount); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		if workloadIdentity == nil {
+			return nil
+		}
+
+		serviceAccount = &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        r.getWorkloadIdentityServiceAccount(),
+				Namespace:   r.aeroBackupService.Namespace,
+				Annotations: getServiceAccountAnnotations(workloadIdentity),
+			},
+		}
+
+		if err = controllerutil.SetControllerReference(r.aeroBackupService, serviceAccount, r.Scheme); err != nil {
+			return err
+		}
+
+		if err = r.Create(context.TODO(), serviceAccount, common.CreateOption); err != nil {
+			return fmt.Errorf("failed to create ServiceAccount: %v", err)
+		}
+
+		r.Log.Info("Created Backup Service ServiceAccount", "name", r.getNamespacedName(serviceAccount.Name))
+
+		return nil
+	}
+
+	if !utils.IsOwnedBy(serviceAccount, r.aeroBackupService) {
+		if workloadIdentity == nil {
+			return nil
+		}
+
+		return fmt.Errorf("ServiceAccount %s already exists and is not created by the operator for the "+
+			"workload identity, set it in podSpec.serviceAccountName and annotate it instead", serviceAccount.Name)
+	}
+
+	if workloadIdentity == nil {
+		r.Log.Info("Deleting Backup Service ServiceAccount", "name", r.getNamespacedName(serviceAccount.Name))
+
+		if err := r.Delete(context.TODO(), serviceAccount); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	annotations := getServiceAccountAnnotations(workloadIdentity)
+	updated := false
+
+	if serviceAccount.Annotations == nil {
+		serviceAccount.Annotations = make(map[string]string, len(annotations))
+	}
+
+	// The annotations of a previously configured provider are removed.
+	for _, key := range workloadIdentityAnnotations {
+		if _, ok := annotations[key]; !ok {
+			if _, ok := serviceAccount.Annotations[key]; ok {
+				delete(serviceAccount.Annotations, key)
+
+				updated = true
+			}
+		}
+	}
+
+	for key, value := range annotations {
+		if serviceAccount.Annotations[key] != value {
+			serviceAccount.Annotations[key] = value
+			updated = true
+		}
+	}
+
+	if !updated {
+		return nil
+	}
+
+	if err := r.Update(context.TODO(), serviceAccount, common.UpdateOption); err != nil {
+		return fmt.Errorf("failed to update ServiceAccount: %v", err)
+	}
+
+	r.Log.Info("Updated Backup Service ServiceAccount annotations", "name", r.getNamespacedName(serviceAccount.Name))
+
+	return nil
+}
+
+// getWorkloadIdentityServiceAccount returns the name of the ServiceAccount created by the operator for the workload
+// identity, which is named after the AerospikeBackupService.
+func (r *SingleBackupServiceReconciler) getWorkloadIdentityServiceAccount() string {
+	return r.aeroBackupService.Name
+}
+
+func getServiceAccountAnnotations(workloadIdentity *asdbv1beta1.WorkloadIdentity) map[string]string {
+	switch workloadIdentity.Provider {
+	case asdbv1beta1.WorkloadIdentityAWS:
+		return map[string]string{
+			awsRoleARNAnnotation:  workloadIdentity.RoleARN,
+			awsAudienceAnnotation: getTokenAudience(workloadIdentity),
+		}
+
+	case asdbv1beta1.WorkloadIdentityGCP:
+		return map[string]string{gcpServiceAccountAnnotation: workloadIdentity.GCPServiceAccount}
+
+	case asdbv1beta1.WorkloadIdentityAzure:
+		return map[string]string{
+			azureClientIDAnnotation:        workloadIdentity.ClientID,
+			azureTenantIDAnnotation:        workloadIdentity.TenantID,
+			azureTokenExpirationAnnotation: fmt.Sprint(getTokenExpirationSeconds(workloadIdentity)),
+		}
+	}
+
+	return nil
+}
+
+// updateDeploymentWorkloadIdentity adds the projected ServiceAccount token volume and the env vars used by the
+// cloud SDKs to assume the workload identity. GKE needs none, as the token is served by its metadata server.
+func (r *SingleBackupServiceReconciler) updateDeploymentWorkloadIdentity(deploy *app.Deployment) {
+	workloadIdentity := r.aeroBackupService.Spec.WorkloadIdentity
+	if workloadIdentity == nil {
+		return
+	}
+
+	var (
+		volumeName, mountPath string
+		env                   []corev1.EnvVar
+	)
+
+	switch workloadIdentity.Provider {
+	case asdbv1beta1.WorkloadIdentityAWS:
+		volumeName, mountPath = asdbv1beta1.AWSTokenVolumeName, awsTokenMountPath
+		env = []corev1.EnvVar{
+			{Name: "AWS_ROLE_ARN", Value: workloadIdentity.RoleARN},
+			{Name: "AWS_WEB_IDENTITY_TOKEN_FILE", Value: filepath.Join(mountPath, tokenFileName)},
+		}
+
+	case asdbv1beta1.WorkloadIdentityAzure:
+		volumeName, mountPath = asdbv1beta1.AzureTokenVolumeName, azureTokenMountPath
+		env = []corev1.EnvVar{
+			{Name: "AZURE_CLIENT_ID", Value: workloadIdentity.ClientID},
+			{Name: "AZURE_TENANT_ID", Value: workloadIdentity.TenantID},
+			{Name: "AZURE_FEDERATED_TOKEN_FILE", Value: filepath.Join(mountPath, tokenFileName)},
+			{Name: "AZURE_AUTHORITY_HOST", Value: azureAuthorityHost},
+		}
+
+	default:
+		return
+	}
+
+	expirationSeconds := getTokenExpirationSeconds(workloadIdentity)
+
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          getTokenAudience(workloadIdentity),
+							ExpirationSeconds: &expirationSeconds,
+							Path:              tokenFileName,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	container := &deploy.Spec.Template.Spec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, env...)
+}
+
+func getTokenAudience(workloadIdentity *asdbv1beta1.WorkloadIdentity) string {
+	if workloadIdentity.Audience != "" {
+		return workloadIdentity.Audience
+	}
+
+	if workloadIdentity.Provider == asdbv1beta1.WorkloadIdentityAzure {
+		return azureDefaultAudience
+	}
+
+	return awsDefaultAudience
+}
+
+func getTokenExpirationSeconds(workloadIdentity *asdbv1beta1.WorkloadIdentity) int64 {
+	if workloadIdentity.ExpirationSeconds != nil {
+		return *workloadIdentity.ExpirationSeconds
+	}
+
+	return defaultTokenExpirationSeconds
+}
@@ -0,0 +1,308 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const defaultInventoryPollingPeriod = 300 * time.Second
+
+func (r *SingleBackupReconciler) getInventoryPollingPeriod() time.Duration {
+	if r.aeroBackup.Spec.InventoryPollingPeriod.Duration > 0 {
+		return r.aeroBackup.Spec.InventoryPollingPeriod.Duration
+	}
+
+	return defaultInventoryPollingPeriod
+}
+
+// syncInventory syncs the backups of the routines from the backup service into the status,
+// and creates an AerospikeBackupSnapshot for every full backup.
+func (r *SingleBackupReconciler) syncInventory() error {
+	r.Log.Info("Syncing backup inventory")
+
+	var config dto.Config
+
+	if err := yaml.Unmarshal(r.aeroBackup.Spec.Config.Raw, &config); err != nil {
+		return err
+	}
+
+	serviceClient, err := backup_service.GetBackupServiceClient(r.Client, &r.aeroBackup.Spec.BackupService)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	routines := make(map[string]asdbv1beta1.BackupRoutineStatus, len(config.BackupRoutines))
+	snapshots := make(map[string]*asdbv1beta1.AerospikeBackupSnapshot)
+	unknownRoutines := sets.New[string]()
+
+	for routineName, routine := range config.BackupRoutines {
+		fullBackups, fullFound, err := r.getBackups(serviceClient.GetFullBackupsForRoutine, routineName)
+		if err != nil {
+			return err
+		}
+
+		incrBackups, incrFound, err := r.getBackups(serviceClient.GetIncrementalBackupsForRoutine, routineName)
+		if err != nil {
+			return err
+		}
+
+		// The backups of the routine are unknown, so its status and snapshots are kept as is.
+		if !fullFound || !incrFound {
+			unknownRoutines.Insert(routineName)
+
+			if routineStatus, ok := r.aeroBackup.Status.Routines[routineName]; ok {
+				routines[routineName] = routineStatus
+			}
+
+			continue
+		}
+
+		routineStatus := asdbv1beta1.BackupRoutineStatus{
+			FullBackupCount:        int32(len(fullBackups)),
+			IncrementalBackupCount: int32(len(incrBackups)),
+		}
+
+		for idx := range fullBackups {
+			snapshot, err := r.newBackupSnapshot(routineName, &fullBackups[idx])
+			if err != nil {
+				return err
+			}
+
+			if other, ok := snapshots[snapshot.Name]; ok && other.Spec.Key != snapshot.Spec.Key {
+				return fmt.Errorf("AerospikeBackupSnapshot name %s collision for backups %s and %s",
+					snapshot.Name, other.Spec.Key, snapshot.Spec.Key)
+			}
+
+			snapshots[snapshot.Name] = snapshot
+		}
+
+		if latest := latestBackup(fullBackups); latest != nil {
+			routineStatus.LastFullBackup = newBackupInfo(latest)
+			routineStatus.LastFullBackup.SnapshotName = r.backupSnapshotName(latest)
+		}
+
+		if latest := latestBackup(incrBackups); latest != nil {
+			routineStatus.LastIncrementalBackup = newBackupInfo(latest)
+		}
+
+		lastBackupTime := r.aeroBackup.CreationTimestamp.Time
+		if routineStatus.LastFullBackup != nil {
+			lastBackupTime = routineStatus.LastFullBackup.Created.Time
+		}
+
+		if routine != nil && routine.IntervalCron != "" {
+			deadline, err := backup_service.NextBackupDeadline(routine.IntervalCron, lastBackupTime)
+			if err != nil {
+				r.Log.Error(err, "Failed to get next full backup deadline", "routine", routineName)
+			} else {
+				routineStatus.NextFullBackupDeadline = &metav1.Time{Time: deadline}
+				routineStatus.Overdue = now.After(deadline)
+			}
+		}
+
+		if routineStatus.Overdue && !r.aeroBackup.Status.Routines[routineName].Overdue {
+			r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeWarning, "BackupRoutineOverdue",
+				"No full backup of routine %s has succeeded since %s", routineName,
+				lastBackupTime.Format(time.RFC3339))
+		}
+
+		routines[routineName] = routineStatus
+	}
+
+	if err := r.reconcileBackupSnapshots(snapshots, unknownRoutines); err != nil {
+		return err
+	}
+
+	r.aeroBackup.Status.Routines = routines
+	r.aeroBackup.Status.InventorySyncTime = &metav1.Time{Time: now}
+
+	r.Log.Info("Synced backup inventory")
+
+	return nil
+}
+
+// getBackups returns the backups of the routine, and false if the routine is not found in the backup service.
+// A routine may be transiently not found, like while the backup service restarts or reloads its config, so its
+// backups are unknown rather than none.
+func (r *SingleBackupReconciler) getBackups(
+	get func(context.Context, string) ([]dto.BackupDetails, error), routineName string,
+) ([]dto.BackupDetails, bool, error) {
+	backups, err := get(context.TODO(), routineName)
+	if err != nil {
+		if backup_service.StatusCode(err) == http.StatusNotFound {
+			r.Log.Info("Backup routine not found in backup service", "routine", routineName)
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return backups, true, nil
+}
+
+func latestBackup(backups []dto.BackupDetails) *dto.BackupDetails {
+	var latest *dto.BackupDetails
+
+	for idx := range backups {
+		if latest == nil || backups[idx].Created.After(latest.Created) {
+			latest = &backups[idx]
+		}
+	}
+
+	return latest
+}
+
+func newBackupInfo(backup *dto.BackupDetails) *asdbv1beta1.BackupInfo {
+	return &asdbv1beta1.BackupInfo{
+		AerospikeNamespace: backup.Namespace,
+		Key:                backup.Key,
+		StoragePath:        backup_service.StoragePath(backup.Storage, backup.Key),
+		Created:            metav1.Time{Time: backup.Created},
+		Finished:           metav1.Time{Time: backup.Finished},
+		RecordCount:        int64(backup.RecordCount),
+		ByteCount:          int64(backup.ByteCount),
+	}
+}
+
+// backupSnapshotName returns a stable name for the AerospikeBackupSnapshot of the backup.
+func (r *SingleBackupReconciler) backupSnapshotName(backup *dto.BackupDetails) string {
+	return utils.GetHashedName(r.aeroBackup.Name, backup.Key)
+}
+
+func (r *SingleBackupReconciler) newBackupSnapshot(
+	routineName string, backup *dto.BackupDetails,
+) (*asdbv1beta1.AerospikeBackupSnapshot, error) {
+	snapshot := &asdbv1beta1.AerospikeBackupSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.backupSnapshotName(backup),
+			Namespace: r.aeroBackup.Namespace,
+		},
+		Spec: asdbv1beta1.AerospikeBackupSnapshotSpec{
+			Backup:              r.aeroBackup.Name,
+			RoutineName:         routineName,
+			Type:                asdbv1beta1.BackupSnapshotFull,
+			AerospikeNamespace:  backup.Namespace,
+			Key:                 backup.Key,
+			StoragePath:         backup_service.StoragePath(backup.Storage, backup.Key),
+			Created:             metav1.Time{Time: backup.Created},
+			Finished:            metav1.Time{Time: backup.Finished},
+			RecordCount:         int64(backup.RecordCount),
+			ByteCount:           int64(backup.ByteCount),
+			FileCount:           int64(backup.FileCount),
+			SecondaryIndexCount: int64(backup.SecondaryIndexCount),
+			UDFCount:            int64(backup.UDFCount),
+			Compression:         backup.Compression,
+			Encryption:          backup.Encryption,
+		},
+	}
+
+	if backup.Storage != nil {
+		storage, err := json.Marshal(backup.Storage)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Spec.Storage = runtime.RawExtension{Raw: storage}
+	}
+
+	if err := controllerutil.SetControllerReference(r.aeroBackup, snapshot, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// reconcileBackupSnapshots creates the missing AerospikeBackupSnapshots and deletes the ones
+// whose backups are no longer in the storage. The snapshots of the routines with unknown backups are not deleted.
+func (r *SingleBackupReconciler) reconcileBackupSnapshots(
+	snapshots map[string]*asdbv1beta1.AerospikeBackupSnapshot, unknownRoutines sets.Set[string],
+) error {
+	snapshotList := &asdbv1beta1.AerospikeBackupSnapshotList{}
+	if err := r.List(context.TODO(), snapshotList, client.InNamespace(r.aeroBackup.Namespace)); err != nil {
+		return err
+	}
+
+	existing := sets.New[string]()
+
+	for idx := range snapshotList.Items {
+		snapshot := &snapshotList.Items[idx]
+		if snapshot.Spec.Backup != r.aeroBackup.Name {
+			continue
+		}
+
+		if desired, ok := snapshots[snapshot.Name]; ok {
+			if err := checkBackupSnapshotCollision(snapshot, desired); err != nil {
+				return err
+			}
+
+			existing.Insert(snapshot.Name)
+
+			continue
+		}
+
+		if unknownRoutines.Has(snapshot.Spec.RoutineName) {
+			continue
+		}
+
+		r.Log.Info("Deleting AerospikeBackupSnapshot of removed backup", "name", snapshot.Name)
+
+		if err := r.Delete(context.TODO(), snapshot); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for name, snapshot := range snapshots {
+		if existing.Has(name) {
+			continue
+		}
+
+		r.Log.Info("Creating AerospikeBackupSnapshot", "name", name, "key", snapshot.Spec.Key)
+
+		if err := r.Create(context.TODO(), snapshot); err != nil {
+			if !errors.IsAlreadyExists(err) {
+				return err
+			}
+
+			// The snapshot may be missing from the cache, or belong to another backup.
+			found := &asdbv1beta1.AerospikeBackupSnapshot{}
+			if err := r.Get(context.TODO(), client.ObjectKeyFromObject(snapshot), found); err != nil {
+				return err
+			}
+
+			if err := checkBackupSnapshotCollision(found, snapshot); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkBackupSnapshotCollision returns an error if the existing AerospikeBackupSnapshot with the name of the desired
+// one is for another backup.
+func checkBackupSnapshotCollision(existing, desired *asdbv1beta1.AerospikeBackupSnapshot) error {
+	if existing.Spec.Backup != desired.Spec.Backup || existing.Spec.Key != desired.Spec.Key {
+		return fmt.Errorf("AerospikeBackupSnapshot %s already exists for backup %s of AerospikeBackup %s",
+			existing.Name, existing.Spec.Key, existing.Spec.Backup)
+	}
+
+	return nil
+}
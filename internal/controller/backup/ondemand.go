@@ -0,0 +1,312 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
+)
+
+const (
+	defaultOnDemandBackupRetention = 10
+
+	// onDemandBackupPollingPeriod is the polling period for the status of the unfinished on-demand backups.
+	onDemandBackupPollingPeriod = 10 * time.Second
+
+	// onDemandBackupStartTimeout is the time after the expected start time within which
+	// a queued on-demand backup must start.
+	onDemandBackupStartTimeout = 5 * time.Minute
+)
+
+func (r *SingleBackupReconciler) getRequeuePeriod() time.Duration {
+	for idx := range r.aeroBackup.Status.OnDemandBackups {
+		if !r.aeroBackup.Status.OnDemandBackups[idx].IsFinished() {
+			return min(onDemandBackupPollingPeriod, r.getInventoryPollingPeriod())
+		}
+	}
+
+	return r.getInventoryPollingPeriod()
+}
+
+// scheduleOnDemandBackups schedules the on-demand backups in spec which are not yet in status.
+// The status is persisted right away so that a backup is never scheduled twice.
+//
+// The backup service does not return an ID for a scheduled backup, so the on-demand backups of a routine are
+// scheduled one at a time, when no full backup of the routine is running. The full backup taken after the
+// scheduling is then the on-demand backup.
+func (r *SingleBackupReconciler) scheduleOnDemandBackups(serviceClient *backup_service.Client) error {
+	scheduled := sets.New[string]()
+	busyRoutines := sets.New[string]()
+
+	for idx := range r.aeroBackup.Status.OnDemandBackups {
+		status := &r.aeroBackup.Status.OnDemandBackups[idx]
+		scheduled.Insert(status.ID)
+
+		if !status.IsFinished() {
+			busyRoutines.Insert(status.RoutineName)
+		}
+	}
+
+	var newStatuses []asdbv1beta1.OnDemandBackupStatus
+
+	for idx := range r.aeroBackup.Spec.OnDemandBackups {
+		onDemand := r.aeroBackup.Spec.OnDemandBackups[idx]
+		if scheduled.Has(onDemand.ID) {
+			continue
+		}
+
+		if busyRoutines.Has(onDemand.RoutineName) {
+			r.Log.Info("Waiting for the running backup of the routine to schedule on-demand backup",
+				"ID", onDemand.ID, "routine", onDemand.RoutineName)
+
+			continue
+		}
+
+		r.Log.Info("Scheduling on-demand backup", "ID", onDemand.ID, "routine", onDemand.RoutineName)
+
+		status := asdbv1beta1.OnDemandBackupStatus{
+			OnDemandBackupSpec: onDemand,
+			Phase:              asdbv1beta1.OnDemandBackupQueued,
+		}
+
+		state, err := serviceClient.GetCurrentBackup(context.TODO(), onDemand.RoutineName)
+		if err != nil && backup_service.StatusCode(err) != http.StatusNotFound {
+			return err
+		}
+
+		if state != nil && state.Full != nil {
+			r.Log.Info("Waiting for the running full backup of the routine to schedule on-demand backup",
+				"ID", onDemand.ID, "routine", onDemand.RoutineName)
+
+			busyRoutines.Insert(onDemand.RoutineName)
+
+			continue
+		}
+
+		if state != nil && state.LastFull != nil {
+			status.PreviousFullBackupTime = &metav1.Time{Time: *state.LastFull}
+		}
+
+		busyRoutines.Insert(onDemand.RoutineName)
+
+		status.ScheduledTime = &metav1.Time{Time: time.Now()}
+
+		if err := serviceClient.ScheduleBackup(context.TODO(), onDemand.RoutineName, onDemand.Delay); err != nil {
+			statusCode := backup_service.StatusCode(err)
+			if statusCode < http.StatusBadRequest || statusCode >= http.StatusInternalServerError {
+				return err
+			}
+
+			r.Log.Error(err, "Failed to schedule on-demand backup", "ID", onDemand.ID)
+
+			status.Phase = asdbv1beta1.OnDemandBackupFailed
+			status.CompletionTime = &metav1.Time{Time: time.Now()}
+			status.Message = err.Error()
+
+			r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeWarning, "OnDemandBackupFailed",
+				"Failed to schedule on-demand backup %s: %v", onDemand.ID, err)
+		} else {
+			r.Log.Info("Scheduled on-demand backup", "ID", onDemand.ID, "routine", onDemand.RoutineName)
+			r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeNormal, "OnDemandBackupScheduled",
+				"Scheduled on-demand backup %s for routine %s", onDemand.ID, onDemand.RoutineName)
+		}
+
+		newStatuses = append(newStatuses, status)
+	}
+
+	if len(newStatuses) == 0 {
+		return nil
+	}
+
+	r.aeroBackup.Status.OnDemandBackups = append(r.aeroBackup.Status.OnDemandBackups, newStatuses...)
+
+	return r.Client.Status().Update(context.TODO(), r.aeroBackup)
+}
+
+// refreshOnDemandBackups updates the status of the unfinished on-demand backups from the
+// current backup state of their routines.
+func (r *SingleBackupReconciler) refreshOnDemandBackups(serviceClient *backup_service.Client) error {
+	// The backups attributed to an on-demand backup are not attributed to any other one.
+	claimedBackups := sets.New[string]()
+
+	for idx := range r.aeroBackup.Status.OnDemandBackups {
+		status := &r.aeroBackup.Status.OnDemandBackups[idx]
+
+		// The on-demand backups scheduled by older operator versions have no phase, and their
+		// progress was not tracked.
+		if status.Phase == "" {
+			status.Phase = asdbv1beta1.OnDemandBackupUnknown
+		}
+
+		for bIdx := range status.Backups {
+			claimedBackups.Insert(status.Backups[bIdx].Key)
+		}
+	}
+
+	for idx := range r.aeroBackup.Status.OnDemandBackups {
+		status := &r.aeroBackup.Status.OnDemandBackups[idx]
+		if status.IsFinished() {
+			continue
+		}
+
+		if err := r.refreshOnDemandBackup(serviceClient, status, claimedBackups); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refreshOnDemandBackup updates the status of an on-demand backup. The first full backup of the routine
+// taken after the previous full backup recorded at scheduling is the on-demand backup. Only the times
+// reported by the backup service are compared, so the clock of the operator does not matter.
+func (r *SingleBackupReconciler) refreshOnDemandBackup(
+	serviceClient *backup_service.Client, status *asdbv1beta1.OnDemandBackupStatus, claimedBackups sets.Set[string],
+) error {
+	now := time.Now()
+
+	expectedStart := now
+	if status.ScheduledTime != nil {
+		expectedStart = status.ScheduledTime.Add(status.Delay.Duration)
+	}
+
+	var previousFull time.Time
+	if status.PreviousFullBackupTime != nil {
+		previousFull = status.PreviousFullBackupTime.Time
+	}
+
+	state, err := serviceClient.GetCurrentBackup(context.TODO(), status.RoutineName)
+	if err != nil {
+		if backup_service.StatusCode(err) == http.StatusNotFound {
+			r.setOnDemandBackupFailed(status, fmt.Sprintf("backup routine %s not found", status.RoutineName))
+			return nil
+		}
+
+		return err
+	}
+
+	switch {
+	case state.LastFull != nil && state.LastFull.After(previousFull):
+		backups, err := serviceClient.GetFullBackupsForRoutine(context.TODO(), status.RoutineName)
+		if err != nil {
+			return err
+		}
+
+		status.Backups = nil
+
+		for idx := range backups {
+			if !backups[idx].Created.After(previousFull) {
+				continue
+			}
+
+			backupInfo := newBackupInfo(&backups[idx])
+			if claimedBackups.Has(backupInfo.Key) {
+				continue
+			}
+
+			claimedBackups.Insert(backupInfo.Key)
+
+			backupInfo.SnapshotName = r.backupSnapshotName(&backups[idx])
+			status.Backups = append(status.Backups, *backupInfo)
+		}
+
+		if status.StartTime == nil {
+			status.StartTime = &metav1.Time{Time: *state.LastFull}
+		}
+
+		status.Phase = asdbv1beta1.OnDemandBackupCompleted
+		status.PercentageDone = 100
+		status.CompletionTime = &metav1.Time{Time: now}
+
+		r.Log.Info("On-demand backup completed", "ID", status.ID)
+		r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeNormal, "OnDemandBackupCompleted",
+			"Completed on-demand backup %s", status.ID)
+
+	case state.Full != nil && state.Full.StartTime.After(previousFull):
+		status.Phase = asdbv1beta1.OnDemandBackupRunning
+		status.StartTime = &metav1.Time{Time: state.Full.StartTime}
+		status.PercentageDone = int32(state.Full.PercentageDone)
+
+	case status.Phase == asdbv1beta1.OnDemandBackupRunning:
+		r.setOnDemandBackupFailed(status, "backup finished without a successful full backup")
+
+	case state.Full == nil && now.After(expectedStart.Add(onDemandBackupStartTimeout)):
+		r.setOnDemandBackupFailed(status, fmt.Sprintf("backup did not start within %s", onDemandBackupStartTimeout))
+	}
+
+	return nil
+}
+
+func (r *SingleBackupReconciler) setOnDemandBackupFailed(status *asdbv1beta1.OnDemandBackupStatus, message string) {
+	status.Phase = asdbv1beta1.OnDemandBackupFailed
+	status.CompletionTime = &metav1.Time{Time: time.Now()}
+	status.Message = message
+
+	r.Log.Info("On-demand backup failed", "ID", status.ID, "reason", message)
+	r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeWarning, "OnDemandBackupFailed",
+		"On-demand backup %s failed: %s", status.ID, message)
+}
+
+// pruneOnDemandBackups removes the oldest finished on-demand backups, which are removed from spec,
+// from status beyond the retention count.
+func (r *SingleBackupReconciler) pruneOnDemandBackups() {
+	retention := defaultOnDemandBackupRetention
+	if r.aeroBackup.Spec.OnDemandBackupRetention != nil {
+		retention = int(*r.aeroBackup.Spec.OnDemandBackupRetention)
+	}
+
+	inSpec := sets.New[string]()
+	for idx := range r.aeroBackup.Spec.OnDemandBackups {
+		inSpec.Insert(r.aeroBackup.Spec.OnDemandBackups[idx].ID)
+	}
+
+	var prunable []*asdbv1beta1.OnDemandBackupStatus
+
+	for idx := range r.aeroBackup.Status.OnDemandBackups {
+		status := &r.aeroBackup.Status.OnDemandBackups[idx]
+		if status.IsFinished() && !inSpec.Has(status.ID) {
+			prunable = append(prunable, status)
+		}
+	}
+
+	if len(prunable) <= retention {
+		return
+	}
+
+	sort.Slice(prunable, func(i, j int) bool {
+		return finishedTime(prunable[i]).After(finishedTime(prunable[j]))
+	})
+
+	pruned := sets.New[string]()
+	for _, status := range prunable[retention:] {
+		pruned.Insert(status.ID)
+	}
+
+	statuses := make([]asdbv1beta1.OnDemandBackupStatus, 0, len(r.aeroBackup.Status.OnDemandBackups)-pruned.Len())
+
+	for idx := range r.aeroBackup.Status.OnDemandBackups {
+		if !pruned.Has(r.aeroBackup.Status.OnDemandBackups[idx].ID) {
+			statuses = append(statuses, r.aeroBackup.Status.OnDemandBackups[idx])
+		}
+	}
+
+	r.Log.Info("Pruned finished on-demand backups from status", "IDs", sets.List(pruned))
+
+	r.aeroBackup.Status.OnDemandBackups = statuses
+}
+
+func finishedTime(status *asdbv1beta1.OnDemandBackupStatus) time.Time {
+	if status.CompletionTime != nil {
+		return status.CompletionTime.Time
+	}
+
+	return time.Time{}
+}
@@ -88,6 +88,14 @@ func (r *SingleBackupReconciler) Reconcile() (result ctrl.Result, recErr error)
 		return reconcile.Result{}, err
 	}
 
+	// The inventory is best effort, a failure to sync it does not fail the reconcile.
+	if err := r.syncInventory(); err != nil {
+		r.Log.Error(err, "Failed to sync backup inventory")
+		r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeWarning,
+			"InventorySyncFailed", "Failed to sync backup inventory %s/%s",
+			r.aeroBackup.Namespace, r.aeroBackup.Name)
+	}
+
 	if err := r.updateStatus(); err != nil {
 		r.Log.Error(err, "Failed to update status")
 		r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeWarning,
@@ -99,7 +107,7 @@ func (r *SingleBackupReconciler) Reconcile() (result ctrl.Result, recErr error)
 
 	r.Log.Info("Reconcile completed successfully")
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.getRequeuePeriod()}, nil
 }
 
 func (r *SingleBackupReconciler) addFinalizer(finalizerName string) error {
@@ -332,42 +340,6 @@ func (r *SingleBackupReconciler) removeBackupInfoFromConfigMap() error {
 	return nil
 }
 
-func (r *SingleBackupReconciler) scheduleOnDemandBackup() error {
-	r.Log.Info("Reconciling on-demand backup")
-
-	// There can be only one on-demand backup allowed right now.
-	if len(r.aeroBackup.Status.OnDemandBackups) > 0 &&
-		r.aeroBackup.Spec.OnDemandBackups[0].ID == r.aeroBackup.Status.OnDemandBackups[0].ID {
-		r.Log.Info("On-demand backup already scheduled for the same ID",
-			"ID", r.aeroBackup.Status.OnDemandBackups[0].ID)
-
-		return nil
-	}
-
-	r.Log.Info("Scheduling on-demand backup",
-		"ID", r.aeroBackup.Spec.OnDemandBackups[0].ID, "routine", r.aeroBackup.Spec.OnDemandBackups[0].RoutineName)
-
-	backupServiceClient, err := backup_service.GetBackupServiceClient(r.Client, &r.aeroBackup.Spec.BackupService)
-	if err != nil {
-		return err
-	}
-
-	if err = backupServiceClient.ScheduleBackup(r.aeroBackup.Spec.OnDemandBackups[0].RoutineName,
-		r.aeroBackup.Spec.OnDemandBackups[0].Delay); err != nil {
-		r.Log.Error(err, "Failed to schedule on-demand backup")
-		return err
-	}
-
-	r.Log.Info("Scheduled on-demand backup", "ID", r.aeroBackup.Spec.OnDemandBackups[0].ID,
-		"routine", r.aeroBackup.Spec.OnDemandBackups[0].RoutineName)
-	r.Recorder.Eventf(r.aeroBackup, corev1.EventTypeNormal, "OnDemandBackupScheduled",
-		"Scheduled on-demand backup %s/%s", r.aeroBackup.Namespace, r.aeroBackup.Name)
-
-	r.Log.Info("Reconciled on-demand backup")
-
-	return nil
-}
-
 func (r *SingleBackupReconciler) reconcileBackup() error {
 	if err := r.reconcileScheduledBackup(); err != nil {
 		return err
@@ -384,7 +356,7 @@ func (r *SingleBackupReconciler) reconcileScheduledBackup() error {
 		return err
 	}
 
-	backupSvcConfig, err := serviceClient.GetBackupServiceConfig()
+	backupSvcConfig, err := serviceClient.GetBackupServiceConfig(context.TODO())
 	if err != nil {
 		return err
 	}
@@ -496,21 +468,37 @@ func (r *SingleBackupReconciler) checkForDeletedRoutines(
 	return false
 }
 func (r *SingleBackupReconciler) reconcileOnDemandBackup() error {
-	// Schedule on-demand backup if given
-	if len(r.aeroBackup.Spec.OnDemandBackups) > 0 {
-		if err := r.scheduleOnDemandBackup(); err != nil {
-			r.Log.Error(err, "Failed to schedule backup")
-			return err
-		}
+	if len(r.aeroBackup.Spec.OnDemandBackups) == 0 && len(r.aeroBackup.Status.OnDemandBackups) == 0 {
+		return nil
+	}
+
+	r.Log.Info("Reconciling on-demand backups")
+
+	backupServiceClient, err := backup_service.GetBackupServiceClient(r.Client, &r.aeroBackup.Spec.BackupService)
+	if err != nil {
+		return err
+	}
+
+	if err := r.scheduleOnDemandBackups(backupServiceClient); err != nil {
+		r.Log.Error(err, "Failed to schedule backup")
+		return err
 	}
 
+	if err := r.refreshOnDemandBackups(backupServiceClient); err != nil {
+		r.Log.Error(err, "Failed to refresh on-demand backup status")
+		return err
+	}
+
+	r.pruneOnDemandBackups()
+
+	r.Log.Info("Reconciled on-demand backups")
+
 	return nil
 }
 
 func (r *SingleBackupReconciler) updateStatus() error {
 	r.aeroBackup.Status.BackupService = r.aeroBackup.Spec.BackupService
 	r.aeroBackup.Status.Config = r.aeroBackup.Spec.Config
-	r.aeroBackup.Status.OnDemandBackups = r.aeroBackup.Spec.OnDemandBackups
 
 	return r.Client.Status().Update(context.Background(), r.aeroBackup)
 }
@@ -16,8 +16,11 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -208,7 +211,12 @@ func (r *SingleClusterReconciler) newAllHostConnWithOption(ignorablePodNames set
 		return nil, fmt.Errorf("pod list empty")
 	}
 
-	return r.newPodsHostConnWithOption(podList.Items, ignorablePodNames)
+	hostConns, err := r.newPodsHostConnWithOption(podList.Items, ignorablePodNames)
+	if err != nil || r.aeroCluster.Spec.Federation == nil {
+		return hostConns, err
+	}
+
+	return append(hostConns, r.newFederationHostConns()...), nil
 }
 
 // newPodsHostConnWithOption returns connections to all pods given skipping pods that are not running and
@@ -249,26 +257,68 @@ func (r *SingleClusterReconciler) newPodsHostConnWithOption(pods []corev1.Pod, i
 }
 
 func (r *SingleClusterReconciler) newAsConn(pod *corev1.Pod) *deployment.ASConn {
+	return NewASConn(r.aeroCluster, pod.Name, pod.Status.PodIP, r.Log)
+}
+
+// NewASConn returns the connection used by the operator for info calls to the Aerospike server in the pod
+// of the aeroCluster.
+func NewASConn(aeroCluster *asdbv1.AerospikeCluster, podName, podIP string, log logr.Logger) *deployment.ASConn {
 	// Use pod IP and direct service port from within the operator for info calls.
-	tlsName, port := r.getServiceTLSNameAndPortIfConfigured()
+	tlsName, port := getServiceTLSNameAndPortIfConfigured(aeroCluster)
 
 	if tlsName == "" || port == nil {
-		port = asdbv1.GetServicePort(r.aeroCluster.Spec.AerospikeConfig)
+		port = asdbv1.GetServicePort(aeroCluster.Spec.AerospikeConfig)
+	}
+
+	// With a service mesh, the admin port is excluded from the sidecar proxy, so use it when configured.
+	if adminTLSName, adminPort := getServiceMeshAdminTLSNameAndPort(aeroCluster); adminPort != nil {
+		tlsName, port = adminTLSName, adminPort
 	}
 
-	host := pod.Status.PodIP
 	asConn := &deployment.ASConn{
-		AerospikeHostName: host,
+		AerospikeHostName: podIP,
 		AerospikePort:     int(*port),
 		AerospikeTLSName:  tlsName,
-		Log:               r.Log.WithValues("host", pod.Name),
+		Log:               log.WithValues("host", podName),
 	}
 
 	return asConn
 }
 
+// getServiceMeshAdminTLSNameAndPort returns the admin tlsName and port to use for info calls if a service mesh
+// is enabled and the same admin port is configured in both spec and status, so that it is open on all pods.
+func getServiceMeshAdminTLSNameAndPort(aeroCluster *asdbv1.AerospikeCluster) (tlsName string, port *int32) {
+	if aeroCluster.Spec.PodSpec.ServiceMesh == nil {
+		return "", nil
+	}
+
+	specTLSName, specPort := getAdminTLSNameAndPort(aeroCluster.Spec.AerospikeConfig)
+	if specPort == nil {
+		return "", nil
+	}
+
+	if aeroCluster.Status.AerospikeConfig != nil {
+		statusTLSName, statusPort := getAdminTLSNameAndPort(aeroCluster.Status.AerospikeConfig)
+		if statusPort == nil || *statusPort != *specPort || statusTLSName != specTLSName {
+			return "", nil
+		}
+	}
+
+	return specTLSName, specPort
+}
+
+// getAdminTLSNameAndPort returns the admin TLS name and port if admin TLS is configured, else the clear admin port.
+func getAdminTLSNameAndPort(aeroConf *asdbv1.AerospikeConfigSpec) (tlsName string, port *int32) {
+	if tlsName, port = asdbv1.GetAdminTLSNameAndPort(aeroConf); tlsName != "" && port != nil {
+		return tlsName, port
+	}
+
+	return "", asdbv1.GetAdminPort(aeroConf)
+}
+
 func hostID(hostName string, hostPort int) string {
-	return fmt.Sprintf("%s:%d", hostName, hostPort)
+	// JoinHostPort brackets the IPv6 addresses.
+	return net.JoinHostPort(hostName, strconv.Itoa(hostPort))
 }
 
 func (r *SingleClusterReconciler) setMigrateFillDelay(
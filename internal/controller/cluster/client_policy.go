@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -103,28 +104,37 @@ func (pp fromSecretPasswordProvider) getPasswordFromSecret(
 }
 
 func (r *SingleClusterReconciler) getPasswordProvider() fromSecretPasswordProvider {
+	return newPasswordProvider(r.Client, r.aeroCluster.Namespace)
+}
+
+func newPasswordProvider(k8sClient client.Client, namespace string) fromSecretPasswordProvider {
 	return fromSecretPasswordProvider{
-		k8sClient: &r.Client, namespace: r.aeroCluster.Namespace,
+		k8sClient: &k8sClient, namespace: namespace,
 	}
 }
 
 func (r *SingleClusterReconciler) getClientPolicy() *as.ClientPolicy {
+	return GetClientPolicy(r.Client, r.aeroCluster, r.Log)
+}
+
+// GetClientPolicy returns the client policy used by the operator to connect to the aeroCluster.
+func GetClientPolicy(k8sClient client.Client, aeroCluster *asdbv1.AerospikeCluster, log logr.Logger) *as.ClientPolicy {
 	policy := as.NewClientPolicy()
 
 	policy.SeedOnlyCluster = true
 
 	// cluster name
-	policy.ClusterName = r.aeroCluster.Name
+	policy.ClusterName = aeroCluster.Name
 
 	// tls config
-	if tlsName, _ := r.getServiceTLSNameAndPortIfConfigured(); tlsName != "" {
-		r.Log.V(1).Info("Set tls config in aerospike client policy")
-		clientCertSpec := r.aeroCluster.Spec.OperatorClientCertSpec
+	if tlsName, _ := getServiceTLSNameAndPortIfConfigured(aeroCluster); tlsName != "" {
+		log.V(1).Info("Set tls config in aerospike client policy")
+		clientCertSpec := aeroCluster.Spec.OperatorClientCertSpec
 
 		//nolint:gosec // This is a default TLS MinVersion
 		tlsConf := tls.Config{
-			RootCAs: r.getClusterServerCAPool(
-				clientCertSpec, r.aeroCluster.Namespace,
+			RootCAs: getClusterServerCAPool(
+				k8sClient, log, clientCertSpec, aeroCluster.Namespace,
 			),
 			Certificates: []tls.Certificate{},
 			// used only in testing
@@ -133,16 +143,16 @@ func (r *SingleClusterReconciler) getClientPolicy() *as.ClientPolicy {
 
 		if clientCertSpec == nil || !asdbv1.IsClientCertConfigured(clientCertSpec) {
 			// This is possible when tls-authenticate-client = false
-			r.Log.Info(
+			log.Info(
 				"Operator's client cert is not configured. Skip using client certs.",
 				"clientCertSpec", clientCertSpec,
 			)
-		} else if cert, err := r.getClientCertificate(
-			clientCertSpec, r.aeroCluster.Namespace,
+		} else if cert, err := getClientCertificate(
+			k8sClient, log, clientCertSpec, aeroCluster.Namespace,
 		); err == nil {
 			tlsConf.Certificates = append(tlsConf.Certificates, *cert)
 		} else {
-			r.Log.Error(
+			log.Error(
 				err,
 				"Failed to get client certificate. Using basic clientPolicy",
 			)
@@ -160,16 +170,16 @@ func (r *SingleClusterReconciler) getClientPolicy() *as.ClientPolicy {
 	// 	r.Log.Error(err, "Failed to copy spec in status", "err", err)
 	// }
 
-	statusToSpec, err := asdbv1.CopyStatusToSpec(&r.aeroCluster.Status.AerospikeClusterStatusSpec)
+	statusToSpec, err := asdbv1.CopyStatusToSpec(&aeroCluster.Status.AerospikeClusterStatusSpec)
 	if err != nil {
-		r.Log.Error(err, "Failed to copy spec in status", "err", err)
+		log.Error(err, "Failed to copy spec in status", "err", err)
 	}
 
 	user, pass, err := AerospikeAdminCredentials(
-		&r.aeroCluster.Spec, statusToSpec, r.getPasswordProvider(),
+		&aeroCluster.Spec, statusToSpec, newPasswordProvider(k8sClient, aeroCluster.Namespace),
 	)
 	if err != nil {
-		r.Log.Error(err, "Failed to get cluster auth info", "err", err)
+		log.Error(err, "Failed to get cluster auth info", "err", err)
 	}
 	// TODO: What should be the timeout, should make it configurable or just keep it default
 	policy.Timeout = time.Minute * 1
@@ -179,14 +189,15 @@ func (r *SingleClusterReconciler) getClientPolicy() *as.ClientPolicy {
 	return policy
 }
 
-func (r *SingleClusterReconciler) getClusterServerCAPool(
+func getClusterServerCAPool(
+	k8sClient client.Client, log logr.Logger,
 	clientCertSpec *asdbv1.AerospikeOperatorClientCertSpec,
 	clusterNamespace string,
 ) *x509.CertPool {
 	// Try to load system CA certs, otherwise just make an empty pool
 	serverPool, err := x509.SystemCertPool()
 	if err != nil {
-		r.Log.Info(
+		log.Info(
 			"Warn: Failed to add system certificates to the pool", "err", err,
 		)
 
@@ -194,21 +205,21 @@ func (r *SingleClusterReconciler) getClusterServerCAPool(
 	}
 
 	if clientCertSpec == nil {
-		r.Log.Info("`operatorClientCertSpec` is not configured. Using default system CA certs...")
+		log.Info("`operatorClientCertSpec` is not configured. Using default system CA certs...")
 		return serverPool
 	}
 
 	switch {
 	case clientCertSpec.CertPathInOperator != nil:
-		return r.appendCACertFromFileOrPath(
-			clientCertSpec.CertPathInOperator.CaCertsPath, serverPool,
+		return appendCACertFromFileOrPath(
+			log, clientCertSpec.CertPathInOperator.CaCertsPath, serverPool,
 		)
 	case clientCertSpec.SecretCertSource != nil:
-		return r.appendCACertFromSecret(
-			clientCertSpec.SecretCertSource, clusterNamespace, serverPool,
+		return appendCACertFromSecret(
+			k8sClient, log, clientCertSpec.SecretCertSource, clusterNamespace, serverPool,
 		)
 	default:
-		r.Log.Error(
+		log.Error(
 			fmt.Errorf("both `secretName` and `certPathInOperator` are not set"),
 			"Returning empty certPool.",
 		)
@@ -217,11 +228,12 @@ func (r *SingleClusterReconciler) getClusterServerCAPool(
 	}
 }
 
-func (r *SingleClusterReconciler) appendCACertFromFileOrPath(
+func appendCACertFromFileOrPath(
+	log logr.Logger,
 	caPath string, serverPool *x509.CertPool,
 ) *x509.CertPool {
 	if caPath == "" {
-		r.Log.Info("CA path is not provided in `operatorClientCertSpec`. Using default system CA certs...")
+		log.Info("CA path is not provided in `operatorClientCertSpec`. Using default system CA certs...")
 		return serverPool
 	}
 
@@ -240,7 +252,7 @@ func (r *SingleClusterReconciler) appendCACertFromFileOrPath(
 				}
 
 				serverPool.AppendCertsFromPEM(caData)
-				r.Log.Info("Loaded CA certs from file.", "ca-path", caPath,
+				log.Info("Loaded CA certs from file.", "ca-path", caPath,
 					"file", path)
 			}
 
@@ -248,7 +260,7 @@ func (r *SingleClusterReconciler) appendCACertFromFileOrPath(
 		},
 	)
 	if err != nil {
-		r.Log.Error(
+		log.Error(
 			err, "Failed to load CA certs from dir.", "ca-path", caPath,
 		)
 	}
@@ -256,12 +268,13 @@ func (r *SingleClusterReconciler) appendCACertFromFileOrPath(
 	return serverPool
 }
 
-func (r *SingleClusterReconciler) appendCACertFromSecret(
+func appendCACertFromSecret(
+	k8sClient client.Client, log logr.Logger,
 	secretSource *asdbv1.AerospikeSecretCertSource,
 	defaultNamespace string, serverPool *x509.CertPool,
 ) *x509.CertPool {
 	if secretSource.CaCertsFilename == "" && secretSource.CaCertsSource == nil {
-		r.Log.Info(
+		log.Info(
 			"Neither `caCertsFilename` nor `caCertSource` is specified. Using default CA certs...",
 			"secret", secretSource,
 		)
@@ -269,7 +282,7 @@ func (r *SingleClusterReconciler) appendCACertFromSecret(
 		return serverPool
 	}
 	// get the tls info from secret
-	r.Log.Info(
+	log.Info(
 		"Trying to find an appropriate CA cert from the secret...", "secret",
 		secretSource,
 	)
@@ -279,8 +292,8 @@ func (r *SingleClusterReconciler) appendCACertFromSecret(
 	if secretSource.CaCertsSource != nil {
 		secretName := namespacedSecret(secretSource.CaCertsSource.SecretNamespace,
 			secretSource.CaCertsSource.SecretName, defaultNamespace)
-		if err := r.Get(context.TODO(), secretName, found); err != nil {
-			r.Log.Error(
+		if err := k8sClient.Get(context.TODO(), secretName, found); err != nil {
+			log.Error(
 				err,
 				"Failed to get CA certificates secret, returning empty certPool",
 				"secret", secretName,
@@ -290,7 +303,7 @@ func (r *SingleClusterReconciler) appendCACertFromSecret(
 		}
 
 		for file, caData := range found.Data {
-			r.Log.V(1).Info(
+			log.V(1).Info(
 				"Adding cert to tls server-pool from the secret.", "secret",
 				secretName, "file", file,
 			)
@@ -298,8 +311,8 @@ func (r *SingleClusterReconciler) appendCACertFromSecret(
 		}
 	} else {
 		secretName := namespacedSecret(secretSource.SecretNamespace, secretSource.SecretName, defaultNamespace)
-		if err := r.Get(context.TODO(), secretName, found); err != nil {
-			r.Log.Error(
+		if err := k8sClient.Get(context.TODO(), secretName, found); err != nil {
+			log.Error(
 				err,
 				"Failed to get secret certificates to the pool, returning empty certPool",
 				"secret", secretName,
@@ -309,13 +322,13 @@ func (r *SingleClusterReconciler) appendCACertFromSecret(
 		}
 
 		if caData, ok := found.Data[secretSource.CaCertsFilename]; ok {
-			r.Log.V(1).Info(
+			log.V(1).Info(
 				"Adding cert to tls server-pool from the secret.", "secret",
 				secretName,
 			)
 			serverPool.AppendCertsFromPEM(caData)
 		} else {
-			r.Log.V(1).Info(
+			log.V(1).Info(
 				"WARN: Can't find ca-file in the secret. using default certPool.",
 				"secret", secretName, "ca-file", secretSource.CaCertsFilename,
 			)
@@ -325,26 +338,28 @@ func (r *SingleClusterReconciler) appendCACertFromSecret(
 	return serverPool
 }
 
-func (r *SingleClusterReconciler) getClientCertificate(
+func getClientCertificate(
+	k8sClient client.Client, log logr.Logger,
 	clientCertSpec *asdbv1.AerospikeOperatorClientCertSpec,
 	clusterNamespace string,
 ) (*tls.Certificate, error) {
 	switch {
 	case clientCertSpec.CertPathInOperator != nil:
-		return r.loadCertAndKeyFromFiles(
-			clientCertSpec.CertPathInOperator.ClientCertPath,
+		return loadCertAndKeyFromFiles(
+			log, clientCertSpec.CertPathInOperator.ClientCertPath,
 			clientCertSpec.CertPathInOperator.ClientKeyPath,
 		)
 	case clientCertSpec.SecretCertSource != nil:
-		return r.loadCertAndKeyFromSecret(
-			clientCertSpec.SecretCertSource, clusterNamespace,
+		return loadCertAndKeyFromSecret(
+			k8sClient, log, clientCertSpec.SecretCertSource, clusterNamespace,
 		)
 	default:
 		return nil, fmt.Errorf("both `secretName` and `certPathInOperator` are not set")
 	}
 }
 
-func (r *SingleClusterReconciler) loadCertAndKeyFromSecret(
+func loadCertAndKeyFromSecret(
+	k8sClient client.Client, log logr.Logger,
 	secretSource *asdbv1.AerospikeSecretCertSource,
 	defaultNamespace string,
 ) (*tls.Certificate, error) {
@@ -352,8 +367,8 @@ func (r *SingleClusterReconciler) loadCertAndKeyFromSecret(
 	found := &corev1.Secret{}
 
 	secretName := namespacedSecret(secretSource.SecretNamespace, secretSource.SecretName, defaultNamespace)
-	if err := r.Get(context.TODO(), secretName, found); err != nil {
-		r.Log.Info(
+	if err := k8sClient.Get(context.TODO(), secretName, found); err != nil {
+		log.Info(
 			"Warn: Failed to get secret certificates to the pool", "err", err,
 		)
 
@@ -384,7 +399,7 @@ func (r *SingleClusterReconciler) loadCertAndKeyFromSecret(
 		)
 	}
 
-	r.Log.Info(
+	log.Info(
 		"Loading Aerospike Cluster client cert from secret", "secret",
 		secretName,
 	)
@@ -408,7 +423,8 @@ func namespacedSecret(
 	}
 }
 
-func (r *SingleClusterReconciler) loadCertAndKeyFromFiles(
+func loadCertAndKeyFromFiles(
+	log logr.Logger,
 	certPath string, keyPath string,
 ) (*tls.Certificate, error) {
 	certData, certErr := os.ReadFile(certPath)
@@ -429,7 +445,7 @@ func (r *SingleClusterReconciler) loadCertAndKeyFromFiles(
 		)
 	}
 
-	r.Log.Info(
+	log.Info(
 		"Loading Aerospike Cluster client cert from files.", "cert-path",
 		certPath, "key-path", keyPath,
 	)
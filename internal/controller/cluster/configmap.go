@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"math"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -41,19 +43,6 @@ const (
 	aerospikeConfHashFileName = "aerospikeConfHash"
 )
 
-type initializeTemplateInput struct {
-	WorkDir          string
-	NetworkPolicy    asdbv1.AerospikeNetworkPolicy
-	FabricPort       int32
-	PodPort          int32
-	PodTLSPort       int32
-	HeartBeatPort    int32
-	HeartBeatTLSPort int32
-	FabricTLSPort    int32
-	MultiPodPerHost  bool
-	HostNetwork      bool
-}
-
 //go:embed scripts
 var scripts embed.FS
 
@@ -134,6 +123,17 @@ func (r *SingleClusterReconciler) createConfigMapData(rack *asdbv1.Rack) (
 		}
 	}
 
+	// The aerospikeConfig variables are resolved in the pods, so the pods are warm restarted on their change.
+	// They are added only if set so that the existing clusters are not restarted.
+	if configVariables := r.aeroCluster.Spec.AerospikeConfigVariables; len(configVariables) != 0 {
+		configVariablesStr, err := json.Marshal(configVariables)
+		if err != nil {
+			return nil, err
+		}
+
+		confTemp += string(configVariablesStr)
+	}
+
 	// Add conf hash
 	confHash, err := utils.GetHash(confTemp)
 	if err != nil {
@@ -150,6 +150,17 @@ func (r *SingleClusterReconciler) createConfigMapData(rack *asdbv1.Rack) (
 		return nil, err
 	}
 
+	// The external address of the pod service is advertised as the access address, so the pods are
+	// restarted on its change. It is added only if set so that the existing clusters are not restarted.
+	if externalAddress := r.aeroCluster.Spec.PodService.ExternalAddress; externalAddress != "" {
+		policyStr = append(policyStr, externalAddress...)
+	}
+
+	// Same for the advertised TLSRoute hostname and port.
+	if gatewayTLSRoute := r.aeroCluster.Spec.GatewayTLSRoute; gatewayTLSRoute != nil {
+		policyStr = append(policyStr, fmt.Sprintf("%s:%d", gatewayTLSRoute.Hostname, gatewayTLSRoute.Port)...)
+	}
+
 	policyHash, err := utils.GetHash(string(policyStr))
 	if err != nil {
 		return nil, err
@@ -213,6 +224,14 @@ func (r *SingleClusterReconciler) buildConfigTemplate(rack *asdbv1.Rack) (
 	)
 
 	configMap := rack.AerospikeConfig.Value
+
+	if r.aeroCluster.Spec.GatewayTLSRoute != nil && isGatewayTLSRouteNeeded(&r.aeroCluster.Spec.AerospikeNetworkPolicy) {
+		var err error
+		if configMap, err = setGatewayTLSRouteTLSName(configMap); err != nil {
+			return "", err
+		}
+	}
+
 	log.V(1).Info(
 		"AerospikeConfig", "config", configMap, "image",
 		r.aeroCluster.Spec.Image,
@@ -280,10 +299,14 @@ func (r *SingleClusterReconciler) getBaseConfData(rack *asdbv1.Rack) (map[string
 		fabricPortParam = *fabricPort
 	}
 
-	initTemplateInput := initializeTemplateInput{
-		WorkDir:          workDir,
-		MultiPodPerHost:  asdbv1.GetBool(r.aeroCluster.Spec.PodSpec.MultiPodPerHost),
+	initTemplateInput := utils.InitParams{
+		WorkDir:         workDir,
+		MultiPodPerHost: asdbv1.GetBool(r.aeroCluster.Spec.PodSpec.MultiPodPerHost),
+		PodServiceType: asdbv1.GetPodServiceType(
+			r.aeroCluster.Spec.PodSpec.MultiPodPerHost, &r.aeroCluster.Spec.AerospikeNetworkPolicy,
+		),
 		NetworkPolicy:    r.aeroCluster.Spec.AerospikeNetworkPolicy,
+		ConfigVariables:  r.aeroCluster.Spec.AerospikeConfigVariables,
 		PodPort:          servicePortParam,
 		PodTLSPort:       serviceTLSPortParam,
 		HeartBeatPort:    hbPortParam,
@@ -293,6 +316,28 @@ func (r *SingleClusterReconciler) getBaseConfData(rack *asdbv1.Rack) (map[string
 		HostNetwork:      r.aeroCluster.Spec.PodSpec.HostNetwork,
 	}
 
+	if gatewayTLSRoute := r.aeroCluster.Spec.GatewayTLSRoute; gatewayTLSRoute != nil &&
+		isGatewayTLSRouteNeeded(&r.aeroCluster.Spec.AerospikeNetworkPolicy) {
+		// The hostname is expanded with the pod name variable of the script, as the config map is shared by the
+		// pods of the rack. The hostname is validated by the webhook to be a DNS name, so it is safe to be quoted.
+		hostname, err := utils.ExpandPodTemplate(gatewayTLSRoute.Hostname, &utils.PodTemplateVars{
+			PodName:     utils.PodNameVariable,
+			ClusterName: r.aeroCluster.Name,
+			Namespace:   r.aeroCluster.Namespace,
+			RackID:      rack.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		initTemplateInput.TLSRouteHostname = hostname
+		initTemplateInput.TLSRoutePort = asdbv1.DefaultGatewayTLSRoutePort
+
+		if gatewayTLSRoute.Port != 0 {
+			initTemplateInput.TLSRoutePort = gatewayTLSRoute.Port
+		}
+	}
+
 	baseConfData := map[string]string{}
 
 	for path, scriptTemplate := range scriptTemplates {
@@ -305,18 +350,47 @@ func (r *SingleClusterReconciler) getBaseConfData(rack *asdbv1.Rack) (map[string
 		baseConfData[path] = script.String()
 	}
 
+	// The init binary gets the same inputs as the scripts.
+	initParams, err := json.Marshal(initTemplateInput)
+	if err != nil {
+		return nil, err
+	}
+
+	baseConfData[utils.InitParamsFileName] = string(initParams)
+
 	// Include peer list.
 	peers, err := r.getFQDNsForCluster()
 	if err != nil {
 		return nil, err
 	}
 
+	// The pods of the other federation members are seeded by their IPs.
+	peers = append(peers, r.getFederationSeeds()...)
+
 	baseConfData["peers"] = strings.Join(peers, "\n")
 
 	return baseConfData, nil
 }
 
 func (r *SingleClusterReconciler) getFQDNsForCluster() ([]string, error) {
+	heartbeatSeeds := r.aeroCluster.Spec.HeartbeatSeeds
+
+	// Resolved to all the pods, as the headless service publishes the not ready addresses too.
+	if heartbeatSeeds != nil && heartbeatSeeds.Strategy == asdbv1.HeartbeatSeedsHeadlessService {
+		return []string{fmt.Sprintf("%s.%s", getSTSHeadLessSvcName(r.aeroCluster), r.aeroCluster.Namespace)}, nil
+	}
+
+	// All the pods of each rack are seeded by default.
+	podsPerRack := int32(math.MaxInt32)
+
+	if heartbeatSeeds != nil && heartbeatSeeds.Strategy == asdbv1.HeartbeatSeedsPodsPerRack {
+		podsPerRack = asdbv1.DefaultHeartbeatSeedsPodsPerRack
+
+		if heartbeatSeeds.PodsPerRack != 0 {
+			podsPerRack = heartbeatSeeds.PodsPerRack
+		}
+	}
+
 	podNameSet := sets.NewString()
 
 	// The default rack is not listed in config during switchover to rack aware state.
@@ -327,16 +401,23 @@ func (r *SingleClusterReconciler) getFQDNsForCluster() ([]string, error) {
 	}
 
 	for idx := range pods.Items {
-		fqdn := getFQDNForPod(r.aeroCluster, pods.Items[idx].Name)
+		podName := pods.Items[idx].Name
+
+		ordinal, err := strconv.ParseInt(podName[strings.LastIndex(podName, "-")+1:], 10, 32)
+		if err != nil || int32(ordinal) >= podsPerRack {
+			continue
+		}
+
+		fqdn := getFQDNForPod(r.aeroCluster, podName)
 		podNameSet.Insert(fqdn)
 	}
 
 	rackStateList := getConfiguredRackStateList(r.aeroCluster)
 
-	// Use all pods running or to be launched for each rack.
+	// Use the pods running or to be launched for each rack.
 	for idx := range rackStateList {
 		rackState := &rackStateList[idx]
-		size := rackState.Size
+		size := min(rackState.Size, podsPerRack)
 		stsName := utils.GetNamespacedNameForSTSOrConfigMap(r.aeroCluster,
 			utils.GetRackIdentifier(rackState.Rack.ID, rackState.Rack.Revision))
 
@@ -0,0 +1,221 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+	"github.com/aerospike/aerospike-management-lib/deployment"
+)
+
+// federationRefreshInterval is the interval in seconds at which the members of a federation are read again,
+// as the changes of the AerospikeClusters in other Kubernetes clusters cannot be watched.
+const federationRefreshInterval = 60
+
+// federationMember is a federation member read from its Kubernetes cluster.
+type federationMember struct {
+	client      client.Client
+	aeroCluster *asdbv1.AerospikeCluster
+	name        string
+}
+
+// getFederationMembers returns the members of the federation which could be read, and the errors of the members
+// which could not be read.
+func (r *SingleClusterReconciler) getFederationMembers() (members []federationMember, errs []error) {
+	federation := r.aeroCluster.Spec.Federation
+	if federation == nil {
+		return nil, nil
+	}
+
+	for idx := range federation.Members {
+		member := &federation.Members[idx]
+
+		cl, err := r.getFederationMemberClient(member)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("federation member %s: %v", member.String(), err))
+			continue
+		}
+
+		memberCluster := &asdbv1.AerospikeCluster{}
+		if err := cl.Get(context.TODO(), types.NamespacedName{
+			Name: r.aeroCluster.Name, Namespace: r.getFederationMemberNamespace(member),
+		}, memberCluster); err != nil {
+			errs = append(errs, fmt.Errorf("federation member %s: %v", member.String(), err))
+			continue
+		}
+
+		members = append(members, federationMember{client: cl, aeroCluster: memberCluster, name: member.String()})
+	}
+
+	return members, errs
+}
+
+// validateFederationRacks returns an error if a rack is deployed by more than one member of the federation, as
+// their pods would join the cluster with the same node IDs. The members which cannot be read are not checked.
+func (r *SingleClusterReconciler) validateFederationRacks() error {
+	members, _ := r.getFederationMembers()
+	if len(members) == 0 {
+		return nil
+	}
+
+	rackOwners := map[int]string{}
+	for idx := range r.aeroCluster.Spec.RackConfig.Racks {
+		rackOwners[r.aeroCluster.Spec.RackConfig.Racks[idx].ID] = "this cluster"
+	}
+
+	for idx := range members {
+		memberRacks := members[idx].aeroCluster.Spec.RackConfig.Racks
+		for rackIdx := range memberRacks {
+			rackID := memberRacks[rackIdx].ID
+			if owner, ok := rackOwners[rackID]; ok {
+				return fmt.Errorf("rack %d is deployed by both federation member %s and %s", rackID,
+					members[idx].name, owner)
+			}
+
+			rackOwners[rackID] = "federation member " + members[idx].name
+		}
+	}
+
+	return nil
+}
+
+// getFederationMemberClient returns the client for the Kubernetes cluster of the federation member.
+// The remote clients are cached by the kubeconfig secret.
+func (r *SingleClusterReconciler) getFederationMemberClient(member *asdbv1.FederationMember) (client.Client, error) {
+	if member.KubeconfigSecretName == "" {
+		return r.Client, nil
+	}
+
+	return common.NewRemoteClient(r.Client, r.Scheme, r.aeroCluster.Namespace, member.KubeconfigSecretName)
+}
+
+func (r *SingleClusterReconciler) getFederationMemberNamespace(member *asdbv1.FederationMember) string {
+	if member.Namespace != "" {
+		return member.Namespace
+	}
+
+	return r.aeroCluster.Namespace
+}
+
+// getPods returns the pods of the federation member.
+func (m *federationMember) getPods() (*corev1.PodList, error) {
+	podList := &corev1.PodList{}
+	listOps := &client.ListOptions{
+		Namespace:     m.aeroCluster.Namespace,
+		LabelSelector: labels.SelectorFromSet(utils.LabelsForAerospikeCluster(m.aeroCluster.Name)),
+	}
+
+	if err := m.client.List(context.TODO(), podList, listOps); err != nil {
+		return nil, fmt.Errorf("federation member %s: %v", m.aeroCluster.Namespace, err)
+	}
+
+	return podList, nil
+}
+
+// getFederationSeeds returns the pod IPs of the other federation members, used as heartbeat mesh seeds.
+// The members which cannot be read are skipped, as the seeds of any member are enough to join the cluster.
+func (r *SingleClusterReconciler) getFederationSeeds() []string {
+	members, errs := r.getFederationMembers()
+	for _, err := range errs {
+		r.Log.Error(err, "Skipping the seeds of federation member")
+	}
+
+	seeds := sets.New[string]()
+
+	for idx := range members {
+		for podName := range members[idx].aeroCluster.Status.Pods {
+			if podIP := members[idx].aeroCluster.Status.Pods[podName].PodIP; podIP != "" {
+				seeds.Insert(podIP)
+			}
+		}
+	}
+
+	seedList := seeds.UnsortedList()
+	slices.Sort(seedList)
+
+	return seedList
+}
+
+// newFederationHostConns returns connections to the running and ready pods of the other federation members,
+// so that the cluster wide operations and checks cover the whole Aerospike cluster.
+// The members which cannot be read are skipped. If their nodes are still in the cluster, the cluster size does
+// not match the connected hosts, and the cluster stability checks fail.
+func (r *SingleClusterReconciler) newFederationHostConns() []*deployment.HostConn {
+	members, errs := r.getFederationMembers()
+	for _, err := range errs {
+		r.Log.Error(err, "Skipping the pods of federation member")
+	}
+
+	var hostConns []*deployment.HostConn
+
+	for idx := range members {
+		podList, err := members[idx].getPods()
+		if err != nil {
+			r.Log.Error(err, "Skipping the pods of federation member")
+			continue
+		}
+
+		for podIdx := range podList.Items {
+			pod := &podList.Items[podIdx]
+			if utils.IsPodTerminating(pod) || !utils.IsPodRunningAndReady(pod) {
+				continue
+			}
+
+			asConn := r.newAsConn(pod)
+			hostConns = append(hostConns, deployment.NewHostConn(
+				asConn.Log, hostID(asConn.AerospikeHostName, asConn.AerospikePort), asConn,
+			))
+		}
+	}
+
+	return hostConns
+}
+
+// getFederationRosterNodeBlockList returns the rosterNodeBlockList of all the federation members, if all of them
+// are read and have all their pods ready. Returns false if the roster should not be set now.
+func (r *SingleClusterReconciler) getFederationRosterNodeBlockList() ([]string, bool) {
+	members, errs := r.getFederationMembers()
+	if len(errs) > 0 {
+		r.Log.Info("Skipping roster update, federation members not available", "errors", errs)
+		return nil, false
+	}
+
+	var rosterNodeBlockList []string
+
+	for idx := range members {
+		memberCluster := members[idx].aeroCluster
+
+		podList, err := members[idx].getPods()
+		if err != nil {
+			r.Log.Info("Skipping roster update, federation member pods not available", "error", err)
+			return nil, false
+		}
+
+		var readyPods int32
+
+		for podIdx := range podList.Items {
+			if utils.IsPodRunningAndReady(&podList.Items[podIdx]) {
+				readyPods++
+			}
+		}
+
+		if readyPods != memberCluster.Spec.Size {
+			r.Log.Info("Skipping roster update, federation member pods not ready", "member", memberCluster.Namespace,
+				"readyPods", readyPods, "size", memberCluster.Spec.Size)
+			return nil, false
+		}
+
+		rosterNodeBlockList = append(rosterNodeBlockList, memberCluster.Spec.RosterNodeBlockList...)
+	}
+
+	return rosterNodeBlockList, true
+}
@@ -0,0 +1,253 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+// defaultExporterPort is the default port of the Aerospike Prometheus exporter.
+const defaultExporterPort int32 = 9145
+
+func (r *SingleClusterReconciler) reconcileNetworkPolicy() error {
+	// If spec.NetworkIsolation is not set, then we don't need to create NetworkPolicy
+	// If it was created earlier then delete it
+	if r.aeroCluster.Spec.NetworkIsolation == nil {
+		if r.aeroCluster.Status.NetworkIsolation != nil {
+			r.Log.Info("NetworkIsolation is removed. Deleting old NetworkPolicy")
+			return r.deleteNetworkPolicy()
+		}
+
+		return nil
+	}
+
+	return r.createOrUpdateNetworkPolicy()
+}
+
+func (r *SingleClusterReconciler) deleteNetworkPolicy() error {
+	networkPolicy := &networkingv1.NetworkPolicy{}
+
+	if err := r.Get(context.TODO(), getNetworkPolicyNamespacedName(r.aeroCluster), networkPolicy); err != nil {
+		if errors.IsNotFound(err) {
+			// NetworkPolicy is already deleted
+			return nil
+		}
+
+		return err
+	}
+
+	if !utils.IsOwnedBy(networkPolicy, r.aeroCluster) {
+		r.Log.Info(
+			"NetworkPolicy is not created/owned by operator. Skipping delete",
+			"name", getNetworkPolicyNamespacedName(r.aeroCluster),
+		)
+
+		return nil
+	}
+
+	return r.Delete(context.TODO(), networkPolicy)
+}
+
+func (r *SingleClusterReconciler) createOrUpdateNetworkPolicy() error {
+	networkPolicySpec := r.getNetworkPolicySpec()
+	networkPolicy := &networkingv1.NetworkPolicy{}
+
+	if err := r.Get(context.TODO(), getNetworkPolicyNamespacedName(r.aeroCluster), networkPolicy); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		networkPolicy = &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      r.aeroCluster.Name,
+				Namespace: r.aeroCluster.Namespace,
+				Labels:    utils.LabelsForAerospikeCluster(r.aeroCluster.Name),
+			},
+			Spec: networkPolicySpec,
+		}
+
+		// Set AerospikeCluster instance as the owner and controller
+		if err = controllerutil.SetControllerReference(r.aeroCluster, networkPolicy, r.Scheme); err != nil {
+			return err
+		}
+
+		if err = r.Create(context.TODO(), networkPolicy, common.CreateOption); err != nil {
+			return fmt.Errorf("failed to create NetworkPolicy: %v", err)
+		}
+
+		r.Log.Info("Created new NetworkPolicy", "name", getNetworkPolicyNamespacedName(r.aeroCluster))
+
+		return nil
+	}
+
+	if !utils.IsOwnedBy(networkPolicy, r.aeroCluster) {
+		return fmt.Errorf(
+			"failed to update NetworkPolicy, NetworkPolicy is not created/owned by operator. name: %s",
+			getNetworkPolicyNamespacedName(r.aeroCluster),
+		)
+	}
+
+	if reflect.DeepEqual(networkPolicy.Spec, networkPolicySpec) {
+		return nil
+	}
+
+	networkPolicy.Spec = networkPolicySpec
+
+	if err := r.Update(context.TODO(), networkPolicy, common.UpdateOption); err != nil {
+		return fmt.Errorf("failed to update NetworkPolicy: %v", err)
+	}
+
+	r.Log.Info("Updated NetworkPolicy", "name", getNetworkPolicyNamespacedName(r.aeroCluster))
+
+	return nil
+}
+
+// getNetworkPolicySpec returns the NetworkPolicy spec allowing the traffic to the ports of both the spec and the
+// status Aerospike config, so that the pods can talk to each other while the ports are being changed by a
+// rolling restart. The ports removed from the config are closed by the next reconcile.
+func (r *SingleClusterReconciler) getNetworkPolicySpec() networkingv1.NetworkPolicySpec {
+	networkIsolation := r.aeroCluster.Spec.NetworkIsolation
+	clusterPorts, servicePorts, adminPorts := sets.New[int32](), sets.New[int32](), sets.New[int32]()
+
+	for _, config := range []*asdbv1.AerospikeConfigSpec{
+		r.aeroCluster.Spec.AerospikeConfig, r.aeroCluster.Status.AerospikeConfig,
+	} {
+		if config == nil {
+			continue
+		}
+
+		_, heartbeatTLSPort := asdbv1.GetHeartbeatTLSNameAndPort(config)
+		_, fabricTLSPort := asdbv1.GetFabricTLSNameAndPort(config)
+		_, serviceTLSPort := asdbv1.GetServiceTLSNameAndPort(config)
+		_, adminTLSPort := asdbv1.GetAdminTLSNameAndPort(config)
+
+		insertPorts(clusterPorts, asdbv1.GetHeartbeatPort(config), heartbeatTLSPort,
+			asdbv1.GetFabricPort(config), fabricTLSPort)
+		insertPorts(servicePorts, asdbv1.GetServicePort(config), serviceTLSPort)
+		insertPorts(adminPorts, asdbv1.GetAdminPort(config), adminTLSPort)
+	}
+
+	exporterPorts := sets.New(networkIsolation.ExporterPorts...)
+	if exporterPorts.Len() == 0 {
+		exporterPorts.Insert(defaultExporterPort)
+	}
+
+	clusterSelector := metav1.LabelSelector{MatchLabels: utils.LabelsForAerospikeCluster(r.aeroCluster.Name)}
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+
+	// A rule without ports allows all the ports, so the rules are added only for the configured ports.
+	addRule := func(from []networkingv1.NetworkPolicyPeer, ports sets.Set[int32]) {
+		if ports.Len() == 0 {
+			return
+		}
+
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  from,
+			Ports: getNetworkPolicyPorts(sets.List(ports)),
+		})
+	}
+
+	addRule([]networkingv1.NetworkPolicyPeer{{PodSelector: &clusterSelector}}, clusterPorts)
+
+	// The pods of the federation members join the cluster, and their operators connect to the service ports.
+	if federationPeers := getFederationPeers(r.aeroCluster.Spec.Federation, &clusterSelector); len(federationPeers) != 0 {
+		addRule(federationPeers, clusterPorts.Union(servicePorts))
+	}
+	addRule(networkIsolation.ClientPeers, servicePorts)
+	addRule(getNamespacePeers([]string{networkIsolation.OperatorNamespace}), servicePorts.Union(adminPorts))
+
+	if len(networkIsolation.MonitoringNamespaces) != 0 {
+		addRule(getNamespacePeers(networkIsolation.MonitoringNamespaces), exporterPorts)
+	}
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: clusterSelector,
+		Ingress:     ingress,
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+}
+
+func insertPorts(ports sets.Set[int32], configPorts ...*int32) {
+	for _, port := range configPorts {
+		if port != nil {
+			ports.Insert(*port)
+		}
+	}
+}
+
+func getNetworkPolicyPorts(ports []int32) []networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+
+	for _, port := range ports {
+		policyPort := intstr.FromInt32(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &policyPort})
+	}
+
+	return policyPorts
+}
+
+func getNamespacePeers(namespaces []string) []networkingv1.NetworkPolicyPeer {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(namespaces))
+
+	for _, namespace := range namespaces {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+			},
+		})
+	}
+
+	return peers
+}
+
+// getFederationPeers returns the pods of the federation members in the same Kubernetes cluster, and the pod CIDRs
+// of the members in the other Kubernetes clusters.
+func getFederationPeers(
+	federation *asdbv1.FederationSpec, clusterSelector *metav1.LabelSelector,
+) []networkingv1.NetworkPolicyPeer {
+	if federation == nil {
+		return nil
+	}
+
+	var peers []networkingv1.NetworkPolicyPeer
+
+	for idx := range federation.Members {
+		member := &federation.Members[idx]
+
+		if member.KubeconfigSecretName == "" {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				PodSelector: clusterSelector,
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{corev1.LabelMetadataName: member.Namespace},
+				},
+			})
+
+			continue
+		}
+
+		for _, cidr := range member.PodCIDRs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+		}
+	}
+
+	return peers
+}
+
+func getNetworkPolicyNamespacedName(aeroCluster *asdbv1.AerospikeCluster) types.NamespacedName {
+	return types.NamespacedName{Name: aeroCluster.Name, Namespace: aeroCluster.Namespace}
+}
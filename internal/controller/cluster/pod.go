@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -88,6 +91,13 @@ func (r *SingleClusterReconciler) getRollingRestartTypeMap(rackState *RackState,
 	// Fetching all pods requested for on-demand operations.
 	onDemandQuickRestarts, onDemandPodRestarts := r.podsToRestart()
 
+	configVariablesNeedPodRestart, err := r.configVariablesNeedPodRestart(rackState)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configVariableEnvVars := getConfigVariableEnvVars(r.aeroCluster.Spec.AerospikeConfigVariables)
+
 	for idx := range pods {
 		if ignorablePodNames.Has(pods[idx].Name) {
 			continue
@@ -104,6 +114,13 @@ func (r *SingleClusterReconciler) getRollingRestartTypeMap(rackState *RackState,
 
 		podStatus := r.aeroCluster.Status.Pods[pods[idx].Name]
 		if podStatus.AerospikeConfigHash != requiredConfHash {
+			// The init container env vars of the aerospikeConfig variables are updated only by a pod restart.
+			if configVariablesNeedPodRestart || !hasConfigVariableEnvVars(pods[idx], configVariableEnvVars) {
+				restartTypeMap[pods[idx].Name] = podRestart
+
+				continue
+			}
+
 			serverContainer := getContainer(pods[idx].Spec.Containers, asdbv1.AerospikeServerContainerName)
 
 			version, err := asdbv1.GetImageVersion(serverContainer.Image)
@@ -111,17 +128,19 @@ func (r *SingleClusterReconciler) getRollingRestartTypeMap(rackState *RackState,
 				return nil, nil, err
 			}
 
-			specToStatusDiffs, err := getConfDiff(r.Log, rackState.Rack.AerospikeConfig.Value, pods[idx].Annotations, version)
+			confTemplate, err := r.getPodConfTemplate(pods[idx])
+			if err != nil {
+				return nil, nil, err
+			}
+
+			specToStatusDiffs, err := getConfDiff(r.Log, rackState.Rack.AerospikeConfig.Value, confTemplate, version)
 			if err != nil {
 				return nil, nil, err
 			}
 
 			if len(specToStatusDiffs) != 0 {
 				for key := range specToStatusDiffs {
-					// To update in-memory namespace data-size, we need to restart the pod.
-					// Just a warm restart is not enough.
-					// https://support.aerospike.com/s/article/How-to-change-data-size-config-in-a-running-cluster
-					if strings.HasSuffix(key, ".storage-engine.data-size") {
+					if isPodRestartConfKey(key) {
 						restartTypeMap[pods[idx].Name] = mergeRestartType(restartTypeMap[pods[idx].Name], podRestart)
 
 						break
@@ -134,10 +153,12 @@ func (r *SingleClusterReconciler) getRollingRestartTypeMap(rackState *RackState,
 
 				// If EnableDynamicConfigUpdate is set and dynamic config command exec partially failed in previous try
 				// then skip dynamic config update and fall back to rolling restart.
-				// Continue with dynamic config update in case of Failed DynamicConfigUpdateStatus
+				// Continue with dynamic config update in case of Failed DynamicConfigUpdateStatus.
+				// The values referencing the aerospikeConfig variables are resolved in the pods by the init
+				// container, so these cannot be set dynamically.
 				if asdbv1.GetBool(r.aeroCluster.Spec.EnableDynamicConfigUpdate) &&
 					podStatus.DynamicConfigUpdateStatus != asdbv1.PartiallyFailed &&
-					isAllDynamicConfig(r.Log, specToStatusDiffs, version) {
+					!hasConfigVariableRefs(specToStatusDiffs) && isAllDynamicConfig(r.Log, specToStatusDiffs, version) {
 					dynamicConfDiffPerPod[pods[idx].Name] = specToStatusDiffs
 				}
 			}
@@ -358,8 +379,13 @@ func (r *SingleClusterReconciler) restartASDOrUpdateAerospikeConf(podName string
 		subCommand = "update-conf"
 	}
 
+	// The init binary of the operator is run if copied by the init container, else the akoinit binary of the
+	// upstream init images. The latter writes the pod status in the AerospikeCluster status, so the pods keep the
+	// permission to update it while this fallback exists.
+	operatorInitBinary := filepath.Join("/etc/aerospike", utils.InitBinaryName)
+
 	cmd := []string{
-		initBinary,
+		operatorInitBinary,
 		subCommand,
 		"--cm-name",
 		cmName.Name,
@@ -373,6 +399,15 @@ func (r *SingleClusterReconciler) restartASDOrUpdateAerospikeConf(podName string
 		podNamespacedName, asdbv1.AerospikeServerContainerName, cmd, r.KubeClient,
 		r.KubeConfig,
 	)
+	if err != nil && strings.Contains(err.Error(), operatorInitBinary+": no such file or directory") {
+		cmd[0] = initBinary
+
+		stdout, stderr, err = utils.Exec(
+			podNamespacedName, asdbv1.AerospikeServerContainerName, cmd, r.KubeClient,
+			r.KubeConfig,
+		)
+	}
+
 	if err != nil {
 		if strings.Contains(err.Error(), initBinary+": no such file or directory") {
 			cmd := []string{
@@ -435,6 +470,24 @@ func (r *SingleClusterReconciler) restartPods(
 	restartedPods := make([]*corev1.Pod, 0, len(podsToRestart))
 	restartedPodNames := make([]string, 0, len(podsToRestart))
 	restartedASDPodNames := make([]string, 0, len(podsToRestart))
+	localPVCDeletionPods := sets.Set[string]{}
+
+	var bootstrapPods []*corev1.Pod
+
+	for idx := range podsToRestart {
+		pod := podsToRestart[idx]
+
+		if restartTypeMap[pod.Name] == podRestart && r.isLocalPVCDeletionRequired(rackState, pod) {
+			localPVCDeletionPods.Insert(pod.Name)
+			bootstrapPods = append(bootstrapPods, pod)
+		}
+	}
+
+	// The volume bootstraps are recorded before any PVC is deleted.
+	bootstrapPending, err := r.prepareVolumeBootstraps(rackState, bootstrapPods)
+	if err != nil {
+		return common.ReconcileError(err)
+	}
 
 	for idx := range podsToRestart {
 		pod := podsToRestart[idx]
@@ -451,7 +504,7 @@ func (r *SingleClusterReconciler) restartPods(
 
 			restartedASDPodNames = append(restartedASDPodNames, pod.Name)
 		case podRestart:
-			if r.isLocalPVCDeletionRequired(rackState, pod) {
+			if localPVCDeletionPods.Has(pod.Name) {
 				if err := r.deleteLocalPVCs(rackState, pod); err != nil {
 					return common.ReconcileError(err)
 				}
@@ -475,6 +528,11 @@ func (r *SingleClusterReconciler) restartPods(
 		return common.ReconcileError(err)
 	}
 
+	// The pods are checked by the next reconcile, after the PVCs are re-created.
+	if bootstrapPending {
+		return common.ReconcileRequeueAfter(bootstrapPVCRequeueSeconds)
+	}
+
 	if len(restartedPods) > 0 {
 		if result := r.ensurePodsRunningAndReady(restartedPods); !result.IsSuccess {
 			return result
@@ -680,9 +738,26 @@ func (r *SingleClusterReconciler) deletePodAndEnsureImageUpdated(
 		return common.ReconcileError(err)
 	}
 
-	// Delete pods
+	localPVCDeletionPods := sets.Set[string]{}
+
+	var bootstrapPods []*corev1.Pod
+
 	for _, pod := range podsToUpdate {
 		if r.isLocalPVCDeletionRequired(rackState, pod) {
+			localPVCDeletionPods.Insert(pod.Name)
+			bootstrapPods = append(bootstrapPods, pod)
+		}
+	}
+
+	// The volume bootstraps are recorded before any PVC is deleted.
+	bootstrapPending, err := r.prepareVolumeBootstraps(rackState, bootstrapPods)
+	if err != nil {
+		return common.ReconcileError(err)
+	}
+
+	// Delete pods
+	for _, pod := range podsToUpdate {
+		if localPVCDeletionPods.Has(pod.Name) {
 			if err := r.deleteLocalPVCs(rackState, pod); err != nil {
 				return common.ReconcileError(err)
 			}
@@ -699,6 +774,11 @@ func (r *SingleClusterReconciler) deletePodAndEnsureImageUpdated(
 		)
 	}
 
+	// The pods are checked by the next reconcile, after the PVCs are re-created.
+	if bootstrapPending {
+		return common.ReconcileRequeueAfter(bootstrapPVCRequeueSeconds)
+	}
+
 	return r.ensurePodsImageUpdated(podsToUpdate)
 }
 
@@ -852,7 +932,8 @@ func (r *SingleClusterReconciler) cleanupPods(
 		}
 
 		// Try to delete the corresponding pod service if it was created
-		if asdbv1.GetBool(r.aeroCluster.Spec.PodSpec.MultiPodPerHost) {
+		if asdbv1.GetBool(r.aeroCluster.Spec.PodSpec.MultiPodPerHost) ||
+			podServiceNeeded(r.aeroCluster.Spec.PodSpec.MultiPodPerHost, &r.aeroCluster.Spec.AerospikeNetworkPolicy) {
 			// Remove service for pod
 			// TODO: make it more robust, what if it fails
 			if err := r.deletePodService(
@@ -862,6 +943,16 @@ func (r *SingleClusterReconciler) cleanupPods(
 			}
 		}
 
+		if r.aeroCluster.Spec.GatewayTLSRoute != nil || r.aeroCluster.Status.GatewayTLSRoute != nil {
+			if err := r.deleteTLSRoute(podName, r.aeroCluster.Namespace); err != nil {
+				return err
+			}
+		}
+
+		if err := r.deletePodStatusConfigMap(podName); err != nil {
+			return err
+		}
+
 		if _, ok := r.aeroCluster.Status.Pods[podName]; ok {
 			needStatusCleanup = append(needStatusCleanup, podName)
 		}
@@ -893,6 +984,13 @@ func (r *SingleClusterReconciler) removePodStatus(podNames []string) error {
 			Path:      "/status/pods/" + podName,
 		}
 		patches = append(patches, patch)
+
+		if _, ok := r.aeroCluster.Status.VolumeOperations[podName]; ok {
+			patches = append(patches, jsonpatch.PatchOperation{
+				Operation: "remove",
+				Path:      "/status/volumeOperations/" + podName,
+			})
+		}
 	}
 
 	return r.patchPodStatus(context.TODO(), patches)
@@ -1111,7 +1209,7 @@ func getFQDNForPod(aeroCluster *asdbv1.AerospikeCluster, host string) string {
 
 // GetEndpointsFromInfo returns the aerospike endpoints as a slice of host:port based on context and addressName passed
 // from the info endpointsMap. It returns an empty slice if the access address with addressName is not found in
-// endpointsMap.
+// endpointsMap. The IPv6 addresses are bracketed.
 // E.g. addressName are access, alternate-access
 func GetEndpointsFromInfo(
 	aeroCtx, addressName string, endpointsMap map[string]string,
@@ -1142,7 +1240,7 @@ func GetEndpointsFromInfo(
 
 	for _, host := range hosts {
 		endpoints = append(
-			endpoints, net.JoinHostPort(host, strconv.Itoa(int(port))),
+			endpoints, net.JoinHostPort(strings.Trim(host, "[]"), strconv.Itoa(int(port))),
 		)
 	}
 
@@ -1526,6 +1624,105 @@ func (r *SingleClusterReconciler) getConfigMap(rackIdentifier string) (*corev1.C
 	return confMap, nil
 }
 
+// hasConfigVariableRefs checks if any of the configuration changes references the aerospikeConfig variables.
+// isPodRestartConfKey returns true if a change of the flattened conf key needs a pod restart.
+func isPodRestartConfKey(key string) bool {
+	// To update in-memory namespace data-size, we need to restart the pod.
+	// Just a warm restart is not enough.
+	// https://support.aerospike.com/s/article/How-to-change-data-size-config-in-a-running-cluster
+	return strings.HasSuffix(key, ".storage-engine.data-size")
+}
+
+// configVariablesNeedPodRestart returns true if a conf key of the rack needing a pod restart references an
+// aerospikeConfig variable changed since the last reconcile, directly or through an expression.
+func (r *SingleClusterReconciler) configVariablesNeedPodRestart(rackState *RackState) (bool, error) {
+	statusVariables := make(map[string]*asdbv1.AerospikeConfigVariable, len(r.aeroCluster.Status.AerospikeConfigVariables))
+	for idx := range r.aeroCluster.Status.AerospikeConfigVariables {
+		statusVariables[r.aeroCluster.Status.AerospikeConfigVariables[idx].Name] =
+			&r.aeroCluster.Status.AerospikeConfigVariables[idx]
+	}
+
+	changed := sets.New[string]()
+
+	for idx := range r.aeroCluster.Spec.AerospikeConfigVariables {
+		variable := &r.aeroCluster.Spec.AerospikeConfigVariables[idx]
+
+		statusVariable, ok := statusVariables[variable.Name]
+		if !ok || !reflect.DeepEqual(statusVariable, variable) {
+			changed.Insert(variable.Name)
+			continue
+		}
+
+		if variable.Expression != "" {
+			expression, err := utils.ParseSizeExpression(variable.Expression)
+			if err != nil {
+				return false, fmt.Errorf("aerospikeConfig variable %s: %v", variable.Name, err)
+			}
+
+			if changed.HasAny(expression.Variables()...) {
+				changed.Insert(variable.Name)
+			}
+		}
+	}
+
+	if changed.Len() == 0 {
+		return false, nil
+	}
+
+	keys := getConfigVariableRefKeys(rackState.Rack.AerospikeConfig.Value, "", changed, nil)
+
+	return slices.ContainsFunc(keys, isPodRestartConfKey), nil
+}
+
+// getConfigVariableRefKeys returns the keys of the aerospikeConfig whose values reference the given variables.
+// The keys are the dot separated map keys, without the list indexes.
+func getConfigVariableRefKeys(value interface{}, path string, names sets.Set[string], keys []string) []string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			keys = getConfigVariableRefKeys(child, path+"."+key, names, keys)
+		}
+	case []interface{}:
+		for _, child := range value {
+			keys = getConfigVariableRefKeys(child, path, names, keys)
+		}
+	case string:
+		if names.HasAny(asdbv1.GetConfigVariableRefs(value)...) {
+			keys = append(keys, path)
+		}
+	}
+
+	return keys
+}
+
+// hasConfigVariableEnvVars returns true if the init container of the pod has the given env vars of the
+// aerospikeConfig variables.
+func hasConfigVariableEnvVars(pod *corev1.Pod, envVars []corev1.EnvVar) bool {
+	var podEnvVars []corev1.EnvVar
+
+	if container := getContainer(pod.Spec.InitContainers, asdbv1.AerospikeInitContainerName); container != nil {
+		for _, envVar := range container.Env {
+			if isConfigVariableEnvVar(envVar) {
+				podEnvVars = append(podEnvVars, envVar)
+			}
+		}
+	}
+
+	return equality.Semantic.DeepEqual(podEnvVars, envVars)
+}
+
+func hasConfigVariableRefs(specToStatusDiffs asconfig.DynamicConfigMap) bool {
+	for _, diff := range specToStatusDiffs {
+		for _, value := range diff {
+			if len(asdbv1.GetConfigVariableRefs(fmt.Sprint(value))) != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // isAllDynamicConfig checks if all the configuration changes can be applied dynamically
 func isAllDynamicConfig(log logger, specToStatusDiffs asconfig.DynamicConfigMap, version string) bool {
 	isDynamic, err := asconfig.IsAllDynamicConfig(log, specToStatusDiffs, version)
@@ -1552,15 +1749,14 @@ func getFlatConfig(log logger, confStr string) (*asconfig.Conf, error) {
 }
 
 // getConfDiff retrieves the configuration differences between the spec and status Aerospike configurations.
-func getConfDiff(log logger, specConfig map[string]interface{}, podAnnotations map[string]string,
+func getConfDiff(log logger, specConfig map[string]interface{}, confTemplate string,
 	version string) (asconfig.DynamicConfigMap, error) {
-	statusFromAnnotation, ok := podAnnotations["aerospikeConf"]
-	if !ok {
-		log.Info("Pod annotation 'aerospikeConf' missing")
+	if confTemplate == "" {
+		log.Info("Pod aerospike.conf template 'aerospikeConf' missing")
 		return nil, nil
 	}
 
-	asConfStatus, err := getFlatConfig(log, statusFromAnnotation)
+	asConfStatus, err := getFlatConfig(log, confTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config map by lib: %v", err)
 	}
@@ -1630,6 +1826,7 @@ func (r *SingleClusterReconciler) patchPodStatus(ctx context.Context, patches []
 	}
 
 	r.aeroCluster.Status.Pods = patchedAerospikeCluster.Status.Pods
+	r.aeroCluster.Status.VolumeOperations = patchedAerospikeCluster.Status.VolumeOperations
 
 	return nil
 }
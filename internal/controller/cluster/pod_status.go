@@ -0,0 +1,439 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/jsonpatch"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+// podStatusRequeueSeconds is the requeue interval while a published pod status is left to fold.
+const podStatusRequeueSeconds = 1
+
+// reconcilePodStatusConfigMaps creates the pod status ConfigMaps of the pods of the racks and of the existing pods,
+// and allows the pods to patch them with a Role scoped to their names.
+// The pods publish their status in these ConfigMaps, instead of having write access to their pods or to the cluster.
+func (r *SingleClusterReconciler) reconcilePodStatusConfigMaps(rackStates []RackState) error {
+	podNames := sets.New[string]()
+
+	for idx := range rackStates {
+		stsName := utils.GetNamespacedNameForSTSOrConfigMap(
+			r.aeroCluster, utils.GetRackIdentifier(rackStates[idx].Rack.ID, rackStates[idx].Rack.Revision),
+		)
+
+		for podIndex := int32(0); podIndex < rackStates[idx].Size; podIndex++ {
+			podNames.Insert(getSTSPodName(stsName.Name, podIndex))
+		}
+	}
+
+	podList, err := r.getClusterPodList()
+	if err != nil {
+		return err
+	}
+
+	for idx := range podList.Items {
+		podNames.Insert(podList.Items[idx].Name)
+	}
+
+	confMapList, err := r.getPodStatusConfigMapList()
+	if err != nil {
+		return err
+	}
+
+	confMapNames := sets.New[string]()
+
+	for idx := range confMapList.Items {
+		confMapNames.Insert(confMapList.Items[idx].Name)
+	}
+
+	for _, podName := range sets.List(podNames) {
+		if confMapNames.Has(asdbv1.GetPodStatusConfigMapName(podName)) {
+			continue
+		}
+
+		if err := r.createPodStatusConfigMap(podName); err != nil {
+			return err
+		}
+
+		confMapNames.Insert(asdbv1.GetPodStatusConfigMapName(podName))
+	}
+
+	return r.createOrUpdatePodStatusRole(sets.List(confMapNames))
+}
+
+func (r *SingleClusterReconciler) createPodStatusConfigMap(podName string) error {
+	ls := utils.LabelsForAerospikeCluster(r.aeroCluster.Name)
+	ls[asdbv1.AerospikePodNameLabel] = podName
+
+	confMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      asdbv1.GetPodStatusConfigMapName(podName),
+			Namespace: r.aeroCluster.Namespace,
+			Labels:    ls,
+		},
+	}
+
+	// Set AerospikeCluster instance as the owner and controller
+	if err := controllerutil.SetControllerReference(r.aeroCluster, confMap, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(context.TODO(), confMap, common.CreateOption); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to create pod status ConfigMap for pod %s: %v", podName, err)
+	}
+
+	r.Log.Info("Created pod status ConfigMap", "podName", podName)
+
+	return nil
+}
+
+func (r *SingleClusterReconciler) deletePodStatusConfigMap(podName string) error {
+	confMap := &corev1.ConfigMap{}
+	confMap.Name = asdbv1.GetPodStatusConfigMapName(podName)
+	confMap.Namespace = r.aeroCluster.Namespace
+
+	if err := r.Delete(context.TODO(), confMap); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete pod status ConfigMap for pod %s: %v", podName, err)
+	}
+
+	return nil
+}
+
+func (r *SingleClusterReconciler) getPodStatusConfigMapList() (*corev1.ConfigMapList, error) {
+	confMapList := &corev1.ConfigMapList{}
+
+	if err := r.List(
+		context.TODO(), confMapList, client.InNamespace(r.aeroCluster.Namespace),
+		client.MatchingLabels(utils.LabelsForAerospikeCluster(r.aeroCluster.Name)),
+		client.HasLabels{asdbv1.AerospikePodNameLabel},
+	); err != nil {
+		return nil, err
+	}
+
+	return confMapList, nil
+}
+
+// createOrUpdatePodStatusRole allows the aerospike pods to patch only the given pod status ConfigMaps.
+func (r *SingleClusterReconciler) createOrUpdatePodStatusRole(confMapNames []string) error {
+	name := types.NamespacedName{
+		Name:      r.aeroCluster.Name + asdbv1.PodStatusConfigMapSuffix,
+		Namespace: r.aeroCluster.Namespace,
+	}
+
+	var rules []rbacv1.PolicyRule
+
+	// A rule without resource names would allow all the ConfigMaps of the namespace.
+	if len(confMapNames) != 0 {
+		rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				Verbs:         []string{"get", "patch"},
+				ResourceNames: confMapNames,
+			},
+		}
+	}
+
+	role := &rbacv1.Role{}
+
+	if err := r.Get(context.TODO(), name, role); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		role = &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.Name,
+				Namespace: name.Namespace,
+				Labels:    utils.LabelsForAerospikeCluster(r.aeroCluster.Name),
+			},
+			Rules: rules,
+		}
+
+		if err = controllerutil.SetControllerReference(r.aeroCluster, role, r.Scheme); err != nil {
+			return err
+		}
+
+		if err = r.Create(context.TODO(), role, common.CreateOption); err != nil {
+			return fmt.Errorf("failed to create pod status Role: %v", err)
+		}
+
+		r.Log.Info("Created pod status Role", "name", name)
+	} else if !reflect.DeepEqual(role.Rules, rules) {
+		role.Rules = rules
+
+		if err = r.Update(context.TODO(), role, common.UpdateOption); err != nil {
+			return fmt.Errorf("failed to update pod status Role: %v", err)
+		}
+
+		r.Log.Info("Updated pod status Role", "name", name)
+	}
+
+	roleBinding := &rbacv1.RoleBinding{}
+
+	if err := r.Get(context.TODO(), name, roleBinding); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		roleBinding = &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.Name,
+				Namespace: name.Namespace,
+				Labels:    utils.LabelsForAerospikeCluster(r.aeroCluster.Name),
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name.Name,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      aeroClusterServiceAccountName,
+					Namespace: name.Namespace,
+				},
+			},
+		}
+
+		if err = controllerutil.SetControllerReference(r.aeroCluster, roleBinding, r.Scheme); err != nil {
+			return err
+		}
+
+		if err = r.Create(context.TODO(), roleBinding, common.CreateOption); err != nil {
+			return fmt.Errorf("failed to create pod status RoleBinding: %v", err)
+		}
+
+		r.Log.Info("Created pod status RoleBinding", "name", name)
+	}
+
+	return nil
+}
+
+// foldPodStatus folds the pod status and the volume operations progress published by the pods in their pod status
+// ConfigMaps into the cluster status.
+// The pod status is removed from the ConfigMap once folded, so that later pod status changes made by the operator are
+// not overwritten by a stale one. It returns true if a published pod status is left to fold.
+func (r *SingleClusterReconciler) foldPodStatus() (bool, error) {
+	confMapList, err := r.getPodStatusConfigMapList()
+	if err != nil {
+		return false, err
+	}
+
+	podList, err := r.getClusterPodList()
+	if err != nil {
+		return false, err
+	}
+
+	podNames := sets.New[string]()
+
+	for idx := range podList.Items {
+		podNames.Insert(podList.Items[idx].Name)
+	}
+
+	var (
+		patches        []jsonpatch.PatchOperation
+		foldedConfMaps []*corev1.ConfigMap
+	)
+
+	if r.aeroCluster.Status.Pods == nil {
+		patches = append(patches, jsonpatch.PatchOperation{
+			Operation: "add",
+			Path:      "/status/pods",
+			Value:     map[string]asdbv1.AerospikePodStatus{},
+		})
+	}
+
+	if r.aeroCluster.Status.VolumeOperations == nil {
+		patches = append(patches, jsonpatch.PatchOperation{
+			Operation: "add",
+			Path:      "/status/volumeOperations",
+			Value:     map[string]map[string]asdbv1.VolumeOperationProgress{},
+		})
+	}
+
+	// Skip the map creation patches if there is nothing to fold.
+	initPatches := len(patches)
+
+	publishedOperations := sets.New[string]()
+
+	for idx := range confMapList.Items {
+		confMap := &confMapList.Items[idx]
+		podName := confMap.Labels[asdbv1.AerospikePodNameLabel]
+
+		if podStatusJSON, ok := confMap.Data[asdbv1.PodStatusConfigMapKey]; ok {
+			podStatus := asdbv1.AerospikePodStatus{}
+
+			if err := json.Unmarshal([]byte(podStatusJSON), &podStatus); err != nil {
+				r.Log.Error(err, "Failed to parse published pod status, skipping it", "podName", podName)
+			} else {
+				if oldStatus, ok := r.aeroCluster.Status.Pods[podName]; !ok || !reflect.DeepEqual(oldStatus, podStatus) {
+					patches = append(patches, jsonpatch.PatchOperation{
+						Operation: "add",
+						Path:      "/status/pods/" + podName,
+						Value:     podStatus,
+					})
+				}
+
+				foldedConfMaps = append(foldedConfMaps, confMap)
+			}
+		}
+
+		volumeOperationsJSON, ok := confMap.Data[asdbv1.VolumeOperationsConfigMapKey]
+		if !ok || !podNames.Has(podName) {
+			continue
+		}
+
+		publishedOperations.Insert(podName)
+
+		volumeOperations := map[string]asdbv1.VolumeOperationProgress{}
+
+		if err := json.Unmarshal([]byte(volumeOperationsJSON), &volumeOperations); err != nil {
+			r.Log.Error(err, "Failed to parse published volume operations, skipping them", "podName", podName)
+			continue
+		}
+
+		if oldOperations, ok := r.aeroCluster.Status.VolumeOperations[podName]; !ok ||
+			!reflect.DeepEqual(oldOperations, volumeOperations) {
+			patches = append(patches, jsonpatch.PatchOperation{
+				Operation: "add",
+				Path:      "/status/volumeOperations/" + podName,
+				Value:     volumeOperations,
+			})
+		}
+	}
+
+	// The pod removes its volume operations once they all complete. The entries of the pods which no longer exist are
+	// removed as well.
+	for podName := range r.aeroCluster.Status.VolumeOperations {
+		if !publishedOperations.Has(podName) {
+			patches = append(patches, jsonpatch.PatchOperation{
+				Operation: "remove",
+				Path:      "/status/volumeOperations/" + podName,
+			})
+		}
+	}
+
+	if len(patches) > initPatches {
+		r.Log.Info("Folding published pod status into the cluster status", "patches", len(patches)-initPatches)
+
+		if err := r.patchPodStatus(context.TODO(), patches); err != nil {
+			return false, err
+		}
+	}
+
+	var pending bool
+
+	for _, confMap := range foldedConfMaps {
+		removed, err := r.removeFoldedPodStatus(confMap)
+		if err != nil {
+			return false, err
+		}
+
+		pending = pending || !removed
+	}
+
+	return pending, nil
+}
+
+// removeFoldedPodStatus removes the folded pod status from the pod status ConfigMap. The removal is guarded by a test
+// of the folded value, so that a newer pod status published in between is kept and folded next.
+func (r *SingleClusterReconciler) removeFoldedPodStatus(confMap *corev1.ConfigMap) (bool, error) {
+	path := "/data/" + asdbv1.PodStatusConfigMapKey
+
+	patch, err := json.Marshal([]jsonpatch.PatchOperation{
+		{
+			Operation: "test",
+			Path:      path,
+			Value:     confMap.Data[asdbv1.PodStatusConfigMapKey],
+		},
+		{
+			Operation: "remove",
+			Path:      path,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Client.Patch(context.TODO(), confMap, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+		if errors.IsInvalid(err) || errors.IsNotFound(err) {
+			r.Log.Info(
+				"Pod status changed while removing it, will retry",
+				"podName", confMap.Labels[asdbv1.AerospikePodNameLabel],
+			)
+
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// getPodConfTemplate returns the aerospike.conf template in use in the pod, published in its pod status ConfigMap.
+// Older init images set it in the pod annotations.
+func (r *SingleClusterReconciler) getPodConfTemplate(pod *corev1.Pod) (string, error) {
+	confMap := &corev1.ConfigMap{}
+
+	if err := r.Get(
+		context.TODO(),
+		types.NamespacedName{Name: asdbv1.GetPodStatusConfigMapName(pod.Name), Namespace: pod.Namespace},
+		confMap,
+	); err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+
+	if confTemplate, ok := confMap.Data[asdbv1.AerospikeConfConfigMapKey]; ok {
+		return confTemplate, nil
+	}
+
+	return pod.Annotations[asdbv1.AerospikeConfConfigMapKey], nil
+}
+
+// hasPodStatusToFold returns true if the ConfigMap is a pod status ConfigMap with a published pod status or volume
+// operations which differ from the old ones.
+func hasPodStatusToFold(oldObj, newObj client.Object) bool {
+	if _, ok := newObj.GetLabels()[asdbv1.AerospikePodNameLabel]; !ok {
+		return false
+	}
+
+	oldConfMap, ok := oldObj.(*corev1.ConfigMap)
+	if !ok {
+		return false
+	}
+
+	newConfMap, ok := newObj.(*corev1.ConfigMap)
+	if !ok {
+		return false
+	}
+
+	if _, ok := newConfMap.Data[asdbv1.PodStatusConfigMapKey]; ok &&
+		oldConfMap.Data[asdbv1.PodStatusConfigMapKey] != newConfMap.Data[asdbv1.PodStatusConfigMapKey] {
+		return true
+	}
+
+	oldOperations, oldOk := oldConfMap.Data[asdbv1.VolumeOperationsConfigMapKey]
+	newOperations, newOk := newConfMap.Data[asdbv1.VolumeOperationsConfigMapKey]
+
+	return oldOk != newOk || oldOperations != newOperations
+}
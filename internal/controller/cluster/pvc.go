@@ -41,10 +41,10 @@ func (r *SingleClusterReconciler) removePVCsAsync(
 		// Can we do it async in scaleDown
 
 		// Check for path in pvc annotations. We put path annotation while creating statefulset
-		pvcStorageVolName, ok := pvc.Annotations[storageVolumeAnnotationKey]
+		pvcStorageVolName, ok := pvc.Annotations[asdbv1.StorageVolumeAnnotationKey]
 		if !ok {
 			// Try legacy annotation name.
-			pvcStorageVolName, ok = pvc.Annotations[storageVolumeLegacyAnnotationKey]
+			pvcStorageVolName, ok = pvc.Annotations[asdbv1.StorageVolumeLegacyAnnotationKey]
 		}
 
 		if !ok {
@@ -113,14 +113,13 @@ func (r *SingleClusterReconciler) deleteLocalPVCs(rackState *RackState, pod *cor
 	}
 
 	for idx := range pvcItems {
-		pvcStorageClass := pvcItems[idx].Spec.StorageClassName
-		if pvcStorageClass == nil {
+		if pvcItems[idx].Spec.StorageClassName == nil {
 			r.Log.Info("PVC does not have storageClass set, no need to delete PVC", "pvcName", pvcItems[idx].Name)
 
 			continue
 		}
 
-		if utils.ContainsString(rackState.Rack.Storage.LocalStorageClasses, *pvcStorageClass) {
+		if isLocalPVC(rackState, &pvcItems[idx]) {
 			if err := r.Delete(context.TODO(), &pvcItems[idx]); err != nil && !errors.IsNotFound(err) {
 				return fmt.Errorf(
 					"could not delete pvc %s: %v", pvcItems[idx].Name, err,
@@ -132,6 +131,12 @@ func (r *SingleClusterReconciler) deleteLocalPVCs(rackState *RackState, pod *cor
 	return nil
 }
 
+// isLocalPVC returns true if the PVC is created using one of the LocalStorageClasses of the rack.
+func isLocalPVC(rackState *RackState, pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Spec.StorageClassName != nil &&
+		utils.ContainsString(rackState.Rack.Storage.LocalStorageClasses, *pvc.Spec.StorageClassName)
+}
+
 func (r *SingleClusterReconciler) waitForPVCTermination(deletedPVCs []corev1.PersistentVolumeClaim) error {
 	if len(deletedPVCs) == 0 {
 		return nil
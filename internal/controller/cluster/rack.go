@@ -44,6 +44,11 @@ func (r *SingleClusterReconciler) reconcileRacks() common.ReconcileResult {
 		return common.ReconcileError(err)
 	}
 
+	// The pods publish their status in their pod status ConfigMaps, create them before any pod is created.
+	if err = r.reconcilePodStatusConfigMaps(configuredRacks); err != nil {
+		return common.ReconcileError(err)
+	}
+
 	ignorablePodNames, err := r.getIgnorablePods(racksToDelete, configuredRacks, revisionChangedRacks)
 	if err != nil {
 		return common.ReconcileError(err)
@@ -95,6 +100,14 @@ func (r *SingleClusterReconciler) reconcileRacks() common.ReconcileResult {
 				return common.ReconcileError(err)
 			}
 
+			// PVCs restored from a snapshot set have to exist before the pods are created.
+			if r.aeroCluster.Spec.VolumeSnapshotRestore != nil && r.IsStatusEmpty() {
+				if err = r.createPVCsFromSnapshotSet(state); err != nil {
+					r.Log.Error(err, "Failed to restore volumes from VolumeSnapshot set")
+					return common.ReconcileError(err)
+				}
+			}
+
 			// Create statefulset with 0 size rack and then scaleUp later in Reconcile
 			zeroSizedRack := &RackState{Rack: state.Rack, Size: 0}
 
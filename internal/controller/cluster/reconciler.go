@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -105,6 +106,19 @@ func (r *SingleClusterReconciler) Reconcile() (result ctrl.Result, recErr error)
 		return reconcile.Result{}, err
 	}
 
+	if _, err := r.foldPodStatus(); err != nil {
+		r.Log.Error(err, "Failed to fold published pod status")
+		return reconcile.Result{}, err
+	}
+
+	// Re-create the PVCs of the restarted pods from VolumeSnapshots once the old PVCs are removed.
+	if pending, err := r.reconcileVolumeBootstraps(); err != nil {
+		r.Log.Error(err, "Failed to bootstrap volumes from VolumeSnapshots")
+		return reconcile.Result{}, err
+	} else if pending {
+		return reconcile.Result{RequeueAfter: bootstrapPVCRequeueSeconds * time.Second}, nil
+	}
+
 	// Handle previously failed cluster
 	hasFailed, res := r.checkPreviouslyFailedCluster()
 	if !res.IsSuccess {
@@ -132,6 +146,31 @@ func (r *SingleClusterReconciler) Reconcile() (result ctrl.Result, recErr error)
 		return reconcile.Result{}, recErr
 	}
 
+	if err := r.reconcileNetworkPolicy(); err != nil {
+		r.Log.Error(err, "Failed to reconcile NetworkPolicy")
+		r.Recorder.Eventf(
+			r.aeroCluster, corev1.EventTypeWarning, "NetworkPolicyReconcileFailed",
+			"Failed to reconcile NetworkPolicy %s/%s",
+			r.aeroCluster.Namespace, r.aeroCluster.Name,
+		)
+
+		recErr = err
+
+		return reconcile.Result{}, recErr
+	}
+
+	if err := r.validateFederationRacks(); err != nil {
+		r.Recorder.Eventf(
+			r.aeroCluster, corev1.EventTypeWarning, "FederationValidationFailed",
+			"Invalid federation of cluster %s/%s: %v",
+			r.aeroCluster.Namespace, r.aeroCluster.Name, err,
+		)
+
+		recErr = err
+
+		return reconcile.Result{}, recErr
+	}
+
 	// Reconcile all racks
 	if res := r.reconcileRacks(); !res.IsSuccess {
 		if res.Err != nil {
@@ -147,6 +186,11 @@ func (r *SingleClusterReconciler) Reconcile() (result ctrl.Result, recErr error)
 		return res.Result, recErr
 	}
 
+	// Snapshots not deleted now are deleted by the next reconciles.
+	if err := r.cleanupBootstrapSnapshots(); err != nil {
+		r.Log.Error(err, "Failed to cleanup bootstrap VolumeSnapshots")
+	}
+
 	if err := r.reconcilePDB(); err != nil {
 		r.Log.Error(err, "Failed to reconcile PodDisruptionBudget")
 		r.Recorder.Eventf(
@@ -284,8 +328,20 @@ func (r *SingleClusterReconciler) Reconcile() (result ctrl.Result, recErr error)
 		}
 	}
 
+	// Fold the pod status published during the reconcile, and requeue until none is left.
+	if pending, err := r.foldPodStatus(); err != nil {
+		r.Log.Error(err, "Failed to fold published pod status")
+		return reconcile.Result{}, err
+	} else if pending {
+		return common.ReconcileRequeueAfter(podStatusRequeueSeconds).Result, nil
+	}
+
 	r.Log.Info("Reconcile completed successfully")
 
+	if r.aeroCluster.Spec.Federation != nil {
+		return common.ReconcileRequeueAfter(federationRefreshInterval).Result, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -685,11 +741,13 @@ func (r *SingleClusterReconciler) patchStatus(newAeroCluster *asdbv1.AerospikeCl
 	for _, operation := range jsonPatchPatch {
 		// pods should never be updated here
 		// pods is updated only from 2 places
-		// 1: While pod init, it will add pod in pods
+		// 1: While folding the pod status published by the pod init, it will add pod in pods
 		// 2: While pod cleanup, it will remove pod from pods
+		// volumeOperations is updated the same way, from the pod volume operations.
 		if strings.HasPrefix(
 			operation.Path, "/status",
-		) && !strings.HasPrefix(operation.Path, "/status/pods") {
+		) && !strings.HasPrefix(operation.Path, "/status/pods") &&
+			!strings.HasPrefix(operation.Path, "/status/volumeOperations") {
 			filteredPatch = append(filteredPatch, operation)
 		}
 	}
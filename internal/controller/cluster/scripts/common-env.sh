@@ -42,9 +42,9 @@ def getport(data, podname):
             return infoport, tlsport
 print(getport(data, podname))")"
 
-# Get IPs
+# Get IPs, the IPs of all the IP families are comma separated with the primary IP first.
 export PODIP="$MY_POD_IP"
-INTERNALIP="$MY_HOST_IP"
+export PODIPS="${MY_POD_IPS:-$MY_POD_IP}"
 
 # Get External IP
 DATA="$(curl --cacert $CA_CERT -H "Authorization: Bearer $TOKEN" "$KUBE_API_SERVER/api/v1/nodes")"
@@ -53,48 +53,116 @@ DATA="$(curl --cacert $CA_CERT -H "Authorization: Bearer $TOKEN" "$KUBE_API_SERV
 HOSTIPS="$(echo $DATA | python3 -c "import sys, json
 data = json.load(sys.stdin);
 host = '${MY_HOST_IP}';
+def isipv6(ip):
+    return ':' in ip
+
+def primaryfirst(ips):
+    # IPs of the same family as the host IP first, the order is otherwise kept.
+    return sorted(ips, key=lambda ip: isipv6(ip) != isipv6(host))
+
 def gethost(data, host):
-    internalIP = host
-    externalIP = host
+    internalIPs = [host]
+    externalIPs = [host]
 
     # Iterate over all nodes and find this pod's node IPs.
     for item in data['items']:
-        nodeInternalIP = ''
-        nodeExternalIP = ''
+        nodeInternalIPs = []
+        nodeExternalIPs = []
         matchFound = False
         for add in item['status']['addresses']:
             if add['address'] == host:
                matchFound = True
             if add['type'] == 'InternalIP':
-                nodeInternalIP = add['address']
+                nodeInternalIPs.append(add['address'])
                 continue
             if add['type'] == 'ExternalIP':
-                nodeExternalIP = add['address']
+                nodeExternalIPs.append(add['address'])
                 continue
 
         if matchFound:
            # Matching node for this pod found.
-           if nodeInternalIP != '':
-               internalIP = nodeInternalIP
+           if nodeInternalIPs:
+               internalIPs = primaryfirst(nodeInternalIPs)
 
-           if nodeExternalIP != '':
-               externalIP = nodeExternalIP
+           if nodeExternalIPs:
+               externalIPs = primaryfirst(nodeExternalIPs)
 
            break
 
-    return internalIP + ' ' + externalIP
+    return ','.join(internalIPs) + ' ' + ','.join(externalIPs)
 
 print(gethost(data, host))")"
 
-export INTERNALIP=$(echo $HOSTIPS | awk '{print $1}')
-export EXTERNALIP=$(echo $HOSTIPS | awk '{print $2}')
+export INTERNALIPS=$(echo $HOSTIPS | awk '{print $1}')
+export EXTERNALIPS=$(echo $HOSTIPS | awk '{print $2}')
+export INTERNALIP=${INTERNALIPS%%,*}
+export EXTERNALIP=${EXTERNALIPS%%,*}
+
+# Select the IP of the given family from the comma separated IPs, the primary IP is selected if no family is given.
+selectIP() {
+  local family=$1
+  local ip
+
+  for ip in ${2//,/ }; do
+    if [ -z "$family" ] || { [ "$family" == "IPv6" ] && [[ "$ip" == *:* ]]; } ||
+      { [ "$family" == "IPv4" ] && [[ "$ip" != *:* ]]; }; then
+      echo "$ip"
+      return 0
+    fi
+  done
+
+  echo "No $family IP found in $2" >&2
+  return 1
+}
+
+{{- if .PodServiceType}}
+
+# Get the address of the pod service, published by the operator in the rack ConfigMap.
+getPodServiceAddress() {
+  awk -v pod="$MY_POD_NAME" '$1 == pod {print $2}' /configs/podServiceAddresses 2>/dev/null
+}
+{{- end}}
+
+{{- if eq .PodServiceType "LoadBalancer"}}
+
+# Wait for the load balancer address of the pod service to be published.
+# The mounted ConfigMap is refreshed by the kubelet.
+for i in $(seq 1 60); do
+  LBADDRESS="$(getPodServiceAddress)"
+  if [ -n "$LBADDRESS" ]; then
+    break
+  fi
+  sleep 5
+done
+
+if [ -z "$LBADDRESS" ]; then
+  echo "Load balancer ingress address not published for service $MY_POD_NAME"
+  exit 1
+fi
+
+export LBADDRESS
+{{- end}}
+
+{{- if eq .PodServiceType "NodePort"}}
+
+# Use the configured external address of the pod service, defaults to the host's external IP.
+NODEPORTADDRESS="$(getPodServiceAddress)"
+export NODEPORTADDRESS="${NODEPORTADDRESS:-$EXTERNALIP}"
+{{- end}}
+
+{{- if .TLSRouteHostname}}
+
+# Use the SNI hostname and port of the pod TLSRoute.
+export TLSROUTEHOSTNAME="{{.TLSRouteHostname}}"
+export TLSROUTEPORT="{{.TLSRoutePort}}"
+{{- end}}
 
 # Sets up port related variables.
 export POD_PORT="{{.PodPort}}"
 export POD_TLSPORT="{{.PodTLSPort}}"
 
 # Compute the mapped access ports based on config.
-{{- if .MultiPodPerHost}}
+{{- if or .MultiPodPerHost (eq .PodServiceType "NodePort")}}
 # Use mapped service ports.
 export MAPPED_PORT="$(echo $PORTSTRING | awk -F'[, |(|)]' '{print $2}')"
 export MAPPED_TLSPORT="$(echo $PORTSTRING | awk -F'[, |(|)]' '{print $4}')"
@@ -43,30 +43,43 @@ echo "" > $GENERATED_ENV
 substituteEndpoint() {
     local addressType=$1
     local networkType=$2
-    local podIP=$3
-    local internalIP=$4
-    local externalIP=$5
-    local podPort=$6
-    local mappedPort=$7
+    local ipFamily=$3
+    local podPort=$4
+    local mappedPort=$5
 
     case $networkType in
       pod)
-        accessAddress=$podIP
+        accessAddress=$(selectIP "$ipFamily" "$PODIPS")
         accessPort=$podPort
         ;;
 
       hostInternal)
-        accessAddress=$internalIP
+        accessAddress=$(selectIP "$ipFamily" "$INTERNALIPS")
         accessPort=$mappedPort
         ;;
 
       hostExternal)
-        accessAddress=$externalIP
+        accessAddress=$(selectIP "$ipFamily" "$EXTERNALIPS")
         accessPort=$mappedPort
         ;;
 
+      podLoadBalancer)
+        accessAddress=$LBADDRESS
+        accessPort=$podPort
+        ;;
+
+      podNodePort)
+        accessAddress=$NODEPORTADDRESS
+        accessPort=$mappedPort
+        ;;
+
+      gatewayTLSRoute)
+        accessAddress=$TLSROUTEHOSTNAME
+        accessPort=$TLSROUTEPORT
+        ;;
+
       *)
-        accessAddress=$podIP
+        accessAddress=$PODIP
         accessPort=$podPort
         ;;
     esac
@@ -82,14 +95,20 @@ substituteEndpoint() {
     sed -i "s/^\(\s*\)${addressType}-port\s*${podPort}/\1${addressType}-port    ${accessPort}/" ${CFG}
 }
 
-substituteEndpoint "access" {{.NetworkPolicy.AccessType}} $PODIP $INTERNALIP $EXTERNALIP $POD_PORT $MAPPED_PORT
-substituteEndpoint "alternate-access" {{.NetworkPolicy.AlternateAccessType}} $PODIP $INTERNALIP $EXTERNALIP $POD_PORT $MAPPED_PORT
+substituteEndpoint "access" {{.NetworkPolicy.AccessType}} "{{.NetworkPolicy.AccessIPFamily}}" $POD_PORT $MAPPED_PORT
+substituteEndpoint "alternate-access" {{.NetworkPolicy.AlternateAccessType}} "{{.NetworkPolicy.AlternateAccessIPFamily}}" $POD_PORT $MAPPED_PORT
 
 if [ "true" == "$MY_POD_TLS_ENABLED" ]; then
-  substituteEndpoint "tls-access" {{.NetworkPolicy.TLSAccessType}} $PODIP $INTERNALIP $EXTERNALIP $POD_TLSPORT $MAPPED_TLSPORT
-  substituteEndpoint "tls-alternate-access" {{.NetworkPolicy.TLSAlternateAccessType}} $PODIP $INTERNALIP $EXTERNALIP $POD_TLSPORT $MAPPED_TLSPORT
+  substituteEndpoint "tls-access" {{.NetworkPolicy.TLSAccessType}} "{{.NetworkPolicy.TLSAccessIPFamily}}" $POD_TLSPORT $MAPPED_TLSPORT
+  substituteEndpoint "tls-alternate-access" {{.NetworkPolicy.TLSAlternateAccessType}} "{{.NetworkPolicy.TLSAlternateAccessIPFamily}}" $POD_TLSPORT $MAPPED_TLSPORT
 fi
 
+{{- if .TLSRouteHostname}}
+
+# The service uses the TLSRoute hostname as its tls-name, sent by the clients as SNI to the Gateway.
+sed -i "s/<gateway-tls-route-hostname>/${TLSROUTEHOSTNAME}/g" ${CFG}
+{{- end}}
+
 # ------------------------------------------------------------------------------
 # Update mesh seeds in the configuration file
 # ------------------------------------------------------------------------------
@@ -133,6 +152,14 @@ sed -i -e "/fabric {/a \\        tls-address ${MY_POD_IP}" ${CFG}
 {{- end}}
 {{- end}}
 
+# ------------------------------------------------------------------------------
+# The aerospikeConfig variables are only resolved by the aerospike-pod-init binary.
+# ------------------------------------------------------------------------------
+if grep -q "<var:" ${CFG}; then
+	echo "aerospikeConfigVariables require an init image with the aerospike-pod-init binary"
+	exit 1
+fi
+
 echo "---------------------------------"
 cat ${CFG}
 echo "---------------------------------"
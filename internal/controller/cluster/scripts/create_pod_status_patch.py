@@ -6,10 +6,13 @@ import json
 import logging
 import argparse
 import ipaddress
+import threading
 import subprocess
+import time
 import urllib.error
 import urllib.request
 import concurrent.futures
+from datetime import datetime, timezone
 from shlex import quote
 from pprint import pprint
 
@@ -17,6 +20,12 @@ from pprint import pprint
 FILE_SYSTEM_MOUNT_POINT = "/workdir/filesystem-volumes"
 BLOCK_MOUNT_POINT = "/workdir/block-volumes"
 BASE_WIPE_VERSION = 6
+VERIFY_SAMPLE_BLOCKS = 16
+VERIFY_BLOCK_SIZE = 4096
+NVME_SANITIZE_POLL_INTERVAL = 10
+PROGRESS_REPORT_INTERVAL = 30
+POD_STATUS_KEY = "podStatus"
+VOLUME_OPERATIONS_KEY = "volumeOperations"
 ADDRESS_TYPE_NAME = {
     "access": "accessEndpoints",
     "alternate-access": "alternateAccessEndpoints",
@@ -36,6 +45,7 @@ class Volume(object):
 
         self.effective_wipe_method = volume["effectiveWipeMethod"]
         self.effective_init_method = volume["effectiveInitMethod"]
+        self.effective_wipe_verification = volume.get("effectiveWipeVerification", False)
 
         if "aerospike" in volume:
             self.attachment_type = "aerospike"
@@ -75,6 +85,129 @@ class Volume(object):
                f"{self.effective_wipe_method}"
 
 
+def format_time(timestamp):
+    return datetime.fromtimestamp(timestamp, timezone.utc).strftime("%Y-%m-%dT%H:%M:%SZ")
+
+
+class ProgressReporter(object):
+    """
+    Reports progress of volume init and wipe operations in the pod status ConfigMap.
+    The operator folds it into status.volumeOperations.<pod-name> of the cluster object. Progress is patched
+    periodically from a background thread, so that long-running operations do not block on the api-server.
+    """
+
+    def __init__(self, pod_name, namespace, api_server, token, ca_cert):
+        self.pod_name = pod_name
+        self.url = f"{api_server}/api/v1/namespaces/{namespace}/configmaps/{pod_name}-status?fieldManager=pod"
+        self.token = token
+        self.ca_cert = ca_cert
+        self.lock = threading.Lock()
+        self.volumes = {}
+        self.changed = False
+        self.reported = False
+        self.stop_event = threading.Event()
+        self.thread = threading.Thread(target=self.run, daemon=True)
+
+    def start(self, volume_name, operation, method, total_bytes=0):
+        now = time.time()
+        with self.lock:
+            self.volumes[volume_name] = {
+                "operation": operation,
+                "method": method,
+                "phase": "InProgress",
+                "bytesDone": 0,
+                "totalBytes": total_bytes,
+                "startTime": now,
+                "lastUpdateTime": now,
+                "message": "",
+            }
+            self.changed = True
+
+    def update(self, volume_name, bytes_done):
+        with self.lock:
+            self.volumes[volume_name]["bytesDone"] = bytes_done
+            self.volumes[volume_name]["lastUpdateTime"] = time.time()
+            self.changed = True
+
+    def finish(self, volume_name, phase, message=""):
+        with self.lock:
+            progress = self.volumes[volume_name]
+            if phase == "Completed" and progress["totalBytes"]:
+                progress["bytesDone"] = progress["totalBytes"]
+
+            progress["phase"] = phase
+            progress["message"] = message
+            progress["lastUpdateTime"] = time.time()
+            self.changed = True
+
+    def get_status(self):
+        status = {}
+        for volume_name, progress in self.volumes.items():
+            value = dict(progress)
+            value["startTime"] = format_time(progress["startTime"])
+            value["lastUpdateTime"] = format_time(progress["lastUpdateTime"])
+
+            elapsed = progress["lastUpdateTime"] - progress["startTime"]
+            if elapsed > 0 and progress["bytesDone"]:
+                rate = progress["bytesDone"] / elapsed
+                value["bytesPerSecond"] = int(rate)
+
+                if progress["phase"] == "InProgress" and progress["totalBytes"]:
+                    remaining = max(progress["totalBytes"] - progress["bytesDone"], 0)
+                    value["estimatedCompletionTime"] = format_time(progress["lastUpdateTime"] + remaining / rate)
+
+            status[volume_name] = value
+
+        return status
+
+    def patch(self, value):
+        data = json.dumps(value) if value is not None else None
+        payload = {"data": {VOLUME_OPERATIONS_KEY: data}}
+        request = urllib.request.Request(url=self.url, method="PATCH", data=json.dumps(payload).encode("utf-8"))
+        request.add_header("Authorization", f"Bearer {self.token}")
+        request.add_header("Content-Type", "application/merge-patch+json")
+
+        try:
+            with urllib.request.urlopen(request, cafile=self.ca_cert) as response:
+                response.read()
+        except urllib.error.URLError as e:
+            # Progress is best effort, it should never fail the volume operations.
+            logging.warning(f"pod-name: {self.pod_name} - Unable to report volume operations progress - Error: {e}")
+            return False
+
+        return True
+
+    def report(self):
+        with self.lock:
+            if not self.changed:
+                return
+
+            status = self.get_status()
+            self.changed = False
+
+        if self.patch(status):
+            self.reported = True
+
+    def run(self):
+        while not self.stop_event.wait(PROGRESS_REPORT_INTERVAL):
+            self.report()
+
+    def begin(self):
+        self.thread.start()
+
+    def close(self, success):
+        self.stop_event.set()
+        if self.thread.is_alive():
+            self.thread.join()
+
+        if not success:
+            # Keep the failed state around for debugging, the next init run overwrites it.
+            self.report()
+        elif self.reported:
+            # Remove the progress, the pod status has the outcome of the operations.
+            self.patch(None)
+
+
 def longest_match(matches):
     longest = matches[0]
     for i in range(0, len(matches)):
@@ -123,6 +256,222 @@ def execute(cmd):
     logging.debug(f"Execution: {cmd} - completed")
 
 
+def execute_output(cmd):
+    completed_process = subprocess.run([cmd], shell=True, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    completed_process.check_returncode()
+    return completed_process.stdout.decode("utf-8")
+
+
+def run_dd(source, volume, reporter, pass_number=0):
+    device_path = volume.get_mount_point()
+    size = get_block_device_size(device_path)
+    # Bound the copy by the device size, as the sources are endless.
+    cmd = ["dd", f"if={source}", f"of={device_path}", "bs=1M", f"count={size}", "iflag=count_bytes",
+           "status=progress"]
+
+    logging.debug(f"Execution: {cmd}")
+    process = subprocess.Popen(cmd, stdout=subprocess.DEVNULL, stderr=subprocess.PIPE)
+
+    # dd status=progress rewrites the same line using carriage returns.
+    output = b""
+    buffer = b""
+    copied = 0
+    while True:
+        chunk = process.stderr.read1(4096)
+        if not chunk:
+            break
+
+        output += chunk
+        buffer += chunk
+        *lines, buffer = re.split(rb"[\r\n]", buffer)
+        for line in lines:
+            match = re.match(rb"^(\d+) bytes", line)
+            if match:
+                copied = int(match.group(1))
+                reporter.update(volume.volume_name, pass_number * size + min(copied, size))
+
+    process.wait()
+    msg = output.decode("utf-8", errors="replace")
+
+    # dd may still fail with "No space left on device" at the end of the block device, which is a success.
+    if process.returncode != 0 and not ("No space left on device" in msg and copied >= size):
+        logging.debug(f"Execution: {cmd} failed - error: {msg}")
+        raise subprocess.CalledProcessError(process.returncode, cmd, stderr=msg)
+
+    logging.debug(f"Execution: {cmd} - completed")
+
+
+def get_block_device_size(device_path):
+    with open(device_path, mode="rb") as f:
+        return f.seek(0, os.SEEK_END)
+
+
+def sample_block_offsets(device_path):
+    blocks = get_block_device_size(device_path) // VERIFY_BLOCK_SIZE
+    if blocks == 0:
+        return []
+
+    # Always sample the first and last block, these hold headers and are most likely to have data.
+    offsets = {0, (blocks - 1) * VERIFY_BLOCK_SIZE}
+    while len(offsets) < min(VERIFY_SAMPLE_BLOCKS, blocks):
+        offsets.add(int.from_bytes(os.urandom(8), "little") % blocks * VERIFY_BLOCK_SIZE)
+
+    return sorted(offsets)
+
+
+def read_blocks(device_path, offsets):
+    blocks = {}
+    with open(device_path, mode="rb") as f:
+        for offset in offsets:
+            f.seek(offset)
+            blocks[offset] = f.read(VERIFY_BLOCK_SIZE)
+
+    return blocks
+
+
+def check_nvme_single_namespace(device_path):
+    # Sanitize and crypto erase act on the whole controller, or on all its namespaces,
+    # so these would also erase the other namespaces, possibly used by other volumes.
+    ns_list = json.loads(execute_output("nvme list-ns {device} -o json".format(device=quote(device_path))))
+    count = len(ns_list.get("nsid_list", []))
+    if count != 1:
+        raise ValueError(f"device: {device_path} - NVMe controller has {count} namespaces, "
+                         "sanitize and crypto erase are allowed only with a single namespace")
+
+
+def nvme_sanitize(device_path):
+    check_nvme_single_namespace(device_path)
+    id_ctrl = json.loads(execute_output("nvme id-ctrl {device} -o json".format(device=quote(device_path))))
+    sanicap = int(id_ctrl.get("sanicap", 0))
+
+    # Sanitize actions: 2 - block erase, 3 - overwrite, 4 - crypto erase.
+    if sanicap & 0x2:
+        sanact = 2
+    elif sanicap & 0x4:
+        sanact = 3
+    elif sanicap & 0x1:
+        sanact = 4
+    else:
+        raise ValueError(f"device: {device_path} - Does not support NVMe sanitize")
+
+    execute("nvme sanitize {device} --sanact={sanact}".format(device=quote(device_path), sanact=sanact))
+
+    while True:
+        sanitize_log = json.loads(execute_output("nvme sanitize-log {device} -o json".format(
+            device=quote(device_path))))
+        # Log is keyed by the controller name in newer nvme-cli versions.
+        if "sstat" not in sanitize_log and len(sanitize_log) == 1:
+            sanitize_log = next(iter(sanitize_log.values()))
+
+        # Sanitize status: 1 - completed, 2 - in progress, 3 - failed, 4 - completed with no-deallocate.
+        status = int(sanitize_log.get("sstat", 0)) & 0x7
+        if status in (1, 4):
+            return
+        elif status == 2:
+            logging.debug(f"device: {device_path} - NVMe sanitize in progress")
+            time.sleep(NVME_SANITIZE_POLL_INTERVAL)
+        else:
+            raise OSError(f"device: {device_path} - NVMe sanitize failed with status {status}")
+
+
+def nvme_crypto_erase(device_path):
+    check_nvme_single_namespace(device_path)
+    id_ctrl = json.loads(execute_output("nvme id-ctrl {device} -o json".format(device=quote(device_path))))
+
+    if not int(id_ctrl.get("fna", 0)) & 0x4:
+        raise ValueError(f"device: {device_path} - Does not support NVMe crypto erase")
+
+    execute("nvme format {device} --ses=2 --force".format(device=quote(device_path)))
+
+
+def init_block_volume(volume, reporter):
+    device_path = volume.get_mount_point()
+    method = volume.effective_init_method
+
+    reporter.start(volume.volume_name, "init", method, get_block_device_size(device_path))
+
+    try:
+        if method == "dd":
+            run_dd("/dev/zero", volume, reporter)
+        elif method == "blkdiscard":
+            execute("blkdiscard {volume_path}".format(volume_path=quote(device_path)))
+        else:
+            logging.error(f"{volume} - Has invalid effective method")
+            raise ValueError(f"{volume} - Has invalid effective method")
+    except Exception as e:
+        reporter.finish(volume.volume_name, "Failed", str(e))
+        raise
+
+    reporter.finish(volume.volume_name, "Completed")
+
+
+def wipe_block_volume(volume, reporter):
+    device_path = volume.get_mount_point()
+    method = volume.effective_wipe_method
+    before = {}
+
+    if volume.effective_wipe_verification:
+        before = read_blocks(device_path, sample_block_offsets(device_path))
+
+    size = get_block_device_size(device_path)
+    overwrite_sources = ["/dev/urandom", "/dev/urandom", "/dev/zero"]
+    reporter.start(volume.volume_name, "wipe", method,
+                   size * len(overwrite_sources) if method == "multiPassOverwrite" else size)
+
+    try:
+        if method == "dd":
+            run_dd("/dev/zero", volume, reporter)
+        elif method == "blkdiscard":
+            execute("blkdiscard {volume_path}".format(volume_path=quote(device_path)))
+        elif method == "multiPassOverwrite":
+            for pass_number, source in enumerate(overwrite_sources):
+                run_dd(source, volume, reporter, pass_number=pass_number)
+        elif method == "nvmeSanitize":
+            nvme_sanitize(device_path)
+        elif method == "cryptoErase":
+            nvme_crypto_erase(device_path)
+        else:
+            logging.error(f"{volume} - Has invalid effective method")
+            raise ValueError(f"{volume} - Has invalid effective method")
+    except Exception as e:
+        reporter.finish(volume.volume_name, "Failed", str(e))
+        raise
+
+    result = {
+        "method": method,
+        "verification": "Skipped",
+        "message": "",
+    }
+
+    if volume.effective_wipe_verification:
+        after = read_blocks(device_path, before.keys())
+
+        if method in ("dd", "multiPassOverwrite"):
+            # Methods ending with a zero pass must leave every sampled block zeroed.
+            failed = [offset for offset, block in after.items() if block.count(0) != len(block)]
+            check = "not zeroed"
+        else:
+            # Discard, sanitize and crypto erase may return zeroes, ones or random data,
+            # so only check that sampled blocks with data do not hold it anymore.
+            failed = [offset for offset, block in after.items()
+                      if block == before[offset] and block.count(0) != len(block)]
+            check = "unchanged"
+
+        if failed:
+            result["verification"] = "Failed"
+            result["message"] = f"{len(failed)}/{len(after)} sampled blocks {check}, first at offset {failed[0]}"
+            logging.error(f"{volume} - Wipe verification failed: {result['message']}")
+        else:
+            result["verification"] = "Passed"
+            result["message"] = f"{len(after)} sampled blocks verified"
+
+    result["time"] = datetime.now(timezone.utc).strftime("%Y-%m-%dT%H:%M:%SZ")
+    logging.info(f"{volume} - Wiped, verification: {result['verification']}")
+    reporter.finish(volume.volume_name, "Completed", result["message"])
+
+    return result
+
+
 def strtobool(param):
     if len(param) == 0:
         return False
@@ -186,6 +535,11 @@ def get_endpoints(address_type):
         return []
 
 
+def get_ips(env_name):
+    # The IPs of all the IP families are comma separated.
+    return [ip for ip in os.environ.get(env_name, default="").split(",") if ip]
+
+
 def get_node_metadata():
     pod_port = os.environ["POD_PORT"]
     service_port = os.environ["MAPPED_PORT"]
@@ -198,6 +552,9 @@ def get_node_metadata():
         "podIP": os.environ.get("PODIP", default=""),
         "hostInternalIP": os.environ.get("INTERNALIP", default=""),
         "hostExternalIP": os.environ.get("EXTERNALIP", default=""),
+        "podIPs": get_ips("PODIPS"),
+        "hostInternalIPs": get_ips("INTERNALIPS"),
+        "hostExternalIPs": get_ips("EXTERNALIPS"),
         "podPort": int(pod_port),
         "servicePort": int(service_port),
         "aerospike": {
@@ -208,7 +565,7 @@ def get_node_metadata():
     }
 
 
-def update_status(pod_name, pod_image, metadata, volumes, dirty_volumes):
+def update_status(pod_name, pod_image, metadata, volumes, dirty_volumes, wipe_results):
     with open("aerospikeConfHash", mode="r") as f:
         conf_hash = f.read()
 
@@ -222,6 +579,7 @@ def update_status(pod_name, pod_image, metadata, volumes, dirty_volumes):
         "image": pod_image,
         "initializedVolumes": volumes,
         "dirtyVolumes": dirty_volumes,
+        "wipeResults": wipe_results,
         "aerospikeConfigHash": conf_hash,
         "networkPolicyHash": network_policy_hash,
         "podSpecHash": pod_spec_hash,
@@ -231,8 +589,8 @@ def update_status(pod_name, pod_image, metadata, volumes, dirty_volumes):
         metadata["aerospike"][conf_addr_name] = get_endpoints(
             address_type=pod_addr_name)
 
-    payload = [
-        {"op": "replace", "path": f"/status/pods/{pod_name}", "value": metadata}]
+    # The operator folds the pod status into status.pods.<pod-name> of the cluster object.
+    payload = {"data": {POD_STATUS_KEY: json.dumps(metadata)}}
 
     print(40 * "#" + " payload " + 40 * "#")
     pprint(payload)
@@ -276,6 +634,18 @@ def get_dirty_volumes(pod_name, config):
         return set()
 
 
+def get_wipe_results(pod_name, config):
+    try:
+        logging.debug(
+            f"pod-name: {pod_name} - Looking for wipe results in status.pod.{pod_name}.wipeResults")
+
+        return dict(config["status"]["pods"][pod_name]["wipeResults"])
+    except KeyError:
+        logging.debug(
+            f"pod-name: {pod_name} - Wipe results not found")
+        return {}
+
+
 def get_rack(pod_name, config):
     # Assuming podName format stsName-rackID-index
     rack_id = int(pod_name.split("-")[-2])
@@ -380,7 +750,7 @@ def get_namespace_volume_paths(pod_name, config):
     return devicepaths, filepaths
 
 
-def clean_dirty_volumes(pod_name, config, dirty_volumes):
+def clean_dirty_volumes(pod_name, config, dirty_volumes, wipe_results, reporter):
 
     rack = get_rack(pod_name=pod_name, config=config)
     ns_device_paths, _ = get_namespace_volume_paths(pod_name=pod_name, config=config)
@@ -407,41 +777,26 @@ def clean_dirty_volumes(pod_name, config, dirty_volumes):
                                   f"does not exists")
                     raise FileNotFoundError(f"{volume} Volume path not found")
 
-                if volume.effective_wipe_method == "dd":
-
-                    dd = 'dd if=/dev/zero of={volume_path} bs=1M 2> /tmp/init-stderr || grep -q "No space left on device" ' \
-                         '/tmp/init-stderr'.format(
-                        volume_path=quote(volume.get_mount_point()))
-                    futures[executor.submit(lambda: execute(cmd=dd))] = dd
-                    logging.info(f"{volume} - Submitted")
-
-                elif volume.effective_wipe_method == "blkdiscard":
-
-                    blkdiskard = "blkdiscard {volume_path}".format(
-                        volume_path=quote(volume.get_mount_point()))
-                    futures[executor.submit(lambda: execute(cmd=blkdiskard))] = blkdiskard
-                    logging.info(f"{volume} - Submitted")
-
-                elif volume.effective_wipe_method == "none":
+                if volume.effective_wipe_method == "none":
                     logging.info(f"{volume} - Pass through")
                 else:
-                    logging.error(f"{volume} - Has invalid effective method")
-                    raise ValueError(f"{volume} - Has invalid effective method")
+                    futures[executor.submit(wipe_block_volume, volume, reporter)] = volume
+                    logging.info(f"{volume} - Submitted")
 
                 dirty_volumes.remove(volume.volume_name)
 
         for future in concurrent.futures.as_completed(fs=futures):
-            cmd = futures[future]
+            volume = futures[future]
             try:
-                if future.done():
-                    logging.info(f"pod-name: {pod_name} Finished Successfully: {cmd}")
+                wipe_results[volume.volume_name] = future.result()
+                logging.info(f"pod-name: {pod_name} Finished Successfully: {volume}")
             except Exception as e:
-                logging.error(f"pod-name: {pod_name} Error running: {cmd} Error: {e}")
+                logging.error(f"pod-name: {pod_name} Error wiping: {volume} Error: {e}")
                 raise e
-    return dirty_volumes
+    return dirty_volumes, wipe_results
 
 
-def init_volumes(pod_name, config):
+def init_volumes(pod_name, config, reporter):
     volumes = []
 
     initialized_volumes = get_initialized_volumes(
@@ -473,19 +828,9 @@ def init_volumes(pod_name, config):
                                   f"does not exists")
                     raise FileNotFoundError(f"{volume} Volume path not found")
 
-                if volume.effective_init_method == "dd":
-
-                    dd = 'dd if=/dev/zero of={volume_path} bs=1M 2> /tmp/init-stderr || grep -q "No space left on device" ' \
-                         '/tmp/init-stderr'.format(
-                        volume_path=quote(volume.get_mount_point()))
-                    futures[executor.submit(lambda: execute(cmd=dd))] = dd
-                    logging.info(f"{volume} - Submitted")
+                if volume.effective_init_method in ("dd", "blkdiscard"):
 
-                elif volume.effective_init_method == "blkdiscard":
-
-                    blkdiskard = "blkdiscard {volume_path}".format(
-                        volume_path=quote(volume.get_mount_point()))
-                    futures[executor.submit(lambda: execute(cmd=blkdiskard))] = blkdiskard
+                    futures[executor.submit(init_block_volume, volume, reporter)] = volume
                     logging.info(f"{volume} - Submitted")
 
                 elif volume.effective_init_method == "none":
@@ -521,12 +866,12 @@ def init_volumes(pod_name, config):
             volumes.append(volume.volume_name)
 
         for future in concurrent.futures.as_completed(fs=futures):
-            cmd = futures[future]
+            volume = futures[future]
             try:
-                if future.done():
-                    logging.info(f"pod-name: {pod_name} Finished Successfully: {cmd}")
+                future.result()
+                logging.info(f"pod-name: {pod_name} Finished Successfully: {volume}")
             except Exception as e:
-                logging.error(f"pod-name: {pod_name} Error running: {cmd} Error: {e}")
+                logging.error(f"pod-name: {pod_name} Error initializing: {volume} Error: {e}")
                 raise e
 
     logging.debug(f"{volumes} - Extending initialized-volume list")
@@ -535,7 +880,7 @@ def init_volumes(pod_name, config):
     return volumes
 
 
-def wipe_volumes(pod_name, config, dirty_volumes):
+def wipe_volumes(pod_name, config, dirty_volumes, wipe_results, reporter):
     ns_device_paths, ns_file_paths = get_namespace_volume_paths(pod_name=pod_name, config=config)
 
     rack = get_rack(pod_name=pod_name, config=config)
@@ -564,23 +909,10 @@ def wipe_volumes(pod_name, config, dirty_volumes):
                                       f"- Mounting point does not exists")
                         raise FileNotFoundError(f"{volume} - Volume path not found")
 
-                    if volume.effective_wipe_method == "dd":
-                        dd = "dd if=/dev/zero of={volume_path} bs=1M".format(volume_path=quote(volume.get_mount_point()))
-                        futures[executor.submit(lambda: execute(cmd=dd))] = dd
-                        logging.info(f"Submitted - {volume}")
-                        if volume.volume_name in dirty_volumes:
-                            dirty_volumes.remove(volume.volume_name)
-
-                    elif volume.effective_wipe_method == "blkdiscard":
-
-                        blkdiskard = "blkdiscard {volume_path}".format(volume_path=quote(volume.get_mount_point()))
-                        futures[executor.submit(lambda: execute(cmd=blkdiskard))] = blkdiskard
-                        logging.info(f"Submitted - {volume}")
-                        if volume.volume_name in dirty_volumes:
-                           dirty_volumes.remove(volume.volume_name)
-
-                    else:
-                        raise ValueError(f"{volume} - Has invalid effective method")
+                    futures[executor.submit(wipe_block_volume, volume, reporter)] = volume
+                    logging.info(f"Submitted - {volume}")
+                    if volume.volume_name in dirty_volumes:
+                        dirty_volumes.remove(volume.volume_name)
             elif volume.volume_mode == "Filesystem":
                 if volume.effective_wipe_method == "deleteFiles":
 
@@ -589,16 +921,32 @@ def wipe_volumes(pod_name, config, dirty_volumes):
                                       f"- Mounting point does not exists")
                         raise FileNotFoundError(f"{volume} Volume path not found")
 
+                    deleted_files = []
                     for ns_file_path in filter(lambda x: x.startswith(volume.get_attachment_path()), ns_file_paths):
                         _, filename = os.path.split(ns_file_path)
                         file_path = os.path.join(volume.get_mount_point(), filename)
                         if os.path.exists(file_path):
                             logging.info(f"Deleting file - {file_path}")
                             os.remove(file_path)
+                            deleted_files.append(file_path)
                             logging.info(f"Deleted file - {file_path}")
                         else:
                             logging.warning(f"{volume} namespace-file-path: {file_path} - Does not exists")
 
+                    result = {"method": volume.effective_wipe_method, "verification": "Skipped", "message": ""}
+                    if volume.effective_wipe_verification:
+                        remaining_files = [f for f in deleted_files if os.path.exists(f)]
+                        if remaining_files:
+                            result["verification"] = "Failed"
+                            result["message"] = f"files not deleted: {', '.join(remaining_files)}"
+                            logging.error(f"{volume} - Wipe verification failed: {result['message']}")
+                        else:
+                            result["verification"] = "Passed"
+                            result["message"] = f"{len(deleted_files)} deleted files verified"
+
+                    result["time"] = datetime.now(timezone.utc).strftime("%Y-%m-%dT%H:%M:%SZ")
+                    wipe_results[volume.volume_name] = result
+
                 else:
                     logging.error(f"{volume} - Has invalid effective method")
                     raise ValueError(f"{volume} - Has invalid effective method")
@@ -607,14 +955,43 @@ def wipe_volumes(pod_name, config, dirty_volumes):
                 raise ValueError(f"pod-name: {pod_name} Invalid volume-mode: {volume.volume_mode}")
 
         for future in concurrent.futures.as_completed(fs=futures):
-            cmd = futures[future]
+            volume = futures[future]
             try:
-                if future.done():
-                    logging.info(f"pod-name: {pod_name} Finished Successfully: {cmd}")
+                wipe_results[volume.volume_name] = future.result()
+                logging.info(f"pod-name: {pod_name} Finished Successfully: {volume}")
             except Exception as e:
-                logging.error(f"pod-name: {pod_name} Error running: {cmd} Error: {e}")
+                logging.error(f"pod-name: {pod_name} Error wiping: {volume} Error: {e}")
                 raise e
-    return dirty_volumes
+    return dirty_volumes, wipe_results
+
+
+def prepare_volumes(pod_name, config, pod_image, prev_image, dirty_volumes, wipe_results, reporter):
+    volumes = init_volumes(pod_name=pod_name, config=config, reporter=reporter)
+
+    logging.info(f"pod-name: {pod_name} - Checking if volumes should be wiped")
+
+    if prev_image:
+
+        next_major_ver = get_image_version(image=pod_image)[0]
+        prev_major_ver = get_image_version(image=prev_image)[0]
+        logging.info(
+            f"pod-name: {pod_name} - "
+            f"next-major-version: {next_major_ver} prev-major-version: {prev_major_ver}")
+
+        if (next_major_ver >= BASE_WIPE_VERSION > prev_major_ver) or \
+                (next_major_ver < BASE_WIPE_VERSION <= prev_major_ver):
+            logging.info(f"pod-name: {pod_name} - Volumes should be wiped")
+            dirty_volumes, wipe_results = wipe_volumes(pod_name=pod_name, config=config, dirty_volumes=dirty_volumes,
+                                                       wipe_results=wipe_results, reporter=reporter)
+        else:
+            logging.info(f"pod-name: {pod_name} - Volumes should not be wiped")
+    else:
+        logging.info(f"pod-name: {pod_name} - Volumes should not be wiped")
+
+    dirty_volumes, wipe_results = clean_dirty_volumes(pod_name=pod_name, config=config, dirty_volumes=dirty_volumes,
+                                                      wipe_results=wipe_results, reporter=reporter)
+
+    return volumes, dirty_volumes, wipe_results
 
 
 def main():
@@ -655,37 +1032,34 @@ def main():
             prev_image = ""
 
         metadata = get_node_metadata()
-        next_major_ver = get_image_version(image=pod_image)[0]
         volumes = list(get_initialized_volumes(pod_name=args.pod_name, config=config))
         dirty_volumes = list(get_dirty_volumes(pod_name=args.pod_name, config=config))
+        wipe_results = get_wipe_results(pod_name=args.pod_name, config=config)
+
+        reporter = ProgressReporter(
+            pod_name=args.pod_name,
+            namespace=args.namespace,
+            api_server=args.api_server,
+            token=args.token,
+            ca_cert=args.ca_cert)
 
         logging.info(f"pod-name: {args.pod_name} {args.restart_type}- Checking if volume initialization needed")
         if args.restart_type == "podRestart":
-            volumes = init_volumes(pod_name=args.pod_name, config=config)
-
-            logging.info(f"pod-name: {args.pod_name} - Checking if volumes should be wiped")
-
-            if prev_image:
-
-                prev_major_ver = get_image_version(image=prev_image)[0]
-                logging.info(
-                    f"pod-name: {args.pod_name} - "
-                    f"next-major-version: {next_major_ver} prev-major-version: {prev_major_ver}")
-
-                if (next_major_ver >= BASE_WIPE_VERSION > prev_major_ver) or \
-                        (next_major_ver < BASE_WIPE_VERSION <= prev_major_ver):
-                    logging.info(f"pod-name: {args.pod_name} - Volumes should be wiped")
-                    dirty_volumes = wipe_volumes(pod_name=args.pod_name, config=config, dirty_volumes=dirty_volumes)
-                else:
-                    logging.info(f"pod-name: {args.pod_name} - Volumes should not be wiped")
-            else:
-                logging.info(f"pod-name: {args.pod_name} - Volumes should not be wiped")
+            reporter.begin()
+            try:
+                volumes, dirty_volumes, wipe_results = prepare_volumes(
+                    pod_name=args.pod_name, config=config, pod_image=pod_image, prev_image=prev_image,
+                    dirty_volumes=dirty_volumes, wipe_results=wipe_results, reporter=reporter)
+            except Exception:
+                reporter.close(success=False)
+                raise
 
-            dirty_volumes = clean_dirty_volumes(pod_name=args.pod_name, config=config, dirty_volumes=dirty_volumes)
+            reporter.close(success=True)
 
         logging.info(f"pod-name: {args.pod_name} - Updating pod status")
+
         update_status(pod_name=args.pod_name, pod_image=pod_image, metadata=metadata, volumes=volumes,
-                      dirty_volumes=dirty_volumes)
+                      dirty_volumes=dirty_volumes, wipe_results=wipe_results)
 
     except Exception as e:
         print(e)
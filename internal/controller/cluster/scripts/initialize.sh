@@ -37,6 +37,12 @@ do
 	esac
 done
 
+# Use the init binary if shipped in the init image, the scripts are kept as a
+# fallback for the older init images.
+if [ -x /workdir/bin/aerospike-pod-init ]; then
+    exec /workdir/bin/aerospike-pod-init init --config-dir "${CONFIG_VOLUME}" --configmap-dir /configs
+fi
+
 {{- if .WorkDir }}
 # Create required directories.
 DEFAULT_WORK_DIR="/workdir/filesystem-volumes{{.WorkDir}}"
@@ -25,7 +25,8 @@ cd $script_dir
 source ./common-env.sh
 
 # ------------------------------------------------------------------------------
-# Update pod status in the k8s aerospike cluster object
+# Publish pod status in the pod status ConfigMap, the operator folds it into
+# the k8s aerospike cluster object
 # ------------------------------------------------------------------------------
 
 # Parse out cluster name, formatted as: stsname-rackid-index
@@ -50,8 +51,8 @@ then
    exit 1
 fi
 
-# Patch the pod status.
+# Patch the pod status ConfigMap, created by the operator for each pod.
 cat /tmp/patch.json | curl -f -X PATCH -d @- --cacert $CA_CERT -H "Authorization: Bearer $TOKEN"\
      -H 'Accept: application/json' \
-     -H 'Content-Type: application/json-patch+json' \
-     "$KUBE_API_SERVER/apis/asdb.aerospike.com/v1beta1/namespaces/$NAMESPACE/aerospikeclusters/$AERO_CLUSTER_NAME/status?fieldManager=pod"
+     -H 'Content-Type: application/merge-patch+json' \
+     "$KUBE_API_SERVER/api/v1/namespaces/$NAMESPACE/configmaps/$MY_POD_NAME-status?fieldManager=pod"
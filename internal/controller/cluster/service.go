@@ -11,7 +11,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
@@ -73,6 +72,7 @@ func (r *SingleClusterReconciler) createOrUpdateSTSHeadlessSvc() error {
 		}
 
 		service.Spec.Ports = r.getServicePorts()
+		r.updateServiceIPFamily(service)
 
 		// Set AerospikeCluster instance as the owner and controller
 		err = controllerutil.SetControllerReference(
@@ -152,6 +152,8 @@ func (r *SingleClusterReconciler) reconcileSTSLoadBalancerSvc() error {
 				service.Spec.ExternalTrafficPolicy = loadBalancer.ExternalTrafficPolicy
 			}
 
+			r.updateServiceIPFamily(service)
+
 			// Set AerospikeCluster instance as the owner and controller
 			if nErr := controllerutil.SetControllerReference(
 				r.aeroCluster, service, r.Scheme,
@@ -240,6 +242,10 @@ func (r *SingleClusterReconciler) updateLBService(service *corev1.Service, servi
 		updateLBService = true
 	}
 
+	if r.updateServiceIPFamily(service) {
+		updateLBService = true
+	}
+
 	if updateLBService {
 		if err := r.Update(
 			context.TODO(), service, common.UpdateOption,
@@ -262,13 +268,20 @@ func (r *SingleClusterReconciler) updateLBService(service *corev1.Service, servi
 }
 
 func (r *SingleClusterReconciler) reconcilePodService(rackState *RackState) error {
-	// PodService is only created if MultiPodPerHost is enabled
-	if !asdbv1.GetBool(r.aeroCluster.Spec.PodSpec.MultiPodPerHost) {
-		return nil
+	// TLSRoutes are deleted before the pod services backing them
+	if err := r.reconcileTLSRoutes(rackState); err != nil {
+		return err
 	}
 
 	// Safe check to delete all dangling pod services which are no longer required
 	if !podServiceNeeded(r.aeroCluster.Spec.PodSpec.MultiPodPerHost, &r.aeroCluster.Spec.AerospikeNetworkPolicy) {
+		// PodService is only created if MultiPodPerHost is enabled or a pod network type is used
+		if !asdbv1.GetBool(r.aeroCluster.Spec.PodSpec.MultiPodPerHost) &&
+			!podServiceNeeded(r.aeroCluster.Status.PodSpec.MultiPodPerHost,
+				&r.aeroCluster.Status.AerospikeNetworkPolicy) {
+			return nil
+		}
+
 		return r.cleanupDanglingPodServices(rackState)
 	}
 
@@ -277,9 +290,18 @@ func (r *SingleClusterReconciler) reconcilePodService(rackState *RackState) erro
 
 func (r *SingleClusterReconciler) createOrUpdatePodService(pName, pNamespace string) error {
 	podService := &r.aeroCluster.Spec.PodService
+	serviceType := asdbv1.GetPodServiceType(
+		r.aeroCluster.Spec.PodSpec.MultiPodPerHost, &r.aeroCluster.Spec.AerospikeNetworkPolicy,
+	)
+
+	specMetadata, err := r.getPodServiceMetadata(pName, podService, serviceType)
+	if err != nil {
+		return err
+	}
+
 	service := &corev1.Service{}
 
-	err := r.Get(
+	err = r.Get(
 		context.TODO(), types.NamespacedName{
 			Name: pName, Namespace: pNamespace,
 		}, service,
@@ -289,25 +311,31 @@ func (r *SingleClusterReconciler) createOrUpdatePodService(pName, pNamespace str
 			return err
 		}
 
-		r.Log.Info("Creating new service for pod")
+		r.Log.Info("Creating new service for pod", "type", serviceType)
 		// NodePort will be allocated automatically
 		service = &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        pName,
 				Namespace:   pNamespace,
-				Annotations: podService.Metadata.Annotations,
-				Labels:      podService.Metadata.Labels,
+				Annotations: specMetadata.Annotations,
+				Labels:      specMetadata.Labels,
 			},
 			Spec: corev1.ServiceSpec{
-				Type: corev1.ServiceTypeNodePort,
+				Type: serviceType,
 				Selector: map[string]string{
 					"statefulset.kubernetes.io/pod-name": pName,
 				},
-				ExternalTrafficPolicy: "Local",
+				ExternalTrafficPolicy: getPodServiceExternalTrafficPolicy(podService, serviceType),
 			},
 		}
 
+		if serviceType == corev1.ServiceTypeLoadBalancer {
+			service.Spec.LoadBalancerClass = podService.LoadBalancerClass
+			service.Spec.LoadBalancerSourceRanges = podService.LoadBalancerSourceRanges
+		}
+
 		service.Spec.Ports = r.getServicePorts()
+		r.updateServiceIPFamily(service)
 
 		// Set AerospikeCluster instance as the owner and controller.
 		// It is created before Pod, so Pod cannot be the owner
@@ -335,12 +363,139 @@ func (r *SingleClusterReconciler) createOrUpdatePodService(pName, pNamespace str
 	r.Log.Info("Service already exist, checking for update",
 		"name", utils.NamespacedName(service.Namespace, service.Name))
 
-	return r.updateService(
-		service,
-		r.aeroCluster.Status.PodService.Metadata,
-		podService.Metadata,
-		asdbv1.AerospikeObjectMeta{},
+	// Status metadata is expanded the same way, so that the annotations are compared after expansion.
+	// An invalid status template only results in the spec metadata being applied.
+	statusMetadata, _ := r.getPodServiceMetadata(
+		pName, &r.aeroCluster.Status.PodService,
+		asdbv1.GetPodServiceType(r.aeroCluster.Status.PodSpec.MultiPodPerHost,
+			&r.aeroCluster.Status.AerospikeNetworkPolicy),
 	)
+
+	return r.updatePodService(service, statusMetadata, specMetadata, serviceType)
+}
+
+// getPodServiceMetadata returns the pod service metadata with the annotations expanded for the given pod.
+// The expanded externalAddress is added as an annotation for the 'podNodePort' network type, to be read by the pod.
+func (r *SingleClusterReconciler) getPodServiceMetadata(
+	pName string, podService *asdbv1.PodServiceSpec, serviceType corev1.ServiceType,
+) (asdbv1.AerospikeObjectMeta, error) {
+	metadata := asdbv1.AerospikeObjectMeta{}
+
+	rackID, _, err := utils.GetRackIDAndRevisionFromPodName(r.aeroCluster.Name, pName)
+	if err != nil {
+		return metadata, err
+	}
+
+	vars := &utils.PodTemplateVars{
+		PodName:     pName,
+		ClusterName: r.aeroCluster.Name,
+		Namespace:   r.aeroCluster.Namespace,
+		RackID:      rackID,
+	}
+
+	annotations, err := utils.ExpandPodTemplateMap(podService.Metadata.Annotations, vars)
+	if err != nil {
+		return metadata, fmt.Errorf("failed to expand pod service annotations for pod %s: %v", pName, err)
+	}
+
+	if podService.ExternalAddress != "" && serviceType == corev1.ServiceTypeNodePort {
+		externalAddress, err := utils.ExpandPodTemplate(podService.ExternalAddress, vars)
+		if err != nil {
+			return metadata, fmt.Errorf("failed to expand pod service externalAddress for pod %s: %v", pName, err)
+		}
+
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+
+		annotations[asdbv1.PodServiceExternalAddressAnnotation] = externalAddress
+	}
+
+	metadata.Annotations = annotations
+	metadata.Labels = podService.Metadata.Labels
+
+	return metadata, nil
+}
+
+func getPodServiceExternalTrafficPolicy(
+	podService *asdbv1.PodServiceSpec, serviceType corev1.ServiceType,
+) corev1.ServiceExternalTrafficPolicyType {
+	// ExternalTrafficPolicy is only applicable for the externally exposed services
+	if serviceType == corev1.ServiceTypeClusterIP {
+		return ""
+	}
+
+	if podService.ExternalTrafficPolicy != "" {
+		return podService.ExternalTrafficPolicy
+	}
+
+	return corev1.ServiceExternalTrafficPolicyLocal
+}
+
+func (r *SingleClusterReconciler) updatePodService(
+	service *corev1.Service,
+	statusMetadata,
+	specMetadata asdbv1.AerospikeObjectMeta,
+	serviceType corev1.ServiceType,
+) error {
+	podService := &r.aeroCluster.Spec.PodService
+	needsUpdate := r.isServiceMetadataUpdated(service, statusMetadata, specMetadata, asdbv1.AerospikeObjectMeta{})
+
+	if service.Spec.Type != serviceType {
+		service.Spec.Type = serviceType
+		// LoadBalancerClass can only be set while changing the type to LoadBalancer, and must be unset otherwise.
+		service.Spec.LoadBalancerClass = nil
+
+		if serviceType == corev1.ServiceTypeLoadBalancer {
+			service.Spec.LoadBalancerClass = podService.LoadBalancerClass
+		}
+
+		needsUpdate = true
+	}
+
+	trafficPolicy := getPodServiceExternalTrafficPolicy(podService, serviceType)
+	if service.Spec.ExternalTrafficPolicy != trafficPolicy {
+		service.Spec.ExternalTrafficPolicy = trafficPolicy
+		needsUpdate = true
+	}
+
+	var sourceRanges []string
+	if serviceType == corev1.ServiceTypeLoadBalancer {
+		sourceRanges = podService.LoadBalancerSourceRanges
+	}
+
+	if !reflect.DeepEqual(service.Spec.LoadBalancerSourceRanges, sourceRanges) {
+		service.Spec.LoadBalancerSourceRanges = sourceRanges
+		needsUpdate = true
+	}
+
+	if r.areServicePortsUpdated(service) {
+		needsUpdate = true
+	}
+
+	if r.updateServiceIPFamily(service) {
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		r.Log.Info("Service update not required, skipping",
+			"name", utils.NamespacedName(service.Namespace, service.Name))
+
+		return nil
+	}
+
+	if err := r.Update(
+		context.TODO(), service, common.UpdateOption,
+	); err != nil {
+		return fmt.Errorf(
+			"failed to update service %s: %v", service.Name, err,
+		)
+	}
+
+	r.Log.Info("Service updated",
+		"name", utils.NamespacedName(service.Namespace, service.Name))
+
+	return nil
 }
 
 func (r *SingleClusterReconciler) deletePodService(pName, pNamespace string) error {
@@ -483,22 +638,7 @@ func (r *SingleClusterReconciler) cleanupDanglingPodServices(rackState *RackStat
 }
 
 func podServiceNeeded(multiPodPerHost *bool, networkPolicy *asdbv1.AerospikeNetworkPolicy) bool {
-	if !asdbv1.GetBool(multiPodPerHost) || networkPolicy == nil {
-		return false
-	}
-
-	networkSet := sets.NewString(
-		string(asdbv1.AerospikeNetworkTypePod),
-		string(asdbv1.AerospikeNetworkTypeCustomInterface),
-		string(networkPolicy.AccessType),
-		string(networkPolicy.TLSAccessType),
-		string(networkPolicy.AlternateAccessType),
-		string(networkPolicy.TLSAlternateAccessType),
-	)
-
-	// If len of set is more than 2, it means the network type different from "pod" and "customInterface" are present.
-	// In that case, pod service is required
-	return networkSet.Len() > 2
+	return asdbv1.GetPodServiceType(multiPodPerHost, networkPolicy) != ""
 }
 
 func (r *SingleClusterReconciler) createOrUpdatePodServiceIfNeeded(pods []string) error {
@@ -512,16 +652,146 @@ func (r *SingleClusterReconciler) createOrUpdatePodServiceIfNeeded(pods []string
 		); err != nil {
 			return err
 		}
+
+		if isGatewayTLSRouteNeeded(&r.aeroCluster.Spec.AerospikeNetworkPolicy) {
+			if err := r.createOrUpdateTLSRoute(pods[idx], r.aeroCluster.Namespace); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.publishPodServiceAddresses(pods)
+}
+
+// publishPodServiceAddresses publishes the addresses of the given pod services in their rack ConfigMaps, read by
+// the pods as their access address. The address of a LoadBalancer service is published once it is assigned,
+// the pods wait for it.
+func (r *SingleClusterReconciler) publishPodServiceAddresses(pods []string) error {
+	serviceType := asdbv1.GetPodServiceType(
+		r.aeroCluster.Spec.PodSpec.MultiPodPerHost, &r.aeroCluster.Spec.AerospikeNetworkPolicy,
+	)
+	if serviceType != corev1.ServiceTypeLoadBalancer && serviceType != corev1.ServiceTypeNodePort {
+		return nil
+	}
+
+	podAddresses := make(map[types.NamespacedName]map[string]string)
+
+	for _, pName := range pods {
+		rackID, rackRevision, err := utils.GetRackIDAndRevisionFromPodName(r.aeroCluster.Name, pName)
+		if err != nil {
+			return err
+		}
+
+		configMapName := utils.GetNamespacedNameForSTSOrConfigMap(
+			r.aeroCluster, utils.GetRackIdentifier(rackID, rackRevision),
+		)
+
+		address, err := r.getPodServiceAddress(pName, serviceType)
+		if err != nil {
+			return err
+		}
+
+		if podAddresses[configMapName] == nil {
+			podAddresses[configMapName] = make(map[string]string)
+		}
+
+		podAddresses[configMapName][pName] = address
+	}
+
+	for configMapName, addresses := range podAddresses {
+		if err := r.updatePodServiceAddresses(configMapName, addresses); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (r *SingleClusterReconciler) getServiceTLSNameAndPortIfConfigured() (tlsName string, port *int32) {
-	tlsName, port = asdbv1.GetServiceTLSNameAndPort(r.aeroCluster.Spec.AerospikeConfig)
-	if tlsName != "" && r.aeroCluster.Status.AerospikeConfig != nil {
-		statusTLSName, _ := asdbv1.GetServiceTLSNameAndPort(r.aeroCluster.Status.AerospikeConfig)
-		statusPort := asdbv1.GetServicePort(r.aeroCluster.Status.AerospikeConfig)
+// getPodServiceAddress returns the load balancer ingress address of a LoadBalancer pod service, or the expanded
+// externalAddress of a NodePort pod service. It is empty if not assigned or configured.
+func (r *SingleClusterReconciler) getPodServiceAddress(pName string, serviceType corev1.ServiceType) (string, error) {
+	service := &corev1.Service{}
+	if err := r.Get(
+		context.TODO(), types.NamespacedName{Name: pName, Namespace: r.aeroCluster.Namespace}, service,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	if serviceType == corev1.ServiceTypeNodePort {
+		return service.Annotations[asdbv1.PodServiceExternalAddressAnnotation], nil
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP, nil
+		}
+
+		if ingress.Hostname != "" {
+			return ingress.Hostname, nil
+		}
+	}
+
+	r.Log.Info("Load balancer ingress address not assigned yet", "service", pName)
+
+	return "", nil
+}
+
+// updatePodServiceAddresses sets the given pod service addresses in the rack ConfigMap. An empty address removes
+// the pod entry.
+func (r *SingleClusterReconciler) updatePodServiceAddresses(
+	configMapName types.NamespacedName, addresses map[string]string,
+) error {
+	confMap := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), configMapName, confMap); err != nil {
+		if errors.IsNotFound(err) {
+			// Published once the rack ConfigMap is created.
+			return nil
+		}
+
+		return err
+	}
+
+	published := utils.ParsePodServiceAddresses(confMap.Data[asdbv1.PodServiceAddressesFileName])
+
+	for pName, address := range addresses {
+		if address == "" {
+			delete(published, pName)
+		} else {
+			published[pName] = address
+		}
+	}
+
+	data := utils.FormatPodServiceAddresses(published)
+	if confMap.Data[asdbv1.PodServiceAddressesFileName] == data {
+		return nil
+	}
+
+	if confMap.Data == nil {
+		confMap.Data = make(map[string]string)
+	}
+
+	confMap.Data[asdbv1.PodServiceAddressesFileName] = data
+
+	if err := r.Update(context.TODO(), confMap, common.UpdateOption); err != nil {
+		return fmt.Errorf("failed to publish pod service addresses in ConfigMap %s: %v", configMapName.Name, err)
+	}
+
+	r.Log.Info("Published pod service addresses", "ConfigMap", configMapName)
+
+	return nil
+}
+
+// getServiceTLSNameAndPortIfConfigured returns the service TLS name and port of the aeroCluster. The TLS name is
+// empty if TLS is being enabled and the clear port is still open on the pods.
+func getServiceTLSNameAndPortIfConfigured(aeroCluster *asdbv1.AerospikeCluster) (tlsName string, port *int32) {
+	tlsName, port = asdbv1.GetServiceTLSNameAndPort(aeroCluster.Spec.AerospikeConfig)
+	if tlsName != "" && aeroCluster.Status.AerospikeConfig != nil {
+		statusTLSName, _ := asdbv1.GetServiceTLSNameAndPort(aeroCluster.Status.AerospikeConfig)
+		statusPort := asdbv1.GetServicePort(aeroCluster.Status.AerospikeConfig)
 
 		if statusTLSName == "" && statusPort != nil {
 			tlsName = ""
@@ -577,6 +847,10 @@ func (r *SingleClusterReconciler) updateService(
 		needsUpdate = true
 	}
 
+	if r.updateServiceIPFamily(service) {
+		needsUpdate = true
+	}
+
 	if needsUpdate {
 		if err := r.Update(
 			context.TODO(), service, common.UpdateOption,
@@ -597,3 +871,27 @@ func (r *SingleClusterReconciler) updateService(
 
 	return nil
 }
+
+// updateServiceIPFamily sets the IP family policy and IP families given in the spec on the service.
+// The cluster defaults are kept if not given. Returns true if the service is changed.
+func (r *SingleClusterReconciler) updateServiceIPFamily(service *corev1.Service) bool {
+	serviceIPFamily := r.aeroCluster.Spec.ServiceIPFamily
+	if serviceIPFamily == nil {
+		return false
+	}
+
+	var updated bool
+
+	if serviceIPFamily.IPFamilyPolicy != nil &&
+		!reflect.DeepEqual(service.Spec.IPFamilyPolicy, serviceIPFamily.IPFamilyPolicy) {
+		service.Spec.IPFamilyPolicy = serviceIPFamily.IPFamilyPolicy
+		updated = true
+	}
+
+	if len(serviceIPFamily.IPFamilies) > 0 && !reflect.DeepEqual(service.Spec.IPFamilies, serviceIPFamily.IPFamilies) {
+		service.Spec.IPFamilies = serviceIPFamily.IPFamilies
+		updated = true
+	}
+
+	return updated
+}
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+const (
+	// kubeAPIServerPort is the port of the kubernetes.default service, called by the init container
+	// before the sidecar proxy is started.
+	kubeAPIServerPort int32 = 443
+
+	istioExcludeInboundPortsAnnotation  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	istioExcludeOutboundPortsAnnotation = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	istioProxyConfigAnnotation          = "proxy.istio.io/config"
+	linkerdSkipInboundPortsAnnotation   = "config.linkerd.io/skip-inbound-ports"
+	linkerdSkipOutboundPortsAnnotation  = "config.linkerd.io/skip-outbound-ports"
+)
+
+// getPodAnnotations returns the pod annotations given by the user, merged with the service mesh annotations.
+// The annotations given by the user take precedence.
+func (r *SingleClusterReconciler) getPodAnnotations(rackState *RackState) map[string]string {
+	userAnnotations := r.aeroCluster.Spec.PodSpec.AerospikeObjectMeta.Annotations
+	serviceMesh := r.aeroCluster.Spec.PodSpec.ServiceMesh
+
+	if serviceMesh == nil {
+		return userAnnotations
+	}
+
+	annotations := getServiceMeshAnnotations(serviceMesh, rackState.Rack.AerospikeConfig)
+	maps.Copy(annotations, userAnnotations)
+
+	return annotations
+}
+
+// getServiceMeshAnnotations returns the annotations excluding the heartbeat, fabric and admin traffic from the
+// sidecar proxy, as it is either already secured by Aerospike TLS or needed before the proxy is ready.
+// The outbound traffic to the Kubernetes API server is excluded too, so that the init container,
+// started before the proxy, can read the node and service details.
+func getServiceMeshAnnotations(
+	serviceMesh *asdbv1.ServiceMeshSpec, asConfig asdbv1.AerospikeConfigSpec,
+) map[string]string {
+	_, heartbeatTLSPort := asdbv1.GetHeartbeatTLSNameAndPort(&asConfig)
+	_, fabricTLSPort := asdbv1.GetFabricTLSNameAndPort(&asConfig)
+	_, adminTLSPort := asdbv1.GetAdminTLSNameAndPort(&asConfig)
+
+	clusterPorts := []*int32{
+		asdbv1.GetHeartbeatPort(&asConfig), heartbeatTLSPort, asdbv1.GetFabricPort(&asConfig), fabricTLSPort,
+	}
+
+	inboundPorts := joinPorts(append(clusterPorts, asdbv1.GetAdminPort(&asConfig), adminTLSPort)...)
+
+	apiServerPort := kubeAPIServerPort
+	outboundPorts := joinPorts(append(clusterPorts, &apiServerPort)...)
+
+	switch serviceMesh.Provider {
+	case asdbv1.ServiceMeshIstio:
+		return map[string]string{
+			istioExcludeInboundPortsAnnotation:  inboundPorts,
+			istioExcludeOutboundPortsAnnotation: outboundPorts,
+			// Start Aerospike only once the proxy is ready to serve the client traffic.
+			istioProxyConfigAnnotation: `{"holdApplicationUntilProxyStarts": true}`,
+		}
+
+	case asdbv1.ServiceMeshLinkerd:
+		return map[string]string{
+			linkerdSkipInboundPortsAnnotation:  inboundPorts,
+			linkerdSkipOutboundPortsAnnotation: outboundPorts,
+		}
+	}
+
+	return map[string]string{}
+}
+
+// joinPorts returns the sorted unique ports as a comma separated list.
+func joinPorts(ports ...*int32) string {
+	portSet := sets.New[int32]()
+
+	for _, port := range ports {
+		if port != nil {
+			portSet.Insert(*port)
+		}
+	}
+
+	sortedPorts := sets.List(portSet)
+	slices.Sort(sortedPorts)
+
+	portStrs := make([]string, 0, len(sortedPorts))
+	for _, port := range sortedPorts {
+		portStrs = append(portStrs, strconv.Itoa(int(port)))
+	}
+
+	return strings.Join(portStrs, ",")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,11 +35,6 @@ const (
 	// Need to modify this name if prefix is changed in yaml file
 	aeroClusterServiceAccountName string = "aerospike-operator-controller-manager"
 
-	// This storage path annotation is added in pvc to make reverse association with storage.volume.path
-	// while deleting pvc
-	storageVolumeAnnotationKey       = "storage-volume"
-	storageVolumeLegacyAnnotationKey = "storage-path"
-
 	confDirName                = "confdir"
 	initConfDirName            = "initconfigs"
 	podServiceAccountMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
@@ -115,6 +112,7 @@ func (r *SingleClusterReconciler) createSTS(
 		newSTSEnvVar("MY_POD_NAME", "metadata.name"),
 		newSTSEnvVar("MY_POD_NAMESPACE", "metadata.namespace"),
 		newSTSEnvVar("MY_POD_IP", "status.podIP"),
+		newPodIPsEnvVar(),
 		newSTSEnvVar("MY_HOST_IP", "status.hostIP"),
 		newSTSEnvVarStatic("MY_POD_TLS_NAME", tlsName),
 		newSTSEnvVarStatic("MY_POD_CLUSTER_NAME", r.aeroCluster.Name),
@@ -150,7 +148,7 @@ func (r *SingleClusterReconciler) createSTS(
 							Image:           asdbv1.GetAerospikeInitContainerImage(r.aeroCluster),
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							VolumeMounts:    getDefaultAerospikeInitContainerVolumeMounts(),
-							Env: append(
+							Env: append(append(
 								envVarList, []corev1.EnvVar{
 									{
 										// Headless service has the same name as AerospikeCluster
@@ -164,7 +162,7 @@ func (r *SingleClusterReconciler) createSTS(
 											r.aeroCluster, utils.GetRackIdentifier(rackState.Rack.ID, rackState.Rack.Revision),
 										).Name,
 									},
-								}...,
+								}...), getConfigVariableEnvVars(r.aeroCluster.Spec.AerospikeConfigVariables)...,
 							),
 						},
 					},
@@ -309,9 +307,19 @@ func (r *SingleClusterReconciler) waitForSTSToBeReady(
 
 				r.Log.Info("Pod is running and ready", "pod", podName)
 
+				// Fold the status published by the pod, the next steps of the reconcile depend on it.
+				if _, err := r.foldPodStatus(); err != nil {
+					r.Log.Error(err, "Failed to fold published pod status", "pod", podName)
+				}
+
 				break
 			}
 
+			// The pod waits in the init container for the address of its load balancer to be published.
+			if err := r.publishPodServiceAddresses([]string{podName}); err != nil {
+				r.Log.Error(err, "Failed to publish pod service address", "pod", podName)
+			}
+
 			time.Sleep(podStatusRetryInterval)
 		}
 
@@ -453,6 +461,11 @@ func (r *SingleClusterReconciler) createSTSConfigMap(
 		return fmt.Errorf("failed to build config map data: %v", err)
 	}
 
+	// Keep the pod service addresses, they are published on the pod services reconcile.
+	if addresses, ok := confMap.Data[asdbv1.PodServiceAddressesFileName]; ok {
+		configMapData[asdbv1.PodServiceAddressesFileName] = addresses
+	}
+
 	// Replace config map data if differs since we are supposed to create a new config map.
 	if reflect.DeepEqual(confMap.Data, configMapData) {
 		return nil
@@ -491,8 +504,18 @@ func (r *SingleClusterReconciler) updateSTSConfigMap(
 	}
 
 	// Overwrite only spec based keys. Do not touch other keys like pod metadata.
+	var updated bool
+
 	for k, v := range configMapData {
-		confMap.Data[k] = v
+		if confMap.Data[k] != v {
+			confMap.Data[k] = v
+			updated = true
+		}
+	}
+
+	if !updated {
+		r.Log.Info("ConfigMap update not required, skipping", "ConfigMap", namespacedName)
+		return nil
 	}
 
 	if err := r.Update(
@@ -639,6 +662,12 @@ func (r *SingleClusterReconciler) updateSTS(
 	// Updates the readiness probe TCP Port if changed for the aerospike server container
 	r.updateReadinessProbe(statefulSet)
 
+	// Add the env vars missing in the statefulsets created by the older operator versions.
+	updateSTSEnvVars(statefulSet)
+
+	// Update the env vars of the aerospikeConfig variables in the init container.
+	r.updateSTSConfigVariableEnvVars(statefulSet)
+
 	// This should be called before updating storage
 	r.initializeSTSStorage(statefulSet, rackState)
 
@@ -651,6 +680,9 @@ func (r *SingleClusterReconciler) updateSTS(
 			return err
 		}
 
+		// The scheduling gates are only set for volume bootstraps, which may have changed them since.
+		statefulSet.Spec.Template.Spec.SchedulingGates = found.Spec.Template.Spec.SchedulingGates
+
 		// Save the updated stateful set.
 		found.Spec = statefulSet.Spec
 		return r.Update(context.TODO(), found, common.UpdateOption)
@@ -986,7 +1018,7 @@ func (r *SingleClusterReconciler) updateSTSFromPodSpec(
 
 	st.Spec.Template.Spec.HostNetwork = r.aeroCluster.Spec.PodSpec.HostNetwork
 	st.Spec.Template.Labels = mergedLabels
-	st.Spec.Template.Annotations = r.aeroCluster.Spec.PodSpec.AerospikeObjectMeta.Annotations
+	st.Spec.Template.Annotations = r.getPodAnnotations(rackState)
 
 	st.Spec.Template.Spec.DNSPolicy = r.aeroCluster.Spec.PodSpec.DNSPolicy
 	st.Spec.Template.Spec.DNSConfig = r.aeroCluster.Spec.PodSpec.DNSConfig
@@ -1166,6 +1198,46 @@ func (r *SingleClusterReconciler) updateReadinessProbe(statefulSet *appsv1.State
 	}
 }
 
+// updateSTSEnvVars adds the MY_POD_IPS env var to the init and server containers if missing, so that the pods of
+// the statefulsets created by the older operator versions get all their IPs once restarted.
+func updateSTSEnvVars(statefulSet *appsv1.StatefulSet) {
+	podSpec := &statefulSet.Spec.Template.Spec
+	podIPsEnvVar := newPodIPsEnvVar()
+
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for idx := range containers {
+			container := &containers[idx]
+			if container.Name != asdbv1.AerospikeInitContainerName &&
+				container.Name != asdbv1.AerospikeServerContainerName {
+				continue
+			}
+
+			if slices.ContainsFunc(container.Env, func(envVar corev1.EnvVar) bool {
+				return envVar.Name == podIPsEnvVar.Name
+			}) {
+				continue
+			}
+
+			// Keep it next to MY_POD_IP, as in the new statefulsets.
+			podIPIdx := slices.IndexFunc(container.Env, func(envVar corev1.EnvVar) bool {
+				return envVar.Name == "MY_POD_IP"
+			})
+			container.Env = slices.Insert(container.Env, podIPIdx+1, podIPsEnvVar)
+		}
+	}
+}
+
+// updateSTSConfigVariableEnvVars replaces the env vars of the aerospikeConfig variables in the init container.
+func (r *SingleClusterReconciler) updateSTSConfigVariableEnvVars(statefulSet *appsv1.StatefulSet) {
+	container := getContainer(statefulSet.Spec.Template.Spec.InitContainers, asdbv1.AerospikeInitContainerName)
+	if container == nil {
+		return
+	}
+
+	container.Env = slices.DeleteFunc(container.Env, isConfigVariableEnvVar)
+	container.Env = append(container.Env, getConfigVariableEnvVars(r.aeroCluster.Spec.AerospikeConfigVariables)...)
+}
+
 func (r *SingleClusterReconciler) updateAerospikeInitContainerImage(statefulSet *appsv1.StatefulSet) error {
 	for idx := range statefulSet.Spec.Template.Spec.InitContainers {
 		container := &statefulSet.Spec.Template.Spec.InitContainers[idx]
@@ -1392,7 +1464,7 @@ func createPVCForVolumeAttachment(
 	}
 
 	// Use this path annotation while matching pvc with storage volume
-	newAnnotations := map[string]string{storageVolumeAnnotationKey: volume.Name}
+	newAnnotations := map[string]string{asdbv1.StorageVolumeAnnotationKey: volume.Name}
 	for k, v := range pv.Annotations {
 		newAnnotations[k] = v
 	}
@@ -1634,6 +1706,58 @@ func newSTSEnvVar(name, fieldPath string) corev1.EnvVar {
 	}
 }
 
+// getConfigVariableEnvVars returns the init container env vars with the values of the aerospikeConfig variables set
+// by k8s: the pod fields, the container resources, the Secrets and the ConfigMaps.
+func getConfigVariableEnvVars(variables []asdbv1.AerospikeConfigVariable) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+
+	for idx := range variables {
+		if !asdbv1.IsConfigVariableFromEnv(&variables[idx]) {
+			continue
+		}
+
+		source := variables[idx].ValueFrom
+		envVarSource := &corev1.EnvVarSource{}
+
+		switch {
+		case source.FieldRef != nil:
+			envVarSource.FieldRef = &corev1.ObjectFieldSelector{
+				APIVersion: "v1",
+				FieldPath:  source.FieldRef.FieldPath,
+			}
+		case source.ResourceFieldRef != nil:
+			envVarSource.ResourceFieldRef = source.ResourceFieldRef.DeepCopy()
+			if envVarSource.ResourceFieldRef.ContainerName == "" {
+				envVarSource.ResourceFieldRef.ContainerName = asdbv1.AerospikeServerContainerName
+			}
+
+			if envVarSource.ResourceFieldRef.Divisor.IsZero() {
+				envVarSource.ResourceFieldRef.Divisor = resource.MustParse("1")
+			}
+		case source.SecretKeyRef != nil:
+			envVarSource.SecretKeyRef = source.SecretKeyRef.DeepCopy()
+		case source.ConfigMapKeyRef != nil:
+			envVarSource.ConfigMapKeyRef = source.ConfigMapKeyRef.DeepCopy()
+		}
+
+		envVars = append(envVars, corev1.EnvVar{
+			Name:      asdbv1.GetConfigVariableEnvVarName(variables[idx].Name),
+			ValueFrom: envVarSource,
+		})
+	}
+
+	return envVars
+}
+
+func isConfigVariableEnvVar(envVar corev1.EnvVar) bool {
+	return strings.HasPrefix(envVar.Name, asdbv1.ConfigVariableEnvVarPrefix)
+}
+
+// newPodIPsEnvVar returns the env var with the comma separated IPs of all the IP families of the pod.
+func newPodIPsEnvVar() corev1.EnvVar {
+	return newSTSEnvVar("MY_POD_IPS", "status.podIPs")
+}
+
 func newSTSEnvVarStatic(name, value string) corev1.EnvVar {
 	return corev1.EnvVar{
 		Name:  name,
@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"slices"
+
 	gosets "github.com/deckarep/golang-set/v2"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -13,6 +15,20 @@ func (r *SingleClusterReconciler) getAndSetRoster(
 	policy *as.ClientPolicy, rosterNodeBlockList []string,
 	ignorablePodNames sets.Set[string],
 ) error {
+	if federation := r.aeroCluster.Spec.Federation; federation != nil {
+		if !federation.RosterManager {
+			r.Log.Info("Skipping roster update, roster is set by the roster manager member of the federation")
+			return nil
+		}
+
+		federationBlockList, ok := r.getFederationRosterNodeBlockList()
+		if !ok {
+			return nil
+		}
+
+		rosterNodeBlockList = append(slices.Clone(rosterNodeBlockList), federationBlockList...)
+	}
+
 	allHostConns, err := r.newAllHostConnWithOption(ignorablePodNames)
 	if err != nil {
 		return err
@@ -0,0 +1,220 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+	lib "github.com/aerospike/aerospike-management-lib"
+)
+
+// tlsRouteGVK is the Gateway API TLSRoute kind. TLSRoutes are managed as unstructured objects,
+// so that the Gateway API CRDs are needed only if the 'gatewayTLSRoute' network type is used.
+var tlsRouteGVK = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1alpha2",
+	Kind:    "TLSRoute",
+}
+
+func isGatewayTLSRouteNeeded(networkPolicy *asdbv1.AerospikeNetworkPolicy) bool {
+	return networkPolicy.TLSAlternateAccessType == asdbv1.AerospikeNetworkTypeGatewayTLSRoute
+}
+
+// reconcileTLSRoutes deletes the TLSRoutes of the rack pods if the 'gatewayTLSRoute' network type is not used anymore.
+// The TLSRoutes are created along with the pod services.
+func (r *SingleClusterReconciler) reconcileTLSRoutes(rackState *RackState) error {
+	if isGatewayTLSRouteNeeded(&r.aeroCluster.Spec.AerospikeNetworkPolicy) ||
+		r.aeroCluster.Status.GatewayTLSRoute == nil {
+		return nil
+	}
+
+	podList, err := r.getRackPodList(rackState.Rack.ID, rackState.Rack.Revision)
+	if err != nil {
+		return err
+	}
+
+	for idx := range podList.Items {
+		if err := r.deleteTLSRoute(podList.Items[idx].Name, podList.Items[idx].Namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *SingleClusterReconciler) createOrUpdateTLSRoute(pName, pNamespace string) error {
+	routeSpec, err := r.getTLSRouteSpec(pName)
+	if err != nil {
+		return err
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(tlsRouteGVK)
+
+	if err = r.Get(context.TODO(), types.NamespacedName{Name: pName, Namespace: pNamespace}, route); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		route = &unstructured.Unstructured{Object: map[string]interface{}{"spec": routeSpec}}
+		route.SetGroupVersionKind(tlsRouteGVK)
+		route.SetName(pName)
+		route.SetNamespace(pNamespace)
+		route.SetLabels(utils.LabelsForAerospikeCluster(r.aeroCluster.Name))
+
+		// Set AerospikeCluster instance as the owner and controller.
+		// It is created before Pod, so Pod cannot be the owner
+		if err = controllerutil.SetControllerReference(r.aeroCluster, route, r.Scheme); err != nil {
+			return err
+		}
+
+		if err = r.Create(context.TODO(), route, common.CreateOption); err != nil {
+			return fmt.Errorf("failed to create TLSRoute for pod %s: %v", pName, err)
+		}
+
+		r.Log.Info("Created new TLSRoute for pod", "name", utils.NamespacedName(pNamespace, pName))
+
+		return nil
+	}
+
+	if reflect.DeepEqual(route.Object["spec"], routeSpec) {
+		return nil
+	}
+
+	route.Object["spec"] = routeSpec
+
+	if err = r.Update(context.TODO(), route, common.UpdateOption); err != nil {
+		return fmt.Errorf("failed to update TLSRoute for pod %s: %v", pName, err)
+	}
+
+	r.Log.Info("Updated TLSRoute for pod", "name", utils.NamespacedName(pNamespace, pName))
+
+	return nil
+}
+
+// getTLSRouteSpec returns the TLSRoute spec routing the SNI hostname of the pod to the TLS service port of its
+// pod service. The values use the types of the decoded JSON, so that it can be compared with the existing TLSRoute.
+func (r *SingleClusterReconciler) getTLSRouteSpec(pName string) (map[string]interface{}, error) {
+	gatewayTLSRoute := r.aeroCluster.Spec.GatewayTLSRoute
+
+	hostname, err := r.getTLSRouteHostname(pName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, tlsPort := asdbv1.GetServiceTLSNameAndPort(r.aeroCluster.Spec.AerospikeConfig)
+	if tlsPort == nil {
+		return nil, fmt.Errorf("TLS service port is not configured for TLSRoute")
+	}
+
+	parentRefs := make([]interface{}, 0, len(gatewayTLSRoute.ParentRefs))
+
+	for idx := range gatewayTLSRoute.ParentRefs {
+		parentRef := map[string]interface{}{
+			"group": "gateway.networking.k8s.io",
+			"kind":  "Gateway",
+			"name":  gatewayTLSRoute.ParentRefs[idx].Name,
+		}
+
+		if gatewayTLSRoute.ParentRefs[idx].Namespace != "" {
+			parentRef["namespace"] = gatewayTLSRoute.ParentRefs[idx].Namespace
+		}
+
+		if gatewayTLSRoute.ParentRefs[idx].SectionName != "" {
+			parentRef["sectionName"] = gatewayTLSRoute.ParentRefs[idx].SectionName
+		}
+
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	return map[string]interface{}{
+		"parentRefs": parentRefs,
+		"hostnames":  []interface{}{hostname},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"group":  "",
+						"kind":   "Service",
+						"name":   pName,
+						"port":   int64(*tlsPort),
+						"weight": int64(1),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *SingleClusterReconciler) getTLSRouteHostname(pName string) (string, error) {
+	rackID, _, err := utils.GetRackIDAndRevisionFromPodName(r.aeroCluster.Name, pName)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ExpandPodTemplate(r.aeroCluster.Spec.GatewayTLSRoute.Hostname, &utils.PodTemplateVars{
+		PodName:     pName,
+		ClusterName: r.aeroCluster.Name,
+		Namespace:   r.aeroCluster.Namespace,
+		RackID:      rackID,
+	})
+}
+
+// setGatewayTLSRouteTLSName returns a copy of the aerospikeConfig with the service tls-name set to the TLSRoute
+// hostname placeholder, replaced with the hostname of each pod by the init container. The clients send the node
+// tls-name as SNI, so each pod must have its own tls-name to be routed by the Gateway. The TLS config of the service
+// is copied for the placeholder name, so that it is still used as is by the other connections.
+func setGatewayTLSRouteTLSName(config map[string]interface{}) (map[string]interface{}, error) {
+	config = lib.DeepCopy(config).(map[string]interface{})
+
+	network, _ := config[asdbv1.ConfKeyNetwork].(map[string]interface{})
+	service, _ := network[asdbv1.ConfKeyNetworkService].(map[string]interface{})
+
+	tlsName, _ := service[asdbv1.ConfKeyTLSName].(string)
+	if tlsName == "" {
+		return nil, fmt.Errorf("TLS service is not configured for TLSRoute")
+	}
+
+	tlsList, _ := network["tls"].([]interface{})
+	for _, tlsConf := range tlsList {
+		if tlsConfMap, ok := tlsConf.(map[string]interface{}); ok && tlsConfMap["name"] == tlsName {
+			routeTLSConf := lib.DeepCopy(tlsConfMap).(map[string]interface{})
+			routeTLSConf["name"] = asdbv1.GatewayTLSRouteTLSNamePlaceholder
+
+			network["tls"] = append(tlsList, routeTLSConf)
+			service[asdbv1.ConfKeyTLSName] = asdbv1.GatewayTLSRouteTLSNamePlaceholder
+
+			return config, nil
+		}
+	}
+
+	return nil, fmt.Errorf("TLS config %s of the service not found", tlsName)
+}
+
+func (r *SingleClusterReconciler) deleteTLSRoute(pName, pNamespace string) error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(tlsRouteGVK)
+	route.SetName(pName)
+	route.SetNamespace(pNamespace)
+
+	if err := r.Delete(context.TODO(), route); err != nil {
+		// The Gateway API CRDs may be uninstalled along with the routes.
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete TLSRoute for pod %s: %v", pName, err)
+	}
+
+	return nil
+}
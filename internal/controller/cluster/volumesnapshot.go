@@ -0,0 +1,827 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/jsonpatch"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const (
+	// bootstrapSnapshotType is the snapshot type label value for snapshots taken by AKO to bootstrap volumes.
+	bootstrapSnapshotType = "bootstrap"
+
+	// volumeBootstrapsAnnotation is set on the AerospikeCluster to the pending volume bootstraps, so that these
+	// survive operator restarts.
+	volumeBootstrapsAnnotation = "aerospike.com/pending-volume-bootstraps"
+
+	// volumeBootstrapSchedulingGate holds the re-created pods until their PVCs are provisioned from the
+	// VolumeSnapshots, as the StatefulSet controller would otherwise bind them to empty volumes.
+	volumeBootstrapSchedulingGate = "aerospike.com/volume-bootstrap"
+
+	bootstrapPVCRequeueSeconds = 5
+	bootstrapPVCTimeout        = 5 * time.Minute
+)
+
+// pendingVolumeBootstrap is a deleted PVC to be re-created from a VolumeSnapshot once it is removed.
+type pendingVolumeBootstrap struct {
+	// PVC is the PVC to create, with the VolumeSnapshot as data source.
+	PVC         *corev1.PersistentVolumeClaim `json:"pvc"`
+	Deadline    metav1.Time                   `json:"deadline"`
+	OldUID      types.UID                     `json:"oldUID"`
+	StatefulSet string                        `json:"statefulSet"`
+	RackID      int                           `json:"rackID"`
+}
+
+// podName returns the name of the pod using the PVC of the volume bootstrap.
+func (b *pendingVolumeBootstrap) podName() string {
+	return strings.TrimPrefix(b.PVC.Name, getPVCStorageVolumeName(b.PVC)+"-")
+}
+
+// snapshotName returns the name of the VolumeSnapshot the PVC of the volume bootstrap is provisioned from.
+func (b *pendingVolumeBootstrap) snapshotName() string {
+	return b.PVC.Spec.DataSource.Name
+}
+
+// getSnapshotBootstrapVolumes returns the storage volumes which should be bootstrapped from VolumeSnapshots.
+func getSnapshotBootstrapVolumes(storage *asdbv1.AerospikeStorageSpec) sets.Set[string] {
+	volumes := sets.Set[string]{}
+
+	if storage.SnapshotBootstrap == nil {
+		return volumes
+	}
+
+	if len(storage.SnapshotBootstrap.Volumes) != 0 {
+		return volumes.Insert(storage.SnapshotBootstrap.Volumes...)
+	}
+
+	return volumes.Insert(utils.GetAerospikePersistentVolumeNames(storage)...)
+}
+
+// getPVCStorageVolumeName returns the storage volume name this PVC was created for.
+func getPVCStorageVolumeName(pvc *corev1.PersistentVolumeClaim) string {
+	if volName, ok := pvc.Annotations[asdbv1.StorageVolumeAnnotationKey]; ok {
+		return volName
+	}
+
+	return pvc.Annotations[asdbv1.StorageVolumeLegacyAnnotationKey]
+}
+
+// prepareVolumeBootstraps records the bootstraps of the local PVCs of the given pods, to be re-created from
+// VolumeSnapshots of a healthy replica in the rack. It has to be called before these PVCs and pods are deleted.
+// The bootstraps are recorded on the AerospikeCluster, and the re-created pods are held by a scheduling gate until
+// their PVCs are provisioned from the snapshots. It returns true if any volume is to be bootstrapped.
+func (r *SingleClusterReconciler) prepareVolumeBootstraps(rackState *RackState, pods []*corev1.Pod) (bool, error) {
+	bootstrapVolumes := getSnapshotBootstrapVolumes(&rackState.Rack.Storage)
+	if len(bootstrapVolumes) == 0 || len(pods) == 0 {
+		return false, nil
+	}
+
+	podNames := getPodNames(pods)
+
+	pvcItems, err := r.getPodsPVCList(podNames, rackState.Rack.ID, rackState.Rack.Revision)
+	if err != nil {
+		return false, fmt.Errorf("could not find pvc for pods %v: %v", podNames, err)
+	}
+
+	pending, err := r.getPendingVolumeBootstraps()
+	if err != nil {
+		return false, err
+	}
+
+	stsName := utils.GetNamespacedNameForSTSOrConfigMap(r.aeroCluster,
+		utils.GetRackIdentifier(rackState.Rack.ID, rackState.Rack.Revision)).Name
+	restartedPods := sets.New(podNames...)
+
+	// A single snapshot of each volume is used for all the pods restarted together.
+	snapshots := map[string]*unstructured.Unstructured{}
+	added := false
+
+	for idx := range pvcItems {
+		oldPVC := &pvcItems[idx]
+
+		volName := getPVCStorageVolumeName(oldPVC)
+		if !isLocalPVC(rackState, oldPVC) || !bootstrapVolumes.Has(volName) || utils.IsPVCTerminating(oldPVC) {
+			continue
+		}
+
+		snapshot, ok := snapshots[volName]
+		if !ok {
+			if snapshot, err = r.getBootstrapVolumeSnapshot(rackState, volName, restartedPods); err != nil {
+				return false, err
+			}
+
+			snapshots[volName] = snapshot
+		}
+
+		if snapshot == nil {
+			r.Log.Info(
+				"No snapshot source found for volume, it will be filled by migrations",
+				"PVC", oldPVC.Name, "volume", volName,
+			)
+
+			continue
+		}
+
+		pending = slices.DeleteFunc(pending, func(bootstrap pendingVolumeBootstrap) bool {
+			return bootstrap.PVC.Name == oldPVC.Name
+		})
+		pending = append(pending, pendingVolumeBootstrap{
+			PVC:         newBootstrapPVC(oldPVC, snapshot.GetName()),
+			Deadline:    metav1.NewTime(time.Now().Add(bootstrapPVCTimeout)),
+			OldUID:      oldPVC.UID,
+			StatefulSet: stsName,
+			RackID:      rackState.Rack.ID,
+		})
+		added = true
+	}
+
+	if !added {
+		return false, nil
+	}
+
+	if err := r.setPendingVolumeBootstraps(pending); err != nil {
+		return false, err
+	}
+
+	return true, r.setSTSVolumeBootstrapGate(stsName, true)
+}
+
+// reconcileVolumeBootstraps re-creates the PVCs of the pending volume bootstraps from their VolumeSnapshots once the
+// old PVCs are removed, and releases the scheduling gate of the pods whose PVCs are ready.
+// It returns true if any volume bootstrap is still pending.
+func (r *SingleClusterReconciler) reconcileVolumeBootstraps() (bool, error) {
+	if _, ok := r.aeroCluster.Annotations[volumeBootstrapsAnnotation]; !ok && !r.hasSnapshotBootstrap() {
+		return false, nil
+	}
+
+	pending, err := r.getPendingVolumeBootstraps()
+	if err != nil {
+		return false, err
+	}
+
+	var stillPending []pendingVolumeBootstrap
+
+	for idx := range pending {
+		bootstrap := &pending[idx]
+
+		done, err := r.reconcileVolumeBootstrap(bootstrap)
+		if err != nil {
+			return false, err
+		}
+
+		if done {
+			continue
+		}
+
+		if time.Now().After(bootstrap.Deadline.Time) {
+			r.Log.Info(
+				"PVC termination timed out, volume will be filled by migrations", "PVC", bootstrap.PVC.Name,
+			)
+
+			continue
+		}
+
+		stillPending = append(stillPending, *bootstrap)
+	}
+
+	// The gates are released before the bootstraps are removed, so that no pod is left gated on failure.
+	if err := r.releaseVolumeBootstrapGates(stillPending); err != nil {
+		return false, err
+	}
+
+	if len(stillPending) != len(pending) {
+		if err := r.setPendingVolumeBootstraps(stillPending); err != nil {
+			return false, err
+		}
+	}
+
+	return len(stillPending) != 0, nil
+}
+
+// reconcileVolumeBootstrap creates the PVC of the volume bootstrap from its VolumeSnapshot once the old PVC is
+// removed. The empty PVC created by the StatefulSet controller for the gated pod in the meantime is deleted first.
+// It returns true once the volume bootstrap is done, or if the volume is left to be filled by migrations.
+func (r *SingleClusterReconciler) reconcileVolumeBootstrap(bootstrap *pendingVolumeBootstrap) (bool, error) {
+	existingPVC := &corev1.PersistentVolumeClaim{}
+
+	err := r.Get(context.TODO(), types.NamespacedName{Name: bootstrap.PVC.Name, Namespace: bootstrap.PVC.Namespace},
+		existingPVC)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if err == nil {
+		switch {
+		case isPVCFromSnapshot(existingPVC, bootstrap.snapshotName()):
+			// Created by an earlier reconcile which failed before recording it.
+			return true, r.markVolumeInitialized(existingPVC)
+
+		case utils.IsPVCTerminating(existingPVC):
+			return false, nil
+
+		case existingPVC.UID == bootstrap.OldUID:
+			r.Log.Info("PVC was not deleted, skipping volume bootstrap", "PVC", existingPVC.Name)
+			return true, nil
+
+		case existingPVC.Spec.VolumeName != "":
+			r.Log.Info(
+				"PVC already provisioned by StatefulSet, volume will be filled by migrations", "PVC", existingPVC.Name,
+			)
+
+			return true, nil
+		}
+
+		gated, gErr := r.isPodVolumeBootstrapGated(bootstrap.podName())
+		if gErr != nil {
+			return false, gErr
+		}
+
+		if !gated {
+			r.Log.Info(
+				"Pod re-created without scheduling gate, volume will be filled by migrations", "PVC", existingPVC.Name,
+			)
+
+			return true, nil
+		}
+
+		// The pod is not scheduled, so the unbound PVC is removed right away.
+		if err := r.Delete(
+			context.TODO(), existingPVC, client.Preconditions{UID: &existingPVC.UID},
+		); err != nil && !errors.IsNotFound(err) {
+			return false, fmt.Errorf("could not delete pvc %s: %v", existingPVC.Name, err)
+		}
+
+		r.Log.Info("Deleted PVC re-created by StatefulSet for volume bootstrap", "PVC", existingPVC.Name)
+
+		return false, nil
+	}
+
+	newPVC := bootstrap.PVC.DeepCopy()
+
+	if err := r.Create(context.TODO(), newPVC); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to create PVC %s from VolumeSnapshot %s: %v", newPVC.Name,
+			bootstrap.snapshotName(), err)
+	}
+
+	r.Log.Info("Created PVC from VolumeSnapshot", "PVC", newPVC.Name, "snapshot", bootstrap.snapshotName())
+
+	r.Recorder.Eventf(
+		r.aeroCluster, corev1.EventTypeNormal, "VolumeBootstrapped",
+		"[rack-%d] Provisioned PVC %s from VolumeSnapshot %s", bootstrap.RackID, newPVC.Name,
+		bootstrap.snapshotName(),
+	)
+
+	// Data restored from the snapshot must not be erased by the volume init method.
+	return true, r.markVolumeInitialized(newPVC)
+}
+
+// hasSnapshotBootstrap returns true if the volumes of any rack are bootstrapped from VolumeSnapshots.
+func (r *SingleClusterReconciler) hasSnapshotBootstrap() bool {
+	racks := r.aeroCluster.Spec.RackConfig.Racks
+	for idx := range racks {
+		if racks[idx].Storage.SnapshotBootstrap != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPendingVolumeBootstraps returns the pending volume bootstraps recorded on the AerospikeCluster.
+func (r *SingleClusterReconciler) getPendingVolumeBootstraps() ([]pendingVolumeBootstrap, error) {
+	value, ok := r.aeroCluster.Annotations[volumeBootstrapsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var pending []pendingVolumeBootstrap
+
+	if err := json.Unmarshal([]byte(value), &pending); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", volumeBootstrapsAnnotation, err)
+	}
+
+	return pending, nil
+}
+
+// setPendingVolumeBootstraps records the pending volume bootstraps on the AerospikeCluster.
+// The annotation is removed if there is none.
+func (r *SingleClusterReconciler) setPendingVolumeBootstraps(pending []pendingVolumeBootstrap) error {
+	var value any
+
+	if len(pending) != 0 {
+		data, err := json.Marshal(pending)
+		if err != nil {
+			return err
+		}
+
+		value = string(data)
+	} else if _, ok := r.aeroCluster.Annotations[volumeBootstrapsAnnotation]; !ok {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{volumeBootstrapsAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	cluster := &asdbv1.AerospikeCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: r.aeroCluster.Name, Namespace: r.aeroCluster.Namespace},
+	}
+
+	if err := r.Patch(context.TODO(), cluster, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to record pending volume bootstraps: %v", err)
+	}
+
+	// Keep the in-memory cluster in sync, as it is updated later in the reconcile.
+	r.aeroCluster.Annotations = cluster.Annotations
+	r.aeroCluster.ResourceVersion = cluster.ResourceVersion
+
+	return nil
+}
+
+// releaseVolumeBootstrapGates removes the volume bootstrap scheduling gate from the StatefulSets and the pods which
+// have no pending volume bootstrap. The StatefulSets are released first, so that no pod is re-created gated after.
+func (r *SingleClusterReconciler) releaseVolumeBootstrapGates(pending []pendingVolumeBootstrap) error {
+	gatedSTSs := sets.Set[string]{}
+	gatedPods := sets.Set[string]{}
+
+	for idx := range pending {
+		gatedSTSs.Insert(pending[idx].StatefulSet)
+		gatedPods.Insert(pending[idx].podName())
+	}
+
+	stsList, err := r.getClusterSTSList()
+	if err != nil {
+		return err
+	}
+
+	for idx := range stsList.Items {
+		sts := &stsList.Items[idx]
+
+		if hasVolumeBootstrapGate(&sts.Spec.Template.Spec) && !gatedSTSs.Has(sts.Name) {
+			if err := r.setSTSVolumeBootstrapGate(sts.Name, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	podList, err := r.getClusterPodList()
+	if err != nil {
+		return err
+	}
+
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+
+		if !hasVolumeBootstrapGate(&pod.Spec) || gatedPods.Has(pod.Name) {
+			continue
+		}
+
+		pod.Spec.SchedulingGates = slices.DeleteFunc(pod.Spec.SchedulingGates, isVolumeBootstrapGate)
+
+		if err := r.Update(context.TODO(), pod); err != nil {
+			return fmt.Errorf("failed to release scheduling gate of pod %s: %v", pod.Name, err)
+		}
+
+		r.Log.Info("Released volume bootstrap scheduling gate of pod", "podName", pod.Name)
+	}
+
+	return nil
+}
+
+// setSTSVolumeBootstrapGate adds or removes the volume bootstrap scheduling gate in the pod template of the
+// StatefulSet.
+func (r *SingleClusterReconciler) setSTSVolumeBootstrapGate(stsName string, gated bool) error {
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sts := &appsv1.StatefulSet{}
+		if err := r.Get(
+			context.TODO(), types.NamespacedName{Name: stsName, Namespace: r.aeroCluster.Namespace}, sts,
+		); err != nil {
+			return err
+		}
+
+		podSpec := &sts.Spec.Template.Spec
+		if hasVolumeBootstrapGate(podSpec) == gated {
+			return nil
+		}
+
+		if gated {
+			podSpec.SchedulingGates = append(podSpec.SchedulingGates,
+				corev1.PodSchedulingGate{Name: volumeBootstrapSchedulingGate})
+		} else {
+			podSpec.SchedulingGates = slices.DeleteFunc(podSpec.SchedulingGates, isVolumeBootstrapGate)
+		}
+
+		return r.Update(context.TODO(), sts, common.UpdateOption)
+	}); err != nil {
+		return fmt.Errorf("failed to update scheduling gates of StatefulSet %s: %v", stsName, err)
+	}
+
+	return nil
+}
+
+// isPodVolumeBootstrapGated returns true if the pod exists and is held by the volume bootstrap scheduling gate.
+func (r *SingleClusterReconciler) isPodVolumeBootstrapGated(podName string) (bool, error) {
+	pod := &corev1.Pod{}
+
+	if err := r.Get(
+		context.TODO(), types.NamespacedName{Name: podName, Namespace: r.aeroCluster.Namespace}, pod,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return hasVolumeBootstrapGate(&pod.Spec), nil
+}
+
+func hasVolumeBootstrapGate(podSpec *corev1.PodSpec) bool {
+	return slices.ContainsFunc(podSpec.SchedulingGates, isVolumeBootstrapGate)
+}
+
+func isVolumeBootstrapGate(gate corev1.PodSchedulingGate) bool {
+	return gate.Name == volumeBootstrapSchedulingGate
+}
+
+// isPVCFromSnapshot returns true if the PVC is provisioned from the given VolumeSnapshot.
+func isPVCFromSnapshot(pvc *corev1.PersistentVolumeClaim, snapshotName string) bool {
+	dataSource := pvc.Spec.DataSource
+
+	return dataSource != nil && dataSource.Kind == utils.VolumeSnapshotKind && dataSource.Name == snapshotName
+}
+
+// getBootstrapVolumeSnapshot returns a recent ready snapshot of the volume in the rack if allowed by maxSnapshotAge,
+// else takes a new snapshot of the volume of a healthy replica in the rack.
+func (r *SingleClusterReconciler) getBootstrapVolumeSnapshot(
+	rackState *RackState, volName string, excludedPods sets.Set[string],
+) (*unstructured.Unstructured, error) {
+	bootstrapSpec := rackState.Rack.Storage.SnapshotBootstrap
+
+	if bootstrapSpec.MaxSnapshotAge != nil {
+		snapshots, err := r.getRackVolumeSnapshots(rackState, volName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if snapshot := utils.GetLatestReadyVolumeSnapshot(
+			snapshots, bootstrapSpec.MaxSnapshotAge.Duration, time.Now(),
+		); snapshot != nil {
+			r.Log.Info("Using existing VolumeSnapshot for volume bootstrap", "volume", volName,
+				"snapshot", snapshot.GetName())
+
+			return snapshot, nil
+		}
+	}
+
+	sourcePVC, err := r.getHealthyReplicaPVC(rackState, volName, excludedPods)
+	if err != nil || sourcePVC == nil {
+		return nil, err
+	}
+
+	sourcePodName := strings.TrimPrefix(sourcePVC.Name, volName+"-")
+
+	labels := utils.LabelsForAerospikeClusterRack(r.aeroCluster.Name, rackState.Rack.ID, rackState.Rack.Revision)
+	labels[asdbv1.AerospikeVolumeNameLabel] = volName
+	labels[asdbv1.AerospikePodNameLabel] = sourcePodName
+	labels[asdbv1.AerospikeSnapshotTypeLabel] = bootstrapSnapshotType
+
+	snapshot := utils.NewVolumeSnapshot(
+		r.aeroCluster.Namespace, "", bootstrapSpec.VolumeSnapshotClassName, sourcePVC.Name, labels,
+	)
+
+	// Several snapshots of the same PVC may be taken within a second.
+	snapshot.SetGenerateName(sourcePVC.Name + "-")
+
+	// Snapshots taken by AKO are garbage collected along with the AerospikeCluster.
+	if err := controllerutil.SetOwnerReference(r.aeroCluster, snapshot, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	if err := r.Create(context.TODO(), snapshot); err != nil {
+		return nil, fmt.Errorf("failed to create VolumeSnapshot of PVC %s: %v", sourcePVC.Name, err)
+	}
+
+	r.Log.Info("Created VolumeSnapshot for volume bootstrap", "volume", volName, "snapshot", snapshot.GetName(),
+		"sourcePod", sourcePodName)
+
+	return snapshot, nil
+}
+
+// getHealthyReplicaPVC returns the bound PVC of the volume of a running and ready pod in the rack.
+func (r *SingleClusterReconciler) getHealthyReplicaPVC(
+	rackState *RackState, volName string, excludedPods sets.Set[string],
+) (*corev1.PersistentVolumeClaim, error) {
+	podList, err := r.getRackPodList(rackState.Rack.ID, rackState.Rack.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcList, err := r.getRackPVCList(rackState.Rack.ID, rackState.Rack.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	for podIdx := range podList.Items {
+		pod := &podList.Items[podIdx]
+
+		if excludedPods.Has(pod.Name) || !utils.IsPodRunningAndReady(pod) {
+			continue
+		}
+
+		for pvcIdx := range pvcList {
+			pvc := &pvcList[pvcIdx]
+
+			if pvc.Name == volName+"-"+pod.Name && pvc.Status.Phase == corev1.ClaimBound &&
+				!utils.IsPVCTerminating(pvc) {
+				return pvc, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// getRackVolumeSnapshots lists the VolumeSnapshots of the given volume in the rack.
+// If snapshotType is not empty, only snapshots of this type are listed.
+func (r *SingleClusterReconciler) getRackVolumeSnapshots(
+	rackState *RackState, volName, snapshotType string,
+) ([]unstructured.Unstructured, error) {
+	labels := utils.LabelsForAerospikeClusterRack(r.aeroCluster.Name, rackState.Rack.ID, rackState.Rack.Revision)
+
+	if volName != "" {
+		labels[asdbv1.AerospikeVolumeNameLabel] = volName
+	}
+
+	if snapshotType != "" {
+		labels[asdbv1.AerospikeSnapshotTypeLabel] = snapshotType
+	}
+
+	snapshotList := utils.NewVolumeSnapshotList()
+	if err := r.List(
+		context.TODO(), snapshotList, client.InNamespace(r.aeroCluster.Namespace), client.MatchingLabels(labels),
+	); err != nil {
+		return nil, fmt.Errorf("failed to list VolumeSnapshots: %v", err)
+	}
+
+	return snapshotList.Items, nil
+}
+
+// newBootstrapPVC returns the old PVC to be created again with the snapshot as data source.
+func newBootstrapPVC(oldPVC *corev1.PersistentVolumeClaim, snapshotName string) *corev1.PersistentVolumeClaim {
+	newPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            oldPVC.Name,
+			Namespace:       oldPVC.Namespace,
+			Labels:          oldPVC.Labels,
+			Annotations:     map[string]string{},
+			OwnerReferences: oldPVC.OwnerReferences,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      oldPVC.Spec.AccessModes,
+			Selector:         oldPVC.Spec.Selector,
+			Resources:        oldPVC.Spec.Resources,
+			StorageClassName: oldPVC.Spec.StorageClassName,
+			VolumeMode:       oldPVC.Spec.VolumeMode,
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(utils.VolumeSnapshotAPIGroup),
+				Kind:     utils.VolumeSnapshotKind,
+				Name:     snapshotName,
+			},
+		},
+	}
+
+	// Drop annotations set by the PV controller for the old claim.
+	for key, val := range oldPVC.Annotations {
+		if !strings.HasPrefix(key, "pv.kubernetes.io/") && !strings.HasPrefix(key, "volume.") {
+			newPVC.Annotations[key] = val
+		}
+	}
+
+	return newPVC
+}
+
+// markVolumeInitialized adds the volume of the given PVC to the initializedVolumes of its pod.
+func (r *SingleClusterReconciler) markVolumeInitialized(pvc *corev1.PersistentVolumeClaim) error {
+	volName := getPVCStorageVolumeName(pvc)
+	podName := strings.TrimPrefix(pvc.Name, volName+"-")
+
+	podStatus, ok := r.aeroCluster.Status.Pods[podName]
+	if !ok {
+		return nil
+	}
+
+	initializedVolume := fmt.Sprintf("%s@%s", volName, pvc.UID)
+	if slices.Contains(podStatus.InitializedVolumes, initializedVolume) {
+		return nil
+	}
+
+	initializedVolumes := make([]string, 0, len(podStatus.InitializedVolumes)+1)
+	initializedVolumes = append(initializedVolumes, podStatus.InitializedVolumes...)
+	initializedVolumes = append(initializedVolumes, initializedVolume)
+
+	patches := []jsonpatch.PatchOperation{
+		{
+			// The initializedVolumes may not be set yet, and add replaces it if set.
+			Operation: "add",
+			Path:      "/status/pods/" + podName + "/initializedVolumes",
+			Value:     initializedVolumes,
+		},
+	}
+
+	return r.patchPodStatus(context.TODO(), patches)
+}
+
+// cleanupBootstrapSnapshots deletes the snapshots taken by AKO for volume bootstrap once they are no longer needed.
+// A snapshot is kept while a volume bootstrap or a PVC provisioned from it is pending, or while it can still be
+// reused as per maxSnapshotAge.
+func (r *SingleClusterReconciler) cleanupBootstrapSnapshots() error {
+	var pendingPVCSources sets.Set[string]
+
+	pending, err := r.getPendingVolumeBootstraps()
+	if err != nil {
+		return err
+	}
+
+	racks := r.aeroCluster.Spec.RackConfig.Racks
+	for idx := range racks {
+		bootstrapSpec := racks[idx].Storage.SnapshotBootstrap
+		if bootstrapSpec == nil {
+			continue
+		}
+
+		if pendingPVCSources == nil {
+			pvcList, err := r.getClusterPVCList()
+			if err != nil {
+				return err
+			}
+
+			pendingPVCSources = sets.Set[string]{}
+
+			for bootstrapIdx := range pending {
+				pendingPVCSources.Insert(pending[bootstrapIdx].snapshotName())
+			}
+
+			for pvcIdx := range pvcList {
+				dataSource := pvcList[pvcIdx].Spec.DataSource
+				if dataSource != nil && dataSource.Kind == utils.VolumeSnapshotKind &&
+					pvcList[pvcIdx].Status.Phase == corev1.ClaimPending {
+					pendingPVCSources.Insert(dataSource.Name)
+				}
+			}
+		}
+
+		rackState := &RackState{Rack: &racks[idx]}
+
+		snapshots, err := r.getRackVolumeSnapshots(rackState, "", bootstrapSnapshotType)
+		if err != nil {
+			return err
+		}
+
+		for snapIdx := range snapshots {
+			snapshot := &snapshots[snapIdx]
+
+			if pendingPVCSources.Has(snapshot.GetName()) || snapshot.GetDeletionTimestamp() != nil {
+				continue
+			}
+
+			if bootstrapSpec.MaxSnapshotAge != nil &&
+				time.Since(utils.GetVolumeSnapshotCreationTime(snapshot)) <= bootstrapSpec.MaxSnapshotAge.Duration {
+				continue
+			}
+
+			if err := r.Delete(context.TODO(), snapshot); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete VolumeSnapshot %s: %v", snapshot.GetName(), err)
+			}
+
+			r.Log.Info("Deleted bootstrap VolumeSnapshot", "snapshot", snapshot.GetName())
+		}
+	}
+
+	return nil
+}
+
+// createPVCsFromSnapshotSet creates the PVCs of a new rack from the VolumeSnapshots of the configured snapshot set.
+// The StatefulSet adopts these PVCs as they follow its volumeClaimTemplate naming.
+// Snapshots are matched to the pods of the rack by rack id, pod ordinal and volume name.
+func (r *SingleClusterReconciler) createPVCsFromSnapshotSet(rackState *RackState) error {
+	restoreSpec := r.aeroCluster.Spec.VolumeSnapshotRestore
+
+	snapshotList := utils.NewVolumeSnapshotList()
+	if err := r.List(
+		context.TODO(), snapshotList, client.InNamespace(r.aeroCluster.Namespace), client.MatchingLabels{
+			asdbv1.AerospikeSnapshotBackupLabel: restoreSpec.BackupName,
+			asdbv1.AerospikeSnapshotSetLabel:    restoreSpec.SnapshotSetID,
+			asdbv1.AerospikeRackIDLabel:         strconv.Itoa(rackState.Rack.ID),
+		},
+	); err != nil {
+		return fmt.Errorf("failed to list VolumeSnapshots: %v", err)
+	}
+
+	if len(snapshotList.Items) == 0 {
+		r.Log.Info("No VolumeSnapshot found for rack in snapshot set, volumes will be filled by migrations",
+			"rackID", rackState.Rack.ID, "snapshotSet", restoreSpec.SnapshotSetID)
+
+		return nil
+	}
+
+	stsName := utils.GetNamespacedNameForSTSOrConfigMap(r.aeroCluster,
+		utils.GetRackIdentifier(rackState.Rack.ID, rackState.Rack.Revision))
+
+	for idx := range snapshotList.Items {
+		snapshot := &snapshotList.Items[idx]
+
+		if !utils.IsVolumeSnapshotReady(snapshot) {
+			return fmt.Errorf("VolumeSnapshot %s of snapshot set %s is not ready to use", snapshot.GetName(),
+				restoreSpec.SnapshotSetID)
+		}
+
+		snapshotLabels := snapshot.GetLabels()
+		volName := snapshotLabels[asdbv1.AerospikeVolumeNameLabel]
+		sourcePodName := snapshotLabels[asdbv1.AerospikePodNameLabel]
+
+		ordinal, err := strconv.Atoi(sourcePodName[strings.LastIndex(sourcePodName, "-")+1:])
+		if err != nil {
+			return fmt.Errorf("failed to get pod ordinal of VolumeSnapshot %s: %v", snapshot.GetName(), err)
+		}
+
+		if int32(ordinal) >= rackState.Size { //nolint:gosec // pod ordinal is always small
+			continue
+		}
+
+		volume := getPVCVolumeConfig(&rackState.Rack.Storage, volName)
+		if volume == nil || volume.Source.PersistentVolume == nil {
+			r.Log.Info("Volume of VolumeSnapshot not found in rack storage, skipping",
+				"snapshot", snapshot.GetName(), "volume", volName)
+
+			continue
+		}
+
+		pvc := createPVCForVolumeAttachment(r.aeroCluster, volume)
+		pvc.Name = fmt.Sprintf("%s-%s-%d", volName, stsName.Name, ordinal)
+		pvc.Labels = utils.MergeLabels(
+			utils.LabelsForAerospikeClusterRack(r.aeroCluster.Name, rackState.Rack.ID, rackState.Rack.Revision),
+			pvc.Labels,
+		)
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: ptr.To(utils.VolumeSnapshotAPIGroup),
+			Kind:     utils.VolumeSnapshotKind,
+			Name:     snapshot.GetName(),
+		}
+
+		// The volume can not be smaller than the snapshot.
+		if restoreSize, pErr := resource.ParseQuantity(utils.GetVolumeSnapshotRestoreSize(snapshot)); pErr == nil &&
+			restoreSize.Cmp(pvc.Spec.Resources.Requests[corev1.ResourceStorage]) > 0 {
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = restoreSize
+		}
+
+		if err := r.Create(context.TODO(), &pvc); err != nil {
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+
+			return fmt.Errorf("failed to create PVC %s from VolumeSnapshot %s: %v", pvc.Name, snapshot.GetName(), err)
+		}
+
+		r.Log.Info("Created PVC from VolumeSnapshot", "PVC", pvc.Name, "snapshot", snapshot.GetName())
+	}
+
+	r.Recorder.Eventf(
+		r.aeroCluster, corev1.EventTypeNormal, "VolumesRestored",
+		"[rack-%d] Provisioned PVCs from VolumeSnapshot set %s of %s", rackState.Rack.ID,
+		restoreSpec.SnapshotSetID, restoreSpec.BackupName,
+	)
+
+	return nil
+}
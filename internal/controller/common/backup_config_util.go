@@ -3,13 +3,16 @@ package common
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -49,83 +52,211 @@ func GetBackupServicePodList(k8sClient client.Client, name, namespace string) (*
 	return &podList, nil
 }
 
+// backupServiceStandbySuffix is the suffix of the name of the standby Deployment and ConfigMap of a replicated
+// backup service.
+const backupServiceStandbySuffix = "-standby"
+
+// GetBackupServiceStandbyName returns the name of the standby Deployment and ConfigMap of the backup service.
+func GetBackupServiceStandbyName(name string) string {
+	return name + backupServiceStandbySuffix
+}
+
+const (
+	// configReloadInterval is the interval the config is applied at until the backup service serves it.
+	configReloadInterval = 5 * time.Second
+
+	// configReloadTimeout is the time the ConfigMap update is waited for to be propagated to the mounted
+	// config file, which is bound by the kubelet sync period and ConfigMap cache TTL.
+	configReloadTimeout = 3 * time.Minute
+)
+
+// ReloadBackupServiceConfigInPods reloads the config in the backup service and reports the reload progress in the
+// AerospikeBackupService status.
 func ReloadBackupServiceConfigInPods(
 	k8sClient client.Client,
 	backupServiceClient *backup_service.Client,
 	log logr.Logger,
 	backupSvc *v1beta1.BackupService,
 ) error {
-	log.Info("Reloading backup service config")
+	servingSvc, err := syncServingBackupServiceConfig(k8sClient, backupSvc)
+	if err != nil {
+		return err
+	}
 
-	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		podList, err := GetBackupServicePodList(k8sClient,
-			backupSvc.Name,
-			backupSvc.Namespace,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to get backup service pod list, error: %v", err)
-		}
+	desiredData, err := GetBackupSvcConfigFromCM(k8sClient, servingSvc)
+	if err != nil {
+		return err
+	}
 
-		for idx := range podList.Items {
-			pod := podList.Items[idx]
-			annotations := pod.Annotations
+	desiredHash, err := backup_service.ConfigHashFromYAML(desiredData)
+	if err != nil {
+		return err
+	}
 
-			if annotations == nil {
-				annotations = make(map[string]string)
-			}
+	if err := setConfigReloadStatus(k8sClient, backupSvc, &v1beta1.BackupServiceConfigReloadStatus{
+		Phase:             v1beta1.BackupServiceConfigReloadInProgress,
+		DesiredConfigHash: desiredHash,
+	}); err != nil {
+		return err
+	}
 
-			annotations[v1beta1.RefreshTimeKey] = time.Now().Format(time.RFC3339)
+	appliedHash, reloadErr := ReloadBackupServiceConfig(k8sClient, backupServiceClient, log, servingSvc)
 
-			pod.Annotations = annotations
+	reloadStatus := &v1beta1.BackupServiceConfigReloadStatus{
+		Phase:             v1beta1.BackupServiceConfigReloadCompleted,
+		DesiredConfigHash: desiredHash,
+		AppliedConfigHash: appliedHash,
+	}
 
-			if err := k8sClient.Update(context.TODO(), &pod); err != nil {
-				return err
-			}
-		}
+	if reloadErr != nil {
+		reloadStatus.Phase = v1beta1.BackupServiceConfigReloadFailed
+		reloadStatus.Message = reloadErr.Error()
+	}
 
-		return nil
-	}); err != nil {
+	if err := setConfigReloadStatus(k8sClient, backupSvc, reloadStatus); err != nil {
 		return err
 	}
 
-	// Waiting for 1 second so that pods get the latest configMap update.
-	time.Sleep(1 * time.Second)
+	return reloadErr
+}
 
-	if err := backupServiceClient.ApplyConfig(); err != nil {
-		return err
+// syncServingBackupServiceConfig returns the backup service of the pods serving the API. If the standby pods of a
+// replicated backup service serve in place of the leader, the leader config is copied to the standby ConfigMap, as
+// the promoted standby pods run with the leader config.
+func syncServingBackupServiceConfig(
+	k8sClient client.Client, backupSvc *v1beta1.BackupService,
+) (*v1beta1.BackupService, error) {
+	aeroBackupService := &v1beta1.AerospikeBackupService{}
+
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: backupSvc.Namespace,
+		Name:      backupSvc.Name,
+	}, aeroBackupService); err != nil {
+		return nil, err
+	}
+
+	if aeroBackupService.Status.ServingRole != v1beta1.BackupServiceStandby {
+		return backupSvc, nil
+	}
+
+	standbySvc := &v1beta1.BackupService{
+		Name:      GetBackupServiceStandbyName(backupSvc.Name),
+		Namespace: backupSvc.Namespace,
 	}
 
-	return validateBackupSvcConfigReload(k8sClient, backupServiceClient, log, backupSvc)
+	leaderConfig, err := GetBackupSvcConfigFromCM(k8sClient, backupSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+
+		if err := k8sClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: standbySvc.Namespace,
+			Name:      standbySvc.Name,
+		}, cm); err != nil {
+			return err
+		}
+
+		if cm.Data[v1beta1.BackupServiceConfigYAML] == leaderConfig {
+			return nil
+		}
+
+		cm.Data = map[string]string{v1beta1.BackupServiceConfigYAML: leaderConfig}
+
+		return k8sClient.Update(context.TODO(), cm)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update standby ConfigMap: %v", err)
+	}
+
+	return standbySvc, nil
 }
 
-func validateBackupSvcConfigReload(k8sClient client.Client,
+// ReloadBackupServiceConfig applies the config file in the backup service until it serves the config in the
+// ConfigMap, i.e. till the ConfigMap update is propagated to the mounted config file.
+// It returns the hash of the config served by the backup service.
+func ReloadBackupServiceConfig(
+	k8sClient client.Client,
 	backupServiceClient *backup_service.Client,
 	log logr.Logger,
 	backupSvc *v1beta1.BackupService,
-) error {
-	apiBackupSvcConfig, err := backupServiceClient.GetBackupServiceConfig()
-	if err != nil {
-		return err
-	}
+) (string, error) {
+	log.Info("Reloading backup service config")
 
 	desiredData, err := GetBackupSvcConfigFromCM(k8sClient, backupSvc)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	synced, err := IsBackupSvcFullConfigSynced(apiBackupSvcConfig, desiredData, log)
+	desiredHash, err := backup_service.ConfigHashFromYAML(desiredData)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	if !synced {
-		log.Info("Backup service config not yet updated in pods, requeue")
-		return fmt.Errorf("backup service config not yet updated in pods")
+	var appliedHash string
+
+	if err := wait.PollUntilContextTimeout(context.TODO(), configReloadInterval, configReloadTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := backupServiceClient.ApplyConfig(ctx); err != nil {
+				// Static config changes are rejected by the backup service and need restart.
+				if backup_service.StatusCode(err) == http.StatusBadRequest {
+					return false, err
+				}
+
+				log.Info("Failed to apply backup service config, will retry", "err", err.Error())
+
+				return false, nil
+			}
+
+			apiBackupSvcConfig, err := backupServiceClient.GetBackupServiceConfig(ctx)
+			if err != nil {
+				log.Info("Failed to get backup service config, will retry", "err", err.Error())
+				return false, nil
+			}
+
+			if appliedHash, err = backup_service.ConfigHash(apiBackupSvcConfig); err != nil {
+				return false, err
+			}
+
+			if appliedHash != desiredHash {
+				log.Info("Backup service config not yet updated in pods, will retry",
+					"desiredHash", desiredHash, "appliedHash", appliedHash)
+
+				return false, nil
+			}
+
+			return true, nil
+		}); err != nil {
+		return appliedHash, fmt.Errorf("failed to reload backup service config, desired config hash %s, "+
+			"applied config hash %s: %v", desiredHash, appliedHash, err)
 	}
 
-	log.Info("Reloaded backup service config")
+	log.Info("Reloaded backup service config", "hash", appliedHash)
+
+	return appliedHash, nil
+}
+
+// setConfigReloadStatus sets the config reload status of the AerospikeBackupService.
+func setConfigReloadStatus(
+	k8sClient client.Client, backupSvc *v1beta1.BackupService, reloadStatus *v1beta1.BackupServiceConfigReloadStatus,
+) error {
+	reloadStatus.LastReloadTime = &metav1.Time{Time: time.Now()}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		aeroBackupService := &v1beta1.AerospikeBackupService{}
+
+		if err := k8sClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: backupSvc.Namespace,
+			Name:      backupSvc.Name,
+		}, aeroBackupService); err != nil {
+			return err
+		}
+
+		aeroBackupService.Status.ConfigReload = reloadStatus
 
-	return nil
+		return k8sClient.Status().Update(context.TODO(), aeroBackupService)
+	})
 }
 
 func IsBackupSvcFullConfigSynced(currentBackupSvcConfig map[string]interface{}, desired string,
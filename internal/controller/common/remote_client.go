@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KubeconfigSecretKey is the key of the kubeconfig in the secrets referred by kubeconfigSecretName.
+const KubeconfigSecretKey = "kubeconfig"
+
+// remoteClient is a cached client, built from the given resourceVersion of its kubeconfig secret.
+type remoteClient struct {
+	client          client.Client
+	resourceVersion string
+}
+
+// remoteClients caches the remote clients by kubeconfig secret, as building a client and its REST mapper
+// queries the API discovery of the remote cluster.
+var remoteClients = struct {
+	clients map[types.NamespacedName]remoteClient
+	mutex   sync.Mutex
+}{clients: map[types.NamespacedName]remoteClient{}}
+
+// NewRemoteClient returns a client for the Kubernetes cluster of the kubeconfig in the given secret.
+// The client is reused until the secret is updated.
+func NewRemoteClient(
+	k8sClient client.Client, scheme *runtime.Scheme, namespace, kubeconfigSecretName string,
+) (client.Client, error) {
+	secretName := types.NamespacedName{Name: kubeconfigSecretName, Namespace: namespace}
+
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(context.TODO(), secretName, secret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret %s: %v", kubeconfigSecretName, err)
+	}
+
+	remoteClients.mutex.Lock()
+	defer remoteClients.mutex.Unlock()
+
+	if cached, ok := remoteClients.clients[secretName]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	kubeconfig, ok := secret.Data[KubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig secret %s does not have %s key", kubeconfigSecretName,
+			KubeconfigSecretKey)
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig in secret %s: %v", kubeconfigSecretName, err)
+	}
+
+	cl, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	remoteClients.clients[secretName] = remoteClient{client: cl, resourceVersion: secret.ResourceVersion}
+
+	return cl, nil
+}
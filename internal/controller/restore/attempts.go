@@ -0,0 +1,162 @@
+package restore
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
+)
+
+// maxRetryBackoff is the upper limit of the wait between the restore attempts.
+const maxRetryBackoff = 30 * time.Minute
+
+// cancelRestore cancels the running restore job, if any, and moves the restore to the Cancelled phase.
+func (r *SingleRestoreReconciler) cancelRestore() error {
+	r.Log.Info("Cancelling restore")
+
+	if r.aeroRestore.Status.JobID != nil {
+		if err := r.cancelRestoreJob(); err != nil {
+			return err
+		}
+	}
+
+	r.completeAttempt(asdbv1beta1.AerospikeRestoreCancelled, "")
+
+	r.aeroRestore.Status.Phase = asdbv1beta1.AerospikeRestoreCancelled
+	r.aeroRestore.Status.NextAttemptTime = nil
+
+	if err := r.Client.Status().Update(context.TODO(), r.aeroRestore); err != nil {
+		return err
+	}
+
+	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreCancelled",
+		"Cancelled restore %s/%s", r.aeroRestore.Namespace, r.aeroRestore.Name)
+
+	return nil
+}
+
+// isSkipExistingRecordsAttempt returns true if the next restore job should restore only the records not already in
+// the destination, i.e. if it is part of a retry.
+func (r *SingleRestoreReconciler) isSkipExistingRecordsAttempt() bool {
+	return r.aeroRestore.Spec.RetryPolicy != nil && r.aeroRestore.Spec.RetryPolicy.SkipExistingRecords &&
+		slices.ContainsFunc(r.aeroRestore.Status.Attempts, func(attempt asdbv1beta1.RestoreAttempt) bool {
+			return attempt.Phase == asdbv1beta1.AerospikeRestoreFailed
+		})
+}
+
+// isResumeAttempt returns true if the next attempt should resume the restore chain from the backups not restored
+// by the previous attempts, i.e. if it is a retry and some backups of the chain are already restored.
+func (r *SingleRestoreReconciler) isResumeAttempt() bool {
+	return r.aeroRestore.Spec.RetryPolicy != nil && r.aeroRestore.Spec.RetryPolicy.Resume &&
+		r.aeroRestore.Status.RestoreChain != nil && len(r.aeroRestore.Status.RestoreChain.RestoredKeys) != 0
+}
+
+// isAttemptInProgress returns true if the latest attempt is not completed yet, i.e. while the backups of a restore
+// chain are restored.
+func (r *SingleRestoreReconciler) isAttemptInProgress() bool {
+	attempts := r.aeroRestore.Status.Attempts
+
+	return len(attempts) != 0 && attempts[len(attempts)-1].CompletionTime == nil
+}
+
+// addAttempt records a newly triggered restore job in the status. The restore job of the next backup of a restore
+// chain continues the attempt in progress.
+func (r *SingleRestoreReconciler) addAttempt(jobID int64, skipExistingRecords bool, resumedFromKey string) {
+	r.aeroRestore.Status.JobID = &jobID
+	r.aeroRestore.Status.NextAttemptTime = nil
+
+	if r.isAttemptInProgress() {
+		r.aeroRestore.Status.Attempts[len(r.aeroRestore.Status.Attempts)-1].JobID = jobID
+		return
+	}
+
+	r.aeroRestore.Status.Attempts = append(r.aeroRestore.Status.Attempts, asdbv1beta1.RestoreAttempt{
+		JobID:                  jobID,
+		Phase:                  asdbv1beta1.AerospikeRestoreInProgress,
+		SkippedExistingRecords: skipExistingRecords,
+		ResumedFromKey:         resumedFromKey,
+		StartTime:              metav1.Now(),
+	})
+}
+
+// getCurrentAttempt returns the attempt of the running restore job.
+// It is nil for the restores triggered before the attempts were recorded.
+func (r *SingleRestoreReconciler) getCurrentAttempt() *asdbv1beta1.RestoreAttempt {
+	attempts := r.aeroRestore.Status.Attempts
+	if len(attempts) == 0 || r.aeroRestore.Status.JobID == nil ||
+		attempts[len(attempts)-1].JobID != *r.aeroRestore.Status.JobID {
+		return nil
+	}
+
+	return &attempts[len(attempts)-1]
+}
+
+// updateAttempt updates the current attempt from the restore job status.
+func (r *SingleRestoreReconciler) updateAttempt(restoreStatus *dto.RestoreJobStatus) {
+	attempt := r.getCurrentAttempt()
+	if attempt == nil {
+		return
+	}
+
+	attempt.ReadRecords = int64(restoreStatus.ReadRecords)
+	attempt.InsertedRecords = int64(restoreStatus.InsertedRecords)
+
+	if phase := statusToPhase(restoreStatus.Status); phase != "" && phase != asdbv1beta1.AerospikeRestoreInProgress {
+		r.completeAttempt(phase, restoreStatus.Error)
+	}
+}
+
+// completeAttempt completes the latest attempt, if still in progress.
+func (r *SingleRestoreReconciler) completeAttempt(phase asdbv1beta1.AerospikeRestorePhase, errMsg string) {
+	if !r.isAttemptInProgress() {
+		return
+	}
+
+	attempt := &r.aeroRestore.Status.Attempts[len(r.aeroRestore.Status.Attempts)-1]
+	attempt.Phase = phase
+	attempt.Error = errMsg
+	attempt.CompletionTime = &metav1.Time{Time: time.Now()}
+}
+
+// scheduleRetry schedules the next attempt of the failed restore as per the retry policy.
+// It returns false if no attempt is left, or if the restore failed with an error not fixed by a retry.
+func (r *SingleRestoreReconciler) scheduleRetry(errMsg string) bool {
+	retryPolicy := r.aeroRestore.Spec.RetryPolicy
+	if retryPolicy == nil || len(r.aeroRestore.Status.Attempts) >= int(retryPolicy.MaxAttempts) {
+		return false
+	}
+
+	if !backup_service.IsTransientRestoreError(errMsg) {
+		r.Log.Info("Restore failed with a non transient error, not retrying", "error", errMsg)
+		return false
+	}
+
+	backoff := getRetryBackoff(retryPolicy.Backoff.Duration, len(r.aeroRestore.Status.Attempts))
+
+	r.aeroRestore.Status.Phase = asdbv1beta1.AerospikeRestoreInProgress
+	r.aeroRestore.Status.JobID = nil
+	r.aeroRestore.Status.NextAttemptTime = &metav1.Time{Time: time.Now().Add(backoff)}
+
+	r.Log.Info("Restore failed, scheduled retry", "attempt", len(r.aeroRestore.Status.Attempts)+1,
+		"maxAttempts", retryPolicy.MaxAttempts, "backoff", backoff.String())
+	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeWarning, "RestoreRetryScheduled",
+		"Restore %s/%s failed, attempt %d of %d scheduled after %s", r.aeroRestore.Namespace, r.aeroRestore.Name,
+		len(r.aeroRestore.Status.Attempts)+1, retryPolicy.MaxAttempts, backoff.String())
+
+	return true
+}
+
+// getRetryBackoff returns the backoff doubled for every failed attempt after the first one, up to maxRetryBackoff.
+func getRetryBackoff(backoff time.Duration, failedAttempts int) time.Duration {
+	for i := 1; i < failedAttempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
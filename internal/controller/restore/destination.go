@@ -0,0 +1,356 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
+)
+
+const (
+	// restoreLabel is set on the destination cluster with the name of the AerospikeRestore which created it.
+	restoreLabel = "asdb.aerospike.com/restore"
+
+	// destinationClusterPollingPeriod is the polling period, in seconds, for the destination cluster to be Completed.
+	destinationClusterPollingPeriod = 10
+)
+
+func (r *SingleRestoreReconciler) destinationClusterNamespacedName() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      r.aeroRestore.Spec.DestinationCluster.Name,
+		Namespace: r.aeroRestore.Namespace,
+	}
+}
+
+// reconcileDestinationCluster creates the destination cluster if not created already,
+// and returns true once the cluster is Completed.
+func (r *SingleRestoreReconciler) reconcileDestinationCluster() (*asdbv1.AerospikeCluster, bool, error) {
+	aeroCluster := &asdbv1.AerospikeCluster{}
+
+	if err := r.Client.Get(context.TODO(), r.destinationClusterNamespacedName(), aeroCluster); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, false, err
+		}
+
+		return nil, false, r.createDestinationCluster()
+	}
+
+	if aeroCluster.Labels[restoreLabel] != r.aeroRestore.Name {
+		return nil, false, fmt.Errorf("AerospikeCluster %s already exists and is not created by this restore",
+			aeroCluster.Name)
+	}
+
+	if aeroCluster.Status.Phase != asdbv1.AerospikeClusterCompleted {
+		r.Log.Info("Waiting for destination cluster to be Completed", "cluster", aeroCluster.Name,
+			"phase", aeroCluster.Status.Phase)
+
+		return nil, false, nil
+	}
+
+	return aeroCluster, true, nil
+}
+
+func (r *SingleRestoreReconciler) createDestinationCluster() error {
+	destination := r.aeroRestore.Spec.DestinationCluster
+
+	aeroCluster := &asdbv1.AerospikeCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      destination.Name,
+			Namespace: r.aeroRestore.Namespace,
+			Labels: map[string]string{
+				restoreLabel: r.aeroRestore.Name,
+			},
+		},
+	}
+
+	if err := yaml.UnmarshalStrict(destination.Spec.Raw, &aeroCluster.Spec); err != nil {
+		return fmt.Errorf("invalid destination cluster spec: %v", err)
+	}
+
+	// The cluster is deleted along with the restore only if it is not meant to be kept after the restore.
+	if destination.DeleteAfterRestore {
+		if err := controllerutil.SetOwnerReference(r.aeroRestore, aeroCluster, r.Scheme); err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("Creating destination cluster", "cluster", aeroCluster.Name)
+
+	if err := r.Client.Create(context.TODO(), aeroCluster); err != nil {
+		return err
+	}
+
+	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "DestinationClusterCreated",
+		"Created destination cluster %s/%s", aeroCluster.Namespace, aeroCluster.Name)
+
+	r.aeroRestore.Status.DestinationCluster = &asdbv1beta1.RestoreDestinationClusterStatus{
+		Name:  aeroCluster.Name,
+		Phase: asdbv1beta1.RestoreDestinationClusterProvisioning,
+	}
+
+	return r.Client.Status().Update(context.TODO(), r.aeroRestore)
+}
+
+// getRestoreConfig returns the restore config with the destination set from the destination cluster, if given.
+func (r *SingleRestoreReconciler) getRestoreConfig(aeroCluster *asdbv1.AerospikeCluster) ([]byte, error) {
+	if aeroCluster == nil {
+		return r.aeroRestore.Spec.Config.Raw, nil
+	}
+
+	tlsName, tlsPort := asdbv1.GetServiceTLSNameAndPort(aeroCluster.Spec.AerospikeConfig)
+
+	seedNodes, err := getSeedNodes(aeroCluster.Status.Pods, tlsName, tlsPort)
+	if err != nil {
+		return nil, err
+	}
+
+	backupService := &asdbv1beta1.AerospikeBackupService{}
+
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{
+		Name: r.aeroRestore.Spec.BackupService.Name, Namespace: r.aeroRestore.Spec.BackupService.Namespace,
+	}, backupService); err != nil {
+		return nil, err
+	}
+
+	credentials, err := r.getDestinationCredentials(aeroCluster, backupService)
+	if err != nil {
+		return nil, err
+	}
+
+	tls, err := getDestinationTLS(aeroCluster, backupService, tlsName)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := backup_service.SetRestoreDestination(r.aeroRestore.Spec.Config.Raw, seedNodes, credentials, tls)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds := make([]string, 0, len(seedNodes))
+	for idx := range seedNodes {
+		seeds = append(seeds, net.JoinHostPort(seedNodes[idx].HostName, strconv.Itoa(int(seedNodes[idx].Port))))
+	}
+
+	r.aeroRestore.Status.DestinationCluster = &asdbv1beta1.RestoreDestinationClusterStatus{
+		Name:      aeroCluster.Name,
+		Phase:     asdbv1beta1.RestoreDestinationClusterReady,
+		SeedNodes: seeds,
+	}
+
+	return config, nil
+}
+
+// getSeedNodes returns a seed node per pod, sorted by pod name, using the pod IP.
+// The TLS port is used if tlsName is set.
+func getSeedNodes(pods map[string]asdbv1.AerospikePodStatus, tlsName string, tlsPort *int32) ([]dto.SeedNode, error) {
+	podNames := make([]string, 0, len(pods))
+	for podName := range pods {
+		podNames = append(podNames, podName)
+	}
+
+	sort.Strings(podNames)
+
+	seedNodes := make([]dto.SeedNode, 0, len(podNames))
+
+	for _, podName := range podNames {
+		pod := pods[podName]
+
+		seedNode := dto.SeedNode{
+			HostName: pod.PodIP,
+			Port:     dto.Port(pod.PodPort),
+		}
+
+		if tlsName != "" && tlsPort != nil {
+			seedNode.Port = dto.Port(*tlsPort)
+			seedNode.TLSName = tlsName
+		}
+
+		seedNodes = append(seedNodes, seedNode)
+	}
+
+	if len(seedNodes) == 0 {
+		return nil, fmt.Errorf("no pods found in destination cluster status")
+	}
+
+	return seedNodes, nil
+}
+
+// getDestinationCredentials returns the credentials for the restore, with the password read by the backup service
+// from the user secret mounted in its pods. Nil is returned if security is not enabled in the destination cluster.
+func (r *SingleRestoreReconciler) getDestinationCredentials(
+	aeroCluster *asdbv1.AerospikeCluster, backupService *asdbv1beta1.AerospikeBackupService,
+) (*dto.Credentials, error) {
+	if aeroCluster.Spec.AerospikeAccessControl == nil {
+		return nil, nil
+	}
+
+	user := r.aeroRestore.Spec.DestinationCluster.User
+	if user == "" {
+		user = asdbv1.AdminUsername
+	}
+
+	userSpec, ok := asdbv1.GetUsersFromSpec(&aeroCluster.Spec)[user]
+	if !ok {
+		return nil, fmt.Errorf("user %s not found in destination cluster access control", user)
+	}
+
+	passwordPath, err := getBackupServiceSecretPath(backupService, aeroCluster.Namespace, userSpec.SecretName,
+		"password")
+	if err != nil {
+		return nil, fmt.Errorf("password of user %s: %v", user, err)
+	}
+
+	return &dto.Credentials{User: &user, PasswordPath: &passwordPath}, nil
+}
+
+// getDestinationTLS returns the TLS config for the restore, with the certificates of the operator client cert of the
+// destination cluster read by the backup service from the secrets mounted in its pods. Only the tlsName is set if
+// the operator client cert is not given as a secret. Nil is returned if TLS is not enabled in the destination cluster.
+func getDestinationTLS(
+	aeroCluster *asdbv1.AerospikeCluster, backupService *asdbv1beta1.AerospikeBackupService, tlsName string,
+) (*dto.TLS, error) {
+	if tlsName == "" {
+		return nil, nil
+	}
+
+	tls := &dto.TLS{Name: &tlsName}
+
+	clientCert := aeroCluster.Spec.OperatorClientCertSpec
+	if clientCert == nil || clientCert.SecretCertSource == nil {
+		return tls, nil
+	}
+
+	certSource := clientCert.SecretCertSource
+
+	secretNamespace := certSource.SecretNamespace
+	if secretNamespace == "" {
+		secretNamespace = aeroCluster.Namespace
+	}
+
+	if certSource.CaCertsSource != nil {
+		caNamespace := certSource.CaCertsSource.SecretNamespace
+		if caNamespace == "" {
+			caNamespace = aeroCluster.Namespace
+		}
+
+		caPath, err := getBackupServiceSecretPath(backupService, caNamespace, certSource.CaCertsSource.SecretName, "")
+		if err != nil {
+			return nil, fmt.Errorf("CA certificates: %v", err)
+		}
+
+		tls.CAPath = &caPath
+	} else if certSource.CaCertsFilename != "" {
+		caFile, err := getBackupServiceSecretPath(backupService, secretNamespace, certSource.SecretName,
+			certSource.CaCertsFilename)
+		if err != nil {
+			return nil, fmt.Errorf("CA certificate: %v", err)
+		}
+
+		tls.CAFile = &caFile
+	}
+
+	if certSource.ClientCertFilename != "" && certSource.ClientKeyFilename != "" {
+		certFile, err := getBackupServiceSecretPath(backupService, secretNamespace, certSource.SecretName,
+			certSource.ClientCertFilename)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %v", err)
+		}
+
+		keyFile, err := getBackupServiceSecretPath(backupService, secretNamespace, certSource.SecretName,
+			certSource.ClientKeyFilename)
+		if err != nil {
+			return nil, fmt.Errorf("client key: %v", err)
+		}
+
+		tls.Certfile = &certFile
+		tls.Keyfile = &keyFile
+	}
+
+	return tls, nil
+}
+
+// getBackupServiceSecretPath returns the path of the given key of the secret mounted in the backup service pods.
+// The path of the mount directory is returned if key is empty. The backup service mounts the secrets of its own
+// namespace only, so the secret must be in the namespace of the backup service.
+func getBackupServiceSecretPath(
+	backupService *asdbv1beta1.AerospikeBackupService, secretNamespace, secretName, key string,
+) (string, error) {
+	if secretNamespace != backupService.Namespace {
+		return "", fmt.Errorf("secret %s/%s is not in the namespace of backup service %s/%s", secretNamespace,
+			secretName, backupService.Namespace, backupService.Name)
+	}
+
+	for idx := range backupService.Spec.SecretMounts {
+		secretMount := &backupService.Spec.SecretMounts[idx]
+		if secretMount.SecretName != secretName {
+			continue
+		}
+
+		switch secretMount.VolumeMount.SubPath {
+		case "":
+			return path.Join(secretMount.VolumeMount.MountPath, key), nil
+		case key:
+			return secretMount.VolumeMount.MountPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("secret %s is not mounted in backup service %s/%s, add it to the secrets of the backup "+
+		"service", secretName, backupService.Namespace, backupService.Name)
+}
+
+// deleteDestinationCluster deletes the destination cluster after the restore, if asked for.
+// The cluster is kept for inspection if the restored data could not be verified.
+func (r *SingleRestoreReconciler) deleteDestinationCluster() error {
+	if r.aeroRestore.Spec.Verification != nil && (r.aeroRestore.Status.Verification == nil ||
+		r.aeroRestore.Status.Verification.Phase != asdbv1beta1.RestoreVerified) {
+		return nil
+	}
+
+	if r.aeroRestore.Spec.DestinationCluster == nil || !r.aeroRestore.Spec.DestinationCluster.DeleteAfterRestore ||
+		(r.aeroRestore.Status.DestinationCluster != nil &&
+			r.aeroRestore.Status.DestinationCluster.Phase == asdbv1beta1.RestoreDestinationClusterDeleted) {
+		return nil
+	}
+
+	aeroCluster := &asdbv1.AerospikeCluster{}
+
+	if err := r.Client.Get(context.TODO(), r.destinationClusterNamespacedName(), aeroCluster); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else if aeroCluster.Labels[restoreLabel] == r.aeroRestore.Name {
+		r.Log.Info("Deleting destination cluster", "cluster", aeroCluster.Name)
+
+		if err := r.Client.Delete(context.TODO(), aeroCluster); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "DestinationClusterDeleted",
+			"Deleted destination cluster %s/%s", aeroCluster.Namespace, aeroCluster.Name)
+	}
+
+	if r.aeroRestore.Status.DestinationCluster == nil {
+		r.aeroRestore.Status.DestinationCluster = &asdbv1beta1.RestoreDestinationClusterStatus{
+			Name: r.aeroRestore.Spec.DestinationCluster.Name,
+		}
+	}
+
+	r.aeroRestore.Status.DestinationCluster.Phase = asdbv1beta1.RestoreDestinationClusterDeleted
+
+	return r.Client.Status().Update(context.TODO(), r.aeroRestore)
+}
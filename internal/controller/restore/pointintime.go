@@ -0,0 +1,167 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
+)
+
+// reconcileRestoreChain plans the chain of backups for the point in time restore from the backups of the routine,
+// and sets it in the status before the restore jobs are triggered.
+// The restore fails if no valid chain is found.
+func (r *SingleRestoreReconciler) reconcileRestoreChain(serviceClient *backup_service.Client) common.ReconcileResult {
+	if r.aeroRestore.Status.RestoreChain != nil {
+		return common.ReconcileSuccess()
+	}
+
+	pointInTime := r.aeroRestore.Spec.PointInTime
+
+	routines, err := serviceClient.GetBackupRoutines(context.TODO())
+	if err != nil {
+		return common.ReconcileError(err)
+	}
+
+	routine, ok := routines[pointInTime.Routine]
+	if !ok || routine == nil {
+		return r.failRestoreChainPlan(fmt.Errorf("backup routine %s not found", pointInTime.Routine))
+	}
+
+	fullBackups, err := serviceClient.GetFullBackupsForRoutine(context.TODO(), pointInTime.Routine)
+	if err != nil {
+		return common.ReconcileError(err)
+	}
+
+	incrementalBackups, err := serviceClient.GetIncrementalBackupsForRoutine(context.TODO(), pointInTime.Routine)
+	if err != nil {
+		return common.ReconcileError(err)
+	}
+
+	chain, err := backup_service.PlanRestoreChain(fullBackups, incrementalBackups, pointInTime.Time.Time)
+	if err != nil {
+		return r.failRestoreChainPlan(err)
+	}
+
+	chain.Storage = routine.Storage
+
+	r.Log.Info("Planned restore chain", "fullBackup", chain.FullBackup.Keys,
+		"incrementalBackups", len(chain.IncrementalBackups))
+	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreChainPlanned",
+		"Planned restore chain %s/%s with full backup created at %s and %d incremental backups",
+		r.aeroRestore.Namespace, r.aeroRestore.Name, chain.FullBackup.Created.UTC().Format(time.RFC3339),
+		len(chain.IncrementalBackups))
+
+	r.aeroRestore.Status.RestoreChain = chain
+
+	if err := r.Client.Status().Update(context.TODO(), r.aeroRestore); err != nil {
+		return common.ReconcileError(fmt.Errorf("failed to set restore chain in status: %v", err))
+	}
+
+	return common.ReconcileSuccess()
+}
+
+// failRestoreChainPlan moves the restore to the Failed phase, as the backups of the routine can not be restored as
+// of the time.
+func (r *SingleRestoreReconciler) failRestoreChainPlan(err error) common.ReconcileResult {
+	r.Log.Error(err, "Failed to plan restore chain", "routine", r.aeroRestore.Spec.PointInTime.Routine)
+	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeWarning, "RestoreChainPlanFailed",
+		"Failed to plan restore chain %s/%s: %v", r.aeroRestore.Namespace, r.aeroRestore.Name, err)
+
+	r.aeroRestore.Status.Phase = asdbv1beta1.AerospikeRestoreFailed
+
+	if uErr := r.Client.Status().Update(context.TODO(), r.aeroRestore); uErr != nil {
+		return common.ReconcileError(uErr)
+	}
+
+	// Don't requeue as the backups of the routine can not be restored as of the time.
+	return common.ReconcileError(reconcile.TerminalError(err))
+}
+
+// getNextRestoreChainKey returns the key of the next backup of the chain to restore, or an empty string once all
+// the backups of the chain are restored.
+func getNextRestoreChainKey(chain *asdbv1beta1.RestoreChain) string {
+	for _, key := range backup_service.GetRestoreChainKeys(chain) {
+		if !slices.Contains(chain.RestoredKeys, key) {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// setRestoreChainKeyConfig sets the restore config to restore the next backup of the chain, and returns the restore
+// type of the backup.
+func setRestoreChainKeyConfig(
+	chain *asdbv1beta1.RestoreChain, restoreConfig []byte,
+) ([]byte, asdbv1beta1.RestoreType, error) {
+	key := getNextRestoreChainKey(chain)
+	if key == "" {
+		return nil, "", fmt.Errorf("all the backups of the restore chain are already restored")
+	}
+
+	restoreType := asdbv1beta1.Incremental
+	if slices.Contains(chain.FullBackup.Keys, key) {
+		restoreType = asdbv1beta1.Full
+	}
+
+	restoreConfig, err := backup_service.SetRestoreBackupDataPath(restoreConfig, chain.Storage, key)
+
+	return restoreConfig, restoreType, err
+}
+
+// updateRestoreChain returns the status of the restore job of the chain with the record counts summed over the
+// restored backups of the chain. Once the job is done, its key is recorded as restored, and true is returned if
+// any backup of the chain is left to restore, in which case the returned status is kept Running.
+func (r *SingleRestoreReconciler) updateRestoreChain(
+	restoreStatus *dto.RestoreJobStatus,
+) (*dto.RestoreJobStatus, bool, error) {
+	chain := r.aeroRestore.Status.RestoreChain
+
+	total := *restoreStatus
+
+	if len(chain.RestoredResult.Raw) != 0 {
+		var restored dto.RestoreJobStatus
+
+		if err := json.Unmarshal(chain.RestoredResult.Raw, &restored); err != nil {
+			return nil, false, fmt.Errorf("invalid restored result of restore chain: %v", err)
+		}
+
+		backup_service.AddRestoreJobStatus(&total, &restored)
+	}
+
+	if restoreStatus.Status != dto.JobStatusDone {
+		return &total, false, nil
+	}
+
+	key := getNextRestoreChainKey(chain)
+
+	restored := total
+	restored.CurrentRestore = nil
+
+	restoredBytes, err := json.Marshal(&restored)
+	if err != nil {
+		return nil, false, err
+	}
+
+	chain.RestoredKeys = append(chain.RestoredKeys, key)
+	chain.RestoredResult.Raw = restoredBytes
+
+	r.Log.Info("Restored backup of restore chain", "key", key)
+
+	if getNextRestoreChainKey(chain) == "" {
+		return &total, false, nil
+	}
+
+	total.Status = dto.JobStatusRunning
+
+	return &total, true, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -15,6 +16,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
 	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
 	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
@@ -51,11 +54,32 @@ func (r *SingleRestoreReconciler) Reconcile() (result ctrl.Result, recErr error)
 	}
 
 	if r.aeroRestore.Status.Phase == asdbv1beta1.AerospikeRestoreCompleted {
+		if err := r.reconcilePostRestore(); err != nil {
+			r.Log.Error(err, "Failed to reconcile post restore")
+			return reconcile.Result{}, err
+		}
+
 		// Stop reconciliation as the Aerospike restore is already completed
 		r.Log.Info("Restore already completed, skipping reconciliation")
 		return reconcile.Result{}, nil
 	}
 
+	if r.aeroRestore.Status.Phase == asdbv1beta1.AerospikeRestoreFailed ||
+		r.aeroRestore.Status.Phase == asdbv1beta1.AerospikeRestoreCancelled {
+		// Stop reconciliation as the Aerospike restore has already failed or is cancelled
+		r.Log.Info(fmt.Sprintf("Restore already %s, skipping reconciliation", r.aeroRestore.Status.Phase))
+		return reconcile.Result{}, nil
+	}
+
+	if r.aeroRestore.Spec.Cancel {
+		if err := r.cancelRestore(); err != nil {
+			r.Log.Error(err, "Failed to cancel restore")
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
 	if err := r.setStatusPhase(asdbv1beta1.AerospikeRestoreInProgress); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -86,12 +110,28 @@ func (r *SingleRestoreReconciler) Reconcile() (result ctrl.Result, recErr error)
 		return ctrl.Result{}, err
 	}
 
+	if r.aeroRestore.Status.NextAttemptTime != nil {
+		return ctrl.Result{RequeueAfter: time.Until(r.aeroRestore.Status.NextAttemptTime.Time)}, nil
+	}
+
 	if r.aeroRestore.Status.Phase == asdbv1beta1.AerospikeRestoreInProgress {
+		// The restore job of the next backup of the restore chain is triggered right away.
+		if r.aeroRestore.Status.JobID == nil {
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+
 		return ctrl.Result{RequeueAfter: r.aeroRestore.Spec.PollingPeriod.Duration}, nil
 	}
 
-	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreCompleted",
-		"Restore completed successfully %s/%s", r.aeroRestore.Namespace, r.aeroRestore.Name)
+	if r.aeroRestore.Status.Phase == asdbv1beta1.AerospikeRestoreCompleted {
+		r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreCompleted",
+			"Restore completed successfully %s/%s", r.aeroRestore.Namespace, r.aeroRestore.Name)
+
+		if err := r.reconcilePostRestore(); err != nil {
+			r.Log.Error(err, "Failed to reconcile post restore")
+			return ctrl.Result{}, err
+		}
+	}
 
 	r.Log.Info("Reconcile completed successfully")
 
@@ -104,36 +144,95 @@ func (r *SingleRestoreReconciler) reconcileRestore() common.ReconcileResult {
 		return common.ReconcileSuccess()
 	}
 
+	if nextAttemptTime := r.aeroRestore.Status.NextAttemptTime; nextAttemptTime != nil &&
+		time.Now().Before(nextAttemptTime.Time) {
+		r.Log.Info("Waiting for the next restore attempt", "nextAttemptTime", nextAttemptTime.Time)
+		return common.ReconcileRequeueAfter(int(time.Until(nextAttemptTime.Time).Seconds()) + 1)
+	}
+
 	serviceClient, err := backup_service.GetBackupServiceClient(r.Client, &r.aeroRestore.Spec.BackupService)
 	if err != nil {
 		return common.ReconcileError(err)
 	}
 
-	var (
-		jobID      *int64
-		statusCode *int
-	)
+	if r.aeroRestore.Spec.PointInTime != nil {
+		if res := r.reconcileRestoreChain(serviceClient); !res.IsSuccess {
+			return res
+		}
+	}
+
+	var aeroCluster *asdbv1.AerospikeCluster
+
+	if r.aeroRestore.Spec.DestinationCluster != nil {
+		var ready bool
+
+		aeroCluster, ready, err = r.reconcileDestinationCluster()
+		if err != nil {
+			return common.ReconcileError(err)
+		}
+
+		if !ready {
+			return common.ReconcileRequeueAfter(destinationClusterPollingPeriod)
+		}
+	}
 
-	switch r.aeroRestore.Spec.Type {
+	restoreConfig, err := r.getRestoreConfig(aeroCluster)
+	if err != nil {
+		return common.ReconcileError(err)
+	}
+
+	restoreType := r.aeroRestore.Spec.Type
+
+	var resumedFromKey string
+
+	// The backups of the planned chain are restored one by one, a new attempt restarts from the full backup
+	// unless resumed.
+	if chain := r.aeroRestore.Status.RestoreChain; chain != nil {
+		if !r.isAttemptInProgress() {
+			if r.isResumeAttempt() {
+				resumedFromKey = getNextRestoreChainKey(chain)
+
+				r.Log.Info("Resuming restore chain", "key", resumedFromKey, "restoredKeys", len(chain.RestoredKeys))
+			} else {
+				chain.RestoredKeys = nil
+				chain.RestoredResult.Raw = nil
+			}
+		}
+
+		if restoreConfig, restoreType, err = setRestoreChainKeyConfig(chain, restoreConfig); err != nil {
+			return common.ReconcileError(err)
+		}
+	}
+
+	skipExistingRecords := r.isSkipExistingRecordsAttempt()
+	if skipExistingRecords {
+		if restoreConfig, err = backup_service.SetRestoreUnique(restoreConfig); err != nil {
+			return common.ReconcileError(err)
+		}
+	}
+
+	var jobID int64
+
+	switch restoreType {
 	case asdbv1beta1.Full:
-		jobID, statusCode, err = serviceClient.TriggerRestoreWithType(r.Log, string(asdbv1beta1.Full),
-			r.aeroRestore.Spec.Config.Raw)
+		jobID, err = serviceClient.TriggerRestoreWithType(context.TODO(), r.Log, string(asdbv1beta1.Full),
+			restoreConfig)
 
 	case asdbv1beta1.Incremental:
-		jobID, statusCode, err = serviceClient.TriggerRestoreWithType(r.Log, string(asdbv1beta1.Incremental),
-			r.aeroRestore.Spec.Config.Raw)
+		jobID, err = serviceClient.TriggerRestoreWithType(context.TODO(), r.Log, string(asdbv1beta1.Incremental),
+			restoreConfig)
 
 	case asdbv1beta1.Timestamp:
-		jobID, statusCode, err = serviceClient.TriggerRestoreWithType(r.Log, string(asdbv1beta1.Timestamp),
-			r.aeroRestore.Spec.Config.Raw)
+		jobID, err = serviceClient.TriggerRestoreWithType(context.TODO(), r.Log, string(asdbv1beta1.Timestamp),
+			restoreConfig)
 
 	default:
 		return common.ReconcileError(fmt.Errorf("unsupported restore type"))
 	}
 
 	if err != nil {
-		if statusCode != nil && *statusCode == http.StatusBadRequest {
-			r.Log.Error(err, fmt.Sprintf("Failed to trigger restore with status code %d", *statusCode))
+		if statusCode := backup_service.StatusCode(err); statusCode == http.StatusBadRequest {
+			r.Log.Error(err, fmt.Sprintf("Failed to trigger restore with status code %d", statusCode))
 
 			r.aeroRestore.Status.Phase = asdbv1beta1.AerospikeRestoreFailed
 
@@ -149,10 +248,11 @@ func (r *SingleRestoreReconciler) reconcileRestore() common.ReconcileResult {
 		return common.ReconcileError(err)
 	}
 
-	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreTriggered",
-		"Triggered restore %s/%s", r.aeroRestore.Namespace, r.aeroRestore.Name)
+	r.addAttempt(jobID, skipExistingRecords, resumedFromKey)
 
-	r.aeroRestore.Status.JobID = jobID
+	r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreTriggered",
+		"Triggered %s restore %s/%s, attempt %d", restoreType, r.aeroRestore.Namespace, r.aeroRestore.Name,
+		len(r.aeroRestore.Status.Attempts))
 
 	if err = r.Client.Status().Update(context.Background(), r.aeroRestore); err != nil {
 		r.Log.Error(err, fmt.Sprintf("Failed to update restore status to %+v", err))
@@ -168,15 +268,30 @@ func (r *SingleRestoreReconciler) checkRestoreStatus() error {
 		return err
 	}
 
-	restoreStatus, err := serviceClient.CheckRestoreStatus(*r.aeroRestore.Status.JobID)
+	restoreStatus, err := serviceClient.CheckRestoreStatus(context.TODO(), *r.aeroRestore.Status.JobID)
 	if err != nil {
 		return err
 	}
 
 	r.Log.Info(fmt.Sprintf("Restore status: %+v", restoreStatus))
 
-	if status, ok := restoreStatus["status"]; ok {
-		r.aeroRestore.Status.Phase = statusToPhase(status.(string))
+	var chainPending bool
+
+	if r.aeroRestore.Status.RestoreChain != nil {
+		if restoreStatus, chainPending, err = r.updateRestoreChain(restoreStatus); err != nil {
+			return err
+		}
+	}
+
+	if restoreStatus.Status != "" {
+		r.aeroRestore.Status.Phase = statusToPhase(restoreStatus.Status)
+	}
+
+	r.updateAttempt(restoreStatus)
+
+	// The restore job of the next backup of the chain is triggered by the next reconcile.
+	if chainPending {
+		r.aeroRestore.Status.JobID = nil
 	}
 
 	statusBytes, err := json.Marshal(restoreStatus)
@@ -186,6 +301,11 @@ func (r *SingleRestoreReconciler) checkRestoreStatus() error {
 
 	r.aeroRestore.Status.RestoreResult.Raw = statusBytes
 
+	if r.aeroRestore.Status.Phase == asdbv1beta1.AerospikeRestoreFailed && !r.scheduleRetry(restoreStatus.Error) {
+		r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeWarning, "RestoreFailed",
+			"Restore failed %s/%s: %s", r.aeroRestore.Namespace, r.aeroRestore.Name, restoreStatus.Error)
+	}
+
 	if err = r.Client.Status().Update(context.Background(), r.aeroRestore); err != nil {
 		r.Log.Error(err, fmt.Sprintf("Failed to update restore status to %+v", err))
 		return err
@@ -254,8 +374,8 @@ func (r *SingleRestoreReconciler) cancelRestoreJob() error {
 		return err
 	}
 
-	if statusCode, err := serviceClient.CancelRestoreJob(*r.aeroRestore.Status.JobID); err != nil {
-		if statusCode == http.StatusNotFound {
+	if err := serviceClient.CancelRestoreJob(context.TODO(), *r.aeroRestore.Status.JobID); err != nil {
+		if backup_service.StatusCode(err) == http.StatusNotFound {
 			r.Log.Info("Restore job not found, skipping cancel")
 			return nil
 		}
@@ -268,16 +388,19 @@ func (r *SingleRestoreReconciler) cancelRestoreJob() error {
 	return nil
 }
 
-func statusToPhase(status string) asdbv1beta1.AerospikeRestorePhase {
+func statusToPhase(status dto.JobStatus) asdbv1beta1.AerospikeRestorePhase {
 	switch status {
-	case "Done":
+	case dto.JobStatusDone:
 		return asdbv1beta1.AerospikeRestoreCompleted
 
-	case "Running":
+	case dto.JobStatusRunning:
 		return asdbv1beta1.AerospikeRestoreInProgress
 
-	case "Failed":
+	case dto.JobStatusFailed:
 		return asdbv1beta1.AerospikeRestoreFailed
+
+	case dto.JobStatusCancelled:
+		return asdbv1beta1.AerospikeRestoreCancelled
 	}
 
 	return ""
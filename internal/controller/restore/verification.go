@@ -0,0 +1,291 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/cluster"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+// reconcilePostRestore verifies the restored data and deletes the destination cluster once the restore is completed.
+func (r *SingleRestoreReconciler) reconcilePostRestore() error {
+	if r.aeroRestore.Spec.Verification != nil && r.aeroRestore.Status.Verification == nil {
+		if err := r.verifyRestore(); err != nil {
+			return err
+		}
+	}
+
+	return r.deleteDestinationCluster()
+}
+
+// verifyRestore compares the object counts of the namespaces in the destination cluster against the records
+// in the backup and sets the verification status.
+// An error is returned only if the destination cluster could not be queried, so that the verification is retried.
+func (r *SingleRestoreReconciler) verifyRestore() error {
+	r.Log.Info("Verifying restored data")
+
+	verification := r.aeroRestore.Spec.Verification
+
+	var restoreResult dto.RestoreJobStatus
+
+	if err := json.Unmarshal(r.aeroRestore.Status.RestoreResult.Raw, &restoreResult); err != nil {
+		return r.setVerificationStatus(asdbv1beta1.RestoreVerificationFailed, 0, nil,
+			fmt.Sprintf("invalid restore result: %v", err))
+	}
+
+	snapshot := r.getBackupSnapshot()
+
+	namespaces, err := r.getVerificationNamespaces(snapshot)
+	if err != nil {
+		return r.setVerificationStatus(asdbv1beta1.RestoreVerificationFailed, 0, nil, err.Error())
+	}
+
+	aeroCluster := &asdbv1.AerospikeCluster{}
+
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{
+		Name: r.getVerificationClusterName(), Namespace: r.aeroRestore.Namespace,
+	}, aeroCluster); err != nil {
+		return err
+	}
+
+	objectCounts, err := r.getObjectCounts(aeroCluster, namespaces)
+	if err != nil {
+		return err
+	}
+
+	backupRecords := int64(restoreResult.ReadRecords)
+	if snapshot != nil {
+		backupRecords = snapshot.Spec.RecordCount
+	}
+
+	// Expired and skipped records are dropped by the restore as expected.
+	expectedObjects := backupRecords - int64(restoreResult.ExpiredRecords) - int64(restoreResult.SkippedRecords) -
+		int64(restoreResult.IgnoredRecords)
+	failedRecords := int64(restoreResult.IgnoredRecords) + int64(restoreResult.ErrorsInDoubt)
+
+	var objects int64
+	for idx := range objectCounts {
+		objects += objectCounts[idx].Objects
+	}
+
+	var messages []string
+
+	if restoreResult.Error != "" {
+		messages = append(messages, fmt.Sprintf("restore error: %s", restoreResult.Error))
+	}
+
+	if snapshot != nil && int64(restoreResult.ReadRecords) < snapshot.Spec.RecordCount {
+		messages = append(messages, fmt.Sprintf("restore read %d of %d records in backup",
+			restoreResult.ReadRecords, snapshot.Spec.RecordCount))
+	}
+
+	if failedRecords > verification.MaxFailedRecords {
+		messages = append(messages, fmt.Sprintf("%d records failed to restore, tolerated %d",
+			failedRecords, verification.MaxFailedRecords))
+	}
+
+	if objects < expectedObjects {
+		messages = append(messages, fmt.Sprintf("%d objects found in namespaces %v, expected at least %d",
+			objects, namespaces, expectedObjects))
+	}
+
+	messages = append(messages, checkSetObjects(verification.Sets, objectCounts)...)
+
+	if len(messages) != 0 {
+		return r.setVerificationStatus(asdbv1beta1.RestoreVerificationFailed, expectedObjects, objectCounts,
+			strings.Join(messages, "; "))
+	}
+
+	return r.setVerificationStatus(asdbv1beta1.RestoreVerified, expectedObjects, objectCounts, "")
+}
+
+func (r *SingleRestoreReconciler) setVerificationStatus(
+	phase asdbv1beta1.RestoreVerificationPhase, expectedObjects int64,
+	objectCounts []asdbv1beta1.NamespaceObjectCount, message string,
+) error {
+	r.aeroRestore.Status.Verification = &asdbv1beta1.RestoreVerificationStatus{
+		Phase:            phase,
+		ExpectedObjects:  expectedObjects,
+		Namespaces:       objectCounts,
+		Message:          message,
+		VerificationTime: &metav1.Time{Time: time.Now()},
+	}
+
+	if phase == asdbv1beta1.RestoreVerified {
+		r.Log.Info("Verified restored data")
+		r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeNormal, "RestoreVerified",
+			"Verified restored data %s/%s", r.aeroRestore.Namespace, r.aeroRestore.Name)
+	} else {
+		r.Log.Info("Restored data verification failed", "reason", message)
+		r.Recorder.Eventf(r.aeroRestore, corev1.EventTypeWarning, "RestoreVerificationFailed",
+			"Restored data verification failed %s/%s: %s", r.aeroRestore.Namespace, r.aeroRestore.Name, message)
+	}
+
+	return r.Client.Status().Update(context.TODO(), r.aeroRestore)
+}
+
+func (r *SingleRestoreReconciler) getVerificationClusterName() string {
+	if r.aeroRestore.Spec.Verification.ClusterName != "" {
+		return r.aeroRestore.Spec.Verification.ClusterName
+	}
+
+	return r.aeroRestore.Spec.DestinationCluster.Name
+}
+
+// getBackupSnapshot returns the AerospikeBackupSnapshot restored from, if it still exists.
+func (r *SingleRestoreReconciler) getBackupSnapshot() *asdbv1beta1.AerospikeBackupSnapshot {
+	if r.aeroRestore.Spec.BackupSnapshotName == "" {
+		return nil
+	}
+
+	snapshot := &asdbv1beta1.AerospikeBackupSnapshot{}
+
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{
+		Name: r.aeroRestore.Spec.BackupSnapshotName, Namespace: r.aeroRestore.Namespace,
+	}, snapshot); err != nil {
+		r.Log.Info("Failed to get AerospikeBackupSnapshot, verifying against the restore result",
+			"snapshot", r.aeroRestore.Spec.BackupSnapshotName, "err", err.Error())
+
+		return nil
+	}
+
+	return snapshot
+}
+
+// getVerificationNamespaces returns the namespaces the data is restored into.
+func (r *SingleRestoreReconciler) getVerificationNamespaces(
+	snapshot *asdbv1beta1.AerospikeBackupSnapshot,
+) ([]string, error) {
+	if len(r.aeroRestore.Spec.Verification.Namespaces) != 0 {
+		return r.aeroRestore.Spec.Verification.Namespaces, nil
+	}
+
+	var restoreRequest dto.RestoreRequest
+
+	if err := yaml.Unmarshal(r.aeroRestore.Spec.Config.Raw, &restoreRequest); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case restoreRequest.Policy != nil && restoreRequest.Policy.Namespace != nil &&
+		restoreRequest.Policy.Namespace.Destination != nil:
+		return []string{*restoreRequest.Policy.Namespace.Destination}, nil
+
+	case snapshot != nil:
+		return []string{snapshot.Spec.AerospikeNamespace}, nil
+
+	case restoreRequest.BackupDataPath != "":
+		return []string{path.Base(restoreRequest.BackupDataPath)}, nil
+	}
+
+	return nil, fmt.Errorf("namespaces to verify could not be derived from the restore config")
+}
+
+// getObjectCounts returns the master object counts of the namespaces and their sets in the cluster.
+func (r *SingleRestoreReconciler) getObjectCounts(
+	aeroCluster *asdbv1.AerospikeCluster, namespaces []string,
+) ([]asdbv1beta1.NamespaceObjectCount, error) {
+	if len(aeroCluster.Status.Pods) == 0 {
+		return nil, fmt.Errorf("no pods found in AerospikeCluster %s status", aeroCluster.Name)
+	}
+
+	policy := cluster.GetClientPolicy(r.Client, aeroCluster, r.Log)
+
+	cmds := make([]string, 0, len(namespaces)*2)
+	for _, namespace := range namespaces {
+		cmds = append(cmds, "namespace/"+namespace, "sets/"+namespace)
+	}
+
+	objectCounts := make([]asdbv1beta1.NamespaceObjectCount, 0, len(namespaces))
+	replicaSetObjects := make([]map[string]int64, len(namespaces))
+	replicationFactors := make([]int64, len(namespaces))
+
+	for _, namespace := range namespaces {
+		objectCounts = append(objectCounts, asdbv1beta1.NamespaceObjectCount{Name: namespace})
+	}
+
+	for podName := range aeroCluster.Status.Pods {
+		asConn := cluster.NewASConn(aeroCluster, podName, aeroCluster.Status.Pods[podName].PodIP, r.Log)
+
+		res, err := asConn.RunInfo(policy, cmds...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get object counts from pod %s: %v", podName, err)
+		}
+
+		for idx, namespace := range namespaces {
+			masterObjects, replicationFactor, err := utils.ParseNamespaceObjects(res["namespace/"+namespace])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get objects of namespace %s from pod %s: %v", namespace, podName, err)
+			}
+
+			setObjects, err := utils.ParseSetObjects(res["sets/"+namespace])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get set objects of namespace %s from pod %s: %v",
+					namespace, podName, err)
+			}
+
+			objectCounts[idx].Objects += masterObjects
+			replicationFactors[idx] = max(replicationFactors[idx], replicationFactor)
+
+			if replicaSetObjects[idx] == nil {
+				replicaSetObjects[idx] = make(map[string]int64)
+			}
+
+			for set, objects := range setObjects {
+				replicaSetObjects[idx][set] += objects
+			}
+		}
+	}
+
+	// Set objects include the replica objects.
+	for idx := range objectCounts {
+		if len(replicaSetObjects[idx]) == 0 {
+			continue
+		}
+
+		objectCounts[idx].Sets = make(map[string]int64, len(replicaSetObjects[idx]))
+
+		for set, objects := range replicaSetObjects[idx] {
+			objectCounts[idx].Sets[set] = objects / max(replicationFactors[idx], 1)
+		}
+	}
+
+	return objectCounts, nil
+}
+
+func checkSetObjects(
+	sets []asdbv1beta1.RestoreVerificationSet, objectCounts []asdbv1beta1.NamespaceObjectCount,
+) []string {
+	setObjects := make(map[string]map[string]int64, len(objectCounts))
+	for idx := range objectCounts {
+		setObjects[objectCounts[idx].Name] = objectCounts[idx].Sets
+	}
+
+	var messages []string
+
+	for idx := range sets {
+		objects := setObjects[sets[idx].Namespace][sets[idx].Set]
+		if objects < sets[idx].MinObjects {
+			messages = append(messages, fmt.Sprintf("%d objects found in set %s/%s, expected at least %d",
+				objects, sets[idx].Namespace, sets[idx].Set, sets[idx].MinObjects))
+		}
+	}
+
+	sort.Strings(messages)
+
+	return messages
+}
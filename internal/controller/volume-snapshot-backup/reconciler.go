@@ -0,0 +1,538 @@
+package volumesnapshotbackup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/cluster"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const (
+	// backupSnapshotType is the snapshot type label value for snapshots taken by AerospikeVolumeSnapshotBackup.
+	backupSnapshotType = "backup"
+
+	snapshotSetIDFormat = "20060102-150405"
+
+	snapshotSetPollInterval = 10 * time.Second
+	clusterNotReadyRetry    = 30
+)
+
+// SingleVolumeSnapshotBackupReconciler reconciles a single AerospikeVolumeSnapshotBackup object
+type SingleVolumeSnapshotBackupReconciler struct {
+	client.Client
+	Recorder           record.EventRecorder
+	aeroSnapshotBackup *asdbv1beta1.AerospikeVolumeSnapshotBackup
+	Scheme             *k8sRuntime.Scheme
+	Log                logr.Logger
+}
+
+func (r *SingleVolumeSnapshotBackupReconciler) Reconcile() (result ctrl.Result, recErr error) {
+	// VolumeSnapshots are owned by the AerospikeVolumeSnapshotBackup, they are garbage collected along with it.
+	if !r.aeroSnapshotBackup.DeletionTimestamp.IsZero() {
+		r.Log.Info("Deleting AerospikeVolumeSnapshotBackup")
+		return ctrl.Result{}, nil
+	}
+
+	aeroCluster := &asdbv1.AerospikeCluster{}
+	if err := r.Get(context.TODO(), types.NamespacedName{
+		Name: r.aeroSnapshotBackup.Spec.ClusterName, Namespace: r.aeroSnapshotBackup.Namespace,
+	}, aeroCluster); err != nil {
+		if errors.IsNotFound(err) {
+			r.Log.Info("AerospikeCluster not found, retrying later", "cluster", r.aeroSnapshotBackup.Spec.ClusterName)
+
+			return common.ReconcileRequeueAfter(clusterNotReadyRetry).Result, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateSnapshotSetsPhase(aeroCluster); err != nil {
+		r.Log.Error(err, "Failed to check snapshot sets")
+		return ctrl.Result{}, err
+	}
+
+	res := r.scheduleSnapshotSet(aeroCluster)
+	if res.Err != nil {
+		r.Log.Error(res.Err, "Failed to take snapshot set")
+		r.Recorder.Eventf(r.aeroSnapshotBackup, corev1.EventTypeWarning, "SnapshotSetFailed",
+			"Failed to take snapshot set of AerospikeCluster %s/%s", aeroCluster.Namespace, aeroCluster.Name)
+	}
+
+	if err := r.applyRetention(); err != nil {
+		r.Log.Error(err, "Failed to apply retention")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Client.Status().Update(context.TODO(), r.aeroSnapshotBackup); err != nil {
+		r.Log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	if !res.IsSuccess {
+		return res.Result, res.Err
+	}
+
+	r.Log.Info("Reconcile completed successfully")
+
+	return ctrl.Result{RequeueAfter: r.getRequeueAfter(time.Now())}, nil
+}
+
+// updateSnapshotSetsPhase updates the phase of the in-progress snapshot sets as per their VolumeSnapshots, and runs
+// the postSnapshot info hooks of the sets once all their VolumeSnapshots are cut or the sets failed.
+func (r *SingleVolumeSnapshotBackupReconciler) updateSnapshotSetsPhase(aeroCluster *asdbv1.AerospikeCluster) error {
+	snapshotSets := r.aeroSnapshotBackup.Status.SnapshotSets
+
+	for idx := range snapshotSets {
+		snapshotSet := &snapshotSets[idx]
+
+		if snapshotSet.Phase != asdbv1beta1.VolumeSnapshotSetInProgress && !snapshotSet.PostSnapshotPending {
+			continue
+		}
+
+		snapshots, err := r.getSnapshotSetSnapshots(snapshotSet.ID)
+		if err != nil {
+			return err
+		}
+
+		if snapshotSet.Phase == asdbv1beta1.VolumeSnapshotSetInProgress {
+			r.updateSnapshotSetPhase(snapshotSet, snapshots)
+		}
+
+		if snapshotSet.PostSnapshotPending && (snapshotSet.Phase != asdbv1beta1.VolumeSnapshotSetInProgress ||
+			utils.AreVolumeSnapshotsCut(snapshots, snapshotSet.SnapshotCount)) {
+			r.runPostSnapshotHooks(aeroCluster, snapshotSet)
+		}
+	}
+
+	return nil
+}
+
+func (r *SingleVolumeSnapshotBackupReconciler) updateSnapshotSetPhase(
+	snapshotSet *asdbv1beta1.VolumeSnapshotSet, snapshots []unstructured.Unstructured,
+) {
+	now := time.Now()
+
+	phase, message := utils.GetVolumeSnapshotSetPhase(
+		snapshots, snapshotSet.SnapshotCount, snapshotSet.StartTime.Time,
+		r.aeroSnapshotBackup.Spec.SnapshotWindow.Duration, now,
+	)
+
+	snapshotSet.Phase = phase
+	snapshotSet.Message = message
+
+	switch phase {
+	case asdbv1beta1.VolumeSnapshotSetCompleted:
+		snapshotSet.CompletionTime = &metav1.Time{Time: now}
+
+		r.Recorder.Eventf(r.aeroSnapshotBackup, corev1.EventTypeNormal, "SnapshotSetCompleted",
+			"Snapshot set %s completed with %d VolumeSnapshots", snapshotSet.ID, snapshotSet.SnapshotCount)
+
+	case asdbv1beta1.VolumeSnapshotSetFailed:
+		r.Recorder.Eventf(r.aeroSnapshotBackup, corev1.EventTypeWarning, "SnapshotSetFailed",
+			"Snapshot set %s failed: %s", snapshotSet.ID, message)
+
+	case asdbv1beta1.VolumeSnapshotSetInProgress:
+	}
+}
+
+// scheduleSnapshotSet takes a new snapshot set if one is due.
+// Only one snapshot set is in progress at a time.
+func (r *SingleVolumeSnapshotBackupReconciler) scheduleSnapshotSet(
+	aeroCluster *asdbv1.AerospikeCluster,
+) common.ReconcileResult {
+	status := &r.aeroSnapshotBackup.Status
+
+	for idx := range status.SnapshotSets {
+		if status.SnapshotSets[idx].Phase == asdbv1beta1.VolumeSnapshotSetInProgress {
+			return common.ReconcileSuccess()
+		}
+	}
+
+	if nextTime := r.getNextScheduleTime(); nextTime == nil || time.Now().Before(*nextTime) {
+		return common.ReconcileSuccess()
+	}
+
+	// Without info hooks, each snapshot is only crash consistent. Snapshots are not taken while pods are being
+	// restarted or the cluster is being scaled, to avoid snapshotting volumes in the middle of these operations.
+	if aeroCluster.Status.Phase != asdbv1.AerospikeClusterCompleted {
+		r.Log.Info("Waiting for AerospikeCluster to be in Completed phase before taking snapshot set",
+			"phase", aeroCluster.Status.Phase)
+
+		return common.ReconcileRequeueAfter(clusterNotReadyRetry)
+	}
+
+	if err := r.takeSnapshotSet(aeroCluster); err != nil {
+		return common.ReconcileError(err)
+	}
+
+	return common.ReconcileSuccess()
+}
+
+// getNextScheduleTime returns the time at which the next snapshot set is due.
+// It returns nil if no more snapshot sets are to be taken.
+func (r *SingleVolumeSnapshotBackupReconciler) getNextScheduleTime() *time.Time {
+	lastScheduleTime := r.aeroSnapshotBackup.Status.LastScheduleTime
+	if lastScheduleTime == nil {
+		now := time.Now()
+		return &now
+	}
+
+	if r.aeroSnapshotBackup.Spec.Interval == nil {
+		return nil
+	}
+
+	nextTime := lastScheduleTime.Add(r.aeroSnapshotBackup.Spec.Interval.Duration)
+
+	return &nextTime
+}
+
+// takeSnapshotSet creates VolumeSnapshots of the persistent volumes of all the cluster pods.
+// The snapshots are created back to back so that they are cut within a short window.
+func (r *SingleVolumeSnapshotBackupReconciler) takeSnapshotSet(aeroCluster *asdbv1.AerospikeCluster) error {
+	now := time.Now()
+	status := &r.aeroSnapshotBackup.Status
+
+	snapshotSet := asdbv1beta1.VolumeSnapshotSet{
+		ID:        now.UTC().Format(snapshotSetIDFormat),
+		Phase:     asdbv1beta1.VolumeSnapshotSetInProgress,
+		StartTime: metav1.Time{Time: now},
+	}
+
+	lastScheduleTime := status.LastScheduleTime
+	status.LastScheduleTime = &metav1.Time{Time: now}
+
+	pvcs, err := r.getSnapshotSetPVCs(aeroCluster)
+	if err != nil || len(pvcs) == 0 {
+		snapshotSet.Phase = asdbv1beta1.VolumeSnapshotSetFailed
+		snapshotSet.Message = "no persistent volume found to snapshot"
+
+		if err != nil {
+			snapshotSet.Message = err.Error()
+		}
+
+		status.SnapshotSets = append(status.SnapshotSets, snapshotSet)
+
+		return err
+	}
+
+	snapshotSet.SnapshotCount = utils.Len32(pvcs)
+	snapshotSet.PostSnapshotPending = r.aeroSnapshotBackup.Spec.InfoHooks != nil
+
+	// The snapshot set is recorded before its VolumeSnapshots are created, so that they are tracked by retention
+	// even if a later status update fails.
+	status.SnapshotSets = append(status.SnapshotSets, snapshotSet)
+
+	if err := r.Client.Status().Update(context.TODO(), r.aeroSnapshotBackup); err != nil {
+		status.SnapshotSets = status.SnapshotSets[:len(status.SnapshotSets)-1]
+		status.LastScheduleTime = lastScheduleTime
+
+		return fmt.Errorf("failed to record snapshot set %s: %v", snapshotSet.ID, err)
+	}
+
+	recordedSet := &r.aeroSnapshotBackup.Status.SnapshotSets[len(r.aeroSnapshotBackup.Status.SnapshotSets)-1]
+
+	if err := r.runPreSnapshotHooks(aeroCluster); err != nil {
+		r.failSnapshotSet(aeroCluster, recordedSet, err)
+		return err
+	}
+
+	if err := r.createSnapshotSetSnapshots(aeroCluster, recordedSet.ID, pvcs); err != nil {
+		r.failSnapshotSet(aeroCluster, recordedSet, err)
+		return err
+	}
+
+	r.Recorder.Eventf(r.aeroSnapshotBackup, corev1.EventTypeNormal, "SnapshotSetStarted",
+		"Started snapshot set %s of AerospikeCluster %s/%s with %d VolumeSnapshots", recordedSet.ID,
+		aeroCluster.Namespace, aeroCluster.Name, recordedSet.SnapshotCount)
+
+	return nil
+}
+
+// failSnapshotSet marks the snapshot set failed while it is taken, and runs its postSnapshot info hooks.
+// The VolumeSnapshots already created are kept labelled with the failed set, so that retention deletes them.
+func (r *SingleVolumeSnapshotBackupReconciler) failSnapshotSet(
+	aeroCluster *asdbv1.AerospikeCluster, snapshotSet *asdbv1beta1.VolumeSnapshotSet, err error,
+) {
+	snapshotSet.Phase = asdbv1beta1.VolumeSnapshotSetFailed
+	snapshotSet.Message = err.Error()
+
+	if snapshotSet.PostSnapshotPending {
+		r.runPostSnapshotHooks(aeroCluster, snapshotSet)
+	}
+}
+
+// runPreSnapshotHooks runs the preSnapshot info hooks on all the cluster pods.
+func (r *SingleVolumeSnapshotBackupReconciler) runPreSnapshotHooks(aeroCluster *asdbv1.AerospikeCluster) error {
+	if r.aeroSnapshotBackup.Spec.InfoHooks == nil {
+		return nil
+	}
+
+	if err := r.runInfoHooks(aeroCluster, r.aeroSnapshotBackup.Spec.InfoHooks.PreSnapshot); err != nil {
+		return fmt.Errorf("failed to run preSnapshot info hooks: %v", err)
+	}
+
+	return nil
+}
+
+// runPostSnapshotHooks runs the postSnapshot info hooks of the snapshot set on all the cluster pods.
+// They are retried by the next reconcile if they fail.
+func (r *SingleVolumeSnapshotBackupReconciler) runPostSnapshotHooks(
+	aeroCluster *asdbv1.AerospikeCluster, snapshotSet *asdbv1beta1.VolumeSnapshotSet,
+) {
+	var cmds []string
+	if r.aeroSnapshotBackup.Spec.InfoHooks != nil {
+		cmds = r.aeroSnapshotBackup.Spec.InfoHooks.PostSnapshot
+	}
+
+	if err := r.runInfoHooks(aeroCluster, cmds); err != nil {
+		r.Log.Error(err, "Failed to run postSnapshot info hooks", "id", snapshotSet.ID)
+		r.Recorder.Eventf(r.aeroSnapshotBackup, corev1.EventTypeWarning, "SnapshotSetPostSnapshotHooksFailed",
+			"Failed to run postSnapshot info hooks of snapshot set %s: %v", snapshotSet.ID, err)
+
+		return
+	}
+
+	snapshotSet.PostSnapshotPending = false
+
+	r.Log.Info("Ran postSnapshot info hooks", "id", snapshotSet.ID)
+}
+
+// runInfoHooks runs the info commands on all the cluster pods.
+func (r *SingleVolumeSnapshotBackupReconciler) runInfoHooks(aeroCluster *asdbv1.AerospikeCluster, cmds []string) error {
+	if len(cmds) == 0 {
+		return nil
+	}
+
+	policy := cluster.GetClientPolicy(r.Client, aeroCluster, r.Log)
+
+	for podName := range aeroCluster.Status.Pods {
+		asConn := cluster.NewASConn(aeroCluster, podName, aeroCluster.Status.Pods[podName].PodIP, r.Log)
+
+		res, err := asConn.RunInfo(policy, cmds...)
+		if err != nil {
+			return fmt.Errorf("failed to run info commands on pod %s: %v", podName, err)
+		}
+
+		for _, cmd := range cmds {
+			if strings.HasPrefix(strings.ToLower(res[cmd]), "error") {
+				return fmt.Errorf("info command %s failed on pod %s: %s", cmd, podName, res[cmd])
+			}
+		}
+	}
+
+	return nil
+}
+
+// getSnapshotSetPVCs returns the bound PVCs of the volumes to snapshot of all the cluster pods.
+func (r *SingleVolumeSnapshotBackupReconciler) getSnapshotSetPVCs(
+	aeroCluster *asdbv1.AerospikeCluster,
+) ([]*corev1.PersistentVolumeClaim, error) {
+	volNames := sets.New(r.aeroSnapshotBackup.Spec.Volumes...)
+	if volNames.Len() == 0 {
+		for idx := range aeroCluster.Spec.RackConfig.Racks {
+			volNames.Insert(utils.GetAerospikePersistentVolumeNames(&aeroCluster.Spec.RackConfig.Racks[idx].Storage)...)
+		}
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(
+		context.TODO(), pvcList, client.InNamespace(aeroCluster.Namespace),
+		client.MatchingLabels(utils.LabelsForAerospikeCluster(aeroCluster.Name)),
+	); err != nil {
+		return nil, err
+	}
+
+	var pvcs []*corev1.PersistentVolumeClaim
+
+	podVolumeCount := map[string]int{}
+
+	for idx := range pvcList.Items {
+		pvc := &pvcList.Items[idx]
+
+		volName := pvc.Annotations[asdbv1.StorageVolumeAnnotationKey]
+		if !volNames.Has(volName) || utils.IsPVCTerminating(pvc) || pvc.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+
+		pvcs = append(pvcs, pvc)
+		podVolumeCount[strings.TrimPrefix(pvc.Name, volName+"-")]++
+	}
+
+	// The snapshots of the volumes of a pod are not consistent with each other.
+	if !r.aeroSnapshotBackup.Spec.AllowMultiVolumePods {
+		for podName, count := range podVolumeCount {
+			if count > 1 {
+				return nil, fmt.Errorf("pod %s has %d volumes to snapshot, which are not snapshotted consistently "+
+					"with each other, set allowMultiVolumePods to allow it", podName, count)
+			}
+		}
+	}
+
+	return pvcs, nil
+}
+
+func (r *SingleVolumeSnapshotBackupReconciler) createSnapshotSetSnapshots(
+	aeroCluster *asdbv1.AerospikeCluster, id string, pvcs []*corev1.PersistentVolumeClaim,
+) error {
+	for _, pvc := range pvcs {
+		volName := pvc.Annotations[asdbv1.StorageVolumeAnnotationKey]
+
+		labels := utils.LabelsForAerospikeCluster(aeroCluster.Name)
+		labels[asdbv1.AerospikeRackIDLabel] = pvc.Labels[asdbv1.AerospikeRackIDLabel]
+		labels[asdbv1.AerospikeVolumeNameLabel] = volName
+		labels[asdbv1.AerospikePodNameLabel] = strings.TrimPrefix(pvc.Name, volName+"-")
+		labels[asdbv1.AerospikeSnapshotTypeLabel] = backupSnapshotType
+		labels[asdbv1.AerospikeSnapshotBackupLabel] = r.aeroSnapshotBackup.Name
+		labels[asdbv1.AerospikeSnapshotSetLabel] = id
+
+		snapshot := utils.NewVolumeSnapshot(
+			aeroCluster.Namespace, fmt.Sprintf("%s-%s-%s", r.aeroSnapshotBackup.Name, id, pvc.Name),
+			r.aeroSnapshotBackup.Spec.VolumeSnapshotClassName, pvc.Name, labels,
+		)
+
+		if err := controllerutil.SetOwnerReference(r.aeroSnapshotBackup, snapshot, r.Scheme); err != nil {
+			return err
+		}
+
+		if err := r.Create(context.TODO(), snapshot); err != nil {
+			return fmt.Errorf("failed to create VolumeSnapshot of PVC %s: %v", pvc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyRetention deletes the snapshot sets which are not to be retained as per the retention spec.
+// In-progress sets, sets with pending postSnapshot info hooks and the latest completed set are always retained.
+func (r *SingleVolumeSnapshotBackupReconciler) applyRetention() error {
+	retention := r.aeroSnapshotBackup.Spec.Retention
+	if retention == nil {
+		return nil
+	}
+
+	snapshotSets := r.aeroSnapshotBackup.Status.SnapshotSets
+
+	latestCompletedIdx := -1
+
+	for idx := range snapshotSets {
+		if snapshotSets[idx].Phase == asdbv1beta1.VolumeSnapshotSetCompleted {
+			latestCompletedIdx = idx
+		}
+	}
+
+	var (
+		retainedSets []asdbv1beta1.VolumeSnapshotSet
+		now          = time.Now()
+	)
+
+	// Iterate from the newest set, so that the oldest sets are deleted first.
+	for idx := len(snapshotSets) - 1; idx >= 0; idx-- {
+		snapshotSet := &snapshotSets[idx]
+
+		expired := (retention.MaxAge != nil && now.Sub(snapshotSet.StartTime.Time) > retention.MaxAge.Duration) ||
+			(retention.MaxSets != nil && len(retainedSets) >= int(*retention.MaxSets))
+
+		if !expired || idx == latestCompletedIdx || snapshotSet.Phase == asdbv1beta1.VolumeSnapshotSetInProgress ||
+			snapshotSet.PostSnapshotPending {
+			retainedSets = append([]asdbv1beta1.VolumeSnapshotSet{*snapshotSet}, retainedSets...)
+			continue
+		}
+
+		if err := r.deleteSnapshotSet(snapshotSet.ID); err != nil {
+			return err
+		}
+
+		r.Recorder.Eventf(r.aeroSnapshotBackup, corev1.EventTypeNormal, "SnapshotSetDeleted",
+			"Deleted snapshot set %s as per retention", snapshotSet.ID)
+	}
+
+	r.aeroSnapshotBackup.Status.SnapshotSets = retainedSets
+
+	return nil
+}
+
+func (r *SingleVolumeSnapshotBackupReconciler) deleteSnapshotSet(id string) error {
+	snapshots, err := r.getSnapshotSetSnapshots(id)
+	if err != nil {
+		return err
+	}
+
+	for idx := range snapshots {
+		if err := r.Delete(context.TODO(), &snapshots[idx]); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete VolumeSnapshot %s: %v", snapshots[idx].GetName(), err)
+		}
+	}
+
+	r.Log.Info("Deleted snapshot set", "id", id, "snapshots", len(snapshots))
+
+	return nil
+}
+
+func (r *SingleVolumeSnapshotBackupReconciler) getSnapshotSetSnapshots(id string) ([]unstructured.Unstructured, error) {
+	snapshotList := utils.NewVolumeSnapshotList()
+	if err := r.List(
+		context.TODO(), snapshotList, client.InNamespace(r.aeroSnapshotBackup.Namespace), client.MatchingLabels{
+			asdbv1.AerospikeSnapshotBackupLabel: r.aeroSnapshotBackup.Name,
+			asdbv1.AerospikeSnapshotSetLabel:    id,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list VolumeSnapshots of snapshot set %s: %v", id, err)
+	}
+
+	return snapshotList.Items, nil
+}
+
+// getRequeueAfter returns the duration after which the snapshot sets should be checked again.
+func (r *SingleVolumeSnapshotBackupReconciler) getRequeueAfter(now time.Time) time.Duration {
+	var requeueAfter time.Duration
+
+	setRequeueAfter := func(d time.Duration) {
+		if d < time.Second {
+			d = time.Second
+		}
+
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+
+	snapshotSets := r.aeroSnapshotBackup.Status.SnapshotSets
+	for idx := range snapshotSets {
+		if snapshotSets[idx].Phase == asdbv1beta1.VolumeSnapshotSetInProgress || snapshotSets[idx].PostSnapshotPending {
+			setRequeueAfter(snapshotSetPollInterval)
+		}
+	}
+
+	if nextTime := r.getNextScheduleTime(); nextTime != nil {
+		setRequeueAfter(nextTime.Sub(now))
+	}
+
+	if retention := r.aeroSnapshotBackup.Spec.Retention; retention != nil && retention.MaxAge != nil {
+		for idx := range snapshotSets {
+			if expiry := snapshotSets[idx].StartTime.Add(retention.MaxAge.Duration); expiry.After(now) {
+				setRequeueAfter(expiry.Sub(now))
+			}
+		}
+	}
+
+	return requeueAfter
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xdrtopology
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+)
+
+const finalizerName = "asdb.aerospike.com/xdr-topology-finalizer"
+
+// AerospikeXDRTopologyReconciler reconciles a AerospikeXDRTopology object
+type AerospikeXDRTopologyReconciler struct {
+	client.Client
+	Scheme   *k8sRuntime.Scheme
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+//nolint:lll // for readability
+// +kubebuilder:rbac:groups=asdb.aerospike.com,resources=aerospikexdrtopologies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=asdb.aerospike.com,resources=aerospikexdrtopologies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=asdb.aerospike.com,resources=aerospikexdrtopologies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=asdb.aerospike.com,resources=aerospikeclusters,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *AerospikeXDRTopologyReconciler) Reconcile(
+	_ context.Context, request ctrl.Request,
+) (ctrl.Result, error) {
+	log := r.Log.WithValues("aerospikexdrtopology", request.NamespacedName)
+
+	log.Info("Reconciling AerospikeXDRTopology")
+
+	// Fetch the AerospikeXDRTopology instance
+	aeroXDRTopology := &asdbv1beta1.AerospikeXDRTopology{}
+	if err := r.Get(context.TODO(), request.NamespacedName, aeroXDRTopology); err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after Reconcile request.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	cr := SingleXDRTopologyReconciler{
+		aeroXDRTopology: aeroXDRTopology,
+		Client:          r.Client,
+		Log:             log,
+		Scheme:          r.Scheme,
+		Recorder:        r.Recorder,
+	}
+
+	return cr.Reconcile()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AerospikeXDRTopologyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&asdbv1beta1.AerospikeXDRTopology{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Seeds are resolved from the destination cluster status, so watch status changes of the clusters too.
+		Watches(
+			&asdbv1.AerospikeCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.findTopologiesForCluster),
+		).
+		WithOptions(
+			controller.Options{
+				MaxConcurrentReconciles: common.MaxConcurrentReconciles,
+			},
+		).
+		Complete(r)
+}
+
+// findTopologiesForCluster returns the AerospikeXDRTopologies having the AerospikeCluster as a source or
+// destination in the operator's Kubernetes cluster.
+func (r *AerospikeXDRTopologyReconciler) findTopologiesForCluster(
+	ctx context.Context, obj client.Object,
+) []reconcile.Request {
+	topologies := &asdbv1beta1.AerospikeXDRTopologyList{}
+	if err := r.List(ctx, topologies); err != nil {
+		r.Log.Error(err, "Failed to list AerospikeXDRTopologies")
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for idx := range topologies.Items {
+		topology := &topologies.Items[idx]
+
+		refs := make([]asdbv1beta1.XDRClusterReference, 0, len(topology.Spec.Sources)+len(topology.Spec.Destinations))
+		refs = append(refs, topology.Spec.Sources...)
+
+		for destIdx := range topology.Spec.Destinations {
+			refs = append(refs, topology.Spec.Destinations[destIdx].Cluster)
+		}
+
+		for idx := range refs {
+			ref := getClusterReference(topology, &refs[idx])
+			if ref.KubeconfigSecretName == "" && ref.Name == obj.GetName() && ref.Namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name: topology.Name, Namespace: topology.Namespace,
+				}})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,416 @@
+package xdrtopology
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/common"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const (
+	// remoteClusterRefreshInterval is the interval in seconds at which seeds of clusters in other
+	// Kubernetes clusters are resolved again, as their changes cannot be watched.
+	remoteClusterRefreshInterval = 60
+	errorRetryInterval           = 30
+)
+
+// SingleXDRTopologyReconciler reconciles a single AerospikeXDRTopology object
+type SingleXDRTopologyReconciler struct {
+	client.Client
+	Recorder        record.EventRecorder
+	aeroXDRTopology *asdbv1beta1.AerospikeXDRTopology
+	Scheme          *k8sRuntime.Scheme
+	Log             logr.Logger
+	remoteClients   map[string]client.Client
+}
+
+func (r *SingleXDRTopologyReconciler) Reconcile() (ctrl.Result, error) {
+	// Check DeletionTimestamp to see if the topology is being deleted
+	if !r.aeroXDRTopology.DeletionTimestamp.IsZero() {
+		r.Log.Info("Deleting AerospikeXDRTopology")
+
+		if err := r.cleanUpAndRemoveFinalizer(); err != nil {
+			r.Log.Error(err, "Failed to remove finalizer")
+			return ctrl.Result{}, err
+		}
+
+		// Stop reconciliation as the topology is being deleted
+		return ctrl.Result{}, nil
+	}
+
+	// The topology is not being deleted, add finalizer if not added already
+	if err := r.addFinalizer(); err != nil {
+		r.Log.Error(err, "Failed to add finalizer")
+		return ctrl.Result{}, err
+	}
+
+	status := &r.aeroXDRTopology.Status
+	dcs, dcStatuses, unresolvedDCs := r.resolveDCs()
+
+	var messages []string
+
+	for idx := range dcStatuses {
+		if dcStatuses[idx].Message != "" {
+			messages = append(messages, fmt.Sprintf("dc %s: %s", dcStatuses[idx].Name, dcStatuses[idx].Message))
+		}
+	}
+
+	// DCs of unresolved destinations are left as is in the sources, till their seeds can be resolved again.
+	managedDCNames := sets.New[string]()
+	for idx := range status.DCs {
+		managedDCNames.Insert(status.DCs[idx].Name)
+	}
+
+	for idx := range dcStatuses {
+		managedDCNames.Insert(dcStatuses[idx].Name)
+	}
+
+	managedDCNames = managedDCNames.Difference(unresolvedDCs)
+
+	sources := make([]asdbv1beta1.XDRClusterReference, 0, len(r.aeroXDRTopology.Spec.Sources))
+	specSources := sets.New[string]()
+
+	for idx := range r.aeroXDRTopology.Spec.Sources {
+		source := getClusterReference(r.aeroXDRTopology, &r.aeroXDRTopology.Spec.Sources[idx])
+		sources = append(sources, source)
+		specSources.Insert(source.String())
+
+		if err := r.applyDCs(&source, sourceDCs(&source, dcs, dcStatuses), managedDCNames, false); err != nil {
+			r.Log.Error(err, "Failed to apply XDR DCs", "source", source.String())
+			messages = append(messages, fmt.Sprintf("source %s: %v", source.String(), err))
+		}
+	}
+
+	// Remove the DCs from the sources which are removed from the topology.
+	for idx := range status.Sources {
+		source := status.Sources[idx]
+		if specSources.Has(source.String()) {
+			continue
+		}
+
+		if err := r.applyDCs(&source, nil, managedDCNames.Union(unresolvedDCs), true); err != nil {
+			r.Log.Error(err, "Failed to remove XDR DCs", "source", source.String())
+			messages = append(messages, fmt.Sprintf("source %s: %v", source.String(), err))
+			// Keep the source in status to retry the removal.
+			sources = append(sources, source)
+		}
+	}
+
+	status.Sources = sources
+	status.DCs = dcStatuses
+	status.Phase = asdbv1beta1.AerospikeXDRTopologyCompleted
+	status.Message = ""
+
+	if len(messages) > 0 {
+		status.Phase = asdbv1beta1.AerospikeXDRTopologyError
+		status.Message = strings.Join(messages, "; ")
+
+		r.Recorder.Eventf(r.aeroXDRTopology, corev1.EventTypeWarning, "TopologyReconcileFailed",
+			"Failed to reconcile AerospikeXDRTopology %s/%s: %s", r.aeroXDRTopology.Namespace,
+			r.aeroXDRTopology.Name, status.Message)
+	}
+
+	if err := r.Client.Status().Update(context.TODO(), r.aeroXDRTopology); err != nil {
+		r.Log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	if len(messages) > 0 {
+		return common.ReconcileRequeueAfter(errorRetryInterval).Result, nil
+	}
+
+	if r.hasRemoteClusters() {
+		return common.ReconcileRequeueAfter(remoteClusterRefreshInterval).Result, nil
+	}
+
+	r.Log.Info("Reconcile completed successfully")
+
+	return ctrl.Result{}, nil
+}
+
+func (r *SingleXDRTopologyReconciler) addFinalizer() error {
+	// The object is not being deleted, so if it does not have our finalizer,
+	// then lets add the finalizer and update the object. This is equivalent
+	// registering our finalizer.
+	if !utils.ContainsString(r.aeroXDRTopology.Finalizers, finalizerName) {
+		r.aeroXDRTopology.Finalizers = append(r.aeroXDRTopology.Finalizers, finalizerName)
+
+		return r.Update(context.TODO(), r.aeroXDRTopology)
+	}
+
+	return nil
+}
+
+func (r *SingleXDRTopologyReconciler) cleanUpAndRemoveFinalizer() error {
+	if utils.ContainsString(r.aeroXDRTopology.Finalizers, finalizerName) {
+		r.Log.Info("Removing finalizer")
+
+		managedDCNames := sets.New[string]()
+		for idx := range r.aeroXDRTopology.Status.DCs {
+			managedDCNames.Insert(r.aeroXDRTopology.Status.DCs[idx].Name)
+		}
+
+		for idx := range r.aeroXDRTopology.Status.Sources {
+			source := r.aeroXDRTopology.Status.Sources[idx]
+			if err := r.applyDCs(&source, nil, managedDCNames, true); err != nil {
+				return fmt.Errorf("failed to remove XDR DCs from source %s: %v", source.String(), err)
+			}
+		}
+
+		// Remove finalizer from the list
+		r.aeroXDRTopology.Finalizers = utils.RemoveString(r.aeroXDRTopology.Finalizers, finalizerName)
+
+		if err := r.Update(context.TODO(), r.aeroXDRTopology); err != nil {
+			return err
+		}
+
+		r.Log.Info("Removed finalizer")
+	}
+
+	return nil
+}
+
+// resolveDCs resolves the seeds of every destination and returns the generated DCs, their status and
+// the names of the DCs which could not be resolved.
+func (r *SingleXDRTopologyReconciler) resolveDCs() (
+	dcs []map[string]interface{}, dcStatuses []asdbv1beta1.XDRDCStatus, unresolvedDCs sets.Set[string],
+) {
+	unresolvedDCs = sets.New[string]()
+
+	for idx := range r.aeroXDRTopology.Spec.Destinations {
+		destination := &r.aeroXDRTopology.Spec.Destinations[idx]
+		dcStatus := asdbv1beta1.XDRDCStatus{
+			Name:    destination.DCName,
+			Cluster: getClusterReference(r.aeroXDRTopology, &destination.Cluster),
+		}
+
+		dc, seeds, err := r.resolveDC(destination, &dcStatus.Cluster)
+		if err != nil {
+			r.Log.Error(err, "Failed to resolve XDR DC", "dc", destination.DCName)
+
+			dcStatus.Message = err.Error()
+			unresolvedDCs.Insert(destination.DCName)
+		} else {
+			dcStatus.Seeds = seeds
+			dcs = append(dcs, dc)
+		}
+
+		dcStatuses = append(dcStatuses, dcStatus)
+	}
+
+	return dcs, dcStatuses, unresolvedDCs
+}
+
+func (r *SingleXDRTopologyReconciler) resolveDC(
+	destination *asdbv1beta1.XDRDestinationSpec, clusterRef *asdbv1beta1.XDRClusterReference,
+) (dc map[string]interface{}, seeds []string, err error) {
+	cl, err := r.getClient(clusterRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aeroCluster := &asdbv1.AerospikeCluster{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{
+		Name: clusterRef.Name, Namespace: clusterRef.Namespace,
+	}, aeroCluster); err != nil {
+		return nil, nil, fmt.Errorf("failed to get destination cluster: %v", err)
+	}
+
+	switch destination.SeedSource {
+	case asdbv1beta1.XDRSeedSourceLoadBalancer:
+		seeds, err = r.getLoadBalancerSeeds(cl, aeroCluster, destination.TLSName)
+	default:
+		seeds, err = utils.GetXDRSeedsFromPods(aeroCluster.Status.Pods, destination.TLSName)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(seeds) == 0 {
+		return nil, nil, fmt.Errorf("no seeds found for destination cluster")
+	}
+
+	dcConfig := map[string]interface{}{}
+
+	if destination.DCConfig != nil && len(destination.DCConfig.Raw) > 0 {
+		if err := json.Unmarshal(destination.DCConfig.Raw, &dcConfig); err != nil {
+			return nil, nil, fmt.Errorf("invalid dcConfig: %v", err)
+		}
+	}
+
+	return utils.NewXDRDC(destination.DCName, seeds, dcConfig), seeds, nil
+}
+
+func (r *SingleXDRTopologyReconciler) getLoadBalancerSeeds(
+	cl client.Client, aeroCluster *asdbv1.AerospikeCluster, tlsName string,
+) ([]string, error) {
+	if aeroCluster.Spec.SeedsFinderServices.LoadBalancer == nil {
+		return nil, fmt.Errorf("destination cluster does not have seedsFinderServices.loadBalancer")
+	}
+
+	service := &corev1.Service{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{
+		Name: aeroCluster.Name + "-lb", Namespace: aeroCluster.Namespace,
+	}, service); err != nil {
+		return nil, fmt.Errorf("failed to get destination cluster LoadBalancer service: %v", err)
+	}
+
+	if len(service.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("destination cluster LoadBalancer service %s has no ports", service.Name)
+	}
+
+	seeds := make([]string, 0, len(service.Status.LoadBalancer.Ingress))
+
+	for idx := range service.Status.LoadBalancer.Ingress {
+		host := service.Status.LoadBalancer.Ingress[idx].IP
+		if host == "" {
+			host = service.Status.LoadBalancer.Ingress[idx].Hostname
+		}
+
+		if host != "" {
+			seeds = append(seeds, utils.FormatXDRSeed(host, service.Spec.Ports[0].Port, tlsName))
+		}
+	}
+
+	return seeds, nil
+}
+
+// applyDCs sets the DCs in the source cluster aerospikeConfig and removes other managed DCs from it.
+func (r *SingleXDRTopologyReconciler) applyDCs(
+	source *asdbv1beta1.XDRClusterReference, dcs []map[string]interface{}, managedDCNames sets.Set[string],
+	ignoreNotFound bool,
+) error {
+	cl, err := r.getClient(source)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		aeroCluster := &asdbv1.AerospikeCluster{}
+		if err := cl.Get(context.TODO(), types.NamespacedName{
+			Name: source.Name, Namespace: source.Namespace,
+		}, aeroCluster); err != nil {
+			if errors.IsNotFound(err) && ignoreNotFound {
+				return nil
+			}
+
+			return err
+		}
+
+		if aeroCluster.Spec.AerospikeConfig == nil {
+			return fmt.Errorf("source cluster does not have aerospikeConfig")
+		}
+
+		changed, err := utils.SetXDRDCs(aeroCluster.Spec.AerospikeConfig.Value, dcs, managedDCNames)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+
+		r.Log.Info("Updating XDR DCs of source cluster", "source", source.String())
+
+		if err := cl.Update(context.TODO(), aeroCluster); err != nil {
+			return err
+		}
+
+		r.Recorder.Eventf(r.aeroXDRTopology, corev1.EventTypeNormal, "SourceUpdated",
+			"Updated XDR DCs of source cluster %s", source.String())
+
+		return nil
+	})
+}
+
+// getClient returns the client for the Kubernetes cluster of the AerospikeCluster reference.
+func (r *SingleXDRTopologyReconciler) getClient(clusterRef *asdbv1beta1.XDRClusterReference) (client.Client, error) {
+	if clusterRef.KubeconfigSecretName == "" {
+		return r.Client, nil
+	}
+
+	if cl, ok := r.remoteClients[clusterRef.KubeconfigSecretName]; ok {
+		return cl, nil
+	}
+
+	cl, err := common.NewRemoteClient(r.Client, r.Scheme, r.aeroXDRTopology.Namespace, clusterRef.KubeconfigSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.remoteClients == nil {
+		r.remoteClients = map[string]client.Client{}
+	}
+
+	r.remoteClients[clusterRef.KubeconfigSecretName] = cl
+
+	return cl, nil
+}
+
+func (r *SingleXDRTopologyReconciler) hasRemoteClusters() bool {
+	for idx := range r.aeroXDRTopology.Spec.Sources {
+		if r.aeroXDRTopology.Spec.Sources[idx].KubeconfigSecretName != "" {
+			return true
+		}
+	}
+
+	for idx := range r.aeroXDRTopology.Spec.Destinations {
+		if r.aeroXDRTopology.Spec.Destinations[idx].Cluster.KubeconfigSecretName != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sourceDCs returns the DCs to apply to the source, skipping the DC of the source cluster itself.
+func sourceDCs(
+	source *asdbv1beta1.XDRClusterReference, dcs []map[string]interface{}, dcStatuses []asdbv1beta1.XDRDCStatus,
+) []map[string]interface{} {
+	selfDCs := sets.New[string]()
+
+	for idx := range dcStatuses {
+		if dcStatuses[idx].Cluster == *source {
+			selfDCs.Insert(dcStatuses[idx].Name)
+		}
+	}
+
+	filtered := make([]map[string]interface{}, 0, len(dcs))
+
+	for _, dc := range dcs {
+		if !selfDCs.Has(dc["name"].(string)) {
+			filtered = append(filtered, dc)
+		}
+	}
+
+	return filtered
+}
+
+// getClusterReference returns the cluster reference with the namespace defaulted to the topology namespace.
+func getClusterReference(
+	topology *asdbv1beta1.AerospikeXDRTopology, clusterRef *asdbv1beta1.XDRClusterReference,
+) asdbv1beta1.XDRClusterReference {
+	ref := *clusterRef
+	if ref.Namespace == "" {
+		ref.Namespace = topology.Namespace
+	}
+
+	return ref
+}
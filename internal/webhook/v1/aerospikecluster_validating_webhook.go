@@ -21,6 +21,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -150,6 +152,19 @@ func (acv *AerospikeClusterCustomValidator) ValidateUpdate(_ context.Context, ol
 		return warnings, err
 	}
 
+	if !reflect.DeepEqual(
+		oldObject.Spec.PodService.LoadBalancerClass, aerospikeCluster.Spec.PodService.LoadBalancerClass,
+	) && isNetworkTypeUsed(&oldObject.Spec.AerospikeNetworkPolicy, asdbv1.AerospikeNetworkTypePodLoadBalancer) &&
+		isNetworkTypeUsed(&aerospikeCluster.Spec.AerospikeNetworkPolicy, asdbv1.AerospikeNetworkTypePodLoadBalancer) {
+		return warnings, fmt.Errorf("cannot update podService loadBalancerClass")
+	}
+
+	if err := validateServiceIPFamilyUpdate(
+		oldObject.Spec.ServiceIPFamily, aerospikeCluster.Spec.ServiceIPFamily,
+	); err != nil {
+		return warnings, err
+	}
+
 	if err := validateOperationUpdate(
 		&oldObject.Spec, &aerospikeCluster.Spec, &aerospikeCluster.Status,
 	); err != nil {
@@ -303,6 +318,10 @@ func validate(aslog logr.Logger, cluster *asdbv1.AerospikeCluster) (admission.Wa
 		}
 	}
 
+	if err := validateVolumeSnapshotRestore(cluster); err != nil {
+		return warnings, err
+	}
+
 	// Validate resource and limit
 	if err := validatePodSpecResourceAndLimits(aslog, cluster); err != nil {
 		return warnings, err
@@ -326,6 +345,37 @@ func validate(aslog logr.Logger, cluster *asdbv1.AerospikeCluster) (admission.Wa
 		return warnings, err
 	}
 
+	if err := validatePodService(cluster); err != nil {
+		return warnings, err
+	}
+
+	if err := validateGatewayTLSRoute(cluster); err != nil {
+		return warnings, err
+	}
+
+	if err := validateServiceIPFamily(cluster.Spec.ServiceIPFamily); err != nil {
+		return warnings, err
+	}
+
+	if err := validateFederation(cluster); err != nil {
+		return warnings, err
+	}
+
+	if err := validateAerospikeConfigVariables(cluster); err != nil {
+		return warnings, err
+	}
+
+	if heartbeatSeeds := cluster.Spec.HeartbeatSeeds; heartbeatSeeds != nil && heartbeatSeeds.PodsPerRack != 0 &&
+		heartbeatSeeds.Strategy != asdbv1.HeartbeatSeedsPodsPerRack {
+		return warnings, fmt.Errorf("heartbeatSeeds podsPerRack is allowed only with 'podsPerRack' strategy")
+	}
+
+	if cluster.Spec.NetworkIsolation != nil && cluster.Spec.PodSpec.HostNetwork {
+		warnings = append(warnings, "networkIsolation NetworkPolicy does not apply to the pods using hostNetwork")
+	}
+
+	warnings = append(warnings, getServiceMeshWarnings(cluster)...)
+
 	// Validate Sidecars
 	if err := validatePodSpec(cluster); err != nil {
 		return warnings, err
@@ -848,18 +898,21 @@ func validateAerospikeConfig(
 	storage *asdbv1.AerospikeStorageSpec, clSize int,
 	clientCert *asdbv1.AerospikeOperatorClientCertSpec,
 ) error {
+	// The values referencing the aerospikeConfig variables are resolved in the pods, so they are left out.
+	config := withoutConfigVariableRefs(configSpec.Value)
+
 	// It validates the aerospikeConfig schema and generic aerospikeConfig fields
 	if err := validation.ValidateAerospikeConfig(
-		aslog, version, configSpec.Value, clSize,
+		aslog, version, config, clSize,
 	); err != nil {
 		return err
 	}
 
-	if err := validateNetworkConfig(configSpec.Value, clientCert); err != nil {
+	if err := validateNetworkConfig(config, clientCert); err != nil {
 		return err
 	}
 
-	return validateNamespaceConfig(configSpec.Value, storage)
+	return validateNamespaceConfig(config, storage)
 }
 
 func validateNetworkConfig(config map[string]interface{},
@@ -1463,6 +1516,26 @@ func validateNetworkPolicy(cluster *asdbv1.AerospikeCluster) error {
 		}
 	}
 
+	for _, access := range []struct {
+		name       string
+		accessType asdbv1.AerospikeNetworkType
+		ipFamily   v1.IPFamily
+	}{
+		{"access", networkPolicy.AccessType, networkPolicy.AccessIPFamily},
+		{"alternateAccess", networkPolicy.AlternateAccessType, networkPolicy.AlternateAccessIPFamily},
+		{"tlsAccess", networkPolicy.TLSAccessType, networkPolicy.TLSAccessIPFamily},
+		{"tlsAlternateAccess", networkPolicy.TLSAlternateAccessType, networkPolicy.TLSAlternateAccessIPFamily},
+	} {
+		if access.ipFamily != "" && access.accessType != asdbv1.AerospikeNetworkTypePod &&
+			access.accessType != asdbv1.AerospikeNetworkTypeHostInternal &&
+			access.accessType != asdbv1.AerospikeNetworkTypeHostExternal {
+			return fmt.Errorf(
+				"%sIPFamily is allowed only with 'pod', 'hostInternal' and 'hostExternal' %s types",
+				access.name, access.name,
+			)
+		}
+	}
+
 	if networkPolicy.FabricType == asdbv1.AerospikeNetworkTypeCustomInterface {
 		if err := validateNetworkList(
 			networkPolicy.CustomFabricNetworkNames,
@@ -1484,6 +1557,126 @@ func validateNetworkPolicy(cluster *asdbv1.AerospikeCluster) error {
 	return nil
 }
 
+func validatePodService(cluster *asdbv1.AerospikeCluster) error {
+	podService := &cluster.Spec.PodService
+	networkPolicy := &cluster.Spec.AerospikeNetworkPolicy
+	podLoadBalancer := isNetworkTypeUsed(networkPolicy, asdbv1.AerospikeNetworkTypePodLoadBalancer)
+	podNodePort := isNetworkTypeUsed(networkPolicy, asdbv1.AerospikeNetworkTypePodNodePort)
+
+	if podLoadBalancer && podNodePort {
+		return fmt.Errorf("'podLoadBalancer' and 'podNodePort' network types cannot be used together")
+	}
+
+	if !podLoadBalancer && (podService.LoadBalancerClass != nil || len(podService.LoadBalancerSourceRanges) != 0) {
+		return fmt.Errorf(
+			"podService loadBalancerClass and loadBalancerSourceRanges are allowed only with 'podLoadBalancer' network type",
+		)
+	}
+
+	for _, sourceRange := range podService.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+			return fmt.Errorf("invalid podService loadBalancerSourceRanges %s: %v", sourceRange, err)
+		}
+	}
+
+	if podService.ExternalAddress != "" && !podNodePort {
+		return fmt.Errorf("podService externalAddress is allowed only with 'podNodePort' network type")
+	}
+
+	// Expand the templates with sample values to catch the invalid templates before they reach the pods.
+	vars := &utils.PodTemplateVars{
+		PodName:     cluster.Name + "-1-0",
+		ClusterName: cluster.Name,
+		Namespace:   cluster.Namespace,
+		RackID:      1,
+	}
+
+	if _, err := utils.ExpandPodTemplateMap(podService.Metadata.Annotations, vars); err != nil {
+		return fmt.Errorf("invalid podService annotations: %v", err)
+	}
+
+	if _, err := utils.ExpandPodTemplate(podService.ExternalAddress, vars); err != nil {
+		return fmt.Errorf("invalid podService externalAddress: %v", err)
+	}
+
+	return nil
+}
+
+func validateGatewayTLSRoute(cluster *asdbv1.AerospikeCluster) error {
+	gatewayTLSRoute := cluster.Spec.GatewayTLSRoute
+
+	if cluster.Spec.AerospikeNetworkPolicy.TLSAlternateAccessType != asdbv1.AerospikeNetworkTypeGatewayTLSRoute {
+		if gatewayTLSRoute != nil {
+			return fmt.Errorf("gatewayTLSRoute is allowed only with 'gatewayTLSRoute' tlsAlternateAccess network type")
+		}
+
+		return nil
+	}
+
+	if gatewayTLSRoute == nil {
+		return fmt.Errorf("gatewayTLSRoute is required with 'gatewayTLSRoute' tlsAlternateAccess network type")
+	}
+
+	tlsName, tlsPort := asdbv1.GetServiceTLSNameAndPort(cluster.Spec.AerospikeConfig)
+	if tlsPort == nil {
+		return fmt.Errorf("'gatewayTLSRoute' tlsAlternateAccess network type requires TLS service port")
+	}
+
+	// The service tls-name of each pod is set to its hostname from the TLS config of the service, as the clients
+	// send the node tls-name as SNI.
+	if !isTLSConfigPresent(cluster.Spec.AerospikeConfig, tlsName) {
+		return fmt.Errorf("'gatewayTLSRoute' tlsAlternateAccess network type requires the TLS config %q of the "+
+			"service in network.tls", tlsName)
+	}
+
+	// Expand the hostname for two pods, to check that it is a valid DNS name unique per pod.
+	hostnames := sets.NewString()
+
+	for _, podName := range []string{cluster.Name + "-1-0", cluster.Name + "-1-1"} {
+		hostname, err := utils.ExpandPodTemplate(gatewayTLSRoute.Hostname, &utils.PodTemplateVars{
+			PodName:     podName,
+			ClusterName: cluster.Name,
+			Namespace:   cluster.Namespace,
+			RackID:      1,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid gatewayTLSRoute hostname: %v", err)
+		}
+
+		if errs := k8svalidation.IsDNS1123Subdomain(hostname); len(errs) != 0 {
+			return fmt.Errorf("invalid gatewayTLSRoute hostname %s: %v", hostname, errs)
+		}
+
+		hostnames.Insert(hostname)
+	}
+
+	if hostnames.Len() != 2 {
+		return fmt.Errorf("gatewayTLSRoute hostname %s is not unique per pod, it must use {{.PodName}}",
+			gatewayTLSRoute.Hostname)
+	}
+
+	return nil
+}
+
+// isTLSConfigPresent returns true if the network.tls list of the aerospikeConfig has the TLS config of the given name.
+func isTLSConfigPresent(configSpec *asdbv1.AerospikeConfigSpec, tlsName string) bool {
+	network, _ := configSpec.Value[asdbv1.ConfKeyNetwork].(map[string]interface{})
+	tlsList, _ := network["tls"].([]interface{})
+
+	for _, tlsConf := range tlsList {
+		if tlsConfMap, ok := tlsConf.(map[string]interface{}); ok && tlsConfMap["name"] == tlsName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNetworkTypeUsed(networkPolicy *asdbv1.AerospikeNetworkPolicy, networkType asdbv1.AerospikeNetworkType) bool {
+	return networkPolicy.AccessType == networkType || networkPolicy.AlternateAccessType == networkType ||
+		networkPolicy.TLSAccessType == networkType || networkPolicy.TLSAlternateAccessType == networkType
+}
+
 // validateBatchSize validates the batch size for the following types:
 // - rollingUpdateBatchSize: Rolling update batch size
 // - scaleDownBatchSize: Scale down batch size
@@ -1741,3 +1934,112 @@ func getMinRunningInitVersion(pods map[string]asdbv1.AerospikePodStatus) (string
 
 	return minVersion, nil
 }
+
+// getServiceMeshWarnings returns the warnings for the setups where the service mesh sidecar cannot be bypassed.
+func getServiceMeshWarnings(cluster *asdbv1.AerospikeCluster) admission.Warnings {
+	if cluster.Spec.PodSpec.ServiceMesh == nil {
+		return nil
+	}
+
+	var warnings admission.Warnings
+
+	if cluster.Spec.PodSpec.HostNetwork {
+		warnings = append(warnings, "serviceMesh sidecar is not injected in the pods using hostNetwork")
+	}
+
+	_, adminTLSPort := asdbv1.GetAdminTLSNameAndPort(cluster.Spec.AerospikeConfig)
+	if asdbv1.GetAdminPort(cluster.Spec.AerospikeConfig) == nil && adminTLSPort == nil {
+		warnings = append(warnings, "serviceMesh is enabled without an admin port, "+
+			"operator info calls will go through the sidecar proxy on the service port")
+	}
+
+	return warnings
+}
+
+func validateServiceIPFamily(serviceIPFamily *asdbv1.ServiceIPFamilySpec) error {
+	if serviceIPFamily == nil {
+		return nil
+	}
+
+	ipFamilies := serviceIPFamily.IPFamilies
+
+	if len(ipFamilies) == 2 && ipFamilies[0] == ipFamilies[1] {
+		return fmt.Errorf("serviceIPFamily ipFamilies must be unique, found %v", ipFamilies)
+	}
+
+	if serviceIPFamily.IPFamilyPolicy != nil && *serviceIPFamily.IPFamilyPolicy == v1.IPFamilyPolicySingleStack &&
+		len(ipFamilies) > 1 {
+		return fmt.Errorf("serviceIPFamily ipFamilies must have one family with 'SingleStack' ipFamilyPolicy")
+	}
+
+	return nil
+}
+
+func validateServiceIPFamilyUpdate(oldServiceIPFamily, newServiceIPFamily *asdbv1.ServiceIPFamilySpec) error {
+	if oldServiceIPFamily == nil || newServiceIPFamily == nil ||
+		len(oldServiceIPFamily.IPFamilies) == 0 || len(newServiceIPFamily.IPFamilies) == 0 {
+		return nil
+	}
+
+	// Kubernetes does not allow changing the primary IP family of a service.
+	if oldServiceIPFamily.IPFamilies[0] != newServiceIPFamily.IPFamilies[0] {
+		return fmt.Errorf("cannot update the primary serviceIPFamily ipFamily %s",
+			oldServiceIPFamily.IPFamilies[0])
+	}
+
+	return nil
+}
+
+// validateFederation validates that each rack is owned by only one member of the federation, and the pod CIDRs of
+// the members needed by the NetworkPolicy.
+func validateFederation(cluster *asdbv1.AerospikeCluster) error {
+	federation := cluster.Spec.Federation
+	if federation == nil {
+		return nil
+	}
+
+	rackOwners := map[int]string{}
+	for idx := range cluster.Spec.RackConfig.Racks {
+		rackOwners[cluster.Spec.RackConfig.Racks[idx].ID] = "this cluster"
+	}
+
+	members := sets.New[string]()
+
+	for idx := range federation.Members {
+		member := federation.Members[idx]
+
+		if member.Namespace == "" {
+			member.Namespace = cluster.Namespace
+		}
+
+		if member.KubeconfigSecretName == "" && member.Namespace == cluster.Namespace {
+			return fmt.Errorf("federation member in the same Kubernetes cluster must be in another namespace")
+		}
+
+		if members.Has(member.String()) {
+			return fmt.Errorf("duplicate federation member %s", member.String())
+		}
+
+		members.Insert(member.String())
+
+		if member.KubeconfigSecretName != "" && cluster.Spec.NetworkIsolation != nil && len(member.PodCIDRs) == 0 {
+			return fmt.Errorf("podCIDRs of federation member %s are required with networkIsolation", member.String())
+		}
+
+		for _, cidr := range member.PodCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid podCIDR %s of federation member %s: %v", cidr, member.String(), err)
+			}
+		}
+
+		for _, rackID := range member.RackIDs {
+			if owner, ok := rackOwners[rackID]; ok {
+				return fmt.Errorf("rack %d of federation member %s is also owned by %s", rackID, member.String(), owner)
+			}
+
+			rackOwners[rackID] = member.String()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+	lib "github.com/aerospike/aerospike-management-lib"
+)
+
+// containerResourceNames are the container resources supported by the downward API.
+var containerResourceNames = sets.New("cpu", "memory", "ephemeral-storage")
+
+// podFieldMapPathRegex matches the downward API field paths of a pod label or annotation.
+var podFieldMapPathRegex = regexp.MustCompile(`^metadata\.(labels|annotations)\['([^']+)'\]$`)
+
+// validateAerospikeConfigVariables validates the aerospikeConfig variables and their references in the
+// aerospikeConfig of the racks.
+func validateAerospikeConfigVariables(cluster *asdbv1.AerospikeCluster) error {
+	containerNames := sets.New(asdbv1.AerospikeServerContainerName)
+	for idx := range cluster.Spec.PodSpec.Sidecars {
+		containerNames.Insert(cluster.Spec.PodSpec.Sidecars[idx].Name)
+	}
+
+	// The variables are resolved only by the init binary of the operator, the default init image does not ship it.
+	nameAndTag := asdbv1.GetAerospikeInitContainerNameAndTag(cluster)
+	if len(cluster.Spec.AerospikeConfigVariables) != 0 && nameAndTag == asdbv1.AerospikeInitContainerDefaultNameAndTag {
+		return fmt.Errorf("aerospikeConfigVariables require an init image shipping the %s binary, "+
+			"the init image %s does not", utils.InitBinaryName, nameAndTag)
+	}
+
+	defined := sets.New[string]()
+
+	for idx := range cluster.Spec.AerospikeConfigVariables {
+		variable := &cluster.Spec.AerospikeConfigVariables[idx]
+
+		if defined.Has(variable.Name) {
+			return fmt.Errorf("duplicate aerospikeConfig variable %s", variable.Name)
+		}
+
+		sources := 0
+
+		for _, set := range []bool{variable.Value != "", variable.Expression != "", variable.ValueFrom != nil} {
+			if set {
+				sources++
+			}
+		}
+
+		if sources != 1 {
+			return fmt.Errorf("aerospikeConfig variable %s must have exactly one of value, expression and valueFrom",
+				variable.Name)
+		}
+
+		if variable.Expression != "" {
+			expression, err := utils.ParseSizeExpression(variable.Expression)
+			if err != nil {
+				return fmt.Errorf("aerospikeConfig variable %s: %v", variable.Name, err)
+			}
+
+			for _, name := range expression.Variables() {
+				if !defined.Has(name) {
+					return fmt.Errorf("aerospikeConfig variable %s uses variable %s not defined before it",
+						variable.Name, name)
+				}
+			}
+		}
+
+		if variable.Value != "" {
+			if err := asdbv1.ValidateConfigVariableValue(variable.Value); err != nil {
+				return fmt.Errorf("aerospikeConfig variable %s: %v", variable.Name, err)
+			}
+		}
+
+		if variable.ValueFrom != nil {
+			if err := validateConfigVariableSource(variable.ValueFrom, containerNames); err != nil {
+				return fmt.Errorf("aerospikeConfig variable %s: %v", variable.Name, err)
+			}
+		}
+
+		defined.Insert(variable.Name)
+	}
+
+	for idx := range cluster.Spec.RackConfig.Racks {
+		if err := validateConfigVariableRefs(
+			cluster.Spec.RackConfig.Racks[idx].AerospikeConfig.Value, "", defined,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateConfigVariableSource(source *asdbv1.AerospikeConfigVariableSource, containerNames sets.Set[string]) error {
+	sources := 0
+
+	for _, set := range []bool{
+		source.FieldRef != nil, source.ResourceFieldRef != nil, source.NodeLabel != "", source.SecretKeyRef != nil,
+		source.ConfigMapKeyRef != nil,
+	} {
+		if set {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return fmt.Errorf("valueFrom must have exactly one source")
+	}
+
+	switch {
+	case source.FieldRef != nil:
+		return validatePodFieldPath(source.FieldRef.FieldPath)
+	case source.ResourceFieldRef != nil:
+		if containerName := source.ResourceFieldRef.ContainerName; containerName != "" &&
+			!containerNames.Has(containerName) {
+			return fmt.Errorf("container %s not found in the pod", containerName)
+		}
+
+		resources, name, _ := strings.Cut(source.ResourceFieldRef.Resource, ".")
+		if (resources != "limits" && resources != "requests") || !containerResourceNames.Has(name) {
+			return fmt.Errorf("unsupported resource %q", source.ResourceFieldRef.Resource)
+		}
+	case source.SecretKeyRef != nil:
+		if source.SecretKeyRef.Name == "" || source.SecretKeyRef.Key == "" {
+			return fmt.Errorf("secretKeyRef name and key are required")
+		}
+	case source.ConfigMapKeyRef != nil:
+		if source.ConfigMapKeyRef.Name == "" || source.ConfigMapKeyRef.Key == "" {
+			return fmt.Errorf("configMapKeyRef name and key are required")
+		}
+	}
+
+	return nil
+}
+
+// validatePodFieldPath returns an error if the pod field path is not supported by the downward API env vars.
+func validatePodFieldPath(fieldPath string) error {
+	switch fieldPath {
+	case "metadata.name", "metadata.namespace", "metadata.uid", "spec.nodeName", "spec.serviceAccountName",
+		"status.hostIP", "status.podIP":
+		return nil
+	}
+
+	if podFieldMapPathRegex.MatchString(fieldPath) {
+		return nil
+	}
+
+	return fmt.Errorf("unsupported pod field path %q", fieldPath)
+}
+
+// validateConfigVariableRefs validates that the referenced variables are defined, and are not used in the keys and
+// in the values the operator depends on: the network config, the namespace names, the storage-engine types and the
+// devices and files matched with the volumes.
+func validateConfigVariableRefs(value interface{}, path string, defined sets.Set[string]) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if len(asdbv1.GetConfigVariableRefs(key)) != 0 {
+				return fmt.Errorf("aerospikeConfig variables cannot be used in aerospikeConfig keys, found in %s",
+					key)
+			}
+
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			if err := validateConfigVariableRefs(child, childPath, defined); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := validateConfigVariableRefs(item, path, defined); err != nil {
+				return err
+			}
+		}
+	case string:
+		refs := asdbv1.GetConfigVariableRefs(value)
+		if len(refs) == 0 {
+			return nil
+		}
+
+		if !isConfigVariableRefAllowed(path) {
+			return fmt.Errorf("aerospikeConfig variables cannot be used in aerospikeConfig %s", path)
+		}
+
+		for _, ref := range refs {
+			if !defined.Has(ref) {
+				return fmt.Errorf("aerospikeConfig variable %s used in aerospikeConfig %s is not defined", ref, path)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isConfigVariableRefAllowed(path string) bool {
+	if path == "network" || strings.HasPrefix(path, "network.") {
+		return false
+	}
+
+	switch path[strings.LastIndex(path, ".")+1:] {
+	case "name", "type", "devices", "files":
+		return false
+	}
+
+	return true
+}
+
+// withoutConfigVariableRefs returns a copy of the aerospikeConfig without the values referencing the aerospikeConfig
+// variables. These are resolved in the pods, so they are not validated against the schema.
+func withoutConfigVariableRefs(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	configCopy := lib.DeepCopy(config).(map[string]interface{})
+	removeConfigVariableRefs(configCopy)
+
+	return configCopy
+}
+
+// removeConfigVariableRefs removes the values referencing the aerospikeConfig variables, and returns true if the
+// given value references them.
+func removeConfigVariableRefs(value interface{}) bool {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if removeConfigVariableRefs(child) {
+				delete(value, key)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if _, ok := item.(string); ok && removeConfigVariableRefs(item) {
+				return true
+			}
+
+			removeConfigVariableRefs(item)
+		}
+	case string:
+		return len(asdbv1.GetConfigVariableRefs(value)) != 0
+	}
+
+	return false
+}
@@ -180,6 +180,12 @@ func setAerospikePersistentVolumePolicyDefaults(pvPolicy *asdbv1.AerospikePersis
 	} else {
 		pvPolicy.CascadeDelete = *pvPolicy.InputCascadeDelete
 	}
+
+	if pvPolicy.InputWipeVerification == nil {
+		pvPolicy.WipeVerification = defaultPolicy.WipeVerification
+	} else {
+		pvPolicy.WipeVerification = *pvPolicy.InputWipeVerification
+	}
 }
 
 // GetPVsVolumesFromStorage returns the PV volumes from the storage spec.
@@ -248,6 +254,10 @@ func validateStorage(
 		)
 	}
 
+	if err := validateSnapshotBootstrap(storage); err != nil {
+		return err
+	}
+
 	reservedPaths := map[string]int{
 		// Reserved mount paths for the operator.
 		"/etc/aerospike": 1,
@@ -345,6 +355,75 @@ func validateStorage(
 	return nil
 }
 
+// validateSnapshotBootstrap validates that the volumes to bootstrap from snapshots are persistent volumes.
+func validateSnapshotBootstrap(storage *asdbv1.AerospikeStorageSpec) error {
+	bootstrapSpec := storage.SnapshotBootstrap
+	if bootstrapSpec == nil {
+		return nil
+	}
+
+	if bootstrapSpec.VolumeSnapshotClassName == "" {
+		return fmt.Errorf("snapshotBootstrap.volumeSnapshotClassName cannot be empty")
+	}
+
+	if bootstrapSpec.MaxSnapshotAge != nil && bootstrapSpec.MaxSnapshotAge.Duration <= 0 {
+		return fmt.Errorf("snapshotBootstrap.maxSnapshotAge should be a positive duration")
+	}
+
+	for _, volName := range bootstrapSpec.Volumes {
+		found := false
+
+		for idx := range storage.Volumes {
+			if storage.Volumes[idx].Name != volName {
+				continue
+			}
+
+			if storage.Volumes[idx].Source.PersistentVolume == nil {
+				return fmt.Errorf("snapshotBootstrap volume %s is not a persistent volume", volName)
+			}
+
+			found = true
+
+			break
+		}
+
+		if !found {
+			return fmt.Errorf("snapshotBootstrap volume %s not found in storage volumes", volName)
+		}
+	}
+
+	return nil
+}
+
+// validateVolumeSnapshotRestore validates that the volumes restored from a snapshot set are not initialized.
+// Pods of a new cluster have no initialized volumes, so the init method would erase the restored data.
+func validateVolumeSnapshotRestore(cluster *asdbv1.AerospikeCluster) error {
+	restoreSpec := cluster.Spec.VolumeSnapshotRestore
+	if restoreSpec == nil {
+		return nil
+	}
+
+	if restoreSpec.BackupName == "" || restoreSpec.SnapshotSetID == "" {
+		return fmt.Errorf("volumeSnapshotRestore.backupName and volumeSnapshotRestore.snapshotSetID cannot be empty")
+	}
+
+	for rackIdx := range cluster.Spec.RackConfig.Racks {
+		volumes := cluster.Spec.RackConfig.Racks[rackIdx].Storage.Volumes
+
+		for idx := range volumes {
+			if volumes[idx].Source.PersistentVolume != nil && volumes[idx].Aerospike != nil &&
+				volumes[idx].InitMethod != asdbv1.AerospikeVolumeMethodNone {
+				return fmt.Errorf(
+					"volume %s is restored from VolumeSnapshot set, its initMethod should be %s, given %s",
+					volumes[idx].Name, asdbv1.AerospikeVolumeMethodNone, volumes[idx].InitMethod,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
 func validateContainerAttachmentPaths(
 	volumeAttachments []asdbv1.VolumeAttachment,
 	containerAttachmentPaths map[string]map[string]int,
@@ -501,6 +580,9 @@ func validateStorageVolumeSource(volume *asdbv1.VolumeSpec) error {
 			validWipeMethods := sets.New(
 				asdbv1.AerospikeVolumeMethodBlkdiscard,
 				asdbv1.AerospikeVolumeMethodDD,
+				asdbv1.AerospikeVolumeMethodMultiPassOverwrite,
+				asdbv1.AerospikeVolumeMethodNvmeSanitize,
+				asdbv1.AerospikeVolumeMethodCryptoErase,
 			)
 
 			if !validWipeMethods.Has(volume.WipeMethod) {
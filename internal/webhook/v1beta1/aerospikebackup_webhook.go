@@ -211,10 +211,10 @@ func validateBackupConfig(k8sClient client.Client, backup *asdbv1beta1.Aerospike
 	}
 
 	// Validate on-demand backup
-	if len(backup.Spec.OnDemandBackups) > 0 {
-		if _, ok := backupSvcConfig.BackupRoutines[backup.Spec.OnDemandBackups[0].RoutineName]; !ok {
+	for idx := range backup.Spec.OnDemandBackups {
+		if _, ok := backupSvcConfig.BackupRoutines[backup.Spec.OnDemandBackups[idx].RoutineName]; !ok {
 			return fmt.Errorf("invalid onDemand config, backup routine %s not found",
-				backup.Spec.OnDemandBackups[0].RoutineName)
+				backup.Spec.OnDemandBackups[idx].RoutineName)
 		}
 	}
 
@@ -258,26 +258,32 @@ func getValidatedAerospikeClusters(backupConfig map[string]interface{},
 }
 
 func validateOnDemandBackupsUpdate(oldObj, newObj *asdbv1beta1.AerospikeBackup) error {
-	// Check if backup config is updated along with onDemand backup add/update
-	if !reflect.DeepEqual(newObj.Spec.OnDemandBackups, newObj.Status.OnDemandBackups) &&
-		!reflect.DeepEqual(newObj.Spec.Config.Raw, newObj.Status.Config.Raw) {
-		return fmt.Errorf("can not add/update onDemand backup along with backup config change")
+	oldOnDemandBackups := make(map[string]asdbv1beta1.OnDemandBackupSpec, len(oldObj.Spec.OnDemandBackups))
+	for idx := range oldObj.Spec.OnDemandBackups {
+		oldOnDemandBackups[oldObj.Spec.OnDemandBackups[idx].ID] = oldObj.Spec.OnDemandBackups[idx]
 	}
 
-	if len(newObj.Spec.OnDemandBackups) > 0 && len(oldObj.Spec.OnDemandBackups) > 0 {
+	added := false
+
+	for idx := range newObj.Spec.OnDemandBackups {
+		newOnDemand := newObj.Spec.OnDemandBackups[idx]
+
+		oldOnDemand, ok := oldOnDemandBackups[newOnDemand.ID]
+		if !ok {
+			added = true
+			continue
+		}
+
 		// Check if onDemand backup spec is updated
-		if newObj.Spec.OnDemandBackups[0].ID == oldObj.Spec.OnDemandBackups[0].ID &&
-			!reflect.DeepEqual(newObj.Spec.OnDemandBackups[0], oldObj.Spec.OnDemandBackups[0]) {
+		if !reflect.DeepEqual(newOnDemand, oldOnDemand) {
 			return fmt.Errorf("existing onDemand backup cannot be updated. " +
 				"However, It can be removed and a new onDemand backup can be added")
 		}
+	}
 
-		// Check if previous onDemand backup is completed before allowing new onDemand backup
-		if newObj.Spec.OnDemandBackups[0].ID != oldObj.Spec.OnDemandBackups[0].ID &&
-			(len(newObj.Status.OnDemandBackups) == 0 ||
-				newObj.Status.OnDemandBackups[0].ID != oldObj.Spec.OnDemandBackups[0].ID) {
-			return fmt.Errorf("can not add new onDemand backup when previous onDemand backup is not completed")
-		}
+	// Check if backup config is updated along with onDemand backup add
+	if added && !reflect.DeepEqual(newObj.Spec.Config.Raw, oldObj.Spec.Config.Raw) {
+		return fmt.Errorf("can not add/update onDemand backup along with backup config change")
 	}
 
 	return nil
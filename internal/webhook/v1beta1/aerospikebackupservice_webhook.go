@@ -141,6 +141,15 @@ func validateBackupService(backupSvc *asdbv1beta1.AerospikeBackupService) (admis
 		return nil, err
 	}
 
+	if err := validateWorkloadIdentity(backupSvc); err != nil {
+		return nil, err
+	}
+
+	// A single standby pod, so that only one pod runs the backup routines once promoted.
+	if replicas := backupSvc.Spec.Replicas; replicas != nil && (*replicas < 1 || *replicas > 2) {
+		return nil, fmt.Errorf("replicas must be 1 or 2, found %d", *replicas)
+	}
+
 	return validateServicePodSpec(backupSvc)
 }
 
@@ -185,6 +194,117 @@ func validateBackupServiceSecrets(secretMounts []asdbv1beta1.SecretMount) error
 	return nil
 }
 
+// validateWorkloadIdentity validates the provider fields, and that the backup service config has the storage
+// using the provider without the static credentials.
+func validateWorkloadIdentity(backupSvc *asdbv1beta1.AerospikeBackupService) error {
+	workloadIdentity := backupSvc.Spec.WorkloadIdentity
+	if workloadIdentity == nil {
+		return nil
+	}
+
+	switch workloadIdentity.Provider {
+	case asdbv1beta1.WorkloadIdentityAWS:
+		if workloadIdentity.RoleARN == "" {
+			return fmt.Errorf("workloadIdentity roleARN is required for provider %s", workloadIdentity.Provider)
+		}
+
+		if workloadIdentity.GCPServiceAccount != "" || workloadIdentity.ClientID != "" ||
+			workloadIdentity.TenantID != "" {
+			return fmt.Errorf("workloadIdentity gcpServiceAccount, clientID and tenantID are not allowed "+
+				"for provider %s", workloadIdentity.Provider)
+		}
+
+	case asdbv1beta1.WorkloadIdentityGCP:
+		if workloadIdentity.GCPServiceAccount == "" {
+			return fmt.Errorf("workloadIdentity gcpServiceAccount is required for provider %s",
+				workloadIdentity.Provider)
+		}
+
+		if workloadIdentity.RoleARN != "" || workloadIdentity.ClientID != "" || workloadIdentity.TenantID != "" ||
+			workloadIdentity.Audience != "" || workloadIdentity.ExpirationSeconds != nil {
+			return fmt.Errorf("workloadIdentity roleARN, clientID, tenantID, audience and expirationSeconds "+
+				"are not allowed for provider %s", workloadIdentity.Provider)
+		}
+
+	case asdbv1beta1.WorkloadIdentityAzure:
+		if workloadIdentity.ClientID == "" || workloadIdentity.TenantID == "" {
+			return fmt.Errorf("workloadIdentity clientID and tenantID are required for provider %s",
+				workloadIdentity.Provider)
+		}
+
+		if workloadIdentity.RoleARN != "" || workloadIdentity.GCPServiceAccount != "" {
+			return fmt.Errorf("workloadIdentity roleARN and gcpServiceAccount are not allowed for provider %s",
+				workloadIdentity.Provider)
+		}
+
+	default:
+		return fmt.Errorf("invalid workloadIdentity provider %s", workloadIdentity.Provider)
+	}
+
+	var config dto.Config
+
+	if err := yaml.Unmarshal(backupSvc.Spec.Config.Raw, &config); err != nil {
+		return err
+	}
+
+	providerStorages := 0
+
+	for name, storage := range config.Storage {
+		if storage == nil {
+			continue
+		}
+
+		var staticCredentials bool
+
+		switch {
+		case storage.S3Storage != nil && workloadIdentity.Provider == asdbv1beta1.WorkloadIdentityAWS:
+			staticCredentials = storage.S3Storage.AccessKeyID != nil || storage.S3Storage.SecretAccessKey != nil ||
+				storage.S3Storage.S3Profile != ""
+		case storage.GcpStorage != nil && workloadIdentity.Provider == asdbv1beta1.WorkloadIdentityGCP:
+			staticCredentials = storage.GcpStorage.KeyFile != "" || storage.GcpStorage.Key != ""
+		case storage.AzureStorage != nil && workloadIdentity.Provider == asdbv1beta1.WorkloadIdentityAzure:
+			staticCredentials = storage.AzureStorage.AccountName != "" || storage.AzureStorage.AccountKey != "" ||
+				storage.AzureStorage.ClientSecret != ""
+		default:
+			continue
+		}
+
+		if staticCredentials {
+			return fmt.Errorf("storage %s can not have static credentials with workloadIdentity provider %s",
+				name, workloadIdentity.Provider)
+		}
+
+		providerStorages++
+	}
+
+	if providerStorages == 0 {
+		return fmt.Errorf("workloadIdentity provider %s is not supported by any storage in backup service config, "+
+			"%s storage is required", workloadIdentity.Provider, getWorkloadIdentityStorageType(workloadIdentity.Provider))
+	}
+
+	for idx := range backupSvc.Spec.SecretMounts {
+		if name := backupSvc.Spec.SecretMounts[idx].VolumeMount.Name; name == asdbv1beta1.AWSTokenVolumeName ||
+			name == asdbv1beta1.AzureTokenVolumeName {
+			return fmt.Errorf("volume name %s in secrets field is reserved for workloadIdentity", name)
+		}
+	}
+
+	return nil
+}
+
+func getWorkloadIdentityStorageType(provider asdbv1beta1.WorkloadIdentityProvider) string {
+	switch provider {
+	case asdbv1beta1.WorkloadIdentityAWS:
+		return "s3-storage"
+	case asdbv1beta1.WorkloadIdentityGCP:
+		return "gcp-storage"
+	case asdbv1beta1.WorkloadIdentityAzure:
+		return "azure-storage"
+	}
+
+	return ""
+}
+
 func validateServicePodSpec(backupSvc *asdbv1beta1.AerospikeBackupService) (admission.Warnings, error) {
 	if err := validatePodObjectMeta(&backupSvc.Spec.PodSpec.ObjectMeta); err != nil {
 		return nil, err
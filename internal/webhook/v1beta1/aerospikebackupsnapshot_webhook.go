@@ -0,0 +1,106 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+// SetupAerospikeBackupSnapshotWebhookWithManager registers the webhook for AerospikeBackupSnapshot in the manager.
+func SetupAerospikeBackupSnapshotWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&asdbv1beta1.AerospikeBackupSnapshot{}).
+		WithValidator(&AerospikeBackupSnapshotCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:object:generate=false
+type AerospikeBackupSnapshotCustomValidator struct {
+}
+
+//nolint:lll // for readability
+// +kubebuilder:webhook:path=/validate-asdb-aerospike-com-v1beta1-aerospikebackupsnapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=asdb.aerospike.com,resources=aerospikebackupsnapshots,verbs=create;update,versions=v1beta1,name=vaerospikebackupsnapshot.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &AerospikeBackupSnapshotCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (bsv *AerospikeBackupSnapshotCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	snapshot, ok := obj.(*asdbv1beta1.AerospikeBackupSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeBackupSnapshot, got %T", obj)
+	}
+
+	bsLog := logf.Log.WithName(namespacedName(snapshot))
+
+	bsLog.Info("Validate create")
+
+	if snapshot.Spec.Backup == "" {
+		return nil, fmt.Errorf("backup cannot be empty")
+	}
+
+	if snapshot.Spec.Key == "" {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (bsv *AerospikeBackupSnapshotCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	snapshot, ok := newObj.(*asdbv1beta1.AerospikeBackupSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeBackupSnapshot, got %T", newObj)
+	}
+
+	bsLog := logf.Log.WithName(namespacedName(snapshot))
+
+	bsLog.Info("Validate update")
+
+	oldSnapshot := oldObj.(*asdbv1beta1.AerospikeBackupSnapshot)
+
+	if !reflect.DeepEqual(oldSnapshot.Spec, snapshot.Spec) {
+		return nil, fmt.Errorf("aerospikeBackupSnapshot Spec is immutable")
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (bsv *AerospikeBackupSnapshotCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	snapshot, ok := obj.(*asdbv1beta1.AerospikeBackupSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeBackupSnapshot, got %T", obj)
+	}
+
+	bsLog := logf.Log.WithName(namespacedName(snapshot))
+
+	bsLog.Info("Validate delete")
+
+	return nil, nil
+}
@@ -18,11 +18,15 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -32,10 +36,19 @@ import (
 
 	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
 	"github.com/aerospike/aerospike-backup-service/v3/pkg/validation"
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
 	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
 )
 
-const defaultPollingPeriod time.Duration = 60 * time.Second
+const (
+	defaultPollingPeriod time.Duration = 60 * time.Second
+	defaultRetryBackoff  time.Duration = 60 * time.Second
+
+	// destinationPlaceholderPort is the port of the placeholder seed used to validate the restore config
+	// of a restore into a destination cluster.
+	destinationPlaceholderPort = 3000
+)
 
 // SetupAerospikeRestoreWebhookWithManager registers the webhook for AerospikeRestore in the manager.
 func SetupAerospikeRestoreWebhookWithManager(mgr ctrl.Manager) error {
@@ -72,6 +85,68 @@ func (ard *AerospikeRestoreCustomDefaulter) Default(_ context.Context, obj runti
 		restore.Spec.PollingPeriod.Duration = defaultPollingPeriod
 	}
 
+	if restore.Spec.RetryPolicy != nil && restore.Spec.RetryPolicy.Backoff.Seconds() == 0 {
+		restore.Spec.RetryPolicy.Backoff.Duration = defaultRetryBackoff
+	}
+
+	if restore.Spec.BackupSnapshotName != "" && restore.Spec.Type != asdbv1beta1.Timestamp {
+		return setRestoreConfigFromSnapshot(restore)
+	}
+
+	return nil
+}
+
+// setRestoreConfigFromSnapshot sets the backup-data-path and source of the restore config from the
+// AerospikeBackupSnapshot, if not given.
+func setRestoreConfigFromSnapshot(restore *asdbv1beta1.AerospikeRestore) error {
+	restoreConfig := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(restore.Spec.Config.Raw, &restoreConfig); err != nil {
+		return err
+	}
+
+	_, hasDataPath := restoreConfig[asdbv1beta1.BackupDataPathKey]
+	_, hasSource := restoreConfig[asdbv1beta1.SourceKey]
+
+	if hasDataPath && hasSource {
+		return nil
+	}
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return err
+	}
+
+	snapshot := &asdbv1beta1.AerospikeBackupSnapshot{}
+
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: restore.Namespace,
+		Name:      restore.Spec.BackupSnapshotName,
+	}, snapshot); err != nil {
+		return fmt.Errorf("failed to get AerospikeBackupSnapshot %s: %v", restore.Spec.BackupSnapshotName, err)
+	}
+
+	if !hasDataPath {
+		restoreConfig[asdbv1beta1.BackupDataPathKey] = snapshot.Spec.Key
+	}
+
+	if !hasSource && len(snapshot.Spec.Storage.Raw) > 0 {
+		source := make(map[string]interface{})
+
+		if err := json.Unmarshal(snapshot.Spec.Storage.Raw, &source); err != nil {
+			return err
+		}
+
+		restoreConfig[asdbv1beta1.SourceKey] = source
+	}
+
+	raw, err := json.Marshal(restoreConfig)
+	if err != nil {
+		return err
+	}
+
+	restore.Spec.Config.Raw = raw
+
 	return nil
 }
 
@@ -108,6 +183,38 @@ func (arv *AerospikeRestoreCustomValidator) ValidateCreate(_ context.Context, ob
 		return nil, err
 	}
 
+	if restore.Spec.BackupSnapshotName != "" && restore.Spec.Type == asdbv1beta1.Timestamp {
+		return nil, fmt.Errorf("backupSnapshotName is not allowed for restore type %s", restore.Spec.Type)
+	}
+
+	if restore.Spec.PointInTime != nil {
+		if err := validatePointInTime(restore); err != nil {
+			return nil, err
+		}
+	}
+
+	if restore.Spec.DestinationCluster != nil {
+		if err := validateDestinationCluster(k8sClient, restore); err != nil {
+			return nil, err
+		}
+	}
+
+	if restore.Spec.Verification != nil {
+		if err := validateRestoreVerification(restore); err != nil {
+			return nil, err
+		}
+	}
+
+	if restore.Spec.RetryPolicy != nil && restore.Spec.RetryPolicy.SkipExistingRecords {
+		if _, err := backup_service.SetRestoreUnique(restore.Spec.Config.Raw); err != nil {
+			return nil, fmt.Errorf("retryPolicy skipExistingRecords is not supported with the restore config: %v", err)
+		}
+	}
+
+	if restore.Spec.RetryPolicy != nil && restore.Spec.RetryPolicy.Resume && restore.Spec.PointInTime == nil {
+		return nil, fmt.Errorf("retryPolicy resume is only allowed along with pointInTime")
+	}
+
 	if err := validateRestoreConfig(k8sClient, restore); err != nil {
 		return nil, err
 	}
@@ -129,7 +236,15 @@ func (arv *AerospikeRestoreCustomValidator) ValidateUpdate(_ context.Context, ol
 
 	oldRestore := oldObj.(*asdbv1beta1.AerospikeRestore)
 
-	if !reflect.DeepEqual(oldRestore.Spec, restore.Spec) {
+	if oldRestore.Spec.Cancel && !restore.Spec.Cancel {
+		return nil, fmt.Errorf("cancel can not be unset once set")
+	}
+
+	// Cancel is the only mutable field.
+	oldSpec := oldRestore.Spec.DeepCopy()
+	oldSpec.Cancel = restore.Spec.Cancel
+
+	if !reflect.DeepEqual(*oldSpec, restore.Spec) {
 		return nil, fmt.Errorf("aerospikeRestore Spec is immutable")
 	}
 
@@ -152,19 +267,135 @@ func (arv *AerospikeRestoreCustomValidator) ValidateDelete(_ context.Context, ob
 	return nil, nil
 }
 
-func validateRestoreConfig(k8sClient client.Client, restore *asdbv1beta1.AerospikeRestore) error {
+func validateDestinationCluster(k8sClient client.Client, restore *asdbv1beta1.AerospikeRestore) error {
+	var clusterSpec asdbv1.AerospikeClusterSpec
+
+	if err := yaml.UnmarshalStrict(restore.Spec.DestinationCluster.Spec.Raw, &clusterSpec); err != nil {
+		return fmt.Errorf("invalid destinationCluster spec: %v", err)
+	}
+
+	aeroCluster := &asdbv1.AerospikeCluster{}
+
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{
+		Name:      restore.Spec.DestinationCluster.Name,
+		Namespace: restore.Namespace,
+	}, aeroCluster)
+	if err == nil {
+		return fmt.Errorf("destinationCluster %s already exists", restore.Spec.DestinationCluster.Name)
+	}
+
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if user := restore.Spec.DestinationCluster.User; user != "" {
+		if _, ok := asdbv1.GetUsersFromSpec(&clusterSpec)[user]; !ok {
+			return fmt.Errorf("destinationCluster user %s not found in aerospikeAccessControl", user)
+		}
+	}
+
+	return nil
+}
+
+func validatePointInTime(restore *asdbv1beta1.AerospikeRestore) error {
+	if restore.Spec.Type != asdbv1beta1.Timestamp {
+		return fmt.Errorf("pointInTime is only allowed for restore type %s", asdbv1beta1.Timestamp)
+	}
+
 	restoreConfig := make(map[string]interface{})
 
 	if err := yaml.Unmarshal(restore.Spec.Config.Raw, &restoreConfig); err != nil {
 		return err
 	}
 
+	for _, key := range []string{asdbv1beta1.RoutineKey, asdbv1beta1.TimeKey, asdbv1beta1.DisableReorderingKey} {
+		if _, ok := restoreConfig[key]; ok {
+			return fmt.Errorf("%s field is not allowed in restore config along with pointInTime", key)
+		}
+	}
+
+	return nil
+}
+
+func validateRestoreVerification(restore *asdbv1beta1.AerospikeRestore) error {
+	verification := restore.Spec.Verification
+
+	if verification.ClusterName == "" && restore.Spec.DestinationCluster == nil {
+		return fmt.Errorf("verification clusterName is required if destinationCluster is not given")
+	}
+
+	if len(verification.Namespaces) == 0 && restore.Spec.Type == asdbv1beta1.Timestamp {
+		return fmt.Errorf("verification namespaces are required for restore type %s", restore.Spec.Type)
+	}
+
+	for idx := range verification.Sets {
+		set := verification.Sets[idx]
+
+		if len(verification.Namespaces) != 0 && !slices.Contains(verification.Namespaces, set.Namespace) {
+			return fmt.Errorf("verification set %s/%s is not in verification namespaces %v",
+				set.Namespace, set.Set, verification.Namespaces)
+		}
+	}
+
+	return nil
+}
+
+func validateRestoreConfig(k8sClient client.Client, restore *asdbv1beta1.AerospikeRestore) error {
+	config := restore.Spec.Config.Raw
+
+	if restore.Spec.DestinationCluster != nil {
+		// The destination is set from the destination cluster once it is created,
+		// validate the rest of the config with a placeholder seed.
+		var err error
+
+		config, err = backup_service.SetRestoreDestination(config, []dto.SeedNode{
+			{HostName: restore.Spec.DestinationCluster.Name, Port: destinationPlaceholderPort},
+		}, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	restoreConfig := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(config, &restoreConfig); err != nil {
+		return err
+	}
+
 	backupSvcConfig, err := getBackupServiceFullConfig(k8sClient, restore.Spec.BackupService.Name,
 		restore.Spec.BackupService.Namespace)
 	if err != nil {
 		return err
 	}
 
+	if pointInTime := restore.Spec.PointInTime; pointInTime != nil {
+		// The backups of the chain are restored from the storage of the routine, validate the config
+		// with the routine as the backup-data-path.
+		routine, ok := backupSvcConfig.BackupRoutines[pointInTime.Routine]
+		if !ok || routine == nil {
+			return fmt.Errorf("backup routine %s not found in backup service config", pointInTime.Routine)
+		}
+
+		for _, key := range []string{asdbv1beta1.SourceKey, asdbv1beta1.SourceNameKey} {
+			if _, ok := restoreConfig[key]; ok {
+				return fmt.Errorf("%s field is not allowed in restore config along with pointInTime", key)
+			}
+		}
+
+		config, err = backup_service.SetRestoreBackupDataPath(config, routine.Storage, pointInTime.Routine)
+		if err != nil {
+			return err
+		}
+
+		var restoreRequest dto.RestoreRequest
+
+		if err := yaml.UnmarshalStrict(config, &restoreRequest); err != nil {
+			return err
+		}
+
+		return validation.ValidateRestoreRequest(&restoreRequest, backupSvcConfig)
+	}
+
 	switch restore.Spec.Type {
 	case asdbv1beta1.Full, asdbv1beta1.Incremental:
 		var restoreRequest dto.RestoreRequest
@@ -177,7 +408,7 @@ func validateRestoreConfig(k8sClient client.Client, restore *asdbv1beta1.Aerospi
 			return fmt.Errorf("time field is not allowed in restore config for restore type %s", restore.Spec.Type)
 		}
 
-		if err := yaml.UnmarshalStrict(restore.Spec.Config.Raw, &restoreRequest); err != nil {
+		if err := yaml.UnmarshalStrict(config, &restoreRequest); err != nil {
 			return err
 		}
 
@@ -190,7 +421,7 @@ func validateRestoreConfig(k8sClient client.Client, restore *asdbv1beta1.Aerospi
 			return fmt.Errorf("source field is not allowed in restore config for restore type %s", restore.Spec.Type)
 		}
 
-		if err := yaml.UnmarshalStrict(restore.Spec.Config.Raw, &restoreRequest); err != nil {
+		if err := yaml.UnmarshalStrict(config, &restoreRequest); err != nil {
 			return err
 		}
 
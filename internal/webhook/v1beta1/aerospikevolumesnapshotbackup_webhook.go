@@ -0,0 +1,172 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+const defaultSnapshotWindow time.Duration = 5 * time.Minute
+
+// SetupAerospikeVolumeSnapshotBackupWebhookWithManager registers the webhook for AerospikeVolumeSnapshotBackup
+// in the manager.
+func SetupAerospikeVolumeSnapshotBackupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&asdbv1beta1.AerospikeVolumeSnapshotBackup{}).
+		WithDefaulter(&AerospikeVolumeSnapshotBackupCustomDefaulter{}).
+		WithValidator(&AerospikeVolumeSnapshotBackupCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:object:generate=false
+// Above marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type AerospikeVolumeSnapshotBackupCustomDefaulter struct {
+	// Default values for various AerospikeVolumeSnapshotBackup fields
+}
+
+//nolint:lll // for readability
+// +kubebuilder:webhook:path=/mutate-asdb-aerospike-com-v1beta1-aerospikevolumesnapshotbackup,mutating=true,failurePolicy=fail,sideEffects=None,groups=asdb.aerospike.com,resources=aerospikevolumesnapshotbackups,verbs=create;update,versions=v1beta1,name=maerospikevolumesnapshotbackup.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomDefaulter = &AerospikeVolumeSnapshotBackupCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (vsd *AerospikeVolumeSnapshotBackupCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	backup, ok := obj.(*asdbv1beta1.AerospikeVolumeSnapshotBackup)
+	if !ok {
+		return fmt.Errorf("expected AerospikeVolumeSnapshotBackup, got %T", obj)
+	}
+
+	vsLog := logf.Log.WithName(namespacedName(backup))
+
+	vsLog.Info("Setting defaults for aerospikeVolumeSnapshotBackup")
+
+	if backup.Spec.SnapshotWindow.Duration == 0 {
+		backup.Spec.SnapshotWindow.Duration = defaultSnapshotWindow
+	}
+
+	return nil
+}
+
+// +kubebuilder:object:generate=false
+type AerospikeVolumeSnapshotBackupCustomValidator struct {
+}
+
+//nolint:lll // for readability
+// +kubebuilder:webhook:path=/validate-asdb-aerospike-com-v1beta1-aerospikevolumesnapshotbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=asdb.aerospike.com,resources=aerospikevolumesnapshotbackups,verbs=create;update,versions=v1beta1,name=vaerospikevolumesnapshotbackup.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &AerospikeVolumeSnapshotBackupCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (vsv *AerospikeVolumeSnapshotBackupCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	backup, ok := obj.(*asdbv1beta1.AerospikeVolumeSnapshotBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeVolumeSnapshotBackup, got %T", obj)
+	}
+
+	vsLog := logf.Log.WithName(namespacedName(backup))
+
+	vsLog.Info("Validate create")
+
+	return nil, validateVolumeSnapshotBackupSpec(&backup.Spec)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (vsv *AerospikeVolumeSnapshotBackupCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	backup, ok := newObj.(*asdbv1beta1.AerospikeVolumeSnapshotBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeVolumeSnapshotBackup, got %T", newObj)
+	}
+
+	vsLog := logf.Log.WithName(namespacedName(backup))
+
+	vsLog.Info("Validate update")
+
+	oldBackup := oldObj.(*asdbv1beta1.AerospikeVolumeSnapshotBackup)
+
+	if oldBackup.Spec.ClusterName != backup.Spec.ClusterName {
+		return nil, fmt.Errorf("clusterName cannot be updated")
+	}
+
+	return nil, validateVolumeSnapshotBackupSpec(&backup.Spec)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (vsv *AerospikeVolumeSnapshotBackupCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	backup, ok := obj.(*asdbv1beta1.AerospikeVolumeSnapshotBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeVolumeSnapshotBackup, got %T", obj)
+	}
+
+	vsLog := logf.Log.WithName(namespacedName(backup))
+
+	vsLog.Info("Validate delete")
+
+	return nil, nil
+}
+
+func validateVolumeSnapshotBackupSpec(spec *asdbv1beta1.AerospikeVolumeSnapshotBackupSpec) error {
+	if spec.ClusterName == "" {
+		return fmt.Errorf("clusterName cannot be empty")
+	}
+
+	if spec.VolumeSnapshotClassName == "" {
+		return fmt.Errorf("volumeSnapshotClassName cannot be empty")
+	}
+
+	if spec.Interval != nil && spec.Interval.Duration <= 0 {
+		return fmt.Errorf("interval should be a positive duration, given %s", spec.Interval.Duration)
+	}
+
+	if spec.SnapshotWindow.Duration <= 0 {
+		return fmt.Errorf("snapshotWindow should be a positive duration, given %s", spec.SnapshotWindow.Duration)
+	}
+
+	if spec.Interval != nil && spec.Interval.Duration <= spec.SnapshotWindow.Duration {
+		return fmt.Errorf("interval %s should be greater than snapshotWindow %s",
+			spec.Interval.Duration, spec.SnapshotWindow.Duration)
+	}
+
+	if spec.Retention != nil && spec.Retention.MaxAge != nil && spec.Retention.MaxAge.Duration <= 0 {
+		return fmt.Errorf("retention maxAge should be a positive duration, given %s", spec.Retention.MaxAge.Duration)
+	}
+
+	if spec.InfoHooks != nil {
+		for _, cmds := range [][]string{spec.InfoHooks.PreSnapshot, spec.InfoHooks.PostSnapshot} {
+			for _, cmd := range cmds {
+				if strings.TrimSpace(cmd) == "" {
+					return fmt.Errorf("infoHooks commands cannot be empty")
+				}
+			}
+		}
+	}
+
+	return nil
+}
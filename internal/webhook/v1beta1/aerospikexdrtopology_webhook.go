@@ -0,0 +1,201 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+// xdrTopologyManagedDCKeys are the DC config keys generated by the operator.
+var xdrTopologyManagedDCKeys = []string{"name", "node-address-ports"}
+
+// SetupAerospikeXDRTopologyWebhookWithManager registers the webhook for AerospikeXDRTopology in the manager.
+func SetupAerospikeXDRTopologyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&asdbv1beta1.AerospikeXDRTopology{}).
+		WithDefaulter(&AerospikeXDRTopologyCustomDefaulter{}).
+		WithValidator(&AerospikeXDRTopologyCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:object:generate=false
+// Above marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type AerospikeXDRTopologyCustomDefaulter struct {
+	// Default values for various AerospikeXDRTopology fields
+}
+
+//nolint:lll // for readability
+// +kubebuilder:webhook:path=/mutate-asdb-aerospike-com-v1beta1-aerospikexdrtopology,mutating=true,failurePolicy=fail,sideEffects=None,groups=asdb.aerospike.com,resources=aerospikexdrtopologies,verbs=create;update,versions=v1beta1,name=maerospikexdrtopology.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomDefaulter = &AerospikeXDRTopologyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (xd *AerospikeXDRTopologyCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	topology, ok := obj.(*asdbv1beta1.AerospikeXDRTopology)
+	if !ok {
+		return fmt.Errorf("expected AerospikeXDRTopology, got %T", obj)
+	}
+
+	xdrLog := logf.Log.WithName(namespacedName(topology))
+
+	xdrLog.Info("Setting defaults for aerospikeXDRTopology")
+
+	for idx := range topology.Spec.Sources {
+		if topology.Spec.Sources[idx].Namespace == "" {
+			topology.Spec.Sources[idx].Namespace = topology.Namespace
+		}
+	}
+
+	for idx := range topology.Spec.Destinations {
+		destination := &topology.Spec.Destinations[idx]
+
+		if destination.Cluster.Namespace == "" {
+			destination.Cluster.Namespace = topology.Namespace
+		}
+
+		if destination.SeedSource == "" {
+			destination.SeedSource = asdbv1beta1.XDRSeedSourcePod
+		}
+	}
+
+	return nil
+}
+
+// +kubebuilder:object:generate=false
+type AerospikeXDRTopologyCustomValidator struct {
+}
+
+//nolint:lll // for readability
+// +kubebuilder:webhook:path=/validate-asdb-aerospike-com-v1beta1-aerospikexdrtopology,mutating=false,failurePolicy=fail,sideEffects=None,groups=asdb.aerospike.com,resources=aerospikexdrtopologies,verbs=create;update,versions=v1beta1,name=vaerospikexdrtopology.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &AerospikeXDRTopologyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (xv *AerospikeXDRTopologyCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	topology, ok := obj.(*asdbv1beta1.AerospikeXDRTopology)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeXDRTopology, got %T", obj)
+	}
+
+	xdrLog := logf.Log.WithName(namespacedName(topology))
+
+	xdrLog.Info("Validate create")
+
+	return nil, validateXDRTopologySpec(&topology.Spec)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (xv *AerospikeXDRTopologyCustomValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object,
+) (admission.Warnings, error) {
+	topology, ok := newObj.(*asdbv1beta1.AerospikeXDRTopology)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeXDRTopology, got %T", newObj)
+	}
+
+	xdrLog := logf.Log.WithName(namespacedName(topology))
+
+	xdrLog.Info("Validate update")
+
+	return nil, validateXDRTopologySpec(&topology.Spec)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (xv *AerospikeXDRTopologyCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	topology, ok := obj.(*asdbv1beta1.AerospikeXDRTopology)
+	if !ok {
+		return nil, fmt.Errorf("expected AerospikeXDRTopology, got %T", obj)
+	}
+
+	xdrLog := logf.Log.WithName(namespacedName(topology))
+
+	xdrLog.Info("Validate delete")
+
+	return nil, nil
+}
+
+func validateXDRTopologySpec(spec *asdbv1beta1.AerospikeXDRTopologySpec) error {
+	if len(spec.Sources) == 0 {
+		return fmt.Errorf("sources cannot be empty")
+	}
+
+	if len(spec.Destinations) == 0 {
+		return fmt.Errorf("destinations cannot be empty")
+	}
+
+	sources := sets.New[string]()
+
+	for idx := range spec.Sources {
+		if spec.Sources[idx].Name == "" {
+			return fmt.Errorf("source cluster name cannot be empty")
+		}
+
+		if sources.Has(spec.Sources[idx].String()) {
+			return fmt.Errorf("duplicate source cluster %s", spec.Sources[idx].String())
+		}
+
+		sources.Insert(spec.Sources[idx].String())
+	}
+
+	dcNames := sets.New[string]()
+
+	for idx := range spec.Destinations {
+		destination := &spec.Destinations[idx]
+
+		if destination.DCName == "" {
+			return fmt.Errorf("destination dcName cannot be empty")
+		}
+
+		if dcNames.Has(destination.DCName) {
+			return fmt.Errorf("duplicate destination dcName %s", destination.DCName)
+		}
+
+		dcNames.Insert(destination.DCName)
+
+		if destination.Cluster.Name == "" {
+			return fmt.Errorf("destination cluster name cannot be empty for dc %s", destination.DCName)
+		}
+
+		if destination.DCConfig != nil && len(destination.DCConfig.Raw) > 0 {
+			dcConfig := map[string]interface{}{}
+			if err := json.Unmarshal(destination.DCConfig.Raw, &dcConfig); err != nil {
+				return fmt.Errorf("invalid dcConfig for dc %s: %v", destination.DCName, err)
+			}
+
+			for _, key := range xdrTopologyManagedDCKeys {
+				if _, ok := dcConfig[key]; ok {
+					return fmt.Errorf("dcConfig for dc %s cannot have %s, it is generated by the operator",
+						destination.DCName, key)
+				}
+			}
+		}
+	}
+
+	return nil
+}
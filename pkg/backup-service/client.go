@@ -4,19 +4,25 @@ package backupservice
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	url2 "net/url"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
 )
 
@@ -24,6 +30,17 @@ const restAPIVersion = "v1"
 const defaultContextPath = "/"
 const contentTypeJSON = "application/json"
 
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
 type Client struct {
 	// The address to listen on.
 	Address string `json:"address,omitempty"`
@@ -33,726 +50,737 @@ type Client struct {
 
 	// The port to listen on.
 	Port int32 `json:"port,omitempty"`
+
+	// Scheme is the URL scheme of the API, http or https. Defaults to http.
+	Scheme string `json:"scheme,omitempty"`
+
+	httpClient   *http.Client
+	tlsConfig    *tls.Config
+	timeout      time.Duration
+	bearerToken  string
+	username     string
+	password     string
+	maxRetries   int
+	retryBackoff time.Duration
 }
 
-func GetBackupServiceClient(k8sClient client.Client, svc *v1beta1.BackupService) (*Client, error) {
-	backupSvc := &v1beta1.AerospikeBackupService{}
+// ClientOption configures the Client.
+type ClientOption func(*Client) error
 
-	if err := k8sClient.Get(context.TODO(),
-		types.NamespacedName{
-			Namespace: svc.Namespace,
-			Name:      svc.Name,
-		}, backupSvc,
-	); err != nil {
-		return nil, err
-	}
+// WithTLS enables HTTPS. The caPEM is used to verify the backup service certificate, the system CA pool
+// is used if it is empty. The serverName is used to verify the certificate hostname if set.
+func WithTLS(caPEM []byte, serverName string) ClientOption {
+	return func(c *Client) error {
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: serverName,
+		}
 
-	return NewClient(
-		fmt.Sprintf("%s.%s.svc", backupSvc.Name, backupSvc.Namespace),
-		backupSvc.Status.Port,
-		backupSvc.Status.ContextPath,
-	), nil
-}
+		if len(caPEM) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return fmt.Errorf("failed to parse backup service CA certificate")
+			}
 
-func NewClient(address string, port int32, contextPath string) *Client {
-	return &Client{
-		Address:     address,
-		Port:        port,
-		ContextPath: contextPath,
-	}
-}
+			tlsConfig.RootCAs = pool
+		}
 
-func (c *Client) getAddress() string {
-	return c.Address
-}
+		c.Scheme = schemeHTTPS
+		c.tlsConfig = tlsConfig
 
-func (c *Client) getPort() int32 {
-	return c.Port
+		return nil
+	}
 }
 
-func (c *Client) getContextPath() string {
-	if c.ContextPath != "" {
-		return c.ContextPath
+// WithBearerToken sends the token as a bearer token in every request.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) error {
+		c.bearerToken = token
+		return nil
 	}
-
-	return defaultContextPath
 }
 
-func (c *Client) CheckBackupServiceHealth() error {
-	url := c.API("/health")
+// WithBasicAuth sends the username and password as basic auth in every request.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *Client) error {
+		c.username = username
+		c.password = password
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
+		return nil
 	}
+}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("backup service is not healthy")
+// WithTimeout sets the timeout of a single request attempt.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.timeout = timeout
+		return nil
 	}
-
-	return nil
 }
 
-func (c *Client) GetBackupServiceConfig() (map[string]interface{}, error) {
-	url := c.API("/config")
+// WithRetry sets the number of retries of idempotent requests and the initial backoff between them.
+// The backoff is doubled after every retry.
+func WithRetry(maxRetries int, backoff time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+		return nil
 	}
+}
 
-	defer resp.Body.Close()
+// APIError is returned when the backup service responds with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status code %d, error: %s", e.StatusCode, e.Body)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get backup service config")
+// StatusCode returns the status code of the APIError in the err chain, 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
 	}
 
-	conf := make(map[string]interface{})
+	return 0
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+func GetBackupServiceClient(k8sClient client.Client, svc *v1beta1.BackupService) (*Client, error) {
+	conn := svc.Connection
+	if conn == nil {
+		conn = &v1beta1.BackupServiceConnection{}
 	}
 
-	if err := json.Unmarshal(body, &conf); err != nil {
-		return nil, err
+	address := conn.Address
+	if address == "" {
+		address = fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace)
 	}
 
-	return conf, nil
-}
+	port := conn.Port
+	contextPath := conn.ContextPath
 
-func (c *Client) ApplyConfig() error {
-	url := c.API("/config/apply")
+	// The AerospikeBackupService is not required if the connection is fully specified.
+	if port == 0 || contextPath == "" {
+		backupSvc := &v1beta1.AerospikeBackupService{}
 
-	resp, err := http.Post(url, contentTypeJSON, nil)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
+		if err := k8sClient.Get(context.TODO(),
+			types.NamespacedName{
+				Namespace: svc.Namespace,
+				Name:      svc.Name,
+			}, backupSvc,
+		); err != nil {
+			return nil, err
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
+		if port == 0 {
+			port = backupSvc.Status.Port
 		}
 
-		return fmt.Errorf("failed to apply latest config, error: %s", string(body))
+		if contextPath == "" {
+			contextPath = backupSvc.Status.ContextPath
+		}
 	}
 
-	return nil
+	return NewClientWithConnection(k8sClient, svc.Namespace, address, port, contextPath, conn)
 }
 
-func (c *Client) GetClusters() (map[string]interface{}, error) {
-	url := c.API("/config/clusters")
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get aerospike clusters")
+// NewClientWithConnection returns a client of the backup service API at the given address, with the timeout,
+// TLS and auth options of the connection. The secrets of the connection are read from the given namespace.
+func NewClientWithConnection(
+	k8sClient client.Client, namespace, address string, port int32, contextPath string,
+	conn *v1beta1.BackupServiceConnection,
+) (*Client, error) {
+	if conn == nil {
+		conn = &v1beta1.BackupServiceConnection{}
 	}
 
-	aerospikeClusters := make(map[string]interface{})
-
-	body, err := io.ReadAll(resp.Body)
+	opts, err := getClientOptions(k8sClient, namespace, conn)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &aerospikeClusters); err != nil {
-		return nil, err
-	}
-
-	return aerospikeClusters, nil
+	return NewClient(address, port, contextPath, opts...)
 }
 
-func (c *Client) PutCluster(name, cluster interface{}) error {
-	url := c.API(fmt.Sprintf("/config/clusters/%s", name))
+func getClientOptions(
+	k8sClient client.Client, namespace string, conn *v1beta1.BackupServiceConnection,
+) ([]ClientOption, error) {
+	var opts []ClientOption
 
-	jsonBody, err := json.Marshal(cluster)
-	if err != nil {
-		return err
+	if conn.Timeout != nil {
+		opts = append(opts, WithTimeout(conn.Timeout.Duration))
 	}
 
-	bodyReader := bytes.NewReader(jsonBody)
+	if conn.TLS != nil {
+		var caPEM []byte
 
-	req, err := http.NewRequest(http.MethodPut, url, bodyReader)
-	if err != nil {
-		return err
-	}
+		if conn.TLS.CASecretName != "" {
+			caKey := conn.TLS.CAKey
+			if caKey == "" {
+				caKey = v1beta1.BackupServiceDefaultCAKey
+			}
 
-	cl := &http.Client{}
+			data, err := getSecretData(k8sClient, namespace, conn.TLS.CASecretName, caKey)
+			if err != nil {
+				return nil, err
+			}
 
-	resp, err := cl.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
+			caPEM = data[caKey]
 		}
 
-		return fmt.Errorf("failed to put aerospike cluster, error: %s", string(body))
+		opts = append(opts, WithTLS(caPEM, conn.TLS.ServerName))
 	}
 
-	return nil
-}
+	if conn.Auth != nil {
+		switch conn.Auth.Type {
+		case v1beta1.BackupServiceAuthBearer:
+			data, err := getSecretData(k8sClient, namespace, conn.Auth.SecretName, v1beta1.BackupServiceAuthTokenKey)
+			if err != nil {
+				return nil, err
+			}
 
-func (c *Client) DeleteCluster(name string) error {
-	url := c.API(fmt.Sprintf("/config/clusters/%s", name))
+			opts = append(opts, WithBearerToken(strings.TrimSpace(string(data[v1beta1.BackupServiceAuthTokenKey]))))
 
-	req, err := http.NewRequest(http.MethodDelete, url, http.NoBody)
-	if err != nil {
-		return err
-	}
+		case v1beta1.BackupServiceAuthBasic:
+			data, err := getSecretData(k8sClient, namespace, conn.Auth.SecretName,
+				v1beta1.BackupServiceAuthUsernameKey, v1beta1.BackupServiceAuthPasswordKey)
+			if err != nil {
+				return nil, err
+			}
 
-	cl := &http.Client{}
+			opts = append(opts, WithBasicAuth(string(data[v1beta1.BackupServiceAuthUsernameKey]),
+				string(data[v1beta1.BackupServiceAuthPasswordKey])))
 
-	resp, err := cl.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusNoContent {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
+		default:
+			return nil, fmt.Errorf("unsupported backup service auth type %s", conn.Auth.Type)
 		}
-
-		return fmt.Errorf("failed to delete aerospike cluster, error: %s", string(body))
 	}
 
-	return nil
+	return opts, nil
 }
 
-func (c *Client) AddCluster(name, cluster interface{}) error {
-	url := c.API(fmt.Sprintf("/config/clusters/%s", name))
-
-	jsonBody, err := json.Marshal(cluster)
-	if err != nil {
-		return err
-	}
+func getSecretData(k8sClient client.Client, namespace, name string, keys ...string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
 
-	bodyReader := bytes.NewReader(jsonBody)
-
-	resp, err := http.Post(url, contentTypeJSON, bodyReader)
-	if err != nil {
-		return err
+	if err := k8sClient.Get(context.TODO(),
+		types.NamespacedName{
+			Namespace: namespace,
+			Name:      name,
+		}, secret,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get backup service secret %s/%s: %v", namespace, name, err)
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
+	for _, key := range keys {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, fmt.Errorf("key %s not found in backup service secret %s/%s", key, namespace, name)
 		}
-
-		return fmt.Errorf("failed to update aerospike cluster, error: %s", string(body))
 	}
 
-	return nil
+	return secret.Data, nil
 }
 
-func (c *Client) GetBackupPolicies() (map[string]interface{}, error) {
-	url := c.API("/config/policies")
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+func NewClient(address string, port int32, contextPath string, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		Address:      address,
+		Port:         port,
+		ContextPath:  contextPath,
+		timeout:      defaultTimeout,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get backup policies")
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
 	}
 
-	policies := make(map[string]interface{})
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = c.tlsConfig
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	c.httpClient = &http.Client{
+		Transport: transport,
+		Timeout:   c.timeout,
 	}
 
-	if err := json.Unmarshal(body, &policies); err != nil {
-		return nil, err
-	}
+	return c, nil
+}
 
-	return policies, nil
+func (c *Client) getAddress() string {
+	return c.Address
 }
 
-func (c *Client) PutBackupPolicy(name string, policy interface{}) error {
-	url := c.API(fmt.Sprintf("/config/policies/%s", name))
+func (c *Client) getPort() int32 {
+	return c.Port
+}
 
-	jsonBody, err := json.Marshal(policy)
-	if err != nil {
-		return err
+func (c *Client) getContextPath() string {
+	if c.ContextPath != "" {
+		return c.ContextPath
 	}
 
-	bodyReader := bytes.NewReader(jsonBody)
+	return defaultContextPath
+}
 
-	req, err := http.NewRequest(http.MethodPut, url, bodyReader)
-	if err != nil {
-		return err
+func (c *Client) getScheme() string {
+	if c.Scheme != "" {
+		return c.Scheme
 	}
 
-	cl := &http.Client{}
+	return schemeHTTP
+}
 
-	resp, err := cl.Do(req)
-	if err != nil {
-		return err
+func (c *Client) getHTTPClient() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
+	return &http.Client{Timeout: defaultTimeout}
+}
 
-		return fmt.Errorf("failed to put backup policy, error: %s", string(body))
+func (c *Client) CheckBackupServiceHealth(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodGet, "/health", nil, nil, nil, http.StatusOK); err != nil {
+		return fmt.Errorf("backup service is not healthy: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) AddBackupPolicy(name string, policy interface{}) error {
-	url := c.API(fmt.Sprintf("/config/policies/%s", name))
+// GetBackupServiceConfig returns the backup service config in its raw form,
+// so that it can be compared with the config in the backup service ConfigMap.
+func (c *Client) GetBackupServiceConfig(ctx context.Context) (map[string]interface{}, error) {
+	conf := make(map[string]interface{})
 
-	jsonBody, err := json.Marshal(policy)
-	if err != nil {
-		return err
+	if err := c.do(ctx, http.MethodGet, "/config", nil, nil, &conf, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backup service config: %w", err)
 	}
 
-	bodyReader := bytes.NewReader(jsonBody)
+	return conf, nil
+}
+
+// GetConfig returns the backup service config.
+func (c *Client) GetConfig(ctx context.Context) (*dto.Config, error) {
+	conf := &dto.Config{}
 
-	resp, err := http.Post(url, contentTypeJSON, bodyReader)
-	if err != nil {
-		return err
+	if err := c.do(ctx, http.MethodGet, "/config", nil, nil, conf, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backup service config: %w", err)
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
+	return conf, nil
+}
 
-		return fmt.Errorf("failed to update backup policy, error: %s", string(body))
+func (c *Client) ApplyConfig(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodPost, "/config/apply", nil, nil, nil, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to apply latest config: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) GetBackupRoutines() {}
-
-func (c *Client) PutBackupRoutine(name string, routine interface{}) error {
-	url := c.API(fmt.Sprintf("/config/routines/%s", name))
+func (c *Client) GetClusters(ctx context.Context) (map[string]*dto.AerospikeCluster, error) {
+	aerospikeClusters := make(map[string]*dto.AerospikeCluster)
 
-	jsonBody, err := json.Marshal(routine)
-	if err != nil {
-		return err
+	if err := c.do(ctx, http.MethodGet, "/config/clusters", nil, nil, &aerospikeClusters,
+		http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get aerospike clusters: %w", err)
 	}
 
-	bodyReader := bytes.NewReader(jsonBody)
+	return aerospikeClusters, nil
+}
 
-	req, err := http.NewRequest(http.MethodPut, url, bodyReader)
-	if err != nil {
-		return err
+func (c *Client) PutCluster(ctx context.Context, name string, cluster *dto.AerospikeCluster) error {
+	if err := c.do(ctx, http.MethodPut, "/config/clusters/"+url2.PathEscape(name), nil, cluster, nil,
+		http.StatusOK); err != nil {
+		return fmt.Errorf("failed to put aerospike cluster: %w", err)
 	}
 
-	cl := &http.Client{}
+	return nil
+}
 
-	resp, err := cl.Do(req)
-	if err != nil {
-		return err
+func (c *Client) DeleteCluster(ctx context.Context, name string) error {
+	if err := c.do(ctx, http.MethodDelete, "/config/clusters/"+url2.PathEscape(name), nil, nil, nil,
+		http.StatusNoContent); err != nil {
+		return fmt.Errorf("failed to delete aerospike cluster: %w", err)
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		return fmt.Errorf("failed to put backup routine, error: %s", string(body))
+func (c *Client) AddCluster(ctx context.Context, name string, cluster *dto.AerospikeCluster) error {
+	if err := c.do(ctx, http.MethodPost, "/config/clusters/"+url2.PathEscape(name), nil, cluster, nil,
+		http.StatusCreated); err != nil {
+		return fmt.Errorf("failed to add aerospike cluster: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) AddBackupRoutine(name string, routine interface{}) error {
-	url := c.API(fmt.Sprintf("/config/routines/%s", name))
+func (c *Client) GetBackupPolicies(ctx context.Context) (map[string]*dto.BackupPolicy, error) {
+	policies := make(map[string]*dto.BackupPolicy)
 
-	jsonBody, err := json.Marshal(routine)
-	if err != nil {
-		return err
+	if err := c.do(ctx, http.MethodGet, "/config/policies", nil, nil, &policies, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backup policies: %w", err)
 	}
 
-	bodyReader := bytes.NewReader(jsonBody)
+	return policies, nil
+}
 
-	resp, err := http.Post(url, contentTypeJSON, bodyReader)
-	if err != nil {
-		return err
+func (c *Client) PutBackupPolicy(ctx context.Context, name string, policy *dto.BackupPolicy) error {
+	if err := c.do(ctx, http.MethodPut, "/config/policies/"+url2.PathEscape(name), nil, policy, nil,
+		http.StatusOK); err != nil {
+		return fmt.Errorf("failed to put backup policy: %w", err)
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		return fmt.Errorf("failed to update backup routine, error: %s", string(body))
+func (c *Client) AddBackupPolicy(ctx context.Context, name string, policy *dto.BackupPolicy) error {
+	if err := c.do(ctx, http.MethodPost, "/config/policies/"+url2.PathEscape(name), nil, policy, nil,
+		http.StatusCreated); err != nil {
+		return fmt.Errorf("failed to add backup policy: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) DeleteBackupRoutine(name string) error {
-	url := c.API(fmt.Sprintf("/config/routines/%s", name))
+func (c *Client) GetBackupRoutines(ctx context.Context) (map[string]*dto.BackupRoutine, error) {
+	routines := make(map[string]*dto.BackupRoutine)
 
-	req, err := http.NewRequest(http.MethodDelete, url, http.NoBody)
-	if err != nil {
-		return err
+	if err := c.do(ctx, http.MethodGet, "/config/routines", nil, nil, &routines, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backup routines: %w", err)
 	}
 
-	cl := &http.Client{}
-
-	resp, err := cl.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusNoContent {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
+	return routines, nil
+}
 
-		return fmt.Errorf("failed to delete backup routine, error: %s", string(body))
+func (c *Client) PutBackupRoutine(ctx context.Context, name string, routine *dto.BackupRoutine) error {
+	if err := c.do(ctx, http.MethodPut, "/config/routines/"+url2.PathEscape(name), nil, routine, nil,
+		http.StatusOK); err != nil {
+		return fmt.Errorf("failed to put backup routine: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) GetStorage() (map[string]interface{}, error) {
-	url := c.API("/config/storage")
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+func (c *Client) AddBackupRoutine(ctx context.Context, name string, routine *dto.BackupRoutine) error {
+	if err := c.do(ctx, http.MethodPost, "/config/routines/"+url2.PathEscape(name), nil, routine, nil,
+		http.StatusCreated); err != nil {
+		return fmt.Errorf("failed to add backup routine: %w", err)
 	}
 
-	defer resp.Body.Close()
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get backup storage")
+func (c *Client) DeleteBackupRoutine(ctx context.Context, name string) error {
+	if err := c.do(ctx, http.MethodDelete, "/config/routines/"+url2.PathEscape(name), nil, nil, nil,
+		http.StatusNoContent); err != nil {
+		return fmt.Errorf("failed to delete backup routine: %w", err)
 	}
 
-	storage := make(map[string]interface{})
+	return nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetStorage(ctx context.Context) (map[string]*dto.Storage, error) {
+	storage := make(map[string]*dto.Storage)
 
-	if err := json.Unmarshal(body, &storage); err != nil {
-		return nil, err
+	if err := c.do(ctx, http.MethodGet, "/config/storage", nil, nil, &storage, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backup storage: %w", err)
 	}
 
 	return storage, nil
 }
 
-func (c *Client) PutStorage(name string, storage interface{}) error {
-	url := c.API(fmt.Sprintf("/config/storage/%s", name))
-
-	jsonBody, err := json.Marshal(storage)
-	if err != nil {
-		return err
-	}
-
-	bodyReader := bytes.NewReader(jsonBody)
-
-	req, err := http.NewRequest(http.MethodPut, url, bodyReader)
-	if err != nil {
-		return err
-	}
-
-	cl := &http.Client{}
-
-	resp, err := cl.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-
-		return fmt.Errorf("failed to put backup storage, error: %s", string(body))
+func (c *Client) PutStorage(ctx context.Context, name string, storage *dto.Storage) error {
+	if err := c.do(ctx, http.MethodPut, "/config/storage/"+url2.PathEscape(name), nil, storage, nil,
+		http.StatusOK); err != nil {
+		return fmt.Errorf("failed to put backup storage: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) AddStorage(name string, storage interface{}) error {
-	url := c.API(fmt.Sprintf("/config/storage/%s", name))
-
-	jsonBody, err := json.Marshal(storage)
-	if err != nil {
-		return err
-	}
-
-	bodyReader := bytes.NewReader(jsonBody)
-
-	resp, err := http.Post(url, contentTypeJSON, bodyReader)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-
-		return fmt.Errorf("failed to update backup storage, error: %s", string(body))
+func (c *Client) AddStorage(ctx context.Context, name string, storage *dto.Storage) error {
+	if err := c.do(ctx, http.MethodPost, "/config/storage/"+url2.PathEscape(name), nil, storage, nil,
+		http.StatusCreated); err != nil {
+		return fmt.Errorf("failed to add backup storage: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) GetFullBackups() (map[string][]interface{}, error) {
-	url := c.API("/backups/full")
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
+func (c *Client) GetFullBackups(ctx context.Context) (map[string][]dto.BackupDetails, error) {
+	backups := make(map[string][]dto.BackupDetails)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get backups")
+	if err := c.do(ctx, http.MethodGet, "/backups/full", nil, nil, &backups, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backups: %w", err)
 	}
 
-	backups := make(map[string][]interface{})
+	return backups, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetFullBackupsForRoutine(ctx context.Context, routineName string) ([]dto.BackupDetails, error) {
+	var backups []dto.BackupDetails
 
-	if err := json.Unmarshal(body, &backups); err != nil {
-		return nil, err
+	if err := c.do(ctx, http.MethodGet, "/backups/full/"+url2.PathEscape(routineName), nil, nil, &backups,
+		http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get backups: %w", err)
 	}
 
 	return backups, nil
 }
 
-func (c *Client) GetFullBackupsForRoutine(routineName string) ([]interface{}, error) {
-	url := c.API(fmt.Sprintf("/backups/full/%s", routineName))
+func (c *Client) GetIncrementalBackups(ctx context.Context) (map[string][]dto.BackupDetails, error) {
+	backups := make(map[string][]dto.BackupDetails)
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+	if err := c.do(ctx, http.MethodGet, "/backups/incremental", nil, nil, &backups, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get incremental backups: %w", err)
 	}
 
-	defer resp.Body.Close()
+	return backups, nil
+}
+
+func (c *Client) GetIncrementalBackupsForRoutine(ctx context.Context, routineName string,
+) ([]dto.BackupDetails, error) {
+	var backups []dto.BackupDetails
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get backups")
+	if err := c.do(ctx, http.MethodGet, "/backups/incremental/"+url2.PathEscape(routineName), nil, nil, &backups,
+		http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get incremental backups: %w", err)
 	}
 
-	var backups []interface{}
+	return backups, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// GetCurrentBackup returns the state of the running and the last backups of the routine.
+func (c *Client) GetCurrentBackup(ctx context.Context, routineName string) (*dto.RoutineState, error) {
+	state := &dto.RoutineState{}
 
-	if err := json.Unmarshal(body, &backups); err != nil {
-		return nil, err
+	if err := c.do(ctx, http.MethodGet, "/backups/currentBackup/"+url2.PathEscape(routineName), nil, nil, state,
+		http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get current backup: %w", err)
 	}
 
-	return backups, nil
+	return state, nil
 }
 
-func (c *Client) ScheduleBackup(routineName string, delay metav1.Duration) error {
-	url, err := url2.Parse(c.API(fmt.Sprintf("/backups/schedule/%s", routineName)))
-	if err != nil {
-		return err
-	}
+func (c *Client) ScheduleBackup(ctx context.Context, routineName string, delay metav1.Duration) error {
+	var query url2.Values
 
 	if delay.Milliseconds() > 0 {
-		query := url.Query()
+		query = url2.Values{}
 		query.Add("delay", fmt.Sprintf("%d", delay.Milliseconds()))
-		url.RawQuery = query.Encode()
 	}
 
-	resp, err := http.Post(url.String(), contentTypeJSON, nil)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("failed to schedule backup")
+	if err := c.do(ctx, http.MethodPost, "/backups/schedule/"+url2.PathEscape(routineName), query, nil, nil,
+		http.StatusAccepted); err != nil {
+		return fmt.Errorf("failed to schedule backup: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Client) TriggerRestoreWithType(log logr.Logger, restoreType string,
-	request []byte) (jobID *int64, statusCode *int, err error) {
+func (c *Client) TriggerRestoreWithType(ctx context.Context, log logr.Logger, restoreType string,
+	request []byte) (int64, error) {
 	log.Info(fmt.Sprintf("Triggering %s restore", restoreType))
 
-	var url string
+	var pattern string
 
 	switch restoreType {
 	case "Full":
-		url = c.API("/restore/full")
+		pattern = "/restore/full"
 
 	case "Incremental":
-		url = c.API("/restore/incremental")
+		pattern = "/restore/incremental"
 
 	case "Timestamp":
-		url = c.API("/restore/timestamp")
+		pattern = "/restore/timestamp"
 
 	default:
-		return nil, nil, fmt.Errorf("unsupported restore type")
+		return 0, fmt.Errorf("unsupported restore type")
 	}
 
 	jsonBody, err := yaml.YAMLToJSON(request)
 	if err != nil {
-		return nil, nil, err
+		return 0, err
 	}
 
-	bodyReader := bytes.NewReader(jsonBody)
+	var jobID int64
 
-	resp, err := http.Post(url, contentTypeJSON, bodyReader)
-	if err != nil {
-		return nil, nil, err
+	if err := c.do(ctx, http.MethodPost, pattern, nil, json.RawMessage(jsonBody), &jobID,
+		http.StatusAccepted); err != nil {
+		log.Info("Response", "status-code", StatusCode(err))
+
+		return 0, fmt.Errorf("failed to trigger %s restore: %w", restoreType, err)
 	}
 
-	defer resp.Body.Close()
+	log.Info(fmt.Sprintf("Triggered %s restore", restoreType))
 
-	if resp.StatusCode != http.StatusAccepted {
-		log.Info("Response", "status-code", resp.StatusCode)
+	return jobID, nil
+}
 
-		body, rErr := io.ReadAll(resp.Body)
-		if rErr != nil {
-			return nil, &resp.StatusCode, rErr
-		}
+func (c *Client) CheckRestoreStatus(ctx context.Context, jobID int64) (*dto.RestoreJobStatus, error) {
+	restoreStatus := &dto.RestoreJobStatus{}
 
-		return nil, &resp.StatusCode,
-			fmt.Errorf("failed to trigger %s restore, error: %s", restoreType, string(body))
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/restore/status/%d", jobID), nil, nil, restoreStatus,
+		http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to check restore status: %w", err)
 	}
 
-	jobID = new(int64)
+	return restoreStatus, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, &resp.StatusCode, err
+func (c *Client) CancelRestoreJob(ctx context.Context, jobID int64) error {
+	// 200 OK is for ABS < 3.1.0 and 202 Accepted is for ABS >= 3.1.0
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/restore/cancel/%d", jobID), nil, nil, nil,
+		http.StatusOK, http.StatusAccepted); err != nil {
+		return fmt.Errorf("failed to cancel restore job: %w", err)
 	}
 
-	if err := json.Unmarshal(body, jobID); err != nil {
-		return nil, &resp.StatusCode, err
+	return nil
+}
+
+func (c *Client) API(pattern string) string {
+	contextPath := c.getContextPath()
+
+	if !strings.HasSuffix(contextPath, "/") {
+		contextPath += "/"
 	}
 
-	log.Info(fmt.Sprintf("Triggered %s restore", restoreType))
+	address := fmt.Sprintf("%s:%d", c.getAddress(), c.getPort())
 
-	return jobID, &resp.StatusCode, nil
+	return fmt.Sprintf("%s://%s%s%s%s", c.getScheme(), address, contextPath, restAPIVersion, pattern)
 }
 
-func (c *Client) CheckRestoreStatus(jobID int64) (map[string]interface{}, error) {
-	url := c.API(fmt.Sprintf("/restore/status/%d", jobID))
+// do sends the request to the backup service API and decodes the JSON response into out if it is not nil.
+// Idempotent requests are retried with exponential backoff on network errors and transient status codes.
+func (c *Client) do(ctx context.Context, method, pattern string, query url2.Values, in, out interface{},
+	expectedStatusCodes ...int) error {
+	url := c.API(pattern)
+	if len(query) > 0 {
+		url += "?" + query.Encode()
+	}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+	var body []byte
+
+	if in != nil {
+		var err error
+
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
 	}
 
-	defer resp.Body.Close()
+	attempts := 1
+	if isIdempotent(method) {
+		attempts += c.maxRetries
+	}
+
+	backoff := c.retryBackoff
+
+	var err error
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return errors.Join(ctx.Err(), err)
+			case <-time.After(backoff):
+			}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to check restore restoreStatus")
+			backoff *= 2
+		}
+
+		var retryable bool
+
+		retryable, err = c.doOnce(ctx, method, url, body, out, expectedStatusCodes)
+		if err == nil || !retryable || ctx.Err() != nil {
+			return err
+		}
 	}
 
-	restoreStatus := make(map[string]interface{})
+	return err
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+func (c *Client) doOnce(ctx context.Context, method, url string, body []byte, out interface{},
+	expectedStatusCodes []int) (retryable bool, err error) {
+	var bodyReader io.Reader = http.NoBody
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
 	}
 
-	if err := json.Unmarshal(body, &restoreStatus); err != nil {
-		return nil, err
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return false, err
 	}
 
-	return restoreStatus, nil
-}
+	req.Header.Set("Accept", contentTypeJSON)
 
-func (c *Client) CancelRestoreJob(jobID int64) (int, error) {
-	url := c.API(fmt.Sprintf("/restore/cancel/%d", jobID))
+	if body != nil {
+		req.Header.Set("Content-Type", contentTypeJSON)
+	}
+
+	switch {
+	case c.bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.password)
+	}
 
-	resp, err := http.Post(url, contentTypeJSON, nil)
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
-		return 0, err
+		return true, err
 	}
 
 	defer resp.Body.Close()
 
-	// Check if the response status code is 200 OK or 202 Accepted
-	// 200 OK is for ABS < 3.1.0 and 202 Accepted is for ABS >= 3.1.0
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return 0, err
-		}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, err
+	}
+
+	if !containsStatusCode(expectedStatusCodes, resp.StatusCode) {
+		return isRetryableStatusCode(resp.StatusCode),
+			&APIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+	}
 
-		return resp.StatusCode, fmt.Errorf("failed to cancel restore job, error: %s", string(body))
+	if out == nil || len(respBody) == 0 {
+		return false, nil
 	}
 
-	return resp.StatusCode, nil
+	return false, json.Unmarshal(respBody, out)
 }
 
-func (c *Client) API(pattern string) string {
-	contextPath := c.getContextPath()
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
 
-	if !strings.HasSuffix(contextPath, "/") {
-		contextPath += "/"
+	return false
+}
+
+func isRetryableStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
 	}
 
-	address := fmt.Sprintf("%s:%d", c.getAddress(), c.getPort())
+	return false
+}
+
+func containsStatusCode(statusCodes []int, statusCode int) bool {
+	for _, code := range statusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
 
-	return fmt.Sprintf("http://%s%s%s%s", address, contextPath, restAPIVersion, pattern)
+	return false
 }
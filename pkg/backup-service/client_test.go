@@ -0,0 +1,203 @@
+package backupservice
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+)
+
+func newTestClient(t *testing.T, server *httptest.Server, opts ...ClientOption) *Client {
+	t.Helper()
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	portNum, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts = append([]ClientOption{WithRetry(2, time.Millisecond)}, opts...)
+
+	c, err := NewClient(host, int32(portNum), "", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestClientRetry(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCodes      []int
+		call             func(*Client) error
+		expectedRequests int32
+		expectedStatus   int
+	}{
+		{
+			name:        "idempotent request is retried on transient error",
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusOK},
+			call: func(c *Client) error {
+				_, err := c.GetBackupRoutines(context.TODO())
+				return err
+			},
+			expectedRequests: 2,
+		},
+		{
+			name: "idempotent request gives up after max retries",
+			statusCodes: []int{
+				http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway,
+			},
+			call: func(c *Client) error {
+				_, err := c.GetBackupRoutines(context.TODO())
+				return err
+			},
+			expectedRequests: 3,
+			expectedStatus:   http.StatusBadGateway,
+		},
+		{
+			name:        "non-transient error is not retried",
+			statusCodes: []int{http.StatusNotFound},
+			call: func(c *Client) error {
+				return c.DeleteBackupRoutine(context.TODO(), "routine")
+			},
+			expectedRequests: 1,
+			expectedStatus:   http.StatusNotFound,
+		},
+		{
+			name:        "non-idempotent request is not retried",
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusAccepted},
+			call: func(c *Client) error {
+				_, err := c.TriggerRestoreWithType(context.TODO(), logr.Discard(), "Full", []byte("{}"))
+				return err
+			},
+			expectedRequests: 1,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				idx := atomic.AddInt32(&requests, 1) - 1
+				w.WriteHeader(tt.statusCodes[idx])
+			}))
+			defer server.Close()
+
+			err := tt.call(newTestClient(t, server))
+
+			if requests != tt.expectedRequests {
+				t.Errorf("requests = %d, expected %d", requests, tt.expectedRequests)
+			}
+
+			if StatusCode(err) != tt.expectedStatus {
+				t.Errorf("status code = %d, expected %d, error: %v", StatusCode(err), tt.expectedStatus, err)
+			}
+
+			if tt.expectedStatus == 0 && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestClientAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		expected func(*http.Request) bool
+	}{
+		{
+			name: "no auth",
+			expected: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == ""
+			},
+		},
+		{
+			name: "bearer token",
+			opts: []ClientOption{WithBearerToken("token")},
+			expected: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == "Bearer token"
+			},
+		},
+		{
+			name: "basic auth",
+			opts: []ClientOption{WithBasicAuth("user", "pass")},
+			expected: func(r *http.Request) bool {
+				username, password, ok := r.BasicAuth()
+				return ok && username == "user" && password == "pass"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !tt.expected(r) {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			if err := newTestClient(t, server, tt.opts...).CheckBackupServiceHealth(context.TODO()); err != nil {
+				t.Errorf("CheckBackupServiceHealth() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestClientTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"Done","read-records":10}`))
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr bool
+	}{
+		{
+			name: "trusted CA",
+			opts: []ClientOption{WithTLS(caPEM, "example.com")},
+		},
+		{
+			name:    "untrusted CA",
+			opts:    []ClientOption{WithTLS(nil, "example.com")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := newTestClient(t, server, tt.opts...).CheckRestoreStatus(context.TODO(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckRestoreStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && (status.Status != dto.JobStatusDone || status.ReadRecords != 10) {
+				t.Errorf("CheckRestoreStatus() = %+v", status)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package backupservice
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigHash returns the hash of the backup service config.
+// The config is hashed in its canonical JSON form, so the hash does not depend on the key order or the format
+// the config is read in, i.e. the YAML in the ConfigMap or the JSON served by the config API.
+func ConfigHash(config map[string]interface{}) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ConfigHashFromYAML returns the hash of the backup service config in YAML format.
+func ConfigHashFromYAML(config string) (string, error) {
+	configMap := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(config), &configMap); err != nil {
+		return "", err
+	}
+
+	return ConfigHash(configMap)
+}
@@ -0,0 +1,70 @@
+package backupservice
+
+import (
+	"testing"
+)
+
+func TestConfigHashFromYAML(t *testing.T) {
+	const config = "service:\n  http:\n    port: 8081\nbackup-policies:\n  policy1:\n    parallel: 3\n"
+
+	expected, err := ConfigHash(map[string]interface{}{
+		"backup-policies": map[string]interface{}{
+			"policy1": map[string]interface{}{"parallel": float64(3)},
+		},
+		"service": map[string]interface{}{
+			"http": map[string]interface{}{"port": float64(8081)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		config    string
+		wantEqual bool
+		wantErr   bool
+	}{
+		{
+			name:      "same config",
+			config:    config,
+			wantEqual: true,
+		},
+		{
+			name:      "reordered keys",
+			config:    "backup-policies:\n  policy1:\n    parallel: 3\nservice:\n  http:\n    port: 8081\n",
+			wantEqual: true,
+		},
+		{
+			name:      "json format",
+			config:    `{"service":{"http":{"port":8081}},"backup-policies":{"policy1":{"parallel":3}}}`,
+			wantEqual: true,
+		},
+		{
+			name:   "changed value",
+			config: "service:\n  http:\n    port: 8081\nbackup-policies:\n  policy1:\n    parallel: 4\n",
+		},
+		{
+			name:    "invalid yaml",
+			config:  "service: [",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := ConfigHashFromYAML(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfigHashFromYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if (hash == expected) != tt.wantEqual {
+				t.Errorf("ConfigHashFromYAML() = %s, expected %s, wantEqual %v", hash, expected, tt.wantEqual)
+			}
+		})
+	}
+}
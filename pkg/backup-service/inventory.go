@@ -0,0 +1,51 @@
+package backupservice
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/reugn/go-quartz/quartz"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+)
+
+// StoragePath returns the full path of the backup key in the storage e.g. s3://bucket/path/key.
+func StoragePath(storage *dto.Storage, key string) string {
+	switch {
+	case storage == nil:
+		return key
+	case storage.LocalStorage != nil:
+		return path.Join(storage.LocalStorage.Path, key)
+	case storage.S3Storage != nil:
+		return "s3://" + path.Join(storage.S3Storage.Bucket, storage.S3Storage.Path, key)
+	case storage.GcpStorage != nil:
+		return "gs://" + path.Join(storage.GcpStorage.BucketName, storage.GcpStorage.Path, key)
+	case storage.AzureStorage != nil:
+		return "azure://" + path.Join(storage.AzureStorage.ContainerName, storage.AzureStorage.Path, key)
+	}
+
+	return key
+}
+
+// NextBackupDeadline returns the time by which the next backup is expected to succeed as per the intervalCron,
+// given the start time of the last successful backup. The backup scheduled after the last one must
+// succeed before the one following it is due.
+func NextBackupDeadline(intervalCron string, last time.Time) (time.Time, error) {
+	trigger, err := quartz.NewCronTrigger(strings.TrimSpace(intervalCron))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next, err := trigger.NextFireTime(last.UnixNano())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	deadline, err := trigger.NextFireTime(next)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, deadline).UTC(), nil
+}
@@ -0,0 +1,89 @@
+package backupservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+)
+
+func TestStoragePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		storage  *dto.Storage
+		expected string
+	}{
+		{
+			name:     "no storage",
+			expected: "daily/backup/1/ns1",
+		},
+		{
+			name:     "local storage",
+			storage:  &dto.Storage{LocalStorage: &dto.LocalStorage{Path: "/backups"}},
+			expected: "/backups/daily/backup/1/ns1",
+		},
+		{
+			name:     "s3 storage",
+			storage:  &dto.Storage{S3Storage: &dto.S3Storage{Bucket: "bucket", Path: "prefix"}},
+			expected: "s3://bucket/prefix/daily/backup/1/ns1",
+		},
+		{
+			name:     "gcp storage without path",
+			storage:  &dto.Storage{GcpStorage: &dto.GcpStorage{BucketName: "bucket"}},
+			expected: "gs://bucket/daily/backup/1/ns1",
+		},
+		{
+			name:     "azure storage",
+			storage:  &dto.Storage{AzureStorage: &dto.AzureStorage{ContainerName: "container", Path: "prefix"}},
+			expected: "azure://container/prefix/daily/backup/1/ns1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StoragePath(tt.storage, "daily/backup/1/ns1"); got != tt.expected {
+				t.Errorf("StoragePath() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextBackupDeadline(t *testing.T) {
+	last := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cron     string
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "hourly",
+			cron:     "0 0 * * * *",
+			expected: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily",
+			cron:     "0 0 1 * * *",
+			expected: time.Date(2024, 1, 3, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid",
+			cron:    "invalid",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadline, err := NextBackupDeadline(tt.cron, last)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NextBackupDeadline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !deadline.Equal(tt.expected) {
+				t.Errorf("NextBackupDeadline() = %v, expected %v", deadline, tt.expected)
+			}
+		})
+	}
+}
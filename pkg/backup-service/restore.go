@@ -0,0 +1,292 @@
+package backupservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+// SetRestoreDestination sets the destination of the restore config to the given seed nodes.
+// The credentials are set in the destination only if no user is already given in the config, and the TLS
+// fields only if not already given in the config. Other destination fields given in the config are kept as is.
+func SetRestoreDestination(
+	config []byte, seedNodes []dto.SeedNode, credentials *dto.Credentials, tls *dto.TLS,
+) ([]byte, error) {
+	restoreConfig := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(config, &restoreConfig); err != nil {
+		return nil, err
+	}
+
+	if _, ok := restoreConfig[v1beta1.DestinationNameKey]; ok {
+		return nil, fmt.Errorf("%s field is not allowed in restore config with a destination cluster",
+			v1beta1.DestinationNameKey)
+	}
+
+	destination := make(map[string]interface{})
+
+	if val, ok := restoreConfig[v1beta1.DestinationKey]; ok && val != nil {
+		if destination, ok = val.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%s field is not in the right format", v1beta1.DestinationKey)
+		}
+	}
+
+	var seedNodesInMap []interface{}
+
+	if err := convertViaJSON(seedNodes, &seedNodesInMap); err != nil {
+		return nil, err
+	}
+
+	destination["seed-nodes"] = seedNodesInMap
+
+	if credentials != nil {
+		credentialsInMap, err := getOrCreateMap(destination, "credentials")
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := credentialsInMap["user"]; !ok {
+			if err := convertViaJSON(credentials, &credentialsInMap); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if tls != nil {
+		tlsInMap, err := getOrCreateMap(destination, "tls")
+		if err != nil {
+			return nil, err
+		}
+
+		given := make(map[string]interface{})
+
+		if err := convertViaJSON(tls, &given); err != nil {
+			return nil, err
+		}
+
+		for key, val := range given {
+			if _, ok := tlsInMap[key]; !ok {
+				tlsInMap[key] = val
+			}
+		}
+	}
+
+	restoreConfig[v1beta1.DestinationKey] = destination
+
+	return json.Marshal(restoreConfig)
+}
+
+// SetRestoreUnique sets the unique policy of the restore config, so that only the records not already
+// in the destination are restored. The replace and no-generation policies are not allowed along with it.
+func SetRestoreUnique(config []byte) ([]byte, error) {
+	restoreConfig := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(config, &restoreConfig); err != nil {
+		return nil, err
+	}
+
+	policy, err := getOrCreateMap(restoreConfig, v1beta1.PolicyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{"replace", "no-generation"} {
+		if val, ok := policy[key].(bool); ok && val {
+			return nil, fmt.Errorf("%s policy is not allowed along with unique policy", key)
+		}
+	}
+
+	policy["unique"] = true
+
+	return json.Marshal(restoreConfig)
+}
+
+// SetRestoreBackupDataPath turns the Timestamp restore config into the config restoring the given backup key
+// from the given storage. The fields of the Timestamp restore config not in a restore from path are removed.
+func SetRestoreBackupDataPath(config []byte, storageName, backupDataPath string) ([]byte, error) {
+	restoreConfig := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(config, &restoreConfig); err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{v1beta1.RoutineKey, v1beta1.TimeKey, v1beta1.DisableReorderingKey} {
+		delete(restoreConfig, key)
+	}
+
+	restoreConfig[v1beta1.SourceNameKey] = storageName
+	restoreConfig[v1beta1.BackupDataPathKey] = backupDataPath
+
+	return json.Marshal(restoreConfig)
+}
+
+// GetRestoreChainKeys returns the keys of the backups of the restore chain in the restore order, i.e. the keys of
+// the full backup first, then the keys of the incremental backups, the oldest first.
+func GetRestoreChainKeys(chain *v1beta1.RestoreChain) []string {
+	keys := make([]string, 0, len(chain.FullBackup.Keys))
+	keys = append(keys, chain.FullBackup.Keys...)
+
+	for idx := range chain.IncrementalBackups {
+		keys = append(keys, chain.IncrementalBackups[idx].Keys...)
+	}
+
+	return keys
+}
+
+// AddRestoreJobStatus adds the record counts of the restore job status to the total.
+func AddRestoreJobStatus(total, status *dto.RestoreJobStatus) {
+	total.ReadRecords += status.ReadRecords
+	total.TotalBytes += status.TotalBytes
+	total.ExpiredRecords += status.ExpiredRecords
+	total.SkippedRecords += status.SkippedRecords
+	total.IgnoredRecords += status.IgnoredRecords
+	total.InsertedRecords += status.InsertedRecords
+	total.ExistedRecords += status.ExistedRecords
+	total.FresherRecords += status.FresherRecords
+	total.IndexCount += status.IndexCount
+	total.UDFCount += status.UDFCount
+	total.ErrorsInDoubt += status.ErrorsInDoubt
+}
+
+// nonTransientRestoreErrors are the parts of the restore job errors which are not fixed by retrying the same
+// restore, e.g. the config, validation and authorization errors.
+var nonTransientRestoreErrors = []string{
+	"validation", "invalid", "not found", "does not exist", "no such file", "does not have required namespace",
+	"from different times", "unsupported", "forbidden", "role violation", "not authenticated", "not authorized",
+	"access denied", "accessdenied", "parameter error",
+}
+
+// IsTransientRestoreError returns true if the restore job failed with an error which may not recur on a retry,
+// e.g. a network or timeout error. A job failed without an error is considered transient.
+func IsTransientRestoreError(errMsg string) bool {
+	// The result codes of the Aerospike errors are matched as words, e.g. NOT_AUTHENTICATED.
+	errMsg = strings.ReplaceAll(strings.ToLower(errMsg), "_", " ")
+
+	for _, nonTransient := range nonTransientRestoreErrors {
+		if strings.Contains(errMsg, nonTransient) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PlanRestoreChain returns the chain of backups restored for the given time, i.e. the latest full backup
+// created before the time, and the incremental backups created after it and before the time.
+// Backups of all the namespaces of a routine run are grouped by their creation time.
+// An error is returned if no full backup is found, or if an incremental backup does not continue
+// from the previous backup in the chain.
+func PlanRestoreChain(
+	fullBackups, incrementalBackups []dto.BackupDetails, restoreTime time.Time,
+) (*v1beta1.RestoreChain, error) {
+	var fullBackup *v1beta1.RestoreChainBackup
+
+	for _, backup := range groupBackups(fullBackups) {
+		if backup.Created.Time.Before(restoreTime) {
+			fullBackup = &backup.RestoreChainBackup
+		}
+	}
+
+	if fullBackup == nil {
+		return nil, fmt.Errorf("no full backup found before %s", restoreTime.UTC().Format(time.RFC3339))
+	}
+
+	chain := &v1beta1.RestoreChain{FullBackup: *fullBackup}
+	previous := fullBackup.Created.Time
+
+	for _, backup := range groupBackups(incrementalBackups) {
+		if !backup.Created.Time.After(fullBackup.Created.Time) || !backup.Created.Time.Before(restoreTime) {
+			continue
+		}
+
+		if backup.from.After(previous) {
+			return nil, fmt.Errorf("gap in backup chain, incremental backup %s starts from %s "+
+				"but previous backup is created at %s", backup.Keys, backup.from.UTC().Format(time.RFC3339),
+				previous.UTC().Format(time.RFC3339))
+		}
+
+		chain.IncrementalBackups = append(chain.IncrementalBackups, backup.RestoreChainBackup)
+		previous = backup.Created.Time
+	}
+
+	return chain, nil
+}
+
+type chainBackup struct {
+	v1beta1.RestoreChainBackup
+	from time.Time
+}
+
+// groupBackups groups the backups by creation time, sorted by it.
+func groupBackups(backups []dto.BackupDetails) []*chainBackup {
+	groups := make(map[int64]*chainBackup)
+
+	for idx := range backups {
+		backup := &backups[idx]
+		timestamp := backup.Created.UnixMilli()
+
+		group, ok := groups[timestamp]
+		if !ok {
+			group = &chainBackup{
+				RestoreChainBackup: v1beta1.RestoreChainBackup{Created: metav1.NewTime(backup.Created)},
+				from:               backup.From,
+			}
+			groups[timestamp] = group
+		}
+
+		group.Keys = append(group.Keys, backup.Key)
+		group.RecordCount += int64(backup.RecordCount)
+
+		// The chain continues only if every namespace backup continues from the previous backup.
+		if backup.From.After(group.from) {
+			group.from = backup.From
+		}
+	}
+
+	sorted := make([]*chainBackup, 0, len(groups))
+	for _, group := range groups {
+		sort.Strings(group.Keys)
+		sorted = append(sorted, group)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Created.Before(&sorted[j].Created)
+	})
+
+	return sorted
+}
+
+func getOrCreateMap(parent map[string]interface{}, key string) (map[string]interface{}, error) {
+	val, ok := parent[key]
+	if !ok || val == nil {
+		child := make(map[string]interface{})
+		parent[key] = child
+
+		return child, nil
+	}
+
+	child, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s field is not in the right format", key)
+	}
+
+	return child, nil
+}
+
+// convertViaJSON converts the given value to the out value through its JSON representation.
+func convertViaJSON(in, out interface{}) error {
+	inBytes, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(inBytes, out)
+}
@@ -0,0 +1,356 @@
+package backupservice
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aerospike/aerospike-backup-service/v3/pkg/dto"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+func TestSetRestoreDestination(t *testing.T) {
+	seedNodes := []dto.SeedNode{{HostName: "10.0.0.1", Port: 3000}}
+
+	user, passwordPath := "admin", "/etc/aerospike/secrets/password"
+	tlsName, caFile := "aerospike-a-0.test-runner", "/etc/aerospike/certs/ca.crt"
+
+	credentials := &dto.Credentials{User: &user, PasswordPath: &passwordPath}
+	tls := &dto.TLS{Name: &tlsName, CAFile: &caFile}
+
+	tests := []struct {
+		name        string
+		config      string
+		credentials *dto.Credentials
+		tls         *dto.TLS
+		expected    map[string]interface{}
+		wantErr     bool
+	}{
+		{
+			name:   "no destination and no security",
+			config: "backup-data-path: daily/backup/1/ns1\n",
+			expected: map[string]interface{}{
+				"backup-data-path": "daily/backup/1/ns1",
+				"destination": map[string]interface{}{
+					"seed-nodes": []interface{}{
+						map[string]interface{}{"host-name": "10.0.0.1", "port": float64(3000)},
+					},
+				},
+			},
+		},
+		{
+			name:        "credentials and tls are set",
+			config:      "{}",
+			credentials: credentials,
+			tls:         tls,
+			expected: map[string]interface{}{
+				"destination": map[string]interface{}{
+					"seed-nodes": []interface{}{
+						map[string]interface{}{"host-name": "10.0.0.1", "port": float64(3000)},
+					},
+					"credentials": map[string]interface{}{"user": "admin", "password-path": passwordPath},
+					"tls":         map[string]interface{}{"name": tlsName, "ca-file": caFile},
+				},
+			},
+		},
+		{
+			name: "given destination fields are kept",
+			config: `
+destination:
+  seed-nodes:
+    - host-name: old-host
+      port: 4000
+  credentials:
+    user: restore-user
+    password-path: /etc/secrets/password
+  tls:
+    ca-file: /etc/tls/ca.crt
+`,
+			credentials: credentials,
+			tls:         tls,
+			expected: map[string]interface{}{
+				"destination": map[string]interface{}{
+					"seed-nodes": []interface{}{
+						map[string]interface{}{"host-name": "10.0.0.1", "port": float64(3000)},
+					},
+					"credentials": map[string]interface{}{
+						"user": "restore-user", "password-path": "/etc/secrets/password",
+					},
+					"tls": map[string]interface{}{
+						"ca-file": "/etc/tls/ca.crt", "name": tlsName,
+					},
+				},
+			},
+		},
+		{
+			name:    "destination-name is not allowed",
+			config:  "destination-name: cluster1\n",
+			wantErr: true,
+		},
+		{
+			name:    "destination in wrong format",
+			config:  "destination: cluster1\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetRestoreDestination([]byte(tt.config), seedNodes, tt.credentials, tt.tls)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetRestoreDestination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			gotInMap := make(map[string]interface{})
+
+			if err := json.Unmarshal(got, &gotInMap); err != nil {
+				t.Fatalf("invalid restore config %s: %v", got, err)
+			}
+
+			if !reflect.DeepEqual(gotInMap, tt.expected) {
+				t.Errorf("SetRestoreDestination() = %v, expected %v", gotInMap, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetRestoreUnique(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:   "no policy",
+			config: "backup-data-path: daily/backup/1/ns1\n",
+			expected: map[string]interface{}{
+				"backup-data-path": "daily/backup/1/ns1",
+				"policy":           map[string]interface{}{"unique": true},
+			},
+		},
+		{
+			name: "given policy fields are kept",
+			config: `
+policy:
+  parallel: 3
+  replace: false
+`,
+			expected: map[string]interface{}{
+				"policy": map[string]interface{}{"parallel": float64(3), "replace": false, "unique": true},
+			},
+		},
+		{
+			name:    "replace is not allowed",
+			config:  "policy:\n  replace: true\n",
+			wantErr: true,
+		},
+		{
+			name:    "no-generation is not allowed",
+			config:  "policy:\n  no-generation: true\n",
+			wantErr: true,
+		},
+		{
+			name:    "policy in wrong format",
+			config:  "policy: unique\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetRestoreUnique([]byte(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetRestoreUnique() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			gotInMap := make(map[string]interface{})
+
+			if err := json.Unmarshal(got, &gotInMap); err != nil {
+				t.Fatalf("invalid restore config %s: %v", got, err)
+			}
+
+			if !reflect.DeepEqual(gotInMap, tt.expected) {
+				t.Errorf("SetRestoreUnique() = %v, expected %v", gotInMap, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetRestoreBackupDataPath(t *testing.T) {
+	config := []byte("policy:\n  parallel: 3\nroutine: daily\ntime: 1739538000000\ndisable-reordering: true\n")
+
+	got, err := SetRestoreBackupDataPath(config, "s3Storage", "daily/backup/1739538000000/data/test")
+	if err != nil {
+		t.Fatalf("SetRestoreBackupDataPath() error = %v", err)
+	}
+
+	gotInMap := make(map[string]interface{})
+
+	if err := json.Unmarshal(got, &gotInMap); err != nil {
+		t.Fatalf("invalid restore config %s: %v", got, err)
+	}
+
+	expected := map[string]interface{}{
+		"policy":           map[string]interface{}{"parallel": float64(3)},
+		"source-name":      "s3Storage",
+		"backup-data-path": "daily/backup/1739538000000/data/test",
+	}
+
+	if !reflect.DeepEqual(gotInMap, expected) {
+		t.Errorf("SetRestoreBackupDataPath() = %v, expected %v", gotInMap, expected)
+	}
+}
+
+func TestGetRestoreChainKeys(t *testing.T) {
+	chain := &v1beta1.RestoreChain{
+		FullBackup: v1beta1.RestoreChainBackup{Keys: []string{"full/bar", "full/test"}},
+		IncrementalBackups: []v1beta1.RestoreChainBackup{
+			{Keys: []string{"incr1/bar", "incr1/test"}},
+			{Keys: []string{"incr2/test"}},
+		},
+	}
+
+	expected := []string{"full/bar", "full/test", "incr1/bar", "incr1/test", "incr2/test"}
+
+	if got := GetRestoreChainKeys(chain); !reflect.DeepEqual(got, expected) {
+		t.Errorf("GetRestoreChainKeys() = %v, expected %v", got, expected)
+	}
+}
+
+func TestAddRestoreJobStatus(t *testing.T) {
+	total := &dto.RestoreJobStatus{ReadRecords: 10, InsertedRecords: 8, ExpiredRecords: 2, Status: dto.JobStatusDone}
+
+	AddRestoreJobStatus(total, &dto.RestoreJobStatus{
+		ReadRecords: 5, InsertedRecords: 4, IgnoredRecords: 1, Status: dto.JobStatusRunning, Error: "error",
+	})
+
+	expected := &dto.RestoreJobStatus{
+		ReadRecords: 15, InsertedRecords: 12, ExpiredRecords: 2, IgnoredRecords: 1, Status: dto.JobStatusDone,
+	}
+
+	if !reflect.DeepEqual(total, expected) {
+		t.Errorf("AddRestoreJobStatus() = %+v, expected %+v", total, expected)
+	}
+}
+
+func TestIsTransientRestoreError(t *testing.T) {
+	tests := []struct {
+		name     string
+		errMsg   string
+		expected bool
+	}{
+		{name: "no error", errMsg: "", expected: true},
+		{name: "network error", errMsg: "failed to connect: i/o timeout", expected: true},
+		{name: "cluster error", errMsg: "error writing record: ERR_CLUSTER", expected: true},
+		{name: "authentication error", errMsg: "ResultCode: NOT_AUTHENTICATED", expected: false},
+		{name: "missing backup", errMsg: "open backups/full/1: no such file or directory", expected: false},
+		{name: "validation error", errMsg: "empty field validation error: \"time\" required", expected: false},
+		{name: "missing namespace", errMsg: "backup does not have required namespace test", expected: false},
+		{name: "storage access error", errMsg: "AccessDenied: Access Denied", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientRestoreError(tt.errMsg); got != tt.expected {
+				t.Errorf("IsTransientRestoreError(%q) = %v, expected %v", tt.errMsg, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanRestoreChain(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return base.Add(time.Duration(hours) * time.Hour)
+	}
+
+	backup := func(created, from int, key string) dto.BackupDetails {
+		details := dto.BackupDetails{Created: at(created), Key: key, RecordCount: 10}
+		if from >= 0 {
+			details.From = at(from)
+		}
+
+		return details
+	}
+
+	chainBackup := func(created int, records int64, keys ...string) v1beta1.RestoreChainBackup {
+		return v1beta1.RestoreChainBackup{Created: metav1.NewTime(at(created)), Keys: keys, RecordCount: records}
+	}
+
+	fullBackups := []dto.BackupDetails{
+		backup(0, -1, "full/0/ns1"), backup(0, -1, "full/0/ns2"), backup(24, -1, "full/24/ns1"),
+	}
+
+	tests := []struct {
+		name               string
+		incrementalBackups []dto.BackupDetails
+		restoreTime        time.Time
+		expected           *v1beta1.RestoreChain
+		wantErr            bool
+	}{
+		{
+			name: "latest full backup with incremental chain",
+			incrementalBackups: []dto.BackupDetails{
+				backup(2, 0, "incr/2/ns1"), backup(1, 0, "incr/1/ns1"), backup(4, 2, "incr/4/ns1"),
+			},
+			restoreTime: at(3),
+			expected: &v1beta1.RestoreChain{
+				FullBackup: chainBackup(0, 20, "full/0/ns1", "full/0/ns2"),
+				IncrementalBackups: []v1beta1.RestoreChainBackup{
+					chainBackup(1, 10, "incr/1/ns1"), chainBackup(2, 10, "incr/2/ns1"),
+				},
+			},
+		},
+		{
+			name: "incremental backups before the full backup are skipped",
+			incrementalBackups: []dto.BackupDetails{
+				backup(23, 22, "incr/23/ns1"), backup(25, 24, "incr/25/ns1"),
+			},
+			restoreTime: at(30),
+			expected: &v1beta1.RestoreChain{
+				FullBackup: chainBackup(24, 10, "full/24/ns1"),
+				IncrementalBackups: []v1beta1.RestoreChainBackup{
+					chainBackup(25, 10, "incr/25/ns1"),
+				},
+			},
+		},
+		{
+			name:        "no full backup before the time",
+			restoreTime: base,
+			wantErr:     true,
+		},
+		{
+			name: "gap in incremental chain",
+			incrementalBackups: []dto.BackupDetails{
+				backup(1, 0, "incr/1/ns1"), backup(3, 2, "incr/3/ns1"),
+			},
+			restoreTime: at(4),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanRestoreChain(fullBackups, tt.incrementalBackups, tt.restoreTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanRestoreChain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("PlanRestoreChain() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package podinit
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+// Address types of the access endpoints advertised by the Aerospike node.
+const (
+	AddressTypeAccess             = "access"
+	AddressTypeAlternateAccess    = "alternate-access"
+	AddressTypeTLSAccess          = "tls-access"
+	AddressTypeTLSAlternateAccess = "tls-alternate-access"
+)
+
+// The indentation of the conf lines added in the network sub-contexts, fixed in the config writer of the
+// management lib.
+const confSubContextIndent = "        "
+
+var rackIDRegex = regexp.MustCompile(`rack-id.*0`)
+
+// Addresses has the addresses of the pod, used to compute its access endpoints.
+type Addresses struct {
+	// PodIPs, InternalIPs and ExternalIPs have the IPs of all the IP families, the primary IP first.
+	PodIPs      []string
+	InternalIPs []string
+	ExternalIPs []string
+
+	LoadBalancerAddress string
+	NodePortAddress     string
+	TLSRouteHostname    string
+	TLSRoutePort        int32
+}
+
+// Endpoint is an access endpoint of the Aerospike node.
+type Endpoint struct {
+	// AddressType is the address type of the endpoint, like access or tls-alternate-access.
+	AddressType string
+	Address     string
+	// PodPort is the port in the conf template, replaced by Port.
+	PodPort int32
+	Port    int32
+}
+
+// String returns the endpoint as host:port.
+func (e *Endpoint) String() string {
+	return net.JoinHostPort(e.Address, strconv.Itoa(int(e.Port)))
+}
+
+// GetEndpoint computes the endpoint of the given address type for the network type of the network policy.
+// The pod port is used for the networks reaching the pod directly and the mapped port for the host networks.
+func GetEndpoint(
+	addressType string, networkType asdbv1.AerospikeNetworkType, ipFamily corev1.IPFamily, addresses *Addresses,
+	podPort, mappedPort int32,
+) (*Endpoint, error) {
+	endpoint := &Endpoint{
+		AddressType: addressType,
+		PodPort:     podPort,
+		Port:        podPort,
+	}
+
+	var err error
+
+	switch networkType {
+	case asdbv1.AerospikeNetworkTypePod:
+		endpoint.Address, err = SelectIP(ipFamily, addresses.PodIPs)
+	case asdbv1.AerospikeNetworkTypeHostInternal:
+		endpoint.Address, err = SelectIP(ipFamily, addresses.InternalIPs)
+		endpoint.Port = mappedPort
+	case asdbv1.AerospikeNetworkTypeHostExternal:
+		endpoint.Address, err = SelectIP(ipFamily, addresses.ExternalIPs)
+		endpoint.Port = mappedPort
+	case asdbv1.AerospikeNetworkTypePodLoadBalancer:
+		endpoint.Address = addresses.LoadBalancerAddress
+	case asdbv1.AerospikeNetworkTypePodNodePort:
+		endpoint.Address = addresses.NodePortAddress
+		endpoint.Port = mappedPort
+	case asdbv1.AerospikeNetworkTypeGatewayTLSRoute:
+		endpoint.Address = addresses.TLSRouteHostname
+		endpoint.Port = addresses.TLSRoutePort
+	case asdbv1.AerospikeNetworkTypeUnspecified, asdbv1.AerospikeNetworkTypeConfigured,
+		asdbv1.AerospikeNetworkTypeCustomInterface:
+		endpoint.Address, err = SelectIP("", addresses.PodIPs)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s address: %v", addressType, err)
+	}
+
+	return endpoint, nil
+}
+
+// ConfInput has the pod specific values substituted in the aerospike.conf template.
+type ConfInput struct {
+	PodName   string
+	NodeID    string
+	Endpoints []Endpoint
+	// Peers are the heartbeat seeds. The FQDN of the pod itself is skipped.
+	Peers []string
+	// PodIP is advertised for heartbeat and fabric with host networking.
+	PodIP string
+	// TLSRouteHostname replaces the tls-name placeholder of the service, if the TLSRoute is used.
+	TLSRouteHostname string
+	RackID           int
+	HeartBeatPort    int32
+	HeartBeatTLSPort int32
+	FabricPort       int32
+	FabricTLSPort    int32
+	HostNetwork      bool
+}
+
+// CreateConf substitutes the pod specific values in the aerospike.conf template.
+func CreateConf(template string, input *ConfInput) string {
+	conf := strings.ReplaceAll(template, "ENV_NODE_ID", input.NodeID)
+	conf = rackIDRegex.ReplaceAllString(conf, fmt.Sprintf("rack-id    %d", input.RackID))
+
+	for idx := range input.Endpoints {
+		conf = substituteEndpoint(conf, &input.Endpoints[idx])
+	}
+
+	// The service uses the TLSRoute hostname as its tls-name, sent by the clients as SNI to the Gateway.
+	if input.TLSRouteHostname != "" {
+		conf = strings.ReplaceAll(conf, asdbv1.GatewayTLSRouteTLSNamePlaceholder, input.TLSRouteHostname)
+	}
+
+	var heartbeatLines, fabricLines []string
+
+	for _, peer := range input.Peers {
+		peer = strings.TrimSpace(peer)
+		if peer == "" || strings.HasPrefix(peer, input.PodName+".") {
+			continue
+		}
+
+		if input.HeartBeatPort != 0 {
+			heartbeatLines = append(heartbeatLines,
+				fmt.Sprintf("mesh-seed-address-port %s %d", peer, input.HeartBeatPort))
+		}
+
+		if input.HeartBeatTLSPort != 0 {
+			heartbeatLines = append(heartbeatLines,
+				fmt.Sprintf("tls-mesh-seed-address-port %s %d", peer, input.HeartBeatTLSPort))
+		}
+	}
+
+	// With host networking, heartbeat and fabric advertise the network interface bound to the k8s node's host
+	// network.
+	if input.HostNetwork {
+		heartbeatLines = append(heartbeatLines, hostNetworkAddressLines(
+			input.PodIP, input.HeartBeatPort, input.HeartBeatTLSPort)...)
+		fabricLines = hostNetworkAddressLines(input.PodIP, input.FabricPort, input.FabricTLSPort)
+	}
+
+	conf = appendToSubContext(conf, asdbv1.ConfKeyNetworkHeartbeat, heartbeatLines)
+
+	return appendToSubContext(conf, asdbv1.ConfKeyNetworkFabric, fabricLines)
+}
+
+// substituteEndpoint replaces the address placeholder and the port of the endpoint address type.
+func substituteEndpoint(conf string, endpoint *Endpoint) string {
+	addressRegex := regexp.MustCompile(fmt.Sprintf(`(?m)^(\s*)%s-address\s*<%s-address>`,
+		endpoint.AddressType, endpoint.AddressType))
+	conf = addressRegex.ReplaceAllString(conf, fmt.Sprintf("${1}%s-address    %s",
+		endpoint.AddressType, endpoint.Address))
+
+	// The pod port is set as a placeholder by the operator webhook.
+	portRegex := regexp.MustCompile(fmt.Sprintf(`(?m)^(\s*)%s-port\s*%d\b`, endpoint.AddressType, endpoint.PodPort))
+
+	return portRegex.ReplaceAllString(conf, fmt.Sprintf("${1}%s-port    %d", endpoint.AddressType, endpoint.Port))
+}
+
+func hostNetworkAddressLines(podIP string, port, tlsPort int32) []string {
+	var lines []string
+
+	if port != 0 {
+		lines = append(lines, "address "+podIP)
+	}
+
+	if tlsPort != 0 {
+		lines = append(lines, "tls-address "+podIP)
+	}
+
+	return lines
+}
+
+// appendToSubContext adds the lines at the start of the given network sub-context.
+func appendToSubContext(conf, subContext string, lines []string) string {
+	if len(lines) == 0 {
+		return conf
+	}
+
+	confLines := strings.Split(conf, "\n")
+	newConfLines := make([]string, 0, len(confLines)+len(lines))
+	marker := subContext + " {"
+
+	for _, line := range confLines {
+		newConfLines = append(newConfLines, line)
+
+		if strings.TrimSpace(line) == marker {
+			for _, newLine := range lines {
+				newConfLines = append(newConfLines, confSubContextIndent+newLine)
+			}
+		}
+	}
+
+	return strings.Join(newConfLines, "\n")
+}
@@ -0,0 +1,263 @@
+package podinit
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+const testConfTemplate = `service {
+    node-id    ENV_NODE_ID
+}
+
+network {
+    service {
+        port    3000
+        access-port    3000
+        access-address    <access-address>
+        alternate-access-port    3000
+        alternate-access-address    <alternate-access-address>
+    }
+    heartbeat {
+        mode    mesh
+        port    3002
+    }
+    fabric {
+        port    3001
+    }
+}
+
+namespace test {
+    rack-id    0
+}`
+
+func TestGetEndpoint(t *testing.T) {
+	addresses := &Addresses{
+		PodIPs:              []string{"10.0.0.10", "2001:db8::10"},
+		InternalIPs:         []string{"10.0.0.1"},
+		ExternalIPs:         []string{"192.0.2.1"},
+		LoadBalancerAddress: "lb.example.com",
+		NodePortAddress:     "203.0.113.1",
+		TLSRouteHostname:    "pod.example.com",
+		TLSRoutePort:        443,
+	}
+
+	tests := []struct {
+		name        string
+		networkType asdbv1.AerospikeNetworkType
+		ipFamily    corev1.IPFamily
+		expected    string
+	}{
+		{
+			name:        "pod",
+			networkType: asdbv1.AerospikeNetworkTypePod,
+			expected:    "10.0.0.10:3000",
+		},
+		{
+			name:        "pod IPv6",
+			networkType: asdbv1.AerospikeNetworkTypePod,
+			ipFamily:    corev1.IPv6Protocol,
+			expected:    "[2001:db8::10]:3000",
+		},
+		{
+			name:        "host internal",
+			networkType: asdbv1.AerospikeNetworkTypeHostInternal,
+			expected:    "10.0.0.1:30000",
+		},
+		{
+			name:        "host external",
+			networkType: asdbv1.AerospikeNetworkTypeHostExternal,
+			expected:    "192.0.2.1:30000",
+		},
+		{
+			name:        "pod load balancer",
+			networkType: asdbv1.AerospikeNetworkTypePodLoadBalancer,
+			expected:    "lb.example.com:3000",
+		},
+		{
+			name:        "pod node port",
+			networkType: asdbv1.AerospikeNetworkTypePodNodePort,
+			expected:    "203.0.113.1:30000",
+		},
+		{
+			name:        "gateway TLS route",
+			networkType: asdbv1.AerospikeNetworkTypeGatewayTLSRoute,
+			expected:    "pod.example.com:443",
+		},
+		{
+			name:        "configured",
+			networkType: asdbv1.AerospikeNetworkTypeConfigured,
+			expected:    "10.0.0.10:3000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, err := GetEndpoint(AddressTypeAccess, tt.networkType, tt.ipFamily, addresses, 3000, 30000)
+			if err != nil {
+				t.Fatalf("GetEndpoint() error = %v", err)
+			}
+
+			if endpoint.String() != tt.expected {
+				t.Errorf("GetEndpoint() = %s, expected %s", endpoint.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateConf(t *testing.T) {
+	endpoints := []Endpoint{
+		{AddressType: AddressTypeAccess, Address: "10.0.0.10", PodPort: 3000, Port: 3000},
+		{AddressType: AddressTypeAlternateAccess, Address: "192.0.2.1", PodPort: 3000, Port: 30000},
+	}
+
+	tests := []struct {
+		name     string
+		input    *ConfInput
+		expected string
+	}{
+		{
+			name: "pod network",
+			input: &ConfInput{
+				PodName:       "aerocluster-1-0",
+				NodeID:        "1a0",
+				RackID:        1,
+				Endpoints:     endpoints,
+				Peers:         []string{"aerocluster-1-0.aerocluster.ns", "aerocluster-1-1.aerocluster.ns", ""},
+				HeartBeatPort: 3002,
+				FabricPort:    3001,
+			},
+			expected: `service {
+    node-id    1a0
+}
+
+network {
+    service {
+        port    3000
+        access-port    3000
+        access-address    10.0.0.10
+        alternate-access-port    30000
+        alternate-access-address    192.0.2.1
+    }
+    heartbeat {
+        mesh-seed-address-port aerocluster-1-1.aerocluster.ns 3002
+        mode    mesh
+        port    3002
+    }
+    fabric {
+        port    3001
+    }
+}
+
+namespace test {
+    rack-id    1
+}`,
+		},
+		{
+			name: "host network",
+			input: &ConfInput{
+				PodName:       "aerocluster-2-0",
+				NodeID:        "2a0",
+				RackID:        2,
+				Peers:         []string{"aerocluster.ns"},
+				PodIP:         "10.0.0.1",
+				HeartBeatPort: 3002,
+				FabricPort:    3001,
+				HostNetwork:   true,
+			},
+			expected: `service {
+    node-id    2a0
+}
+
+network {
+    service {
+        port    3000
+        access-port    3000
+        access-address    <access-address>
+        alternate-access-port    3000
+        alternate-access-address    <alternate-access-address>
+    }
+    heartbeat {
+        mesh-seed-address-port aerocluster.ns 3002
+        address 10.0.0.1
+        mode    mesh
+        port    3002
+    }
+    fabric {
+        address 10.0.0.1
+        port    3001
+    }
+}
+
+namespace test {
+    rack-id    2
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := CreateConf(testConfTemplate, tt.input)
+			if conf != tt.expected {
+				t.Errorf("CreateConf() = %s, expected %s", conf, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCreateConfTLSRouteHostname(t *testing.T) {
+	template := `network {
+    service {
+        tls-name    <gateway-tls-route-hostname>
+    }
+}
+
+network {
+    tls <gateway-tls-route-hostname> {
+        cert-file    /etc/aerospike/secret/svc_cluster_chain.pem
+    }
+    tls aerospike-a-0.test-runner {
+        cert-file    /etc/aerospike/secret/svc_cluster_chain.pem
+    }
+}`
+
+	tests := []struct {
+		name     string
+		hostname string
+		expected string
+	}{
+		{
+			name:     "placeholder replaced by the TLSRoute hostname",
+			hostname: "aerocluster-1-0.aerospike.example.com",
+			expected: `network {
+    service {
+        tls-name    aerocluster-1-0.aerospike.example.com
+    }
+}
+
+network {
+    tls aerocluster-1-0.aerospike.example.com {
+        cert-file    /etc/aerospike/secret/svc_cluster_chain.pem
+    }
+    tls aerospike-a-0.test-runner {
+        cert-file    /etc/aerospike/secret/svc_cluster_chain.pem
+    }
+}`,
+		},
+		{
+			name:     "template unchanged without TLSRoute",
+			expected: template,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := CreateConf(template, &ConfInput{NodeID: "1a0", RackID: 1, TLSRouteHostname: tt.hostname})
+			if conf != tt.expected {
+				t.Errorf("CreateConf() = %s, expected %s", conf, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,688 @@
+package podinit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+const (
+	// DefaultConfigDir is the directory of the aerospike.conf shared with the Aerospike server container.
+	DefaultConfigDir = "/etc/aerospike"
+
+	// DefaultConfigMapDir is the directory where the rack ConfigMap is mounted in the init container.
+	DefaultConfigMapDir = "/configs"
+
+	loadBalancerRetries       = 60
+	loadBalancerRetryInterval = 5 * time.Second
+	asdTerminateRetries       = 60
+	asdTerminateRetryInterval = 5 * time.Second
+)
+
+// Initializer runs the init steps in the Aerospike pod.
+type Initializer struct {
+	Client client.Client
+	Log    logr.Logger
+	Env    *PodEnv
+	// ConfigDir is the directory where the aerospike.conf template is written.
+	ConfigDir string
+}
+
+// PublishStatus patches the keys of the pod status ConfigMap. A nil value removes the key.
+// The pods are only allowed to patch their pod status ConfigMaps, which are created by the operator.
+func (i *Initializer) PublishStatus(ctx context.Context, data map[string]*string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": data,
+	})
+	if err != nil {
+		return err
+	}
+
+	confMap := &corev1.ConfigMap{}
+	confMap.Name = asdbv1.GetPodStatusConfigMapName(i.Env.PodName)
+	confMap.Namespace = i.Env.Namespace
+
+	return i.Client.Patch(ctx, confMap, client.RawPatch(types.MergePatchType, patch), client.FieldOwner("pod"))
+}
+
+// Init runs the init container steps: it writes the aerospike.conf template of the pod, initializes and wipes the
+// volumes and publishes the pod status.
+func (i *Initializer) Init(ctx context.Context, configMapDir string) error {
+	data, err := readConfigMapDir(configMapDir)
+	if err != nil {
+		return err
+	}
+
+	params, err := utils.ParseInitParams(data)
+	if err != nil {
+		return err
+	}
+
+	if params.WorkDir != "" {
+		for _, dir := range []string{"smd", "usr/udf/lua"} {
+			workDir := filepath.Join(DefaultFileSystemMountPoint, params.WorkDir, dir)
+
+			i.Log.Info("Creating work directory", "dir", workDir)
+
+			if err := os.MkdirAll(workDir, 0o755); err != nil { //nolint:gosec // read by the server
+				return err
+			}
+		}
+	}
+
+	// The binary is copied in the config directory for the warm restart, run by the operator in the server container.
+	if err := copyExecutable(filepath.Join(i.ConfigDir, utils.InitBinaryName)); err != nil {
+		return err
+	}
+
+	if params.PodServiceType == corev1.ServiceTypeLoadBalancer {
+		if data, err = i.waitForPodServiceAddress(ctx, configMapDir, data); err != nil {
+			return err
+		}
+	}
+
+	status, err := i.writeConf(ctx, data, params)
+	if err != nil {
+		return err
+	}
+
+	return i.publishStatus(ctx, data, status, true)
+}
+
+// QuickRestart fetches the rack ConfigMap, writes the aerospike.conf template and restarts the Aerospike server
+// without restarting the pod.
+func (i *Initializer) QuickRestart(ctx context.Context, configMapName string) error {
+	data, params, err := i.getConfigMap(ctx, configMapName)
+	if err != nil {
+		return err
+	}
+
+	// tini restarts the server on SIGUSR1 if started with -r.
+	cmdline, err := os.ReadFile("/proc/1/cmdline")
+	if err != nil {
+		return err
+	}
+
+	args := strings.Split(string(cmdline), "\x00")
+	if !strings.Contains(args[0], "tini") || !slices.Contains(args, "-r") {
+		return fmt.Errorf("warm restart not supported - aborting")
+	}
+
+	status, err := i.writeConf(ctx, data, params)
+	if err != nil {
+		return err
+	}
+
+	asdPid, err := findASDPid()
+	if err != nil {
+		return err
+	}
+
+	i.Log.Info("Restarting Aerospike server", "pid", asdPid)
+
+	if err := syscall.Kill(1, syscall.SIGUSR1); err != nil {
+		return fmt.Errorf("failed to signal the init process: %v", err)
+	}
+
+	terminated := false
+
+	for range asdTerminateRetries {
+		if _, err := os.Stat(fmt.Sprintf("/proc/%d", asdPid)); os.IsNotExist(err) {
+			terminated = true
+			break
+		}
+
+		i.Log.Info("Waiting for Aerospike server to terminate")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(asdTerminateRetryInterval):
+		}
+	}
+
+	if !terminated {
+		return fmt.Errorf("aborting warm start - Aerospike server did not terminate")
+	}
+
+	return i.publishStatus(ctx, data, status, false)
+}
+
+// UpdateConf fetches the rack ConfigMap and writes the aerospike.conf template, after the config is updated
+// dynamically, so that the server uses it on the next restart.
+func (i *Initializer) UpdateConf(ctx context.Context, configMapName string) error {
+	data, params, err := i.getConfigMap(ctx, configMapName)
+	if err != nil {
+		return err
+	}
+
+	status, err := i.writeConf(ctx, data, params)
+	if err != nil {
+		return err
+	}
+
+	return i.publishStatus(ctx, data, status, false)
+}
+
+func (i *Initializer) getConfigMap(ctx context.Context, name string) (map[string]string, *utils.InitParams, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: i.Env.Namespace}, configMap); err != nil {
+		return nil, nil, fmt.Errorf("failed to get ConfigMap %s: %v", name, err)
+	}
+
+	params, err := utils.ParseInitParams(configMap.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return configMap.Data, params, nil
+}
+
+// writeConf writes the aerospike.conf template with the pod specific values, the peers and the feature key file
+// in the config directory. It returns the pod status with the network details.
+func (i *Initializer) writeConf(
+	ctx context.Context, data map[string]string, params *utils.InitParams,
+) (*asdbv1.AerospikePodStatus, error) {
+	rackID, nodeID, err := GetRackAndNodeID(i.Env.ClusterName, i.Env.PodName)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, podPorts, mappedPorts, err := i.getAddresses(ctx, data, params)
+	if err != nil {
+		return nil, err
+	}
+
+	networkPolicy := &params.NetworkPolicy
+	endpointInputs := []struct {
+		addressType string
+		networkType asdbv1.AerospikeNetworkType
+		ipFamily    corev1.IPFamily
+		tls         bool
+	}{
+		{AddressTypeAccess, networkPolicy.AccessType, networkPolicy.AccessIPFamily, false},
+		{AddressTypeAlternateAccess, networkPolicy.AlternateAccessType, networkPolicy.AlternateAccessIPFamily, false},
+		{AddressTypeTLSAccess, networkPolicy.TLSAccessType, networkPolicy.TLSAccessIPFamily, true},
+		{
+			AddressTypeTLSAlternateAccess, networkPolicy.TLSAlternateAccessType,
+			networkPolicy.TLSAlternateAccessIPFamily, true,
+		},
+	}
+
+	var endpoints []Endpoint
+
+	for _, input := range endpointInputs {
+		if input.tls && !i.Env.TLSEnabled {
+			continue
+		}
+
+		portIdx := 0
+		if input.tls {
+			portIdx = 1
+		}
+
+		endpoint, err := GetEndpoint(input.addressType, input.networkType, input.ipFamily, addresses,
+			podPorts[portIdx], mappedPorts[portIdx])
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, *endpoint)
+	}
+
+	template, ok := data[ConfTemplateFileName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the ConfigMap", ConfTemplateFileName)
+	}
+
+	if len(params.ConfigVariables) != 0 {
+		values, err := i.resolveConfigVariables(ctx, params.ConfigVariables)
+		if err != nil {
+			return nil, err
+		}
+
+		if template, err = asdbv1.ExpandConfigVariables(template, values); err != nil {
+			return nil, err
+		}
+	}
+
+	conf := CreateConf(template, &ConfInput{
+		PodName:          i.Env.PodName,
+		NodeID:           nodeID,
+		RackID:           rackID,
+		Endpoints:        endpoints,
+		Peers:            strings.Split(data[PeersFileName], "\n"),
+		PodIP:            i.Env.PodIP,
+		TLSRouteHostname: addresses.TLSRouteHostname,
+		HeartBeatPort:    params.HeartBeatPort,
+		HeartBeatTLSPort: params.HeartBeatTLSPort,
+		FabricPort:       params.FabricPort,
+		FabricTLSPort:    params.FabricTLSPort,
+		HostNetwork:      params.HostNetwork,
+	})
+
+	if err := os.MkdirAll(i.ConfigDir, 0o755); err != nil { //nolint:gosec // read by the server
+		return nil, err
+	}
+
+	files := map[string]string{
+		ConfTemplateFileName: conf,
+		PeersFileName:        data[PeersFileName],
+	}
+
+	if features, ok := data[FeaturesFileName]; ok {
+		files[FeaturesFileName] = features
+	}
+
+	for name, content := range files {
+		//nolint:gosec // read by the server
+		if err := os.WriteFile(filepath.Join(i.ConfigDir, name), []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	i.Log.Info("Created Aerospike configuration", "nodeID", nodeID, "rackID", rackID, "endpoints", endpoints)
+
+	podPort, servicePort := podPorts[0], mappedPorts[0]
+	if i.Env.TLSEnabled {
+		podPort, servicePort = podPorts[1], mappedPorts[1]
+	}
+
+	return NewPodStatus(i.Env, addresses, nodeID, rackID, endpoints, podPort, servicePort), nil
+}
+
+// resolveConfigVariables returns the values of the aerospikeConfig variables for the pod.
+func (i *Initializer) resolveConfigVariables(
+	ctx context.Context, variables []asdbv1.AerospikeConfigVariable,
+) (map[string]string, error) {
+	envValues, err := i.getConfigVariableEnvValues(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := i.getNode(ctx, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := ResolveConfigVariables(node, variables, envValues)
+	if err != nil {
+		return nil, err
+	}
+
+	// The values of the Secrets are not logged.
+	i.Log.Info("Resolved aerospikeConfig variables", "count", len(values))
+
+	return values, nil
+}
+
+// getConfigVariableEnvValues returns the values of the aerospikeConfig variables set by k8s in the init container env
+// vars. The values are saved in the config dir, as a warm restart runs in the server container without these env vars.
+func (i *Initializer) getConfigVariableEnvValues(
+	variables []asdbv1.AerospikeConfigVariable,
+) (map[string]string, error) {
+	envValues := map[string]string{}
+	missing := false
+
+	for idx := range variables {
+		if !asdbv1.IsConfigVariableFromEnv(&variables[idx]) {
+			continue
+		}
+
+		value, ok := os.LookupEnv(asdbv1.GetConfigVariableEnvVarName(variables[idx].Name))
+		if !ok {
+			missing = true
+			break
+		}
+
+		envValues[variables[idx].Name] = value
+	}
+
+	fileName := filepath.Join(i.ConfigDir, ConfigVariablesFileName)
+
+	if missing {
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the aerospikeConfig variable values: %v", err)
+		}
+
+		envValues = map[string]string{}
+		if err := json.Unmarshal(content, &envValues); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", fileName, err)
+		}
+
+		return envValues, nil
+	}
+
+	if len(envValues) == 0 {
+		return envValues, nil
+	}
+
+	content, err := json.Marshal(envValues)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(i.ConfigDir, 0o755); err != nil { //nolint:gosec // read by the server
+		return nil, err
+	}
+
+	// The values of the Secrets are only readable by the owner.
+	if err := os.WriteFile(fileName, content, 0o600); err != nil {
+		return nil, err
+	}
+
+	return envValues, nil
+}
+
+// getNode returns the k8s node of the pod, if a variable needs a node label.
+func (i *Initializer) getNode(ctx context.Context, variables []asdbv1.AerospikeConfigVariable) (*corev1.Node, error) {
+	needsNode := slices.ContainsFunc(variables, func(variable asdbv1.AerospikeConfigVariable) bool {
+		return variable.ValueFrom != nil && variable.ValueFrom.NodeLabel != ""
+	})
+	if !needsNode {
+		return nil, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: i.Env.PodName, Namespace: i.Env.Namespace}, pod); err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %v", i.Env.PodName, err)
+	}
+
+	if pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		return nil, fmt.Errorf("failed to get k8s node %s: %v", pod.Spec.NodeName, err)
+	}
+
+	return node, nil
+}
+
+// getAddresses returns the addresses of the pod, with the pod and the mapped service and TLS service ports.
+// The pod service address is read from the rack ConfigMap data, published by the operator.
+func (i *Initializer) getAddresses(
+	ctx context.Context, data map[string]string, params *utils.InitParams,
+) (addresses *Addresses, podPorts, mappedPorts [2]int32, err error) {
+	nodes := &corev1.NodeList{}
+	if err := i.Client.List(ctx, nodes); err != nil {
+		return nil, podPorts, mappedPorts, fmt.Errorf("failed to list k8s nodes: %v", err)
+	}
+
+	addresses = &Addresses{
+		PodIPs:           i.Env.PodIPs,
+		TLSRouteHostname: strings.ReplaceAll(params.TLSRouteHostname, utils.PodNameVariable, i.Env.PodName),
+		TLSRoutePort:     params.TLSRoutePort,
+	}
+	addresses.InternalIPs, addresses.ExternalIPs = GetHostIPs(nodes.Items, i.Env.HostIP)
+
+	podPorts = [2]int32{params.PodPort, params.PodTLSPort}
+	mappedPorts = podPorts
+
+	if !params.MultiPodPerHost && params.PodServiceType == "" {
+		return addresses, podPorts, mappedPorts, nil
+	}
+
+	if params.MultiPodPerHost || params.PodServiceType == corev1.ServiceTypeNodePort {
+		service := &corev1.Service{}
+		key := types.NamespacedName{Name: i.Env.PodName, Namespace: i.Env.Namespace}
+
+		if err := i.Client.Get(ctx, key, service); err != nil {
+			return nil, podPorts, mappedPorts, fmt.Errorf("failed to get pod service %s: %v", key.Name, err)
+		}
+
+		// Use the mapped service ports.
+		mappedPorts = [2]int32{}
+
+		for _, port := range service.Spec.Ports {
+			switch port.Name {
+			case asdbv1.ServicePortName:
+				mappedPorts[0] = port.NodePort
+			case asdbv1.ServiceTLSPortName:
+				mappedPorts[1] = port.NodePort
+			}
+		}
+	}
+
+	podServiceAddress := utils.ParsePodServiceAddresses(data[asdbv1.PodServiceAddressesFileName])[i.Env.PodName]
+
+	switch params.PodServiceType {
+	case corev1.ServiceTypeLoadBalancer:
+		if podServiceAddress == "" {
+			return nil, podPorts, mappedPorts, fmt.Errorf(
+				"load balancer ingress address not published for service %s", i.Env.PodName)
+		}
+
+		addresses.LoadBalancerAddress = podServiceAddress
+	case corev1.ServiceTypeNodePort:
+		// Use the configured external address of the pod service, defaults to the host's external IP.
+		addresses.NodePortAddress = podServiceAddress
+		if addresses.NodePortAddress == "" && len(addresses.ExternalIPs) != 0 {
+			addresses.NodePortAddress = addresses.ExternalIPs[0]
+		}
+	case corev1.ServiceTypeClusterIP, corev1.ServiceTypeExternalName:
+	}
+
+	return addresses, podPorts, mappedPorts, nil
+}
+
+// waitForPodServiceAddress waits for the load balancer address of the pod service to be published in the rack
+// ConfigMap, refreshed by the kubelet in the mounted directory. It returns the refreshed ConfigMap data.
+func (i *Initializer) waitForPodServiceAddress(
+	ctx context.Context, configMapDir string, data map[string]string,
+) (map[string]string, error) {
+	for range loadBalancerRetries {
+		if utils.ParsePodServiceAddresses(data[asdbv1.PodServiceAddressesFileName])[i.Env.PodName] != "" {
+			return data, nil
+		}
+
+		i.Log.Info("Waiting for load balancer ingress address", "service", i.Env.PodName)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(loadBalancerRetryInterval):
+		}
+
+		var err error
+		if data, err = readConfigMapDir(configMapDir); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("load balancer ingress address not published for service %s", i.Env.PodName)
+}
+
+// publishStatus completes the pod status with the image, the volumes and the hashes and publishes it.
+// The volumes are initialized and wiped if prepareVolumes is true, on a pod start.
+func (i *Initializer) publishStatus(
+	ctx context.Context, data map[string]string, status *asdbv1.AerospikePodStatus, prepareVolumes bool,
+) error {
+	aeroCluster := &asdbv1.AerospikeCluster{}
+	if err := i.Client.Get(
+		ctx, types.NamespacedName{Name: i.Env.ClusterName, Namespace: i.Env.Namespace}, aeroCluster,
+	); err != nil {
+		return fmt.Errorf("failed to get AerospikeCluster %s: %v", i.Env.ClusterName, err)
+	}
+
+	pod := &corev1.Pod{}
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: i.Env.PodName, Namespace: i.Env.Namespace}, pod); err != nil {
+		return fmt.Errorf("failed to get pod %s: %v", i.Env.PodName, err)
+	}
+
+	for idx := range pod.Spec.Containers {
+		if pod.Spec.Containers[idx].Name == asdbv1.AerospikeServerContainerName {
+			status.Image = pod.Spec.Containers[idx].Image
+		}
+	}
+
+	prevStatus, restarted := aeroCluster.Status.Pods[i.Env.PodName]
+	if restarted {
+		i.Log.Info("Restarted", "prevImage", prevStatus.Image)
+	} else {
+		i.Log.Info("Initializing")
+	}
+
+	state := &VolumeState{
+		InitializedVolumes: prevStatus.InitializedVolumes,
+		DirtyVolumes:       prevStatus.DirtyVolumes,
+		WipeResults:        prevStatus.WipeResults,
+	}
+
+	if prepareVolumes {
+		var err error
+
+		if state, err = i.prepareVolumes(ctx, aeroCluster, pod, prevStatus.Image, status.Image, state); err != nil {
+			return err
+		}
+	}
+
+	status.InitializedVolumes = state.InitializedVolumes
+	status.DirtyVolumes = state.DirtyVolumes
+	status.WipeResults = state.WipeResults
+	status.AerospikeConfigHash = data[AerospikeConfHashFileName]
+	status.NetworkPolicyHash = data[NetworkPolicyHashFileName]
+	status.PodSpecHash = data[PodSpecHashFileName]
+
+	i.Log.Info("Updating pod status")
+
+	return PublishPodStatus(ctx, i, status, data[ConfTemplateFileName])
+}
+
+func (i *Initializer) prepareVolumes(
+	ctx context.Context, aeroCluster *asdbv1.AerospikeCluster, pod *corev1.Pod, prevImage, image string,
+	state *VolumeState,
+) (*VolumeState, error) {
+	rackID, _, err := GetRackAndNodeID(i.Env.ClusterName, i.Env.PodName)
+	if err != nil {
+		return nil, err
+	}
+
+	rackVolumes, err := GetRackVolumes(aeroCluster, rackID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pod volumes of the PVCs are named after the storage volumes.
+	pvcUIDs := map[string]string{}
+
+	for idx := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[idx]
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := i.Client.Get(ctx, types.NamespacedName{
+			Name: volume.PersistentVolumeClaim.ClaimName, Namespace: i.Env.Namespace,
+		}, pvc); err != nil {
+			return nil, fmt.Errorf("failed to get PVC %s: %v", volume.PersistentVolumeClaim.ClaimName, err)
+		}
+
+		pvcUIDs[volume.Name] = string(pvc.UID)
+	}
+
+	reporter := NewProgressReporter(i, i.Log)
+	manager := &VolumeManager{
+		Log:                  i.Log,
+		Reporter:             reporter,
+		FileSystemMountPoint: DefaultFileSystemMountPoint,
+		BlockMountPoint:      DefaultBlockMountPoint,
+	}
+
+	i.Log.Info("Checking if volume initialization needed")
+
+	reporter.Begin(ctx)
+
+	newState, err := manager.PrepareVolumes(ctx, rackVolumes, pvcUIDs, prevImage, image, state)
+	reporter.Close(ctx, err == nil)
+
+	return newState, err
+}
+
+// readConfigMapDir reads the files of the mounted ConfigMap, skipping the hidden files of the volume.
+func readConfigMapDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		data[entry.Name()] = string(content)
+	}
+
+	return data, nil
+}
+
+func copyExecutable(destination string) error {
+	source, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil { //nolint:gosec // read by the server
+		return err
+	}
+
+	return os.WriteFile(destination, content, 0o755) //nolint:gosec // executable
+}
+
+// findASDPid returns the pid of the Aerospike server process.
+func findASDPid() (int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == 1 {
+			continue
+		}
+
+		cmdline, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+
+		args := strings.Split(string(cmdline), "\x00")
+		if filepath.Base(args[0]) == "asd" {
+			return pid, nil
+		}
+	}
+
+	return 0, fmt.Errorf("error getting Aerospike server PID")
+}
@@ -0,0 +1,159 @@
+package podinit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+// PodEnv has the pod details given to the Aerospike pod containers as environment variables.
+type PodEnv struct {
+	PodName     string
+	Namespace   string
+	ClusterName string
+	PodIP       string
+	HostIP      string
+	TLSName     string
+	// PodIPs has the IPs of all the IP families, the primary IP first.
+	PodIPs     []string
+	TLSEnabled bool
+}
+
+// GetPodEnv reads the PodEnv from the environment variables of the container.
+func GetPodEnv() (*PodEnv, error) {
+	env := &PodEnv{
+		PodName:     os.Getenv("MY_POD_NAME"),
+		Namespace:   os.Getenv("MY_POD_NAMESPACE"),
+		ClusterName: os.Getenv("MY_POD_CLUSTER_NAME"),
+		PodIP:       os.Getenv("MY_POD_IP"),
+		HostIP:      os.Getenv("MY_HOST_IP"),
+		TLSName:     os.Getenv("MY_POD_TLS_NAME"),
+		PodIPs:      splitIPs(os.Getenv("MY_POD_IPS")),
+	}
+
+	if env.PodName == "" || env.Namespace == "" || env.ClusterName == "" {
+		return nil, fmt.Errorf("MY_POD_NAME, MY_POD_NAMESPACE and MY_POD_CLUSTER_NAME environment variables are required")
+	}
+
+	if len(env.PodIPs) == 0 && env.PodIP != "" {
+		env.PodIPs = []string{env.PodIP}
+	}
+
+	if tlsEnabled := os.Getenv("MY_POD_TLS_ENABLED"); tlsEnabled != "" {
+		enabled, err := strconv.ParseBool(tlsEnabled)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MY_POD_TLS_ENABLED %q: %v", tlsEnabled, err)
+		}
+
+		env.TLSEnabled = enabled
+	}
+
+	return env, nil
+}
+
+// GetRackAndNodeID returns the rack ID and the Aerospike node ID of the pod.
+// The node ID is the rack ID and the pod ordinal separated by an 'a', so it is a valid hex number.
+func GetRackAndNodeID(clusterName, podName string) (rackID int, nodeID string, err error) {
+	rackID, _, err = utils.GetRackIDAndRevisionFromPodName(clusterName, podName)
+	if err != nil {
+		return 0, "", err
+	}
+
+	ordinal := podName[strings.LastIndex(podName, "-")+1:]
+	if _, err := strconv.Atoi(ordinal); err != nil {
+		return 0, "", fmt.Errorf("invalid pod ordinal in pod name %q", podName)
+	}
+
+	return rackID, fmt.Sprintf("%da%s", rackID, ordinal), nil
+}
+
+// SelectIP returns the first IP of the given family. The first IP is returned if no family is given.
+func SelectIP(family corev1.IPFamily, ips []string) (string, error) {
+	for _, ip := range ips {
+		if family == "" || (family == corev1.IPv6Protocol) == isIPv6(ip) {
+			return ip, nil
+		}
+	}
+
+	return "", fmt.Errorf("no %s IP found in %v", family, ips)
+}
+
+// GetHostIPs returns the internal and the external IPs of the k8s node with the given host IP.
+// The IPs of the same family as the host IP are first, both default to the host IP.
+func GetHostIPs(nodes []corev1.Node, hostIP string) (internalIPs, externalIPs []string) {
+	internalIPs = []string{hostIP}
+	externalIPs = []string{hostIP}
+
+	for idx := range nodes {
+		var (
+			nodeInternalIPs, nodeExternalIPs []string
+			matchFound                       bool
+		)
+
+		for _, address := range nodes[idx].Status.Addresses {
+			if address.Address == hostIP {
+				matchFound = true
+			}
+
+			switch address.Type {
+			case corev1.NodeInternalIP:
+				nodeInternalIPs = append(nodeInternalIPs, address.Address)
+			case corev1.NodeExternalIP:
+				nodeExternalIPs = append(nodeExternalIPs, address.Address)
+			case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			}
+		}
+
+		if !matchFound {
+			continue
+		}
+
+		if len(nodeInternalIPs) != 0 {
+			internalIPs = primaryFirst(nodeInternalIPs, hostIP)
+		}
+
+		if len(nodeExternalIPs) != 0 {
+			externalIPs = primaryFirst(nodeExternalIPs, hostIP)
+		}
+
+		break
+	}
+
+	return internalIPs, externalIPs
+}
+
+// primaryFirst returns the IPs of the same family as the primary IP first, the order is otherwise kept.
+func primaryFirst(ips []string, primaryIP string) []string {
+	sorted := make([]string, 0, len(ips))
+
+	for _, sameFamily := range []bool{true, false} {
+		for _, ip := range ips {
+			if (isIPv6(ip) == isIPv6(primaryIP)) == sameFamily {
+				sorted = append(sorted, ip)
+			}
+		}
+	}
+
+	return sorted
+}
+
+func isIPv6(ip string) bool {
+	return strings.Contains(ip, ":")
+}
+
+func splitIPs(ips string) []string {
+	var split []string
+
+	for _, ip := range strings.Split(ips, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			split = append(split, ip)
+		}
+	}
+
+	return split
+}
@@ -0,0 +1,178 @@
+package podinit
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetRackAndNodeID(t *testing.T) {
+	tests := []struct {
+		name           string
+		podName        string
+		expectedRackID int
+		expectedNodeID string
+		expectErr      bool
+	}{
+		{
+			name:           "pod without rack revision",
+			podName:        "aerocluster-1-2",
+			expectedRackID: 1,
+			expectedNodeID: "1a2",
+		},
+		{
+			name:           "pod with rack revision",
+			podName:        "aerocluster-10-v2-0",
+			expectedRackID: 10,
+			expectedNodeID: "10a0",
+		},
+		{
+			name:      "pod of another cluster",
+			podName:   "other-1-0",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rackID, nodeID, err := GetRackAndNodeID("aerocluster", tt.podName)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("GetRackAndNodeID() expected error for pod %s", tt.podName)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetRackAndNodeID() error = %v", err)
+			}
+
+			if rackID != tt.expectedRackID || nodeID != tt.expectedNodeID {
+				t.Errorf("GetRackAndNodeID() = %d, %s, expected %d, %s", rackID, nodeID, tt.expectedRackID,
+					tt.expectedNodeID)
+			}
+		})
+	}
+}
+
+func TestSelectIP(t *testing.T) {
+	ips := []string{"10.0.0.1", "2001:db8::1"}
+
+	tests := []struct {
+		name      string
+		family    corev1.IPFamily
+		ips       []string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "primary IP",
+			ips:      ips,
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "IPv4",
+			family:   corev1.IPv4Protocol,
+			ips:      ips,
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "IPv6",
+			family:   corev1.IPv6Protocol,
+			ips:      ips,
+			expected: "2001:db8::1",
+		},
+		{
+			name:      "missing family",
+			family:    corev1.IPv6Protocol,
+			ips:       []string{"10.0.0.1"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip, err := SelectIP(tt.family, tt.ips)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("SelectIP() expected error, got %s", ip)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SelectIP() error = %v", err)
+			}
+
+			if ip != tt.expected {
+				t.Errorf("SelectIP() = %s, expected %s", ip, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetHostIPs(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+					{Type: corev1.NodeHostName, Address: "node-1"},
+				},
+			},
+		},
+		{
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalIP, Address: "2001:db8::2"},
+					{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+					{Type: corev1.NodeExternalIP, Address: "2001:db8:1::2"},
+					{Type: corev1.NodeExternalIP, Address: "192.0.2.2"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		hostIP              string
+		expectedInternalIPs []string
+		expectedExternalIPs []string
+	}{
+		{
+			name:                "external IPs default to the host IP",
+			hostIP:              "10.0.0.1",
+			expectedInternalIPs: []string{"10.0.0.1"},
+			expectedExternalIPs: []string{"10.0.0.1"},
+		},
+		{
+			name:                "host IP family first",
+			hostIP:              "10.0.0.2",
+			expectedInternalIPs: []string{"10.0.0.2", "2001:db8::2"},
+			expectedExternalIPs: []string{"192.0.2.2", "2001:db8:1::2"},
+		},
+		{
+			name:                "unknown node",
+			hostIP:              "10.0.0.3",
+			expectedInternalIPs: []string{"10.0.0.3"},
+			expectedExternalIPs: []string{"10.0.0.3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			internalIPs, externalIPs := GetHostIPs(nodes, tt.hostIP)
+
+			if !reflect.DeepEqual(internalIPs, tt.expectedInternalIPs) {
+				t.Errorf("GetHostIPs() internal IPs = %v, expected %v", internalIPs, tt.expectedInternalIPs)
+			}
+
+			if !reflect.DeepEqual(externalIPs, tt.expectedExternalIPs) {
+				t.Errorf("GetHostIPs() external IPs = %v, expected %v", externalIPs, tt.expectedExternalIPs)
+			}
+		})
+	}
+}
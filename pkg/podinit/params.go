@@ -0,0 +1,27 @@
+// Package podinit implements the steps run in the Aerospike pods by the init container and by the operator for a
+// warm restart: volume init and wipe, aerospike.conf templating and publishing the pod status.
+package podinit
+
+const (
+	// ConfTemplateFileName is the rack ConfigMap key with the aerospike.conf template.
+	ConfTemplateFileName = "aerospike.template.conf"
+
+	// PeersFileName is the rack ConfigMap key with the heartbeat seeds, one per line.
+	PeersFileName = "peers"
+
+	// FeaturesFileName is the optional rack ConfigMap key with the feature key file.
+	FeaturesFileName = "features.conf"
+
+	// AerospikeConfHashFileName is the rack ConfigMap key with the hash of the aerospike.conf template.
+	AerospikeConfHashFileName = "aerospikeConfHash"
+
+	// NetworkPolicyHashFileName is the rack ConfigMap key with the hash of the network policy.
+	NetworkPolicyHashFileName = "networkPolicyHash"
+
+	// PodSpecHashFileName is the rack ConfigMap key with the hash of the pod spec.
+	PodSpecHashFileName = "podSpecHash"
+
+	// ConfigVariablesFileName is the file in the config dir with the aerospikeConfig variable values read by the init
+	// container from its env vars, for the warm restarts run in the server container.
+	ConfigVariablesFileName = "configVariables.json"
+)
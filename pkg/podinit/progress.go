@@ -0,0 +1,190 @@
+package podinit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+// progressReportInterval is the interval at which the volume operations progress is published.
+const progressReportInterval = 30 * time.Second
+
+// StatusPublisher patches keys of the pod status ConfigMap. A nil value removes the key.
+type StatusPublisher interface {
+	PublishStatus(ctx context.Context, data map[string]*string) error
+}
+
+// ProgressReporter reports the progress of the volume init and wipe operations in the pod status ConfigMap.
+// The operator folds it into status.volumeOperations of the cluster.
+// Progress is published periodically from a background goroutine, so that long-running operations do not block on
+// the api-server.
+type ProgressReporter struct {
+	publisher StatusPublisher
+	log       logr.Logger
+	volumes   map[string]*asdbv1.VolumeOperationProgress
+	stop      chan struct{}
+	done      chan struct{}
+	lock      sync.Mutex
+	changed   bool
+	reported  bool
+}
+
+// NewProgressReporter creates a ProgressReporter publishing with the given publisher.
+func NewProgressReporter(publisher StatusPublisher, log logr.Logger) *ProgressReporter {
+	return &ProgressReporter{
+		publisher: publisher,
+		log:       log,
+		volumes:   map[string]*asdbv1.VolumeOperationProgress{},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start records the start of an operation on the volume.
+func (p *ProgressReporter) Start(
+	volumeName string, operation asdbv1.VolumeOperationType, method asdbv1.AerospikeVolumeMethod, totalBytes int64,
+) {
+	now := metav1.Now()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.volumes[volumeName] = &asdbv1.VolumeOperationProgress{
+		Operation:      operation,
+		Method:         method,
+		Phase:          asdbv1.VolumeOperationInProgress,
+		TotalBytes:     totalBytes,
+		StartTime:      now,
+		LastUpdateTime: now,
+	}
+	p.changed = true
+}
+
+// Update records the bytes processed so far by the operation on the volume.
+func (p *ProgressReporter) Update(volumeName string, bytesDone int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	progress := p.volumes[volumeName]
+	progress.BytesDone = bytesDone
+	progress.LastUpdateTime = metav1.Now()
+	p.changed = true
+}
+
+// Finish records the end of the operation on the volume.
+func (p *ProgressReporter) Finish(volumeName string, phase asdbv1.VolumeOperationPhase, message string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	progress := p.volumes[volumeName]
+	if phase == asdbv1.VolumeOperationCompleted && progress.TotalBytes != 0 {
+		progress.BytesDone = progress.TotalBytes
+	}
+
+	progress.Phase = phase
+	progress.Message = message
+	progress.LastUpdateTime = metav1.Now()
+	p.changed = true
+}
+
+// Begin starts publishing the progress periodically.
+func (p *ProgressReporter) Begin(ctx context.Context) {
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(progressReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.report(ctx)
+			}
+		}
+	}()
+}
+
+// Close stops publishing the progress. The failed state is kept for debugging, the next init run overwrites it.
+// On success the annotation is removed, the pod status has the outcome of the operations.
+func (p *ProgressReporter) Close(ctx context.Context, success bool) {
+	close(p.stop)
+	<-p.done
+
+	switch {
+	case !success:
+		p.report(ctx)
+	case p.reported:
+		p.publish(ctx, nil)
+	}
+}
+
+func (p *ProgressReporter) report(ctx context.Context) {
+	p.lock.Lock()
+
+	if !p.changed {
+		p.lock.Unlock()
+		return
+	}
+
+	status := p.getStatus()
+	p.changed = false
+	p.lock.Unlock()
+
+	annotation, err := json.Marshal(status)
+	if err != nil {
+		p.log.Error(err, "Failed to marshal volume operations progress")
+		return
+	}
+
+	value := string(annotation)
+
+	if p.publish(ctx, &value) {
+		p.reported = true
+	}
+}
+
+func (p *ProgressReporter) publish(ctx context.Context, value *string) bool {
+	if err := p.publisher.PublishStatus(
+		ctx, map[string]*string{asdbv1.VolumeOperationsConfigMapKey: value},
+	); err != nil {
+		// Progress is best effort, it should never fail the volume operations.
+		p.log.Error(err, "Unable to report volume operations progress")
+		return false
+	}
+
+	return true
+}
+
+// getStatus returns the progress of the volumes with the throughput and the estimated completion time.
+func (p *ProgressReporter) getStatus() map[string]asdbv1.VolumeOperationProgress {
+	status := make(map[string]asdbv1.VolumeOperationProgress, len(p.volumes))
+
+	for volumeName, progress := range p.volumes {
+		value := *progress
+
+		elapsed := progress.LastUpdateTime.Sub(progress.StartTime.Time)
+		if elapsed > 0 && progress.BytesDone != 0 {
+			rate := float64(progress.BytesDone) / elapsed.Seconds()
+			value.BytesPerSecond = int64(rate)
+
+			if progress.Phase == asdbv1.VolumeOperationInProgress && progress.TotalBytes != 0 {
+				remaining := max(progress.TotalBytes-progress.BytesDone, 0)
+				completion := metav1.NewTime(progress.LastUpdateTime.Add(
+					time.Duration(float64(remaining) / rate * float64(time.Second))))
+				value.EstimatedCompletionTime = &completion
+			}
+		}
+
+		status[volumeName] = value
+	}
+
+	return status
+}
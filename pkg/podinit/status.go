@@ -0,0 +1,79 @@
+package podinit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+// NewPodStatus returns the status of the pod with its network details and access endpoints.
+// The volumes, the image and the hashes are set by the caller.
+func NewPodStatus(
+	env *PodEnv, addresses *Addresses, nodeID string, rackID int, endpoints []Endpoint, podPort, servicePort int32,
+) *asdbv1.AerospikePodStatus {
+	status := &asdbv1.AerospikePodStatus{
+		PodIP:           env.PodIP,
+		PodIPs:          addresses.PodIPs,
+		HostInternalIPs: addresses.InternalIPs,
+		HostExternalIPs: addresses.ExternalIPs,
+		PodPort:         int(podPort),
+		ServicePort:     servicePort,
+		Aerospike: asdbv1.AerospikeInstanceSummary{
+			ClusterName: env.ClusterName,
+			NodeID:      nodeID,
+			RackID:      rackID,
+			TLSName:     env.TLSName,
+		},
+	}
+
+	if len(addresses.InternalIPs) != 0 {
+		status.HostInternalIP = addresses.InternalIPs[0]
+	}
+
+	if len(addresses.ExternalIPs) != 0 {
+		status.HostExternalIP = addresses.ExternalIPs[0]
+	}
+
+	for idx := range endpoints {
+		endpoint := &endpoints[idx]
+
+		// Only the IP endpoints are published, the clients connect to the hostnames with the seed address.
+		if net.ParseIP(endpoint.Address) == nil {
+			continue
+		}
+
+		switch endpoint.AddressType {
+		case AddressTypeAccess:
+			status.Aerospike.AccessEndpoints = []string{endpoint.String()}
+		case AddressTypeAlternateAccess:
+			status.Aerospike.AlternateAccessEndpoints = []string{endpoint.String()}
+		case AddressTypeTLSAccess:
+			status.Aerospike.TLSAccessEndpoints = []string{endpoint.String()}
+		case AddressTypeTLSAlternateAccess:
+			status.Aerospike.TLSAlternateAccessEndpoints = []string{endpoint.String()}
+		}
+	}
+
+	return status
+}
+
+// PublishPodStatus publishes the pod status and the aerospike.conf template in use in the pod status ConfigMap.
+// The operator folds the pod status into status.pods.<pod-name> of the cluster.
+func PublishPodStatus(
+	ctx context.Context, publisher StatusPublisher, status *asdbv1.AerospikePodStatus, confTemplate string,
+) error {
+	podStatus, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pod status: %v", err)
+	}
+
+	value := string(podStatus)
+
+	return publisher.PublishStatus(ctx, map[string]*string{
+		asdbv1.PodStatusConfigMapKey:     &value,
+		asdbv1.AerospikeConfConfigMapKey: &confTemplate,
+	})
+}
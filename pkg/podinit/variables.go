@@ -0,0 +1,81 @@
+package podinit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+)
+
+// ResolveConfigVariables returns the values of the aerospikeConfig variables for the pod. The variables are resolved
+// in order, so an expression can use the variables defined before it. The values from the pod fields, the container
+// resources, the Secrets and the ConfigMaps are set by k8s in the init container env vars, given in envValues.
+func ResolveConfigVariables(
+	node *corev1.Node, variables []asdbv1.AerospikeConfigVariable, envValues map[string]string,
+) (map[string]string, error) {
+	values := make(map[string]string, len(variables))
+
+	for idx := range variables {
+		variable := &variables[idx]
+
+		value, err := resolveConfigVariable(node, variable, values, envValues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve aerospikeConfig variable %s: %v", variable.Name, err)
+		}
+
+		if err := asdbv1.ValidateConfigVariableValue(value); err != nil {
+			return nil, fmt.Errorf("invalid aerospikeConfig variable %s: %v", variable.Name, err)
+		}
+
+		values[variable.Name] = value
+	}
+
+	return values, nil
+}
+
+func resolveConfigVariable(
+	node *corev1.Node, variable *asdbv1.AerospikeConfigVariable, values, envValues map[string]string,
+) (string, error) {
+	if variable.Expression != "" {
+		expression, err := utils.ParseSizeExpression(variable.Expression)
+		if err != nil {
+			return "", err
+		}
+
+		result, err := expression.Evaluate(values)
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatInt(result, 10), nil
+	}
+
+	if asdbv1.IsConfigVariableFromEnv(variable) {
+		value, ok := envValues[variable.Name]
+		if !ok {
+			return "", fmt.Errorf("env var %s not set", asdbv1.GetConfigVariableEnvVarName(variable.Name))
+		}
+
+		// The values of the Secrets and the ConfigMaps often end with a newline.
+		return strings.TrimSpace(value), nil
+	}
+
+	source := variable.ValueFrom
+	if source == nil {
+		return variable.Value, nil
+	}
+
+	if source.NodeLabel != "" {
+		if node == nil {
+			return "", fmt.Errorf("k8s node of the pod not found")
+		}
+
+		return node.Labels[source.NodeLabel], nil
+	}
+
+	return "", fmt.Errorf("valueFrom has no source")
+}
@@ -0,0 +1,180 @@
+package podinit
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+func testVariablesNode() *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "us-east-1a"},
+		},
+	}
+}
+
+func TestResolveConfigVariables(t *testing.T) {
+	envValues := map[string]string{
+		"podName":     "aerocluster-1-0",
+		"clusterName": "prod\n",
+		"ratio":       "50%",
+		"memoryLimit": "8589934592",
+	}
+
+	secretVariable := func(name string) asdbv1.AerospikeConfigVariable {
+		return asdbv1.AerospikeConfigVariable{
+			Name: name,
+			ValueFrom: &asdbv1.AerospikeConfigVariableSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "aerospike-secret"},
+					Key:                  name,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		variables []asdbv1.AerospikeConfigVariable
+		envValues map[string]string
+		expected  map[string]string
+		wantErr   bool
+	}{
+		{
+			name: "all sources",
+			variables: []asdbv1.AerospikeConfigVariable{
+				{Name: "port", Value: "3000"},
+				{
+					Name: "zone",
+					ValueFrom: &asdbv1.AerospikeConfigVariableSource{
+						NodeLabel: "topology.kubernetes.io/zone",
+					},
+				},
+				{
+					Name: "podName",
+					ValueFrom: &asdbv1.AerospikeConfigVariableSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+					},
+				},
+				secretVariable("clusterName"),
+				{
+					Name: "ratio",
+					ValueFrom: &asdbv1.AerospikeConfigVariableSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "aerospike-sizing"},
+							Key:                  "ratio",
+						},
+					},
+				},
+				{
+					Name: "memoryLimit",
+					ValueFrom: &asdbv1.AerospikeConfigVariableSource{
+						ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.memory"},
+					},
+				},
+				{Name: "indexSize", Expression: "memoryLimit * ratio"},
+			},
+			envValues: envValues,
+			expected: map[string]string{
+				"port":        "3000",
+				"zone":        "us-east-1a",
+				"podName":     "aerocluster-1-0",
+				"clusterName": "prod",
+				"ratio":       "50%",
+				"memoryLimit": "8589934592",
+				"indexSize":   "4294967296",
+			},
+		},
+		{
+			name:      "env var not set",
+			variables: []asdbv1.AerospikeConfigVariable{secretVariable("password")},
+			wantErr:   true,
+		},
+		{
+			name:      "value with a space",
+			variables: []asdbv1.AerospikeConfigVariable{{Name: "clusterName", Value: "prod cluster"}},
+			wantErr:   true,
+		},
+		{
+			name:      "value with a newline",
+			variables: []asdbv1.AerospikeConfigVariable{{Name: "clusterName", Value: "prod\ncluster"}},
+			wantErr:   true,
+		},
+		{
+			name:      "value with an opening brace",
+			variables: []asdbv1.AerospikeConfigVariable{{Name: "clusterName", Value: "prod{"}},
+			wantErr:   true,
+		},
+		{
+			name:      "value with a closing brace",
+			variables: []asdbv1.AerospikeConfigVariable{{Name: "clusterName", Value: "}prod"}},
+			wantErr:   true,
+		},
+		{
+			name:      "value with a comment",
+			variables: []asdbv1.AerospikeConfigVariable{{Name: "clusterName", Value: "prod#1"}},
+			wantErr:   true,
+		},
+		{
+			name:      "env value with a space",
+			variables: []asdbv1.AerospikeConfigVariable{secretVariable("password")},
+			envValues: map[string]string{"password": "pass word"},
+			wantErr:   true,
+		},
+		{
+			name: "expression using a later variable",
+			variables: []asdbv1.AerospikeConfigVariable{
+				{Name: "indexSize", Expression: "memoryLimit * 50%"},
+				{Name: "memoryLimit", Value: "8Gi"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := ResolveConfigVariables(testVariablesNode(), tt.variables, tt.envValues)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveConfigVariables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(values, tt.expected) {
+				t.Errorf("ResolveConfigVariables() = %v, expected %v", values, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExpandConfigVariables(t *testing.T) {
+	template := "namespace test {\n    index-stage-size <var:indexSize>\n    rack-id <var:zone>-<var:zone>\n}"
+
+	conf, err := asdbv1.ExpandConfigVariables(template, map[string]string{"indexSize": "1G", "zone": "a"})
+	if err != nil {
+		t.Fatalf("ExpandConfigVariables() error = %v", err)
+	}
+
+	expected := "namespace test {\n    index-stage-size 1G\n    rack-id a-a\n}"
+	if conf != expected {
+		t.Errorf("ExpandConfigVariables() = %s, expected %s", conf, expected)
+	}
+
+	invalidValues := []map[string]string{
+		{"zone": "a"},
+		{"indexSize": "1G", "zone": "a b"},
+		{"indexSize": "1G", "zone": "a\n}"},
+		{"indexSize": "1G", "zone": "a{"},
+		{"indexSize": "1G #", "zone": "a"},
+	}
+
+	for _, values := range invalidValues {
+		if _, err := asdbv1.ExpandConfigVariables(template, values); err == nil {
+			t.Errorf("ExpandConfigVariables() expected error for values %v", values)
+		}
+	}
+}
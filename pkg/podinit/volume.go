@@ -0,0 +1,930 @@
+package podinit
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+const (
+	// DefaultFileSystemMountPoint is the directory where the init container mounts the filesystem volumes by name.
+	DefaultFileSystemMountPoint = "/workdir/filesystem-volumes"
+
+	// DefaultBlockMountPoint is the directory where the init container mounts the block volumes by name.
+	DefaultBlockMountPoint = "/workdir/block-volumes"
+
+	// baseWipeVersion is the Aerospike major version from which the storage format changed. The volumes are wiped on
+	// an upgrade or a downgrade across it.
+	baseWipeVersion = 6
+
+	// headerCleanupSize is the size of the Aerospike device header zeroed by the header cleanup methods.
+	headerCleanupSize = 8 * 1024 * 1024
+
+	writeBlockSize           = 1024 * 1024
+	verifySampleBlocks       = 16
+	verifyBlockSize          = 4096
+	nvmeSanitizePollInterval = 10 * time.Second
+)
+
+// VolumeState is the state of the pod volumes, kept in the pod status.
+type VolumeState struct {
+	WipeResults        map[string]asdbv1.VolumeWipeResult
+	InitializedVolumes []string
+	DirtyVolumes       []string
+}
+
+// RackVolumes has the volumes of the rack of the pod and the device and file paths used by its namespaces.
+type RackVolumes struct {
+	DevicePaths sets.Set[string]
+	Volumes     []asdbv1.VolumeSpec
+	FilePaths   []string
+	// Threads is the number of volumes initialized or wiped in parallel.
+	Threads int
+}
+
+// GetRackVolumes returns the RackVolumes of the rack with the given ID.
+func GetRackVolumes(aeroCluster *asdbv1.AerospikeCluster, rackID int) (*RackVolumes, error) {
+	var rack *asdbv1.Rack
+
+	for idx := range aeroCluster.Spec.RackConfig.Racks {
+		if aeroCluster.Spec.RackConfig.Racks[idx].ID == rackID {
+			rack = &aeroCluster.Spec.RackConfig.Racks[idx]
+			break
+		}
+	}
+
+	if rack == nil {
+		return nil, fmt.Errorf("rack %d not found", rackID)
+	}
+
+	rackVolumes := &RackVolumes{
+		Volumes:     rack.Storage.Volumes,
+		Threads:     rack.Storage.CleanupThreads,
+		DevicePaths: sets.New[string](),
+	}
+
+	if len(rackVolumes.Volumes) == 0 {
+		rackVolumes.Volumes = aeroCluster.Spec.Storage.Volumes
+	}
+
+	if rackVolumes.Threads < 1 {
+		rackVolumes.Threads = asdbv1.AerospikeVolumeSingleCleanupThread
+	}
+
+	if rack.AerospikeConfig.Value == nil {
+		return rackVolumes, nil
+	}
+
+	namespaces, _ := rack.AerospikeConfig.Value[asdbv1.ConfKeyNamespace].([]interface{})
+
+	for _, namespace := range namespaces {
+		namespaceConf, _ := namespace.(map[string]interface{})
+		storageEngine, _ := namespaceConf[asdbv1.ConfKeyStorageEngine].(map[string]interface{})
+
+		if storageEngine["type"] != "device" {
+			continue
+		}
+
+		for _, device := range getStringList(storageEngine["devices"]) {
+			// A device can have a shadow device, separated by a space.
+			rackVolumes.DevicePaths.Insert(strings.Fields(device)...)
+		}
+
+		for _, file := range getStringList(storageEngine["files"]) {
+			rackVolumes.FilePaths = append(rackVolumes.FilePaths, strings.Fields(file)...)
+		}
+	}
+
+	return rackVolumes, nil
+}
+
+// IsWipeNeeded returns true if the volumes should be wiped on the image change, when the storage format changes.
+func IsWipeNeeded(prevImage, image string) (bool, error) {
+	if prevImage == "" {
+		return false, nil
+	}
+
+	prevMajorVersion, err := getMajorVersion(prevImage)
+	if err != nil {
+		return false, err
+	}
+
+	majorVersion, err := getMajorVersion(image)
+	if err != nil {
+		return false, err
+	}
+
+	return (majorVersion >= baseWipeVersion) != (prevMajorVersion >= baseWipeVersion), nil
+}
+
+// IsVolumeInitialized returns true if the volume with the given PVC UID is in the initialized volumes.
+// The initialized volumes are formatted as <volume-name>@<pvc-uid>, so that a recreated PVC is initialized again.
+// The entries without the PVC UID, from older init containers, match any PVC.
+func IsVolumeInitialized(initializedVolumes []string, volumeName, pvcUID string) bool {
+	for _, initializedVolume := range initializedVolumes {
+		name, uid, found := strings.Cut(initializedVolume, "@")
+		if name == volumeName && (!found || uid == pvcUID || pvcUID == "") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VolumeManager initializes and wipes the persistent volumes of the pod.
+type VolumeManager struct {
+	Log      logr.Logger
+	Reporter *ProgressReporter
+	// FileSystemMountPoint and BlockMountPoint are the directories where the volumes are mounted by name.
+	FileSystemMountPoint string
+	BlockMountPoint      string
+}
+
+// PrepareVolumes initializes the new volumes, wipes the volumes if the storage format changed with the image and
+// wipes the dirty volumes. pvcUIDs maps the volume names to the UIDs of their PVCs.
+func (m *VolumeManager) PrepareVolumes(
+	ctx context.Context, rackVolumes *RackVolumes, pvcUIDs map[string]string, prevImage, image string,
+	state *VolumeState,
+) (*VolumeState, error) {
+	newState := &VolumeState{
+		DirtyVolumes: slices.Clone(state.DirtyVolumes),
+		WipeResults:  make(map[string]asdbv1.VolumeWipeResult, len(state.WipeResults)),
+	}
+
+	for name, result := range state.WipeResults {
+		newState.WipeResults[name] = result
+	}
+
+	initializedVolumes, err := m.initVolumes(ctx, rackVolumes, pvcUIDs, state.InitializedVolumes)
+	if err != nil {
+		return nil, err
+	}
+
+	newState.InitializedVolumes = append(initializedVolumes, state.InitializedVolumes...)
+
+	wipeNeeded, err := IsWipeNeeded(prevImage, image)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Log.Info("Checked if volumes should be wiped", "prevImage", prevImage, "image", image, "wipe", wipeNeeded)
+
+	if wipeNeeded {
+		if err := m.wipeVolumes(ctx, rackVolumes, newState); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.cleanDirtyVolumes(ctx, rackVolumes, newState); err != nil {
+		return nil, err
+	}
+
+	return newState, nil
+}
+
+// initVolumes initializes the persistent volumes not initialized yet and returns their names.
+func (m *VolumeManager) initVolumes(
+	ctx context.Context, rackVolumes *RackVolumes, pvcUIDs map[string]string, initializedVolumes []string,
+) ([]string, error) {
+	var (
+		volumes []string
+		tasks   []func() error
+	)
+
+	for idx := range rackVolumes.Volumes {
+		volume := &rackVolumes.Volumes[idx]
+		if volume.Source.PersistentVolume == nil ||
+			IsVolumeInitialized(initializedVolumes, volume.Name, pvcUIDs[volume.Name]) {
+			continue
+		}
+
+		log := m.Log.WithValues("volume", volume.Name, "initMethod", volume.InitMethod)
+		mountPoint := m.getMountPoint(volume)
+
+		if _, err := os.Stat(mountPoint); err != nil {
+			return nil, fmt.Errorf("volume %s mount point not found: %v", volume.Name, err)
+		}
+
+		switch volume.Source.PersistentVolume.VolumeMode {
+		case corev1.PersistentVolumeBlock:
+			switch volume.InitMethod {
+			case asdbv1.AerospikeVolumeMethodDD, asdbv1.AerospikeVolumeMethodBlkdiscard,
+				asdbv1.AerospikeVolumeMethodHeaderCleanup, asdbv1.AerospikeVolumeMethodBlkdiscardWithHeaderCleanup:
+				tasks = append(tasks, func() error {
+					return m.initBlockVolume(ctx, volume, mountPoint)
+				})
+
+				log.Info("Submitted volume init")
+			case asdbv1.AerospikeVolumeMethodNone:
+				log.Info("Volume init pass through")
+			default:
+				return nil, fmt.Errorf("volume %s has invalid init method %s", volume.Name, volume.InitMethod)
+			}
+		case corev1.PersistentVolumeFilesystem:
+			switch volume.InitMethod {
+			case asdbv1.AerospikeVolumeMethodDeleteFiles:
+				if err := deleteFiles(mountPoint); err != nil {
+					return nil, fmt.Errorf("failed to init volume %s: %v", volume.Name, err)
+				}
+
+				log.Info("Initialized volume")
+			case asdbv1.AerospikeVolumeMethodNone:
+				log.Info("Volume init pass through")
+			default:
+				return nil, fmt.Errorf("volume %s has invalid init method %s", volume.Name, volume.InitMethod)
+			}
+		default:
+			return nil, fmt.Errorf("volume %s has invalid volume mode %s", volume.Name,
+				volume.Source.PersistentVolume.VolumeMode)
+		}
+
+		if pvcUID := pvcUIDs[volume.Name]; pvcUID != "" {
+			volumes = append(volumes, volume.Name+"@"+pvcUID)
+		} else {
+			volumes = append(volumes, volume.Name)
+		}
+	}
+
+	if err := runParallel(rackVolumes.Threads, tasks); err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+// wipeVolumes wipes the volumes used by the Aerospike namespaces.
+func (m *VolumeManager) wipeVolumes(ctx context.Context, rackVolumes *RackVolumes, state *VolumeState) error {
+	var (
+		tasks []func() error
+		lock  sync.Mutex
+	)
+
+	for idx := range rackVolumes.Volumes {
+		volume := &rackVolumes.Volumes[idx]
+		if volume.Source.PersistentVolume == nil || volume.Aerospike == nil {
+			continue
+		}
+
+		mountPoint := m.getMountPoint(volume)
+
+		switch volume.Source.PersistentVolume.VolumeMode {
+		case corev1.PersistentVolumeBlock:
+			if !rackVolumes.DevicePaths.Has(volume.Aerospike.Path) {
+				continue
+			}
+
+			if _, err := os.Stat(mountPoint); err != nil {
+				return fmt.Errorf("volume %s mount point not found: %v", volume.Name, err)
+			}
+
+			tasks = append(tasks, func() error {
+				result, err := m.wipeBlockVolume(ctx, volume, mountPoint)
+				if err != nil {
+					return err
+				}
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				state.WipeResults[volume.Name] = *result
+
+				return nil
+			})
+
+			state.DirtyVolumes = slices.DeleteFunc(state.DirtyVolumes, func(name string) bool {
+				return name == volume.Name
+			})
+		case corev1.PersistentVolumeFilesystem:
+			if volume.WipeMethod != asdbv1.AerospikeVolumeMethodDeleteFiles {
+				return fmt.Errorf("volume %s has invalid wipe method %s", volume.Name, volume.WipeMethod)
+			}
+
+			if _, err := os.Stat(mountPoint); err != nil {
+				return fmt.Errorf("volume %s mount point not found: %v", volume.Name, err)
+			}
+
+			state.WipeResults[volume.Name] = m.wipeNamespaceFiles(volume, mountPoint, rackVolumes.FilePaths)
+		default:
+			return fmt.Errorf("volume %s has invalid volume mode %s", volume.Name,
+				volume.Source.PersistentVolume.VolumeMode)
+		}
+	}
+
+	return runParallel(rackVolumes.Threads, tasks)
+}
+
+// cleanDirtyVolumes wipes the dirty volumes used by the Aerospike namespaces.
+func (m *VolumeManager) cleanDirtyVolumes(ctx context.Context, rackVolumes *RackVolumes, state *VolumeState) error {
+	var (
+		tasks []func() error
+		lock  sync.Mutex
+	)
+
+	for idx := range rackVolumes.Volumes {
+		volume := &rackVolumes.Volumes[idx]
+		if volume.Source.PersistentVolume == nil || volume.Aerospike == nil ||
+			!slices.Contains(state.DirtyVolumes, volume.Name) || !rackVolumes.DevicePaths.Has(volume.Aerospike.Path) {
+			continue
+		}
+
+		mountPoint := m.getMountPoint(volume)
+
+		if _, err := os.Stat(mountPoint); err != nil {
+			return fmt.Errorf("volume %s mount point not found: %v", volume.Name, err)
+		}
+
+		if volume.WipeMethod == asdbv1.AerospikeVolumeMethodNone {
+			m.Log.Info("Dirty volume wipe pass through", "volume", volume.Name)
+		} else {
+			tasks = append(tasks, func() error {
+				result, err := m.wipeBlockVolume(ctx, volume, mountPoint)
+				if err != nil {
+					return err
+				}
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				state.WipeResults[volume.Name] = *result
+
+				return nil
+			})
+		}
+
+		state.DirtyVolumes = slices.DeleteFunc(state.DirtyVolumes, func(name string) bool {
+			return name == volume.Name
+		})
+	}
+
+	return runParallel(rackVolumes.Threads, tasks)
+}
+
+func (m *VolumeManager) getMountPoint(volume *asdbv1.VolumeSpec) string {
+	if volume.Source.PersistentVolume.VolumeMode == corev1.PersistentVolumeBlock {
+		return filepath.Join(m.BlockMountPoint, volume.Name)
+	}
+
+	return filepath.Join(m.FileSystemMountPoint, volume.Name)
+}
+
+func (m *VolumeManager) initBlockVolume(ctx context.Context, volume *asdbv1.VolumeSpec, devicePath string) error {
+	size, err := getDeviceSize(devicePath)
+	if err != nil {
+		return err
+	}
+
+	method := volume.InitMethod
+	m.Reporter.Start(volume.Name, asdbv1.VolumeOperationInit, method, size)
+
+	if err := m.runBlockMethod(ctx, volume.Name, method, devicePath, size); err != nil {
+		m.Reporter.Finish(volume.Name, asdbv1.VolumeOperationFailed, err.Error())
+		return fmt.Errorf("failed to init volume %s: %v", volume.Name, err)
+	}
+
+	m.Reporter.Finish(volume.Name, asdbv1.VolumeOperationCompleted, "")
+	m.Log.Info("Initialized volume", "volume", volume.Name, "initMethod", method)
+
+	return nil
+}
+
+func (m *VolumeManager) wipeBlockVolume(
+	ctx context.Context, volume *asdbv1.VolumeSpec, devicePath string,
+) (*asdbv1.VolumeWipeResult, error) {
+	size, err := getDeviceSize(devicePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var before map[int64][]byte
+
+	if volume.WipeVerification {
+		if before, err = readBlocks(devicePath, sampleBlockOffsets(size)); err != nil {
+			return nil, err
+		}
+	}
+
+	method := volume.WipeMethod
+	totalBytes := size
+
+	if method == asdbv1.AerospikeVolumeMethodMultiPassOverwrite {
+		totalBytes = size * int64(len(multiPassOverwriteSources))
+	}
+
+	m.Reporter.Start(volume.Name, asdbv1.VolumeOperationWipe, method, totalBytes)
+
+	if err := m.runBlockMethod(ctx, volume.Name, method, devicePath, size); err != nil {
+		m.Reporter.Finish(volume.Name, asdbv1.VolumeOperationFailed, err.Error())
+		return nil, fmt.Errorf("failed to wipe volume %s: %v", volume.Name, err)
+	}
+
+	result := &asdbv1.VolumeWipeResult{
+		Method:       method,
+		Verification: asdbv1.WipeVerificationSkipped,
+	}
+
+	if volume.WipeVerification {
+		if err := verifyWipe(devicePath, method, before, result); err != nil {
+			m.Reporter.Finish(volume.Name, asdbv1.VolumeOperationFailed, err.Error())
+			return nil, err
+		}
+
+		if result.Verification == asdbv1.WipeVerificationFailed {
+			m.Log.Error(errors.New(result.Message), "Wipe verification failed", "volume", volume.Name)
+		}
+	}
+
+	result.Time = metav1.Now()
+	m.Reporter.Finish(volume.Name, asdbv1.VolumeOperationCompleted, result.Message)
+	m.Log.Info("Wiped volume", "volume", volume.Name, "wipeMethod", method, "verification", result.Verification)
+
+	return result, nil
+}
+
+// wipeNamespaceFiles deletes the Aerospike namespace files in the filesystem volume.
+func (m *VolumeManager) wipeNamespaceFiles(
+	volume *asdbv1.VolumeSpec, mountPoint string, namespaceFilePaths []string,
+) asdbv1.VolumeWipeResult {
+	var deletedFiles []string
+
+	for _, namespaceFilePath := range namespaceFilePaths {
+		if !strings.HasPrefix(namespaceFilePath, volume.Aerospike.Path) {
+			continue
+		}
+
+		filePath := filepath.Join(mountPoint, filepath.Base(namespaceFilePath))
+
+		if err := os.Remove(filePath); err != nil {
+			m.Log.Info("Namespace file not deleted", "volume", volume.Name, "file", filePath, "error", err.Error())
+			continue
+		}
+
+		m.Log.Info("Deleted namespace file", "volume", volume.Name, "file", filePath)
+		deletedFiles = append(deletedFiles, filePath)
+	}
+
+	result := asdbv1.VolumeWipeResult{
+		Method:       volume.WipeMethod,
+		Verification: asdbv1.WipeVerificationSkipped,
+	}
+
+	if volume.WipeVerification {
+		var remainingFiles []string
+
+		for _, file := range deletedFiles {
+			if _, err := os.Stat(file); err == nil {
+				remainingFiles = append(remainingFiles, file)
+			}
+		}
+
+		if len(remainingFiles) != 0 {
+			result.Verification = asdbv1.WipeVerificationFailed
+			result.Message = "files not deleted: " + strings.Join(remainingFiles, ", ")
+		} else {
+			result.Verification = asdbv1.WipeVerificationPassed
+			result.Message = fmt.Sprintf("%d deleted files verified", len(deletedFiles))
+		}
+	}
+
+	result.Time = metav1.Now()
+
+	return result
+}
+
+// multiPassOverwriteSources are the sources of the passes of the multiPassOverwrite method, nil for zeroes.
+var multiPassOverwriteSources = []io.Reader{rand.Reader, rand.Reader, nil}
+
+func (m *VolumeManager) runBlockMethod(
+	ctx context.Context, volumeName string, method asdbv1.AerospikeVolumeMethod, devicePath string, size int64,
+) error {
+	progress := func(pass int) func(int64) {
+		return func(bytesDone int64) {
+			m.Reporter.Update(volumeName, int64(pass)*size+bytesDone)
+		}
+	}
+
+	switch method {
+	case asdbv1.AerospikeVolumeMethodDD:
+		return overwrite(ctx, devicePath, nil, size, progress(0))
+	case asdbv1.AerospikeVolumeMethodHeaderCleanup:
+		return overwrite(ctx, devicePath, nil, min(size, headerCleanupSize), nil)
+	case asdbv1.AerospikeVolumeMethodBlkdiscard:
+		_, err := runCommand(ctx, "blkdiscard", devicePath)
+		return err
+	case asdbv1.AerospikeVolumeMethodBlkdiscardWithHeaderCleanup:
+		if _, err := runCommand(ctx, "blkdiscard", devicePath); err != nil {
+			return err
+		}
+
+		return overwrite(ctx, devicePath, nil, min(size, headerCleanupSize), nil)
+	case asdbv1.AerospikeVolumeMethodMultiPassOverwrite:
+		for pass, source := range multiPassOverwriteSources {
+			if err := overwrite(ctx, devicePath, source, size, progress(pass)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case asdbv1.AerospikeVolumeMethodNvmeSanitize:
+		return nvmeSanitize(ctx, devicePath)
+	case asdbv1.AerospikeVolumeMethodCryptoErase:
+		return nvmeCryptoErase(ctx, devicePath)
+	case asdbv1.AerospikeVolumeMethodNone, asdbv1.AerospikeVolumeMethodDeleteFiles:
+	}
+
+	return fmt.Errorf("invalid block volume method %s", method)
+}
+
+// overwrite writes size bytes from the source at the start of the device, zeroes if the source is nil.
+func overwrite(ctx context.Context, devicePath string, source io.Reader, size int64, progress func(int64)) error {
+	device, err := os.OpenFile(devicePath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer device.Close()
+
+	buf := make([]byte, writeBlockSize)
+
+	for written := int64(0); written < size; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		chunk := buf[:min(int64(len(buf)), size-written)]
+
+		if source != nil {
+			if _, err := io.ReadFull(source, chunk); err != nil {
+				return err
+			}
+		}
+
+		n, err := device.Write(chunk)
+		written += int64(n)
+
+		if err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(written)
+		}
+	}
+
+	return device.Sync()
+}
+
+// checkNvmeSingleNamespace returns an error if the NVMe controller of the device has more than one namespace.
+// Sanitize and crypto erase act on the whole controller, or on all its namespaces, so these would also erase the
+// other namespaces, possibly used by other volumes.
+func checkNvmeSingleNamespace(ctx context.Context, devicePath string) error {
+	namespaceList, err := getNvmeJSON(ctx, "list-ns", devicePath)
+	if err != nil {
+		return err
+	}
+
+	if count := getNvmeNamespaceCount(namespaceList); count != 1 {
+		return fmt.Errorf("device %s NVMe controller has %d namespaces, sanitize and crypto erase are allowed only "+
+			"with a single namespace", devicePath, count)
+	}
+
+	return nil
+}
+
+// getNvmeNamespaceCount returns the number of namespaces in the nvme list-ns JSON output.
+func getNvmeNamespaceCount(namespaceList map[string]interface{}) int {
+	namespaces, _ := namespaceList["nsid_list"].([]interface{})
+
+	return len(namespaces)
+}
+
+func nvmeSanitize(ctx context.Context, devicePath string) error {
+	if err := checkNvmeSingleNamespace(ctx, devicePath); err != nil {
+		return err
+	}
+
+	idCtrl, err := getNvmeJSON(ctx, "id-ctrl", devicePath)
+	if err != nil {
+		return err
+	}
+
+	sanitizeCapabilities := getJSONInt(idCtrl["sanicap"])
+
+	// Sanitize actions: 2 - block erase, 3 - overwrite, 4 - crypto erase.
+	var sanitizeAction int
+
+	switch {
+	case sanitizeCapabilities&0x2 != 0:
+		sanitizeAction = 2
+	case sanitizeCapabilities&0x4 != 0:
+		sanitizeAction = 3
+	case sanitizeCapabilities&0x1 != 0:
+		sanitizeAction = 4
+	default:
+		return fmt.Errorf("device %s does not support NVMe sanitize", devicePath)
+	}
+
+	if _, err := runCommand(ctx, "nvme", "sanitize", devicePath, "--sanact="+strconv.Itoa(sanitizeAction)); err != nil {
+		return err
+	}
+
+	for {
+		sanitizeLog, err := getNvmeJSON(ctx, "sanitize-log", devicePath)
+		if err != nil {
+			return err
+		}
+
+		// The log is keyed by the controller name in newer nvme-cli versions.
+		if _, ok := sanitizeLog["sstat"]; !ok && len(sanitizeLog) == 1 {
+			for _, controllerLog := range sanitizeLog {
+				if controllerLog, ok := controllerLog.(map[string]interface{}); ok {
+					sanitizeLog = controllerLog
+				}
+			}
+		}
+
+		// Sanitize status: 1 - completed, 2 - in progress, 3 - failed, 4 - completed with no-deallocate.
+		switch status := getJSONInt(sanitizeLog["sstat"]) & 0x7; status {
+		case 1, 4:
+			return nil
+		case 2:
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(nvmeSanitizePollInterval):
+			}
+		default:
+			return fmt.Errorf("device %s NVMe sanitize failed with status %d", devicePath, status)
+		}
+	}
+}
+
+func nvmeCryptoErase(ctx context.Context, devicePath string) error {
+	if err := checkNvmeSingleNamespace(ctx, devicePath); err != nil {
+		return err
+	}
+
+	idCtrl, err := getNvmeJSON(ctx, "id-ctrl", devicePath)
+	if err != nil {
+		return err
+	}
+
+	if getJSONInt(idCtrl["fna"])&0x4 == 0 {
+		return fmt.Errorf("device %s does not support NVMe crypto erase", devicePath)
+	}
+
+	_, err = runCommand(ctx, "nvme", "format", devicePath, "--ses=2", "--force")
+
+	return err
+}
+
+func getNvmeJSON(ctx context.Context, subCommand, devicePath string) (map[string]interface{}, error) {
+	output, err := runCommand(ctx, "nvme", subCommand, devicePath, "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse nvme %s output: %v", subCommand, err)
+	}
+
+	return result, nil
+}
+
+// verifyWipe reads back the sampled blocks and sets the verification result of the wipe.
+func verifyWipe(
+	devicePath string, method asdbv1.AerospikeVolumeMethod, before map[int64][]byte, result *asdbv1.VolumeWipeResult,
+) error {
+	offsets := make([]int64, 0, len(before))
+	for offset := range before {
+		offsets = append(offsets, offset)
+	}
+
+	after, err := readBlocks(devicePath, offsets)
+	if err != nil {
+		return err
+	}
+
+	var (
+		failed []int64
+		check  string
+	)
+
+	for _, offset := range offsets {
+		block := after[offset]
+		zeroed := isZeroed(block)
+
+		if method == asdbv1.AerospikeVolumeMethodDD || method == asdbv1.AerospikeVolumeMethodMultiPassOverwrite {
+			// Methods ending with a zero pass must leave every sampled block zeroed.
+			check = "not zeroed"
+
+			if !zeroed {
+				failed = append(failed, offset)
+			}
+		} else {
+			// Discard, sanitize and crypto erase may return zeroes, ones or random data,
+			// so only check that sampled blocks with data do not hold it anymore.
+			check = "unchanged"
+
+			if !zeroed && bytes.Equal(block, before[offset]) {
+				failed = append(failed, offset)
+			}
+		}
+	}
+
+	if len(failed) != 0 {
+		sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+		result.Verification = asdbv1.WipeVerificationFailed
+		result.Message = fmt.Sprintf("%d/%d sampled blocks %s, first at offset %d", len(failed), len(after), check,
+			failed[0])
+	} else {
+		result.Verification = asdbv1.WipeVerificationPassed
+		result.Message = fmt.Sprintf("%d sampled blocks verified", len(after))
+	}
+
+	return nil
+}
+
+// sampleBlockOffsets returns the offsets of the blocks sampled for the wipe verification.
+// The first and the last blocks are always sampled, these hold headers and are most likely to have data.
+func sampleBlockOffsets(size int64) []int64 {
+	blocks := size / verifyBlockSize
+	if blocks == 0 {
+		return nil
+	}
+
+	offsets := sets.New[int64](0, (blocks-1)*verifyBlockSize)
+	random := make([]byte, 8)
+
+	for int64(offsets.Len()) < min(verifySampleBlocks, blocks) {
+		if _, err := rand.Read(random); err != nil {
+			break
+		}
+
+		offsets.Insert(int64(binary.LittleEndian.Uint64(random)%uint64(blocks)) * verifyBlockSize) //nolint:gosec // < size
+	}
+
+	return sets.List(offsets)
+}
+
+func readBlocks(devicePath string, offsets []int64) (map[int64][]byte, error) {
+	device, err := os.Open(devicePath)
+	if err != nil {
+		return nil, err
+	}
+	defer device.Close()
+
+	blocks := make(map[int64][]byte, len(offsets))
+
+	for _, offset := range offsets {
+		block := make([]byte, verifyBlockSize)
+
+		n, err := device.ReadAt(block, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		blocks[offset] = block[:n]
+	}
+
+	return blocks, nil
+}
+
+func getDeviceSize(devicePath string) (int64, error) {
+	device, err := os.Open(devicePath)
+	if err != nil {
+		return 0, err
+	}
+	defer device.Close()
+
+	return device.Seek(0, io.SeekEnd)
+}
+
+// deleteFiles deletes the regular files in the directory tree.
+func deleteFiles(dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.Type().IsRegular() {
+			return os.Remove(path)
+		}
+
+		return nil
+	})
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %v: %s", name, strings.Join(args, " "), err, stderr.String())
+	}
+
+	return output, nil
+}
+
+// runParallel runs the tasks with at most threads tasks at a time and returns the first error.
+func runParallel(threads int, tasks []func() error) error {
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		firstErr error
+	)
+
+	semaphore := make(chan struct{}, max(threads, 1))
+
+	for _, task := range tasks {
+		wg.Add(1)
+
+		semaphore <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			if err := task(); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
+
+func isZeroed(block []byte) bool {
+	for _, b := range block {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getMajorVersion(image string) (int, error) {
+	version, err := asdbv1.GetImageVersion(image)
+	if err != nil {
+		return 0, err
+	}
+
+	major, _, _ := strings.Cut(version, ".")
+
+	return strconv.Atoi(major)
+}
+
+func getStringList(value interface{}) []string {
+	list, _ := value.([]interface{})
+	strs := make([]string, 0, len(list))
+
+	for _, item := range list {
+		if str, ok := item.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+
+	return strs
+}
+
+func getJSONInt(value interface{}) int {
+	if number, ok := value.(float64); ok {
+		return int(number)
+	}
+
+	return 0
+}
@@ -0,0 +1,402 @@
+package podinit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+type fakePublisher struct {
+	data []map[string]*string
+}
+
+func (p *fakePublisher) PublishStatus(_ context.Context, data map[string]*string) error {
+	p.data = append(p.data, data)
+	return nil
+}
+
+func TestIsWipeNeeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		prevImage string
+		image     string
+		expected  bool
+	}{
+		{
+			name:     "new pod",
+			image:    "aerospike/aerospike-server-enterprise:7.1.0.0",
+			expected: false,
+		},
+		{
+			name:      "upgrade across the storage format change",
+			prevImage: "aerospike/aerospike-server-enterprise:5.7.0.8",
+			image:     "aerospike/aerospike-server-enterprise:6.0.0.1",
+			expected:  true,
+		},
+		{
+			name:      "downgrade across the storage format change",
+			prevImage: "aerospike/aerospike-server-enterprise:6.1.0.1",
+			image:     "aerospike/aerospike-server-enterprise:5.7.0.8",
+			expected:  true,
+		},
+		{
+			name:      "upgrade with the same storage format",
+			prevImage: "aerospike/aerospike-server-enterprise:6.4.0.0",
+			image:     "aerospike/aerospike-server-enterprise:7.1.0.0",
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wipeNeeded, err := IsWipeNeeded(tt.prevImage, tt.image)
+			if err != nil {
+				t.Fatalf("IsWipeNeeded() error = %v", err)
+			}
+
+			if wipeNeeded != tt.expected {
+				t.Errorf("IsWipeNeeded() = %v, expected %v", wipeNeeded, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsVolumeInitialized(t *testing.T) {
+	tests := []struct {
+		name               string
+		initializedVolumes []string
+		pvcUID             string
+		expected           bool
+	}{
+		{
+			name:               "same PVC",
+			initializedVolumes: []string{"ns@uid-1"},
+			pvcUID:             "uid-1",
+			expected:           true,
+		},
+		{
+			name:               "recreated PVC",
+			initializedVolumes: []string{"ns@uid-1"},
+			pvcUID:             "uid-2",
+			expected:           false,
+		},
+		{
+			name:               "entry without PVC UID",
+			initializedVolumes: []string{"ns"},
+			pvcUID:             "uid-1",
+			expected:           true,
+		},
+		{
+			name:               "other volume",
+			initializedVolumes: []string{"workdir@uid-1"},
+			pvcUID:             "uid-1",
+			expected:           false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if initialized := IsVolumeInitialized(tt.initializedVolumes, "ns", tt.pvcUID); initialized != tt.expected {
+				t.Errorf("IsVolumeInitialized() = %v, expected %v", initialized, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetRackVolumes(t *testing.T) {
+	clusterVolumes := []asdbv1.VolumeSpec{{Name: "workdir"}}
+	aeroCluster := &asdbv1.AerospikeCluster{
+		Spec: asdbv1.AerospikeClusterSpec{
+			Storage: asdbv1.AerospikeStorageSpec{Volumes: clusterVolumes},
+			RackConfig: asdbv1.RackConfig{
+				Racks: []asdbv1.Rack{
+					{
+						ID: 1,
+						AerospikeConfig: asdbv1.AerospikeConfigSpec{
+							Value: map[string]interface{}{
+								asdbv1.ConfKeyNamespace: []interface{}{
+									map[string]interface{}{
+										asdbv1.ConfKeyStorageEngine: map[string]interface{}{
+											"type":    "device",
+											"devices": []interface{}{"/dev/nvme0n1 /dev/sdf", "/dev/nvme1n1"},
+										},
+									},
+									map[string]interface{}{
+										asdbv1.ConfKeyStorageEngine: map[string]interface{}{
+											"type":  "device",
+											"files": []interface{}{"/opt/aerospike/data/test.dat"},
+										},
+									},
+									map[string]interface{}{
+										asdbv1.ConfKeyStorageEngine: map[string]interface{}{"type": "memory"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rackVolumes, err := GetRackVolumes(aeroCluster, 1)
+	if err != nil {
+		t.Fatalf("GetRackVolumes() error = %v", err)
+	}
+
+	expectedDevicePaths := sets.New("/dev/nvme0n1", "/dev/sdf", "/dev/nvme1n1")
+	if !rackVolumes.DevicePaths.Equal(expectedDevicePaths) {
+		t.Errorf("GetRackVolumes() device paths = %v, expected %v", rackVolumes.DevicePaths, expectedDevicePaths)
+	}
+
+	expectedFilePaths := []string{"/opt/aerospike/data/test.dat"}
+	if !reflect.DeepEqual(rackVolumes.FilePaths, expectedFilePaths) {
+		t.Errorf("GetRackVolumes() file paths = %v, expected %v", rackVolumes.FilePaths, expectedFilePaths)
+	}
+
+	if !reflect.DeepEqual(rackVolumes.Volumes, clusterVolumes) {
+		t.Errorf("GetRackVolumes() volumes = %v, expected %v", rackVolumes.Volumes, clusterVolumes)
+	}
+
+	if rackVolumes.Threads != asdbv1.AerospikeVolumeSingleCleanupThread {
+		t.Errorf("GetRackVolumes() threads = %d, expected %d", rackVolumes.Threads,
+			asdbv1.AerospikeVolumeSingleCleanupThread)
+	}
+
+	if _, err := GetRackVolumes(aeroCluster, 2); err == nil {
+		t.Errorf("GetRackVolumes() expected error for a missing rack")
+	}
+}
+
+func TestRunBlockMethod(t *testing.T) {
+	const deviceSize = headerCleanupSize + writeBlockSize + 100
+
+	tests := []struct {
+		name           string
+		method         asdbv1.AerospikeVolumeMethod
+		expectedZeroed int
+	}{
+		{
+			name:           "dd",
+			method:         asdbv1.AerospikeVolumeMethodDD,
+			expectedZeroed: deviceSize,
+		},
+		{
+			name:           "header cleanup",
+			method:         asdbv1.AerospikeVolumeMethodHeaderCleanup,
+			expectedZeroed: headerCleanupSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devicePath := filepath.Join(t.TempDir(), "device")
+			if err := os.WriteFile(devicePath, bytes.Repeat([]byte{0xff}, deviceSize), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			manager := &VolumeManager{Log: logr.Discard(), Reporter: NewProgressReporter(&fakePublisher{}, logr.Discard())}
+			manager.Reporter.Start("ns", asdbv1.VolumeOperationInit, tt.method, deviceSize)
+
+			if err := manager.runBlockMethod(context.TODO(), "ns", tt.method, devicePath, deviceSize); err != nil {
+				t.Fatalf("runBlockMethod() error = %v", err)
+			}
+
+			content, err := os.ReadFile(devicePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(content) != deviceSize {
+				t.Fatalf("runBlockMethod() changed the device size to %d", len(content))
+			}
+
+			if !isZeroed(content[:tt.expectedZeroed]) {
+				t.Errorf("runBlockMethod() did not zero the first %d bytes", tt.expectedZeroed)
+			}
+
+			if !bytes.Equal(content[tt.expectedZeroed:], bytes.Repeat([]byte{0xff}, deviceSize-tt.expectedZeroed)) {
+				t.Errorf("runBlockMethod() changed the bytes after the first %d bytes", tt.expectedZeroed)
+			}
+		})
+	}
+}
+
+func TestVerifyWipe(t *testing.T) {
+	const deviceSize = 64 * verifyBlockSize
+
+	tests := []struct {
+		name     string
+		method   asdbv1.AerospikeVolumeMethod
+		content  []byte
+		expected asdbv1.WipeVerificationResult
+	}{
+		{
+			name:     "zeroed",
+			method:   asdbv1.AerospikeVolumeMethodDD,
+			content:  make([]byte, deviceSize),
+			expected: asdbv1.WipeVerificationPassed,
+		},
+		{
+			name:     "not zeroed",
+			method:   asdbv1.AerospikeVolumeMethodDD,
+			content:  bytes.Repeat([]byte{0xff}, deviceSize),
+			expected: asdbv1.WipeVerificationFailed,
+		},
+		{
+			name:     "discarded to ones",
+			method:   asdbv1.AerospikeVolumeMethodBlkdiscard,
+			content:  bytes.Repeat([]byte{0xff}, deviceSize),
+			expected: asdbv1.WipeVerificationPassed,
+		},
+		{
+			name:     "unchanged",
+			method:   asdbv1.AerospikeVolumeMethodBlkdiscard,
+			content:  bytes.Repeat([]byte{0xaa}, deviceSize),
+			expected: asdbv1.WipeVerificationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devicePath := filepath.Join(t.TempDir(), "device")
+			if err := os.WriteFile(devicePath, bytes.Repeat([]byte{0xaa}, deviceSize), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			before, err := readBlocks(devicePath, sampleBlockOffsets(deviceSize))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(before) != verifySampleBlocks {
+				t.Fatalf("sampleBlockOffsets() sampled %d blocks, expected %d", len(before), verifySampleBlocks)
+			}
+
+			if err := os.WriteFile(devicePath, tt.content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			result := &asdbv1.VolumeWipeResult{}
+			if err := verifyWipe(devicePath, tt.method, before, result); err != nil {
+				t.Fatalf("verifyWipe() error = %v", err)
+			}
+
+			if result.Verification != tt.expected {
+				t.Errorf("verifyWipe() = %s (%s), expected %s", result.Verification, result.Message, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetNvmeNamespaceCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected int
+	}{
+		{
+			name:     "single namespace",
+			output:   `{"nsid_list":[{"nsid":1}]}`,
+			expected: 1,
+		},
+		{
+			name:     "multiple namespaces",
+			output:   `{"nsid_list":[{"nsid":1},{"nsid":2}]}`,
+			expected: 2,
+		},
+		{
+			name:     "unknown output",
+			output:   `{}`,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespaceList := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(tt.output), &namespaceList); err != nil {
+				t.Fatal(err)
+			}
+
+			if count := getNvmeNamespaceCount(namespaceList); count != tt.expected {
+				t.Errorf("getNvmeNamespaceCount() = %d, expected %d", count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrepareVolumesFileSystem(t *testing.T) {
+	mountPoint := t.TempDir()
+	volumeDir := filepath.Join(mountPoint, "ns")
+
+	for _, file := range []string{"test.dat", "other.dat"} {
+		if err := os.MkdirAll(volumeDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(volumeDir, file), []byte("data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rackVolumes := &RackVolumes{
+		Volumes: []asdbv1.VolumeSpec{
+			{
+				Name: "ns",
+				Source: asdbv1.VolumeSource{
+					PersistentVolume: &asdbv1.PersistentVolumeSpec{VolumeMode: corev1.PersistentVolumeFilesystem},
+				},
+				Aerospike: &asdbv1.AerospikeServerVolumeAttachment{Path: "/opt/aerospike/data"},
+				AerospikePersistentVolumePolicySpec: asdbv1.AerospikePersistentVolumePolicySpec{
+					InitMethod:       asdbv1.AerospikeVolumeMethodNone,
+					WipeMethod:       asdbv1.AerospikeVolumeMethodDeleteFiles,
+					WipeVerification: true,
+				},
+			},
+		},
+		FilePaths:   []string{"/opt/aerospike/data/test.dat"},
+		DevicePaths: sets.New[string](),
+		Threads:     1,
+	}
+
+	manager := &VolumeManager{
+		Log:                  logr.Discard(),
+		Reporter:             NewProgressReporter(&fakePublisher{}, logr.Discard()),
+		FileSystemMountPoint: mountPoint,
+	}
+
+	state, err := manager.PrepareVolumes(context.TODO(), rackVolumes, map[string]string{"ns": "uid-1"},
+		"aerospike/aerospike-server-enterprise:5.7.0.8", "aerospike/aerospike-server-enterprise:7.1.0.0",
+		&VolumeState{})
+	if err != nil {
+		t.Fatalf("PrepareVolumes() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(state.InitializedVolumes, []string{"ns@uid-1"}) {
+		t.Errorf("PrepareVolumes() initialized volumes = %v, expected [ns@uid-1]", state.InitializedVolumes)
+	}
+
+	if result := state.WipeResults["ns"]; result.Verification != asdbv1.WipeVerificationPassed {
+		t.Errorf("PrepareVolumes() wipe verification = %s, expected %s", result.Verification,
+			asdbv1.WipeVerificationPassed)
+	}
+
+	if _, err := os.Stat(filepath.Join(volumeDir, "test.dat")); !os.IsNotExist(err) {
+		t.Errorf("PrepareVolumes() did not delete the namespace file")
+	}
+
+	if _, err := os.Stat(filepath.Join(volumeDir, "other.dat")); err != nil {
+		t.Errorf("PrepareVolumes() deleted a file not used by the namespace: %v", err)
+	}
+}
@@ -0,0 +1,357 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SizeExpression is a parsed arithmetic expression computing a size, like "memoryLimit * 60%".
+// It supports the +, -, *, / operators, parentheses, numbers, quantities like 4Gi, percentages, variables and the
+// min and max functions.
+type SizeExpression struct {
+	root      exprNode
+	variables []string
+}
+
+// ParseSizeExpression parses the size expression.
+func ParseSizeExpression(text string) (*SizeExpression, error) {
+	tokens, err := tokenizeExpression(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", text, err)
+	}
+
+	p := &exprParser{tokens: tokens}
+
+	root, err := p.parseSum()
+	if err == nil && p.pos != len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", text, err)
+	}
+
+	return &SizeExpression{root: root, variables: p.variables}, nil
+}
+
+// Variables returns the names of the variables used in the expression.
+func (e *SizeExpression) Variables() []string {
+	return e.variables
+}
+
+// Evaluate computes the expression with the given variable values, numbers or quantities like 4Gi.
+// The result is rounded down to an integer.
+func (e *SizeExpression) Evaluate(values map[string]string) (int64, error) {
+	result, err := e.root.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	if result < 0 || result > math.MaxInt64 {
+		return 0, fmt.Errorf("expression result %v out of range", result)
+	}
+
+	return int64(math.Floor(result)), nil
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	text string
+	kind tokenKind
+}
+
+func tokenizeExpression(text string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(text)
+
+	for pos := 0; pos < len(runes); {
+		char := runes[pos]
+
+		switch {
+		case unicode.IsSpace(char):
+			pos++
+		case unicode.IsDigit(char) || char == '.':
+			// A number with an optional quantity suffix like Gi or a percent sign.
+			start := pos
+			for pos < len(runes) && (unicode.IsDigit(runes[pos]) || runes[pos] == '.') {
+				pos++
+			}
+
+			for pos < len(runes) && (unicode.IsLetter(runes[pos]) || runes[pos] == '%') {
+				pos++
+			}
+
+			tokens = append(tokens, token{text: string(runes[start:pos]), kind: tokenNumber})
+		case unicode.IsLetter(char) || char == '_':
+			start := pos
+			for pos < len(runes) && (unicode.IsLetter(runes[pos]) || unicode.IsDigit(runes[pos]) || runes[pos] == '_') {
+				pos++
+			}
+
+			tokens = append(tokens, token{text: string(runes[start:pos]), kind: tokenIdent})
+		case strings.ContainsRune("+-*/(),", char):
+			tokens = append(tokens, token{text: string(char), kind: tokenOperator})
+			pos++
+		default:
+			return nil, fmt.Errorf("unexpected character %q", char)
+		}
+	}
+
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens    []token
+	variables []string
+	pos       int
+}
+
+func (p *exprParser) peek(text string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOperator && p.tokens[p.pos].text == text
+}
+
+func (p *exprParser) parseSum() (exprNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek("+") || p.peek("-") {
+		operator := p.tokens[p.pos].text
+		p.pos++
+
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseProduct() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek("*") || p.peek("/") {
+		operator := p.tokens[p.pos].text
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.peek("-") {
+		p.pos++
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &binaryNode{operator: "-", left: numberNode(0), right: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.pos == len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end")
+	}
+
+	current := p.tokens[p.pos]
+	p.pos++
+
+	switch current.kind {
+	case tokenNumber:
+		value, err := parseSize(current.text)
+		if err != nil {
+			return nil, err
+		}
+
+		return numberNode(value), nil
+	case tokenIdent:
+		if !p.peek("(") {
+			p.variables = append(p.variables, current.text)
+			return variableNode(current.text), nil
+		}
+
+		if current.text != "min" && current.text != "max" {
+			return nil, fmt.Errorf("unknown function %q", current.text)
+		}
+
+		p.pos++
+
+		function := &functionNode{name: current.text}
+
+		for {
+			arg, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+
+			function.args = append(function.args, arg)
+
+			if !p.peek(",") {
+				break
+			}
+
+			p.pos++
+		}
+
+		if !p.peek(")") {
+			return nil, fmt.Errorf("missing ')'")
+		}
+
+		p.pos++
+
+		return function, nil
+	case tokenOperator:
+		if current.text != "(" {
+			return nil, fmt.Errorf("unexpected %q", current.text)
+		}
+
+		node, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.peek(")") {
+			return nil, fmt.Errorf("missing ')'")
+		}
+
+		p.pos++
+
+		return node, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q", current.text)
+}
+
+// parseSize parses a number, a quantity like 4Gi or a percentage like 60%.
+func parseSize(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+
+	if percent, found := strings.CutSuffix(text, "%"); found {
+		value, err := strconv.ParseFloat(percent, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid percentage %q", text)
+		}
+
+		return value / 100, nil
+	}
+
+	quantity, err := resource.ParseQuantity(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", text)
+	}
+
+	return quantity.AsApproximateFloat64(), nil
+}
+
+type exprNode interface {
+	evaluate(values map[string]string) (float64, error)
+}
+
+type numberNode float64
+
+func (n numberNode) evaluate(map[string]string) (float64, error) {
+	return float64(n), nil
+}
+
+type variableNode string
+
+func (n variableNode) evaluate(values map[string]string) (float64, error) {
+	value, ok := values[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("variable %s not defined", string(n))
+	}
+
+	result, err := parseSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("variable %s: %v", string(n), err)
+	}
+
+	return result, nil
+}
+
+type binaryNode struct {
+	left     exprNode
+	right    exprNode
+	operator string
+}
+
+func (n *binaryNode) evaluate(values map[string]string) (float64, error) {
+	left, err := n.left.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := n.right.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.operator {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+
+		return left / right, nil
+	}
+}
+
+type functionNode struct {
+	name string
+	args []exprNode
+}
+
+func (n *functionNode) evaluate(values map[string]string) (float64, error) {
+	var result float64
+
+	for idx, arg := range n.args {
+		value, err := arg.evaluate(values)
+		if err != nil {
+			return 0, err
+		}
+
+		if idx == 0 || (n.name == "min" && value < result) || (n.name == "max" && value > result) {
+			result = value
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSizeExpression(t *testing.T) {
+	values := map[string]string{
+		"memoryLimit": "8Gi",
+		"cpuLimit":    "4",
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		variables  []string
+		expected   int64
+		wantErr    bool
+	}{
+		{
+			name:       "percentage of variable",
+			expression: "memoryLimit * 60%",
+			variables:  []string{"memoryLimit"},
+			expected:   5153960755,
+		},
+		{
+			name:       "quantity",
+			expression: "memoryLimit - 1Gi",
+			variables:  []string{"memoryLimit"},
+			expected:   7 * 1024 * 1024 * 1024,
+		},
+		{
+			name:       "precedence and parentheses",
+			expression: "(cpuLimit + 2) * 3 - 10 / 4",
+			variables:  []string{"cpuLimit"},
+			expected:   15,
+		},
+		{
+			name:       "unary minus",
+			expression: "cpuLimit - -1",
+			variables:  []string{"cpuLimit"},
+			expected:   5,
+		},
+		{
+			name:       "min and max",
+			expression: "max(min(memoryLimit / 2, 2Gi), 1Gi)",
+			variables:  []string{"memoryLimit"},
+			expected:   2 * 1024 * 1024 * 1024,
+		},
+		{
+			name:       "undefined variable",
+			expression: "diskSize * 50%",
+			variables:  []string{"diskSize"},
+			wantErr:    true,
+		},
+		{
+			name:       "negative result",
+			expression: "cpuLimit - 5",
+			variables:  []string{"cpuLimit"},
+			wantErr:    true,
+		},
+		{
+			name:       "division by zero",
+			expression: "cpuLimit / (cpuLimit - 4)",
+			variables:  []string{"cpuLimit", "cpuLimit"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := ParseSizeExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseSizeExpression() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(expression.Variables(), tt.variables) {
+				t.Errorf("Variables() = %v, expected %v", expression.Variables(), tt.variables)
+			}
+
+			result, err := expression.Evaluate(values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Evaluate() = %d, expected %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSizeExpressionInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"memoryLimit *",
+		"(memoryLimit",
+		"avg(1, 2)",
+		"4Xi",
+		"memoryLimit % 2",
+		"1 2",
+	}
+
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := ParseSizeExpression(expression); err == nil {
+				t.Errorf("ParseSizeExpression(%q) expected error", expression)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseNamespaceObjects returns the master objects and the effective replication factor of the namespace
+// from the namespace/<namespace> info response of a node e.g. "objects=20;master_objects=10;...".
+func ParseNamespaceObjects(res string) (masterObjects, replicationFactor int64, err error) {
+	stats := parseInfo(res, ";", "=")
+
+	if masterObjects, err = parseInt(stats, "master_objects"); err != nil {
+		return 0, 0, err
+	}
+
+	replicationFactor = 1
+
+	if _, ok := stats["effective_replication_factor"]; ok {
+		if replicationFactor, err = parseInt(stats, "effective_replication_factor"); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return masterObjects, replicationFactor, nil
+}
+
+// ParseSetObjects returns the objects, including replica objects, per set from the sets/<namespace>
+// info response of a node e.g. "ns=test:set=s1:objects=10:...;ns=test:set=s2:objects=5:...;".
+func ParseSetObjects(res string) (map[string]int64, error) {
+	setObjects := make(map[string]int64)
+
+	for _, setInfo := range strings.Split(strings.TrimSpace(res), ";") {
+		if setInfo == "" {
+			continue
+		}
+
+		stats := parseInfo(setInfo, ":", "=")
+
+		setName, ok := stats["set"]
+		if !ok {
+			return nil, fmt.Errorf("set name not found in set info %s", setInfo)
+		}
+
+		objects, err := parseInt(stats, "objects")
+		if err != nil {
+			return nil, err
+		}
+
+		setObjects[setName] += objects
+	}
+
+	return setObjects, nil
+}
+
+func parseInfo(res, del, sep string) map[string]string {
+	stats := make(map[string]string)
+
+	for _, kv := range strings.Split(strings.TrimSpace(res), del) {
+		key, value, found := strings.Cut(kv, sep)
+		if found {
+			stats[key] = value
+		}
+	}
+
+	return stats
+}
+
+func parseInt(stats map[string]string, key string) (int64, error) {
+	value, ok := stats[key]
+	if !ok {
+		return 0, fmt.Errorf("%s not found in info response", key)
+	}
+
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s in info response: %v", key, value, err)
+	}
+
+	return intValue, nil
+}
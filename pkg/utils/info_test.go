@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNamespaceObjects(t *testing.T) {
+	tests := []struct {
+		name              string
+		res               string
+		masterObjects     int64
+		replicationFactor int64
+		wantErr           bool
+	}{
+		{
+			name:              "with effective replication factor",
+			res:               "objects=20;master_objects=10;prole_objects=10;effective_replication_factor=2",
+			masterObjects:     10,
+			replicationFactor: 2,
+		},
+		{
+			name:              "without effective replication factor",
+			res:               "objects=10;master_objects=10\n",
+			masterObjects:     10,
+			replicationFactor: 1,
+		},
+		{
+			name:    "master objects missing",
+			res:     "objects=10",
+			wantErr: true,
+		},
+		{
+			name:    "invalid master objects",
+			res:     "master_objects=ten",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masterObjects, replicationFactor, err := ParseNamespaceObjects(tt.res)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseNamespaceObjects() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if masterObjects != tt.masterObjects || replicationFactor != tt.replicationFactor {
+				t.Errorf("ParseNamespaceObjects() = %d, %d, expected %d, %d", masterObjects, replicationFactor,
+					tt.masterObjects, tt.replicationFactor)
+			}
+		})
+	}
+}
+
+func TestParseSetObjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      string
+		expected map[string]int64
+		wantErr  bool
+	}{
+		{
+			name:     "no sets",
+			res:      "",
+			expected: map[string]int64{},
+		},
+		{
+			name: "multiple sets",
+			res: "ns=test:set=s1:objects=10:tombstones=0:memory_data_bytes=0;" +
+				"ns=test:set=s2:objects=5:tombstones=0:memory_data_bytes=0;",
+			expected: map[string]int64{"s1": 10, "s2": 5},
+		},
+		{
+			name:    "objects missing",
+			res:     "ns=test:set=s1:tombstones=0;",
+			wantErr: true,
+		},
+		{
+			name:    "set name missing",
+			res:     "ns=test:objects=10;",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setObjects, err := ParseSetObjects(tt.res)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSetObjects() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(setObjects, tt.expected) {
+				t.Errorf("ParseSetObjects() = %v, expected %v", setObjects, tt.expected)
+			}
+		})
+	}
+}
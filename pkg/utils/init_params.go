@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+const (
+	// InitBinaryName is the name of the init binary of the operator, shipped in the init image in /workdir/bin and
+	// copied by the init container in the config directory. It differs from the akoinit binary of the upstream init
+	// images, which has a different command line.
+	InitBinaryName = "aerospike-pod-init"
+
+	// InitParamsFileName is the rack ConfigMap key with the JSON of the InitParams.
+	InitParamsFileName = "initParams.json"
+
+	// PodNameVariable is expanded with the pod name in InitParams.TLSRouteHostname.
+	PodNameVariable = "${MY_POD_NAME}"
+)
+
+// InitParams has the rack inputs of the pod init steps. The operator renders the ConfigMap scripts with it and adds
+// its JSON to the rack ConfigMap for the init binary.
+type InitParams struct {
+	WorkDir          string                           `json:"workDir,omitempty"`
+	PodServiceType   corev1.ServiceType               `json:"podServiceType,omitempty"`
+	TLSRouteHostname string                           `json:"tlsRouteHostname,omitempty"`
+	TLSRoutePort     int32                            `json:"tlsRoutePort,omitempty"`
+	NetworkPolicy    asdbv1.AerospikeNetworkPolicy    `json:"networkPolicy"`
+	ConfigVariables  []asdbv1.AerospikeConfigVariable `json:"configVariables,omitempty"`
+	FabricPort       int32                            `json:"fabricPort,omitempty"`
+	PodPort          int32                            `json:"podPort,omitempty"`
+	PodTLSPort       int32                            `json:"podTLSPort,omitempty"`
+	HeartBeatPort    int32                            `json:"heartBeatPort,omitempty"`
+	HeartBeatTLSPort int32                            `json:"heartBeatTLSPort,omitempty"`
+	FabricTLSPort    int32                            `json:"fabricTLSPort,omitempty"`
+	MultiPodPerHost  bool                             `json:"multiPodPerHost,omitempty"`
+	HostNetwork      bool                             `json:"hostNetwork,omitempty"`
+}
+
+// ParseInitParams parses the InitParams from the rack ConfigMap data.
+func ParseInitParams(data map[string]string) (*InitParams, error) {
+	paramsJSON, ok := data[InitParamsFileName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the ConfigMap", InitParamsFileName)
+	}
+
+	params := &InitParams{}
+	if err := json.Unmarshal([]byte(paramsJSON), params); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", InitParamsFileName, err)
+	}
+
+	return params, nil
+}
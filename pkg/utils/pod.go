@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -236,6 +238,33 @@ func GetRackIDAndRevisionFromPodName(clusterName, podName string) (rackID int, r
 	return rackID, rackRevision, nil
 }
 
+// ParsePodServiceAddresses parses the pod service addresses published in the rack ConfigMap, formatted as one
+// "<pod-name> <address>" line per pod.
+func ParsePodServiceAddresses(data string) map[string]string {
+	addresses := make(map[string]string)
+
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			addresses[fields[0]] = fields[1]
+		}
+	}
+
+	return addresses
+}
+
+// FormatPodServiceAddresses formats the pod service addresses to be published in the rack ConfigMap, sorted by pod
+// name so that the ConfigMap is updated only on an address change.
+func FormatPodServiceAddresses(addresses map[string]string) string {
+	var buf strings.Builder
+
+	for _, podName := range slices.Sorted(maps.Keys(addresses)) {
+		fmt.Fprintf(&buf, "%s %s\n", podName, addresses[podName])
+	}
+
+	return buf.String()
+}
+
 // Exec executes a non-interactive command on a pod.
 func Exec(podNamespacedName types.NamespacedName, container string, cmd []string, kubeClient *kubernetes.Clientset,
 	kubeConfig *rest.Config) (stdoutStr, stderrStr string, err error) {
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -248,3 +249,59 @@ func TestCheckPodFailed(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePodServiceAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected map[string]string
+	}{
+		{
+			name:     "empty data",
+			data:     "",
+			expected: map[string]string{},
+		},
+		{
+			name: "addresses of multiple pods",
+			data: "aerocluster-1-0 203.0.113.1\naerocluster-1-1 lb.example.com\n",
+			expected: map[string]string{
+				"aerocluster-1-0": "203.0.113.1",
+				"aerocluster-1-1": "lb.example.com",
+			},
+		},
+		{
+			name: "malformed lines are skipped",
+			data: "aerocluster-1-0\n\naerocluster-1-1 203.0.113.2 extra\naerocluster-1-2 203.0.113.3",
+			expected: map[string]string{
+				"aerocluster-1-2": "203.0.113.3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParsePodServiceAddresses(tt.data)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParsePodServiceAddresses() = %v, expected %v", result, tt.expected)
+			}
+
+			// Formatting the parsed addresses must round trip.
+			if reparsed := ParsePodServiceAddresses(FormatPodServiceAddresses(result)); !reflect.DeepEqual(
+				reparsed, tt.expected) {
+				t.Errorf("ParsePodServiceAddresses(FormatPodServiceAddresses()) = %v, expected %v", reparsed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatPodServiceAddresses(t *testing.T) {
+	addresses := map[string]string{
+		"aerocluster-1-1": "203.0.113.2",
+		"aerocluster-1-0": "203.0.113.1",
+	}
+
+	expected := "aerocluster-1-0 203.0.113.1\naerocluster-1-1 203.0.113.2\n"
+	if result := FormatPodServiceAddresses(addresses); result != expected {
+		t.Errorf("FormatPodServiceAddresses() = %q, expected %q", result, expected)
+	}
+}
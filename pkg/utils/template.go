@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// PodTemplateVars are the variables available to the per-pod templates, e.g. the pod service annotations.
+type PodTemplateVars struct {
+	PodName     string
+	ClusterName string
+	Namespace   string
+	RackID      int
+}
+
+// ExpandPodTemplate expands the given text template with the pod variables.
+// Text without any template action is returned as is.
+func ExpandPodTemplate(text string, vars *PodTemplateVars) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %v", text, err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to expand template %q: %v", text, err)
+	}
+
+	return buf.String(), nil
+}
+
+// ExpandPodTemplateMap expands the values of the given map with the pod variables.
+func ExpandPodTemplateMap(values map[string]string, vars *PodTemplateVars) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	expanded := make(map[string]string, len(values))
+
+	for key, value := range values {
+		expandedValue, err := ExpandPodTemplate(value, vars)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %s: %v", key, err)
+		}
+
+		expanded[key] = expandedValue
+	}
+
+	return expanded, nil
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestExpandPodTemplate(t *testing.T) {
+	vars := &PodTemplateVars{
+		PodName:     "aerocluster-1-0",
+		ClusterName: "aerocluster",
+		Namespace:   "aerospike",
+		RackID:      1,
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "plain text is returned as is",
+			text:     "aerospike.example.com",
+			expected: "aerospike.example.com",
+		},
+		{
+			name:     "pod name",
+			text:     "{{.PodName}}.example.com",
+			expected: "aerocluster-1-0.example.com",
+		},
+		{
+			name:     "all variables",
+			text:     "{{.ClusterName}}-rack{{.RackID}}.{{.Namespace}}",
+			expected: "aerocluster-rack1.aerospike",
+		},
+		{
+			name:      "unknown variable",
+			text:      "{{.NodeName}}.example.com",
+			expectErr: true,
+		},
+		{
+			name:      "invalid template",
+			text:      "{{.PodName.example.com",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandPodTemplate(tt.text, vars)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("ExpandPodTemplate() expected error, got %q", result)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExpandPodTemplate() unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("ExpandPodTemplate() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
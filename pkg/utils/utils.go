@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"regexp"
@@ -13,6 +14,7 @@ import (
 	ls "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
@@ -204,6 +206,26 @@ func GetHash(str string) (string, error) {
 	return hex.EncodeToString(res), nil
 }
 
+// hashedNameSuffixLen is the number of hex chars of the sha256 hash of the key in the names from GetHashedName.
+const hashedNameSuffixLen = 16
+
+// GetHashedName returns a DNS-1123 subdomain name stable for the key, made of the prefix and a hash of the key.
+// The prefix is truncated to fit, and its trailing chars other than lowercase letters and digits are removed.
+func GetHashedName(prefix, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	suffix := "-" + hex.EncodeToString(sum[:])[:hashedNameSuffixLen]
+
+	if maxLen := validation.DNS1123SubdomainMaxLength - len(suffix); len(prefix) > maxLen {
+		prefix = prefix[:maxLen]
+	}
+
+	prefix = strings.TrimRightFunc(prefix, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+
+	return prefix + suffix
+}
+
 // GetRackIDAndRevisionFromSTSName gets rackID and rackRevision from the statefulset name.
 // It assumes statefulset name is of format <cluster-name>-<rack-id> or <cluster-name>-<rack-id>-<rack-revision>
 func GetRackIDAndRevisionFromSTSName(clusterName, statefulSetName string) (rackID int, rackRevision string, err error) {
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestGetHashedName(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		key    string
+	}{
+		{
+			name:   "short prefix",
+			prefix: "backup",
+			key:    "routine/backup/1700000000000/source-ns1",
+		},
+		{
+			name:   "truncated prefix",
+			prefix: strings.Repeat("a", validation.DNS1123SubdomainMaxLength),
+			key:    "key",
+		},
+		{
+			name:   "truncated prefix ending with a dash",
+			prefix: strings.Repeat("a", validation.DNS1123SubdomainMaxLength-hashedNameSuffixLen-2) + "-b",
+			key:    "key",
+		},
+		{
+			name:   "truncated prefix ending with a dot",
+			prefix: strings.Repeat("a", validation.DNS1123SubdomainMaxLength-hashedNameSuffixLen-2) + ".b",
+			key:    "key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := GetHashedName(tt.prefix, tt.key)
+
+			if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+				t.Errorf("GetHashedName() = %s, not a DNS-1123 subdomain: %v", name, errs)
+			}
+
+			if name != GetHashedName(tt.prefix, tt.key) {
+				t.Errorf("GetHashedName() is not stable")
+			}
+
+			if name == GetHashedName(tt.prefix, tt.key+"-other") {
+				t.Errorf("GetHashedName() = %s for different keys", name)
+			}
+		})
+	}
+}
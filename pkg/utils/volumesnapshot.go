@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+const (
+	// VolumeSnapshotAPIGroup is the API group of the CSI snapshot CRDs.
+	VolumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+	// VolumeSnapshotKind is the kind of the CSI VolumeSnapshot CRD.
+	VolumeSnapshotKind = "VolumeSnapshot"
+)
+
+// VolumeSnapshotGVK is the GroupVersionKind of the CSI VolumeSnapshot CRD.
+// The CSI snapshot types are handled as unstructured objects to avoid depending on the external-snapshotter client.
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   VolumeSnapshotAPIGroup,
+	Version: "v1",
+	Kind:    VolumeSnapshotKind,
+}
+
+// NewVolumeSnapshot returns a VolumeSnapshot of the given PVC.
+func NewVolumeSnapshot(
+	namespace, name, snapshotClassName, pvcName string, labels map[string]string,
+) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetName(name)
+	snapshot.SetLabels(labels)
+
+	snapshot.Object["spec"] = map[string]interface{}{
+		"volumeSnapshotClassName": snapshotClassName,
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+
+	return snapshot
+}
+
+// NewVolumeSnapshotList returns an empty VolumeSnapshotList to be used with client.List.
+func NewVolumeSnapshotList() *unstructured.UnstructuredList {
+	snapshotList := &unstructured.UnstructuredList{}
+	snapshotList.SetGroupVersionKind(VolumeSnapshotGVK.GroupVersion().WithKind(VolumeSnapshotKind + "List"))
+
+	return snapshotList
+}
+
+// IsVolumeSnapshotReady returns true if the VolumeSnapshot can be used as a PVC data source.
+func IsVolumeSnapshotReady(snapshot *unstructured.Unstructured) bool {
+	if snapshot.GetDeletionTimestamp() != nil {
+		return false
+	}
+
+	ready, found, err := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+
+	return err == nil && found && ready
+}
+
+// GetVolumeSnapshotError returns the error message reported by the snapshot controller, if any.
+func GetVolumeSnapshotError(snapshot *unstructured.Unstructured) string {
+	msg, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return msg
+}
+
+// GetVolumeSnapshotRestoreSize returns the minimum size of a volume provisioned from the VolumeSnapshot.
+func GetVolumeSnapshotRestoreSize(snapshot *unstructured.Unstructured) string {
+	size, _, _ := unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+	return size
+}
+
+// GetVolumeSnapshotCreationTime returns the time at which the snapshot was cut by the storage system.
+// It falls back to the object creation time when the status does not report it yet.
+func GetVolumeSnapshotCreationTime(snapshot *unstructured.Unstructured) time.Time {
+	if cutTime, ok := getVolumeSnapshotCutTime(snapshot); ok {
+		return cutTime
+	}
+
+	return snapshot.GetCreationTimestamp().Time
+}
+
+// AreVolumeSnapshotsCut returns true if the expected count of snapshots are all cut by the storage system.
+func AreVolumeSnapshotsCut(snapshots []unstructured.Unstructured, expectedCount int32) bool {
+	if len(snapshots) < int(expectedCount) {
+		return false
+	}
+
+	for idx := range snapshots {
+		if _, ok := getVolumeSnapshotCutTime(&snapshots[idx]); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getVolumeSnapshotCutTime returns the time at which the snapshot was cut by the storage system,
+// and false if it is not cut yet.
+func getVolumeSnapshotCutTime(snapshot *unstructured.Unstructured) (time.Time, bool) {
+	creationTime, found, err := unstructured.NestedString(snapshot.Object, "status", "creationTime")
+	if err != nil || !found {
+		return time.Time{}, false
+	}
+
+	cutTime, err := time.Parse(time.RFC3339, creationTime)
+
+	return cutTime, err == nil
+}
+
+// GetLatestReadyVolumeSnapshot returns the most recent ready VolumeSnapshot which is not older than maxAge.
+// It returns nil if there is no such snapshot.
+func GetLatestReadyVolumeSnapshot(
+	snapshots []unstructured.Unstructured, maxAge time.Duration, now time.Time,
+) *unstructured.Unstructured {
+	var latest *unstructured.Unstructured
+
+	for idx := range snapshots {
+		snapshot := &snapshots[idx]
+
+		if !IsVolumeSnapshotReady(snapshot) {
+			continue
+		}
+
+		creationTime := GetVolumeSnapshotCreationTime(snapshot)
+		if now.Sub(creationTime) > maxAge {
+			continue
+		}
+
+		if latest == nil || creationTime.After(GetVolumeSnapshotCreationTime(latest)) {
+			latest = snapshot
+		}
+	}
+
+	return latest
+}
+
+// GetAerospikePersistentVolumeNames returns the names of the persistent volumes attached to the Aerospike server
+// container. These are the volumes snapshotted by default.
+func GetAerospikePersistentVolumeNames(storage *asdbv1.AerospikeStorageSpec) []string {
+	var volNames []string
+
+	for idx := range storage.Volumes {
+		if storage.Volumes[idx].Source.PersistentVolume != nil && storage.Volumes[idx].Aerospike != nil {
+			volNames = append(volNames, storage.Volumes[idx].Name)
+		}
+	}
+
+	return volNames
+}
+
+// GetVolumeSnapshotSetPhase returns the phase of a snapshot set with a message explaining it.
+// A set fails if any snapshot fails, or if its snapshots are not cut within the given window.
+// It completes once all its snapshots are ready to use.
+func GetVolumeSnapshotSetPhase(
+	snapshots []unstructured.Unstructured, expectedCount int32, startTime time.Time, window time.Duration,
+	now time.Time,
+) (phase v1beta1.VolumeSnapshotSetPhase, message string) {
+	windowElapsed := now.Sub(startTime) > window
+
+	if len(snapshots) < int(expectedCount) {
+		if windowElapsed {
+			return v1beta1.VolumeSnapshotSetFailed,
+				fmt.Sprintf("found %d of %d VolumeSnapshots", len(snapshots), expectedCount)
+		}
+
+		return v1beta1.VolumeSnapshotSetInProgress, ""
+	}
+
+	var (
+		firstCut, lastCut time.Time
+		cutCount          int
+		readyCount        int
+	)
+
+	for idx := range snapshots {
+		snapshot := &snapshots[idx]
+
+		if msg := GetVolumeSnapshotError(snapshot); msg != "" {
+			return v1beta1.VolumeSnapshotSetFailed,
+				fmt.Sprintf("VolumeSnapshot %s failed: %s", snapshot.GetName(), msg)
+		}
+
+		if cutTime, ok := getVolumeSnapshotCutTime(snapshot); ok {
+			if cutCount == 0 || cutTime.Before(firstCut) {
+				firstCut = cutTime
+			}
+
+			if cutCount == 0 || cutTime.After(lastCut) {
+				lastCut = cutTime
+			}
+
+			cutCount++
+		}
+
+		if IsVolumeSnapshotReady(snapshot) {
+			readyCount++
+		}
+	}
+
+	if cutCount != len(snapshots) {
+		if windowElapsed {
+			return v1beta1.VolumeSnapshotSetFailed,
+				fmt.Sprintf("%d of %d VolumeSnapshots were not cut within snapshot window %s",
+					len(snapshots)-cutCount, len(snapshots), window)
+		}
+
+		return v1beta1.VolumeSnapshotSetInProgress, ""
+	}
+
+	if spread := lastCut.Sub(firstCut); spread > window {
+		return v1beta1.VolumeSnapshotSetFailed,
+			fmt.Sprintf("VolumeSnapshots were cut over %s, more than snapshot window %s", spread, window)
+	}
+
+	if readyCount == len(snapshots) {
+		return v1beta1.VolumeSnapshotSetCompleted, ""
+	}
+
+	return v1beta1.VolumeSnapshotSetInProgress, ""
+}
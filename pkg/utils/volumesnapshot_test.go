@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+)
+
+func newTestVolumeSnapshot(name string, ready bool, creationTime time.Time) unstructured.Unstructured {
+	snapshot := NewVolumeSnapshot("test", name, "csi-snapclass", "ns-aerospike-0", nil)
+	snapshot.Object["status"] = map[string]interface{}{
+		"readyToUse":   ready,
+		"creationTime": creationTime.Format(time.RFC3339),
+	}
+
+	return *snapshot
+}
+
+func TestGetLatestReadyVolumeSnapshot(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name      string
+		snapshots []unstructured.Unstructured
+		maxAge    time.Duration
+		expected  string
+	}{
+		{
+			name:      "no snapshots",
+			snapshots: nil,
+			maxAge:    time.Hour,
+			expected:  "",
+		},
+		{
+			name: "latest ready snapshot is picked",
+			snapshots: []unstructured.Unstructured{
+				newTestVolumeSnapshot("old", true, now.Add(-30*time.Minute)),
+				newTestVolumeSnapshot("new", true, now.Add(-10*time.Minute)),
+				newTestVolumeSnapshot("newest-not-ready", false, now.Add(-time.Minute)),
+			},
+			maxAge:   time.Hour,
+			expected: "new",
+		},
+		{
+			name: "snapshots older than max age are skipped",
+			snapshots: []unstructured.Unstructured{
+				newTestVolumeSnapshot("expired", true, now.Add(-2*time.Hour)),
+			},
+			maxAge:   time.Hour,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetLatestReadyVolumeSnapshot(tt.snapshots, tt.maxAge, now)
+
+			name := ""
+			if result != nil {
+				name = result.GetName()
+			}
+
+			if name != tt.expected {
+				t.Errorf("GetLatestReadyVolumeSnapshot() = %q, expected %q", name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsVolumeSnapshotReady(t *testing.T) {
+	deleting := newTestVolumeSnapshot("deleting", true, time.Now())
+	deleting.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+
+	noStatus := *NewVolumeSnapshot("test", "no-status", "csi-snapclass", "ns-aerospike-0", nil)
+
+	tests := []struct {
+		name     string
+		snapshot unstructured.Unstructured
+		expected bool
+	}{
+		{
+			name:     "ready snapshot",
+			snapshot: newTestVolumeSnapshot("ready", true, time.Now()),
+			expected: true,
+		},
+		{
+			name:     "not ready snapshot",
+			snapshot: newTestVolumeSnapshot("not-ready", false, time.Now()),
+			expected: false,
+		},
+		{
+			name:     "snapshot without status",
+			snapshot: noStatus,
+			expected: false,
+		},
+		{
+			name:     "snapshot being deleted",
+			snapshot: deleting,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsVolumeSnapshotReady(&tt.snapshot); result != tt.expected {
+				t.Errorf("IsVolumeSnapshotReady() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAreVolumeSnapshotsCut(t *testing.T) {
+	now := time.Now()
+	notCut := *NewVolumeSnapshot("test", "not-cut", "csi-snapclass", "ns-aerospike-0", nil)
+
+	tests := []struct {
+		name          string
+		snapshots     []unstructured.Unstructured
+		expectedCount int32
+		expected      bool
+	}{
+		{
+			name: "all snapshots cut",
+			snapshots: []unstructured.Unstructured{
+				newTestVolumeSnapshot("ready", true, now), newTestVolumeSnapshot("not-ready", false, now),
+			},
+			expectedCount: 2,
+			expected:      true,
+		},
+		{
+			name:          "snapshot not cut yet",
+			snapshots:     []unstructured.Unstructured{newTestVolumeSnapshot("cut", false, now), notCut},
+			expectedCount: 2,
+			expected:      false,
+		},
+		{
+			name:          "snapshot not created yet",
+			snapshots:     []unstructured.Unstructured{newTestVolumeSnapshot("cut", true, now)},
+			expectedCount: 2,
+			expected:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := AreVolumeSnapshotsCut(tt.snapshots, tt.expectedCount); result != tt.expected {
+				t.Errorf("AreVolumeSnapshotsCut() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetVolumeSnapshotSetPhase(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	window := 5 * time.Minute
+
+	notCut := *NewVolumeSnapshot("test", "not-cut", "csi-snapclass", "ns-aerospike-0", nil)
+
+	failed := newTestVolumeSnapshot("failed", false, start)
+	failed.Object["status"].(map[string]interface{})["error"] = map[string]interface{}{"message": "disk error"}
+
+	tests := []struct {
+		name      string
+		snapshots []unstructured.Unstructured
+		count     int32
+		now       time.Time
+		expected  v1beta1.VolumeSnapshotSetPhase
+	}{
+		{
+			name: "all snapshots ready",
+			snapshots: []unstructured.Unstructured{
+				newTestVolumeSnapshot("a", true, start), newTestVolumeSnapshot("b", true, start.Add(time.Second)),
+			},
+			count:    2,
+			now:      start.Add(time.Minute),
+			expected: v1beta1.VolumeSnapshotSetCompleted,
+		},
+		{
+			name: "snapshots cut but not ready",
+			snapshots: []unstructured.Unstructured{
+				newTestVolumeSnapshot("a", true, start), newTestVolumeSnapshot("b", false, start),
+			},
+			count:    2,
+			now:      start.Add(time.Hour),
+			expected: v1beta1.VolumeSnapshotSetInProgress,
+		},
+		{
+			name:      "snapshot not cut within window",
+			snapshots: []unstructured.Unstructured{newTestVolumeSnapshot("a", true, start), notCut},
+			count:     2,
+			now:       start.Add(10 * time.Minute),
+			expected:  v1beta1.VolumeSnapshotSetFailed,
+		},
+		{
+			name:      "snapshot not cut yet",
+			snapshots: []unstructured.Unstructured{newTestVolumeSnapshot("a", true, start), notCut},
+			count:     2,
+			now:       start.Add(time.Minute),
+			expected:  v1beta1.VolumeSnapshotSetInProgress,
+		},
+		{
+			name: "snapshots cut over more than window",
+			snapshots: []unstructured.Unstructured{
+				newTestVolumeSnapshot("a", true, start), newTestVolumeSnapshot("b", true, start.Add(10*time.Minute)),
+			},
+			count:    2,
+			now:      start.Add(time.Hour),
+			expected: v1beta1.VolumeSnapshotSetFailed,
+		},
+		{
+			name:      "snapshot failed",
+			snapshots: []unstructured.Unstructured{newTestVolumeSnapshot("a", true, start), failed},
+			count:     2,
+			now:       start.Add(time.Minute),
+			expected:  v1beta1.VolumeSnapshotSetFailed,
+		},
+		{
+			name:      "snapshot missing",
+			snapshots: []unstructured.Unstructured{newTestVolumeSnapshot("a", true, start)},
+			count:     2,
+			now:       start.Add(10 * time.Minute),
+			expected:  v1beta1.VolumeSnapshotSetFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, message := GetVolumeSnapshotSetPhase(tt.snapshots, tt.count, start, window, tt.now)
+			if phase != tt.expected {
+				t.Errorf("GetVolumeSnapshotSetPhase() = %v (%s), expected %v", phase, message, tt.expected)
+			}
+		})
+	}
+}
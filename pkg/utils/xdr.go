@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+const (
+	xdrDCsKey              = "dcs"
+	xdrDCNameKey           = "name"
+	xdrNodeAddressPortsKey = "node-address-ports"
+)
+
+// FormatXDRSeed returns the seed in the XDR DC node-address-ports format i.e. "host port [tls-name]".
+func FormatXDRSeed(host string, port int32, tlsName string) string {
+	if tlsName != "" {
+		return fmt.Sprintf("%s %d %s", host, port, tlsName)
+	}
+
+	return fmt.Sprintf("%s %d", host, port)
+}
+
+// GetXDRSeedsFromPods returns the seeds in the node-address-ports format from the access endpoints of the pods,
+// one seed per pod, sorted by pod name. The TLS access endpoints are used if tlsName is set.
+func GetXDRSeedsFromPods(pods map[string]asdbv1.AerospikePodStatus, tlsName string) ([]string, error) {
+	podNames := make([]string, 0, len(pods))
+	for podName := range pods {
+		podNames = append(podNames, podName)
+	}
+
+	sort.Strings(podNames)
+
+	seeds := make([]string, 0, len(podNames))
+
+	for _, podName := range podNames {
+		endpoints := pods[podName].Aerospike.AccessEndpoints
+		if tlsName != "" {
+			endpoints = pods[podName].Aerospike.TLSAccessEndpoints
+		}
+
+		if len(endpoints) == 0 {
+			continue
+		}
+
+		host, port, err := net.SplitHostPort(endpoints[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid access endpoint %s of pod %s: %v", endpoints[0], podName, err)
+		}
+
+		seed := host + " " + port
+		if tlsName != "" {
+			seed += " " + tlsName
+		}
+
+		seeds = append(seeds, seed)
+	}
+
+	return seeds, nil
+}
+
+// SetXDRDCs sets the given DCs in the xdr section of the aerospikeConfig.
+// DCs having the same name are replaced and new DCs are appended. DCs named in managedDCNames which are
+// not given are removed, other DCs are left untouched. It returns true if the aerospikeConfig is changed.
+func SetXDRDCs(
+	aerospikeConfig map[string]interface{}, dcs []map[string]interface{}, managedDCNames sets.Set[string],
+) (bool, error) {
+	xdrConf, ok := aerospikeConfig[asdbv1.ConfKeyXdr].(map[string]interface{})
+	if !ok {
+		if aerospikeConfig[asdbv1.ConfKeyXdr] != nil {
+			return false, fmt.Errorf("invalid xdr config %v", aerospikeConfig[asdbv1.ConfKeyXdr])
+		}
+
+		if len(dcs) == 0 {
+			return false, nil
+		}
+
+		xdrConf = map[string]interface{}{}
+	}
+
+	var existingDCs []interface{}
+
+	if xdrConf[xdrDCsKey] != nil {
+		existingDCs, ok = xdrConf[xdrDCsKey].([]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid xdr dcs config %v", xdrConf[xdrDCsKey])
+		}
+	}
+
+	dcByName := make(map[string]map[string]interface{}, len(dcs))
+	for _, dc := range dcs {
+		dcByName[dc[xdrDCNameKey].(string)] = dc
+	}
+
+	newDCs := make([]interface{}, 0, len(existingDCs)+len(dcs))
+	added := sets.New[string]()
+
+	for _, existingDC := range existingDCs {
+		dcConf, ok := existingDC.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid xdr dc config %v", existingDC)
+		}
+
+		name, _ := dcConf[xdrDCNameKey].(string)
+
+		if dc, ok := dcByName[name]; ok {
+			newDCs = append(newDCs, dc)
+			added.Insert(name)
+
+			continue
+		}
+
+		if managedDCNames.Has(name) {
+			continue
+		}
+
+		newDCs = append(newDCs, dcConf)
+	}
+
+	for _, dc := range dcs {
+		if !added.Has(dc[xdrDCNameKey].(string)) {
+			newDCs = append(newDCs, dc)
+		}
+	}
+
+	if len(existingDCs) == 0 && len(newDCs) == 0 || reflect.DeepEqual(existingDCs, newDCs) {
+		return false, nil
+	}
+
+	if len(newDCs) == 0 {
+		delete(xdrConf, xdrDCsKey)
+	} else {
+		xdrConf[xdrDCsKey] = newDCs
+	}
+
+	if len(xdrConf) == 0 {
+		delete(aerospikeConfig, asdbv1.ConfKeyXdr)
+	} else {
+		aerospikeConfig[asdbv1.ConfKeyXdr] = xdrConf
+	}
+
+	return true, nil
+}
+
+// NewXDRDC returns the XDR DC config with the given name and seeds merged into the dcConfig.
+func NewXDRDC(name string, seeds []string, dcConfig map[string]interface{}) map[string]interface{} {
+	dc := make(map[string]interface{}, len(dcConfig)+2)
+	for key, value := range dcConfig {
+		dc[key] = value
+	}
+
+	nodeAddressPorts := make([]interface{}, 0, len(seeds))
+	for _, seed := range seeds {
+		nodeAddressPorts = append(nodeAddressPorts, seed)
+	}
+
+	dc[xdrDCNameKey] = name
+	dc[xdrNodeAddressPortsKey] = nodeAddressPorts
+
+	return dc
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+)
+
+func TestGetXDRSeedsFromPods(t *testing.T) {
+	pods := map[string]asdbv1.AerospikePodStatus{
+		"dst-0-1": {
+			Aerospike: asdbv1.AerospikeInstanceSummary{
+				AccessEndpoints:    []string{"10.0.0.2:3000"},
+				TLSAccessEndpoints: []string{"10.0.0.2:4333"},
+			},
+		},
+		"dst-0-0": {
+			Aerospike: asdbv1.AerospikeInstanceSummary{
+				AccessEndpoints:    []string{"[2001:db8::1]:3000", "10.0.0.1:3000"},
+				TLSAccessEndpoints: []string{"[2001:db8::1]:4333"},
+			},
+		},
+		"dst-0-2": {},
+	}
+
+	tests := []struct {
+		name     string
+		tlsName  string
+		expected []string
+	}{
+		{
+			name:     "access endpoints",
+			expected: []string{"2001:db8::1 3000", "10.0.0.2 3000"},
+		},
+		{
+			name:     "tls access endpoints",
+			tlsName:  "dst",
+			expected: []string{"2001:db8::1 4333 dst", "10.0.0.2 4333 dst"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeds, err := GetXDRSeedsFromPods(pods, tt.tlsName)
+			if err != nil {
+				t.Fatalf("GetXDRSeedsFromPods() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(seeds, tt.expected) {
+				t.Errorf("GetXDRSeedsFromPods() = %v, expected %v", seeds, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetXDRDCs(t *testing.T) {
+	userDC := map[string]interface{}{"name": "user", "node-address-ports": []interface{}{"10.0.0.9 3000"}}
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		dcs      []map[string]interface{}
+		managed  sets.Set[string]
+		changed  bool
+		expected map[string]interface{}
+	}{
+		{
+			name:    "xdr section is created",
+			config:  map[string]interface{}{},
+			dcs:     []map[string]interface{}{NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+			managed: sets.New[string](),
+			changed: true,
+			expected: map[string]interface{}{
+				"xdr": map[string]interface{}{
+					"dcs": []interface{}{NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+				},
+			},
+		},
+		{
+			name: "managed dc is updated and user dc is kept",
+			config: map[string]interface{}{
+				"xdr": map[string]interface{}{
+					"dcs": []interface{}{userDC, NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+				},
+			},
+			dcs:     []map[string]interface{}{NewXDRDC("dc1", []string{"10.0.0.2 3000"}, nil)},
+			managed: sets.New("dc1"),
+			changed: true,
+			expected: map[string]interface{}{
+				"xdr": map[string]interface{}{
+					"dcs": []interface{}{userDC, NewXDRDC("dc1", []string{"10.0.0.2 3000"}, nil)},
+				},
+			},
+		},
+		{
+			name: "unchanged dc",
+			config: map[string]interface{}{
+				"xdr": map[string]interface{}{
+					"dcs": []interface{}{NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+				},
+			},
+			dcs:     []map[string]interface{}{NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+			managed: sets.New("dc1"),
+			changed: false,
+			expected: map[string]interface{}{
+				"xdr": map[string]interface{}{
+					"dcs": []interface{}{NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+				},
+			},
+		},
+		{
+			name: "last managed dc is removed",
+			config: map[string]interface{}{
+				"xdr": map[string]interface{}{
+					"dcs": []interface{}{NewXDRDC("dc1", []string{"10.0.0.1 3000"}, nil)},
+				},
+			},
+			managed:  sets.New("dc1"),
+			changed:  true,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "no xdr section and no dcs",
+			config:   map[string]interface{}{},
+			managed:  sets.New("dc1"),
+			changed:  false,
+			expected: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := SetXDRDCs(tt.config, tt.dcs, tt.managed)
+			if err != nil {
+				t.Fatalf("SetXDRDCs() error = %v", err)
+			}
+
+			if changed != tt.changed {
+				t.Errorf("SetXDRDCs() changed = %v, expected %v", changed, tt.changed)
+			}
+
+			if !reflect.DeepEqual(tt.config, tt.expected) {
+				t.Errorf("SetXDRDCs() config = %v, expected %v", tt.config, tt.expected)
+			}
+		})
+	}
+}
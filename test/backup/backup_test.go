@@ -2,10 +2,12 @@ package backup
 
 import (
 	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/yaml"
 
@@ -492,6 +494,44 @@ var _ = Describe(
 				Expect(err).ToNot(HaveOccurred())
 			})
 
+			It("Should track multiple on-demand backups till completion", func() {
+				backup, err = NewBackup(backupNsNm)
+				Expect(err).ToNot(HaveOccurred())
+				err = CreateBackup(k8sClient, backup)
+				Expect(err).ToNot(HaveOccurred())
+
+				backup, err = getBackupObj(k8sClient, backup.Name, backup.Namespace)
+				Expect(err).ToNot(HaveOccurred())
+
+				backup.Spec.OnDemandBackups = []asdbv1beta1.OnDemandBackupSpec{
+					{
+						ID:          "on-demand1",
+						RoutineName: namePrefix(backupNsNm) + "-" + "test-routine",
+					},
+					{
+						ID:          "on-demand2",
+						RoutineName: namePrefix(backupNsNm) + "-" + "test-routine",
+						Delay:       metav1.Duration{Duration: 30 * time.Second},
+					},
+				}
+
+				err = updateBackup(k8sClient, backup)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = waitForOnDemandBackupsCompletion(k8sClient, backup, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Fail when existing on-demand backup is updated")
+				backup, err = getBackupObj(k8sClient, backup.Name, backup.Namespace)
+				Expect(err).ToNot(HaveOccurred())
+
+				backup.Spec.OnDemandBackups[0].Delay = metav1.Duration{Duration: time.Minute}
+
+				err = updateBackup(k8sClient, backup)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("existing onDemand backup cannot be updated"))
+			})
+
 			It("Should unregister backup-routines when removed from backup CR", func() {
 				backupConfig := getBackupConfigInMap(namePrefix(backupNsNm))
 				backupRoutines := backupConfig[asdbv1beta1.BackupRoutinesKey].(map[string]interface{})
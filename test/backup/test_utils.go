@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -233,16 +234,24 @@ func waitForBackup(cl client.Client, backup *asdbv1beta1.AerospikeBackup,
 				return false, nil
 			}
 
-			status := asdbv1beta1.AerospikeBackupStatus{}
-			status.BackupService = backup.Spec.BackupService
-			status.Config = backup.Spec.Config
-			status.OnDemandBackups = backup.Spec.OnDemandBackups
-
-			if !reflect.DeepEqual(status, backup.Status) {
+			if !reflect.DeepEqual(backup.Spec.BackupService, backup.Status.BackupService) ||
+				!reflect.DeepEqual(backup.Spec.Config, backup.Status.Config) {
 				pkgLog.Info("Backup status not updated yet")
 				return false, nil
 			}
 
+			onDemandBackups := sets.New[string]()
+			for idx := range backup.Status.OnDemandBackups {
+				onDemandBackups.Insert(backup.Status.OnDemandBackups[idx].ID)
+			}
+
+			for idx := range backup.Spec.OnDemandBackups {
+				if !onDemandBackups.Has(backup.Spec.OnDemandBackups[idx].ID) {
+					pkgLog.Info("OnDemand backup status not updated yet", "ID", backup.Spec.OnDemandBackups[idx].ID)
+					return false, nil
+				}
+			}
+
 			return true, nil
 		})
 }
@@ -314,6 +323,45 @@ func validateTriggeredBackup(k8sClient client.Client, backup *asdbv1beta1.Aerosp
 	return validateNewEntries(config, desiredConfigInMap, "backup-service API")
 }
 
+// waitForOnDemandBackupsCompletion waits for all the onDemand backups in spec to complete
+func waitForOnDemandBackupsCompletion(cl client.Client, backup *asdbv1beta1.AerospikeBackup,
+	timeout time.Duration) error {
+	namespaceName := types.NamespacedName{
+		Name: backup.Name, Namespace: backup.Namespace,
+	}
+
+	return wait.PollUntilContextTimeout(
+		testCtx, interval,
+		timeout, true, func(ctx context.Context) (bool, error) {
+			if err := cl.Get(ctx, namespaceName, backup); err != nil {
+				return false, nil
+			}
+
+			phases := make(map[string]asdbv1beta1.OnDemandBackupPhase, len(backup.Status.OnDemandBackups))
+			for idx := range backup.Status.OnDemandBackups {
+				phases[backup.Status.OnDemandBackups[idx].ID] = backup.Status.OnDemandBackups[idx].Phase
+			}
+
+			for idx := range backup.Spec.OnDemandBackups {
+				id := backup.Spec.OnDemandBackups[idx].ID
+
+				switch phases[id] {
+				case asdbv1beta1.OnDemandBackupCompleted:
+					continue
+				case asdbv1beta1.OnDemandBackupFailed:
+					return false, fmt.Errorf("onDemand backup %s failed", id)
+				case asdbv1beta1.OnDemandBackupUnknown:
+					return false, fmt.Errorf("onDemand backup %s progress unknown", id)
+				default:
+					pkgLog.Info("OnDemand backup not completed yet", "ID", id, "phase", phases[id])
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+}
+
 func namePrefix(nsNm types.NamespacedName) string {
 	return nsNm.Namespace + "-" + nsNm.Name
 }
@@ -360,7 +408,7 @@ func GetBackupDataPaths(k8sClient client.Client, backup *asdbv1beta1.AerospikeBa
 	if err := wait.PollUntilContextTimeout(testCtx, interval, timeout, true,
 		func(_ context.Context) (bool, error) {
 			for routineName := range config.BackupRoutines {
-				backups, err := serviceClient.GetFullBackupsForRoutine(routineName)
+				backups, err := serviceClient.GetFullBackupsForRoutine(testCtx, routineName)
 				if err != nil {
 					return false, nil
 				}
@@ -371,8 +419,7 @@ func GetBackupDataPaths(k8sClient client.Client, backup *asdbv1beta1.AerospikeBa
 				}
 
 				for idx := range backups {
-					backupMeta := backups[idx].(map[string]interface{})
-					backupDataPaths = append(backupDataPaths, backupMeta["key"].(string))
+					backupDataPaths = append(backupDataPaths, backups[idx].Key)
 				}
 			}
 
@@ -11,10 +11,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
 	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
 )
 
@@ -192,6 +194,49 @@ var _ = Describe(
 					err = DeployBackupService(k8sClient, backupService)
 					Expect(err).Should(HaveOccurred())
 				})
+
+				It("Should fail when required workloadIdentity field is not given for the provider", func() {
+					backupService, err = NewBackupService(backupServiceNamespacedName)
+					Expect(err).ToNot(HaveOccurred())
+
+					backupService.Spec.WorkloadIdentity = &asdbv1beta1.WorkloadIdentity{
+						Provider: asdbv1beta1.WorkloadIdentityAzure,
+						ClientID: "00000000-0000-0000-0000-000000000000",
+					}
+
+					err = DeployBackupService(k8sClient, backupService)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("clientID and tenantID are required"))
+				})
+
+				It("Should fail when no storage supports the workloadIdentity provider", func() {
+					backupService, err = NewBackupService(backupServiceNamespacedName)
+					Expect(err).ToNot(HaveOccurred())
+
+					backupService.Spec.WorkloadIdentity = &asdbv1beta1.WorkloadIdentity{
+						Provider:          asdbv1beta1.WorkloadIdentityGCP,
+						GCPServiceAccount: "backup@test-project.iam.gserviceaccount.com",
+					}
+
+					err = DeployBackupService(k8sClient, backupService)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("is not supported by any storage"))
+				})
+
+				It("Should fail when storage has static credentials with workloadIdentity", func() {
+					backupService, err = NewBackupService(backupServiceNamespacedName)
+					Expect(err).ToNot(HaveOccurred())
+
+					// s3Storage in the test config uses the s3-profile
+					backupService.Spec.WorkloadIdentity = &asdbv1beta1.WorkloadIdentity{
+						Provider: asdbv1beta1.WorkloadIdentityAWS,
+						RoleARN:  "arn:aws:iam::111122223333:role/backup",
+					}
+
+					err = DeployBackupService(k8sClient, backupService)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("can not have static credentials"))
+				})
 			},
 		)
 
@@ -267,6 +312,17 @@ var _ = Describe(
 				Expect(config).ToNot(BeNil())
 				Expect(config[asdbv1beta1.BackupRoutinesKey]).To(BeNil())
 				Expect(config[asdbv1beta1.StorageKey]).To(BeNil())
+
+				By("Validate config reload status")
+				backupService, err = getBackupServiceObj(k8sClient, backupServiceNamespacedName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(backupService.Status.ConfigReload).ToNot(BeNil())
+				Expect(backupService.Status.ConfigReload.Phase).To(Equal(asdbv1beta1.BackupServiceConfigReloadCompleted))
+
+				configHash, hErr := backup_service.ConfigHash(config)
+				Expect(hErr).ToNot(HaveOccurred())
+				Expect(backupService.Status.ConfigReload.AppliedConfigHash).To(Equal(configHash))
+				Expect(backupService.Status.ConfigReload.DesiredConfigHash).To(Equal(configHash))
 			})
 
 			It("Should restart backup service deployment pod when pod spec is changed", func() {
@@ -466,6 +522,41 @@ var _ = Describe(
 
 				validateSA(asdbv1beta1.AerospikeBackupServiceKey)
 			})
+
+			It("Should deploy a standby replica and PodDisruptionBudget when replicas are 2", func() {
+				backupService, err = NewBackupService(backupServiceNamespacedName)
+				Expect(err).ToNot(HaveOccurred())
+
+				backupService.Spec.Replicas = ptr.To(int32(2))
+
+				err = DeployBackupService(k8sClient, backupService)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = validateBackupServiceReplicas(k8sClient, backupServiceNamespacedName, 2)
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Scale down to single replica")
+				backupService, err = getBackupServiceObj(k8sClient, backupServiceNamespacedName)
+				Expect(err).ToNot(HaveOccurred())
+
+				backupService.Spec.Replicas = ptr.To(int32(1))
+
+				err = updateBackupService(k8sClient, backupService)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = validateBackupServiceReplicas(k8sClient, backupServiceNamespacedName, 1)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("Should fail when replicas are more than 2", func() {
+				backupService, err = NewBackupService(backupServiceNamespacedName)
+				Expect(err).ToNot(HaveOccurred())
+
+				backupService.Spec.Replicas = ptr.To(int32(3))
+
+				err = DeployBackupService(k8sClient, backupService)
+				Expect(err).To(HaveOccurred())
+			})
 		})
 
 		Context("When doing recovery", func() {
@@ -8,6 +8,7 @@ import (
 
 	app "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -380,7 +381,7 @@ func GetAPIBackupSvcConfig(k8sClient client.Client, backupServiceName, backupSer
 	// Wait for Backup service to be ready
 	if err := wait.PollUntilContextTimeout(testCtx, interval, timeout, true,
 		func(_ context.Context) (bool, error) {
-			config, err := serviceClient.GetBackupServiceConfig()
+			config, err := serviceClient.GetBackupServiceConfig(testCtx)
 			if err != nil {
 				pkgLog.Error(err, "Failed to get backup service config")
 				return false, nil
@@ -417,3 +418,40 @@ func validateLabelsOrAnnotations(
 
 	return true
 }
+
+// validateBackupServiceReplicas validates the standby deployment and PodDisruptionBudget as per the replicas.
+func validateBackupServiceReplicas(k8sClient client.Client, backupServiceNamespacedName types.NamespacedName,
+	replicas int32) error {
+	standbyNamespacedName := types.NamespacedName{
+		Name: backupServiceNamespacedName.Name + "-standby", Namespace: backupServiceNamespacedName.Namespace,
+	}
+
+	return wait.PollUntilContextTimeout(testCtx, interval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			backupService, err := getBackupServiceObj(k8sClient, backupServiceNamespacedName)
+			if err != nil {
+				return false, err
+			}
+
+			if backupService.Status.Replicas != replicas ||
+				backupService.Status.ServingRole != asdbv1beta1.BackupServiceLeader {
+				return false, nil
+			}
+
+			standby := &app.Deployment{}
+			standbyErr := k8sClient.Get(ctx, standbyNamespacedName, standby)
+
+			pdb := &policyv1.PodDisruptionBudget{}
+			pdbErr := k8sClient.Get(ctx, backupServiceNamespacedName, pdb)
+
+			if replicas == 1 {
+				return k8serrors.IsNotFound(standbyErr) && k8serrors.IsNotFound(pdbErr), nil
+			}
+
+			if standbyErr != nil || pdbErr != nil {
+				return false, nil
+			}
+
+			return standby.Status.ReadyReplicas == replicas-1, nil
+		})
+}
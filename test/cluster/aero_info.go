@@ -132,7 +132,8 @@ func getEndpointIP(
 		return "", fmt.Errorf(
 			"can not use configured network type: %s", networkType,
 		)
-	case asdbv1.AerospikeNetworkTypeCustomInterface:
+	case asdbv1.AerospikeNetworkTypeCustomInterface, asdbv1.AerospikeNetworkTypePodLoadBalancer,
+		asdbv1.AerospikeNetworkTypePodNodePort, asdbv1.AerospikeNetworkTypeGatewayTLSRoute:
 		return "", fmt.Errorf(
 			"%s not support yet", networkType,
 		)
@@ -181,7 +182,8 @@ func createHost(pod *asdbv1.AerospikePodStatus, network string) (*as.Host, error
 		return nil, fmt.Errorf(
 			"can not use configured network type: %s", networkType,
 		)
-	case asdbv1.AerospikeNetworkTypeCustomInterface:
+	case asdbv1.AerospikeNetworkTypeCustomInterface, asdbv1.AerospikeNetworkTypePodLoadBalancer,
+		asdbv1.AerospikeNetworkTypePodNodePort, asdbv1.AerospikeNetworkTypeGatewayTLSRoute:
 		return nil, fmt.Errorf(
 			"%s not support yet", networkType,
 		)
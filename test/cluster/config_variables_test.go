@@ -0,0 +1,212 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
+)
+
+const podInitNameAndTagEnvVar = "POD_INIT_NAME_TAG"
+
+var _ = Describe(
+	"AerospikeConfigVariables", func() {
+		ctx := context.TODO()
+		clusterName := fmt.Sprintf("config-vars-%d", GinkgoParallelProcess())
+		clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+		AfterEach(func() {
+			aeroCluster := &asdbv1.AerospikeCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName,
+					Namespace: namespace,
+				},
+			}
+
+			Expect(DeleteCluster(k8sClient, ctx, aeroCluster)).NotTo(HaveOccurred())
+			Expect(CleanupPVC(k8sClient, aeroCluster.Namespace, aeroCluster.Name)).ToNot(HaveOccurred())
+		})
+
+		It("Should resolve the variables in the pods and warm restart them on a variable change", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			setPodInitImage(aeroCluster)
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{Name: "baseFdMax", Value: "10000"},
+				{Name: "protoFdMax", Expression: "baseFdMax * 150%"},
+			}
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyService].(map[string]interface{})["proto-fd-max"] =
+				"<var:protoFdMax>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+			Expect(getProtoFdMax(ctx, aeroCluster)).To(Equal(int64(15000)))
+
+			By("Updating the variable")
+
+			aeroCluster, err := getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			podPIDMap, err := getPodIDs(ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			aeroCluster.Spec.AerospikeConfigVariables[0].Value = "12000"
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			Expect(getProtoFdMax(ctx, aeroCluster)).To(Equal(int64(18000)))
+			validateServerRestart(ctx, aeroCluster, podPIDMap, asdbv1.OperationWarmRestart)
+		})
+
+		It("Should resolve a variable from a Secret set in the init container env vars", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName + "-vars",
+					Namespace: namespace,
+				},
+				StringData: map[string]string{"protoFdMax": "16000\n"},
+			}
+			Expect(k8sClient.Create(ctx, secret)).ToNot(HaveOccurred())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, secret)).ToNot(HaveOccurred())
+			})
+
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			setPodInitImage(aeroCluster)
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{
+					Name: "protoFdMax",
+					ValueFrom: &asdbv1.AerospikeConfigVariableSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+							Key:                  "protoFdMax",
+						},
+					},
+				},
+			}
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyService].(map[string]interface{})["proto-fd-max"] =
+				"<var:protoFdMax>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+			Expect(getProtoFdMax(ctx, aeroCluster)).To(Equal(int64(16000)))
+		})
+
+		It("Should restart the pods on a change of a variable used in the data-size", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			setPodInitImage(aeroCluster)
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{Name: "memDataSize", Value: "1073741824"},
+			}
+
+			memNamespace := getNonSCInMemoryNamespaceConfig("mem")
+			memNamespace[asdbv1.ConfKeyStorageEngine].(map[string]interface{})["data-size"] = "<var:memDataSize>"
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyNamespace] = append(
+				aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyNamespace].([]interface{}), memNamespace)
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			By("Updating the variable")
+
+			aeroCluster, err := getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			podPIDMap, err := getPodIDs(ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			aeroCluster.Spec.AerospikeConfigVariables[0].Value = "2073741824"
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			validateServerRestart(ctx, aeroCluster, podPIDMap, asdbv1.OperationPodRestart)
+		})
+
+		It("Should fail if a variable value contains whitespace", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			setPodInitImage(aeroCluster)
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{Name: "protoFdMax", Value: "15000 }"},
+			}
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyService].(map[string]interface{})["proto-fd-max"] =
+				"<var:protoFdMax>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).To(HaveOccurred())
+		})
+
+		It("Should fail with the default init image", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			aeroCluster.Spec.PodSpec.AerospikeInitContainerSpec.ImageNameAndTag =
+				asdbv1.AerospikeInitContainerDefaultNameAndTag
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{Name: "protoFdMax", Value: "15000"},
+			}
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyService].(map[string]interface{})["proto-fd-max"] =
+				"<var:protoFdMax>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).To(HaveOccurred())
+		})
+
+		It("Should fail if a referenced variable is not defined", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyService].(map[string]interface{})["proto-fd-max"] =
+				"<var:protoFdMax>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).To(HaveOccurred())
+		})
+
+		It("Should fail if a variable is used in the network config", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			setPodInitImage(aeroCluster)
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{Name: "servicePort", Value: "3000"},
+			}
+
+			networkConf := aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyNetwork].(map[string]interface{})
+			networkConf[asdbv1.ConfKeyNetworkService].(map[string]interface{})["port"] = "<var:servicePort>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).To(HaveOccurred())
+		})
+
+		It("Should fail if an expression uses a variable defined after it", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			setPodInitImage(aeroCluster)
+			aeroCluster.Spec.AerospikeConfigVariables = []asdbv1.AerospikeConfigVariable{
+				{Name: "protoFdMax", Expression: "baseFdMax * 150%"},
+				{Name: "baseFdMax", Value: "10000"},
+			}
+			aeroCluster.Spec.AerospikeConfig.Value[asdbv1.ConfKeyService].(map[string]interface{})["proto-fd-max"] =
+				"<var:protoFdMax>"
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).To(HaveOccurred())
+		})
+	},
+)
+
+// setPodInitImage sets the init image shipping the aerospike-pod-init binary, and skips the test if not given.
+func setPodInitImage(aeroCluster *asdbv1.AerospikeCluster) {
+	nameAndTag, found := os.LookupEnv(podInitNameAndTagEnvVar)
+	if !found {
+		Skip(fmt.Sprintf("%s not set to an init image shipping aerospike-pod-init", podInitNameAndTagEnvVar))
+	}
+
+	customRegistryNamespace := getEnvVar(customInitRegistryNamespaceEnvVar)
+
+	aeroCluster.Spec.PodSpec.ImagePullSecrets = []corev1.LocalObjectReference{
+		{Name: getEnvVar(imagePullSecretNameEnvVar)},
+	}
+	aeroCluster.Spec.PodSpec.AerospikeInitContainerSpec.ImageRegistry = getEnvVar(customInitRegistryEnvVar)
+	aeroCluster.Spec.PodSpec.AerospikeInitContainerSpec.ImageRegistryNamespace = &customRegistryNamespace
+	aeroCluster.Spec.PodSpec.AerospikeInitContainerSpec.ImageNameAndTag = nameAndTag
+}
+
+func getProtoFdMax(ctx context.Context, aeroCluster *asdbv1.AerospikeCluster) (interface{}, error) {
+	conf, err := getAerospikeConfigFromNode(logger, k8sClient, ctx,
+		test.GetNamespacedName(aeroCluster.Name, aeroCluster.Namespace), asdbv1.ConfKeyService, aeroCluster.Name+"-0-0")
+	if err != nil {
+		return nil, err
+	}
+
+	return conf["proto-fd-max"], nil
+}
@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
+)
+
+var _ = Describe(
+	"Federation", func() {
+		ctx := context.TODO()
+		clusterName := fmt.Sprintf("federation-%d", GinkgoParallelProcess())
+		memberOneNamespacedName := test.GetNamespacedName(clusterName, test.MultiClusterNs1)
+		memberTwoNamespacedName := test.GetNamespacedName(clusterName, test.MultiClusterNs2)
+
+		AfterEach(func() {
+			for _, memberNamespace := range []string{test.MultiClusterNs1, test.MultiClusterNs2} {
+				aeroCluster := &asdbv1.AerospikeCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      clusterName,
+						Namespace: memberNamespace,
+					},
+				}
+
+				Expect(DeleteCluster(k8sClient, ctx, aeroCluster)).NotTo(HaveOccurred())
+				Expect(CleanupPVC(k8sClient, aeroCluster.Namespace, aeroCluster.Name)).ToNot(HaveOccurred())
+			}
+		})
+
+		It("Should form a single Aerospike cluster from the racks of the members", func() {
+			memberOne := createDummyAerospikeCluster(memberOneNamespacedName, 2)
+			memberOne.Spec.RackConfig = asdbv1.RackConfig{Racks: []asdbv1.Rack{{ID: 1}}}
+			memberOne.Spec.Federation = &asdbv1.FederationSpec{
+				Members:       []asdbv1.FederationMember{{Namespace: test.MultiClusterNs2, RackIDs: []int{2}}},
+				RosterManager: true,
+			}
+
+			memberTwo := createDummyAerospikeCluster(memberTwoNamespacedName, 2)
+			memberTwo.Spec.RackConfig = asdbv1.RackConfig{Racks: []asdbv1.Rack{{ID: 2}}}
+			memberTwo.Spec.Federation = &asdbv1.FederationSpec{
+				Members: []asdbv1.FederationMember{{Namespace: test.MultiClusterNs1, RackIDs: []int{1}}},
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, memberOne)).ToNot(HaveOccurred())
+			Expect(DeployCluster(k8sClient, ctx, memberTwo)).ToNot(HaveOccurred())
+
+			By("Validating the cluster size seen by the pods of both the members")
+
+			for _, member := range []*asdbv1.AerospikeCluster{memberOne, memberTwo} {
+				podList, err := getClusterPodList(k8sClient, ctx, member)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(podList.Items).ToNot(BeEmpty())
+
+				Eventually(func() (int, error) {
+					stats, err := requestInfoFromNode(logger, k8sClient, ctx, test.GetNamespacedName(
+						member.Name, member.Namespace), "statistics", podList.Items[0].Name)
+					if err != nil {
+						return 0, err
+					}
+
+					return strconv.Atoi(stats["cluster_size"])
+				}, getTimeout(4), retryInterval).Should(Equal(4))
+			}
+		})
+
+		It("Should fail when a rack is owned by more than one member", func() {
+			memberOne := createDummyAerospikeCluster(memberOneNamespacedName, 2)
+			memberOne.Spec.RackConfig = asdbv1.RackConfig{Racks: []asdbv1.Rack{{ID: 1}}}
+			memberOne.Spec.Federation = &asdbv1.FederationSpec{
+				Members: []asdbv1.FederationMember{{Namespace: test.MultiClusterNs2, RackIDs: []int{1, 2}}},
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, memberOne)).Should(HaveOccurred())
+		})
+
+		It("Should fail when a member refers to the same AerospikeCluster", func() {
+			memberOne := createDummyAerospikeCluster(memberOneNamespacedName, 2)
+			memberOne.Spec.Federation = &asdbv1.FederationSpec{
+				Members: []asdbv1.FederationMember{{RackIDs: []int{2}}},
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, memberOne)).Should(HaveOccurred())
+		})
+
+		It("Should fail when a remote member has no podCIDRs with networkIsolation", func() {
+			memberOne := createDummyAerospikeCluster(memberOneNamespacedName, 2)
+			memberOne.Spec.NetworkIsolation = &asdbv1.NetworkIsolationSpec{OperatorNamespace: "aerospike"}
+			memberOne.Spec.Federation = &asdbv1.FederationSpec{
+				Members: []asdbv1.FederationMember{{KubeconfigSecretName: "remote-kubeconfig", RackIDs: []int{2}}},
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, memberOne)).Should(HaveOccurred())
+		})
+	},
+)
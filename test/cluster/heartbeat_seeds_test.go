@@ -0,0 +1,99 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
+)
+
+var _ = Describe(
+	"HeartbeatSeeds", func() {
+		ctx := context.TODO()
+		clusterName := fmt.Sprintf("hb-seeds-%d", GinkgoParallelProcess())
+		clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+		AfterEach(func() {
+			aeroCluster := &asdbv1.AerospikeCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName,
+					Namespace: namespace,
+				},
+			}
+
+			Expect(DeleteCluster(k8sClient, ctx, aeroCluster)).NotTo(HaveOccurred())
+			Expect(CleanupPVC(k8sClient, aeroCluster.Namespace, aeroCluster.Name)).ToNot(HaveOccurred())
+		})
+
+		It("Should bound the seeds and update them without restarting the pods", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 3)
+			aeroCluster.Spec.HeartbeatSeeds = &asdbv1.HeartbeatSeedsSpec{
+				Strategy:    asdbv1.HeartbeatSeedsPodsPerRack,
+				PodsPerRack: 1,
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			stsName := GetNamespacedNameForSTS(aeroCluster, utils.GetRackIdentifier(asdbv1.DefaultRackID, ""))
+			Expect(getHeartbeatSeeds(ctx, aeroCluster)).To(Equal(
+				fmt.Sprintf("%s-0.%s.%s", stsName.Name, aeroCluster.Name, aeroCluster.Namespace)))
+
+			podList, err := getClusterPodList(k8sClient, ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Switching to the headless service seed")
+
+			aeroCluster, err = getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			aeroCluster.Spec.HeartbeatSeeds = &asdbv1.HeartbeatSeedsSpec{
+				Strategy: asdbv1.HeartbeatSeedsHeadlessService,
+			}
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			Expect(getHeartbeatSeeds(ctx, aeroCluster)).To(Equal(
+				fmt.Sprintf("%s.%s", aeroCluster.Name, aeroCluster.Namespace)))
+
+			newPodList, err := getClusterPodList(k8sClient, ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getPodUIDs(newPodList)).To(Equal(getPodUIDs(podList)))
+		})
+
+		It("Should fail if podsPerRack is set without the podsPerRack strategy", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			aeroCluster.Spec.HeartbeatSeeds = &asdbv1.HeartbeatSeedsSpec{
+				Strategy:    asdbv1.HeartbeatSeedsHeadlessService,
+				PodsPerRack: 1,
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).To(HaveOccurred())
+		})
+	},
+)
+
+func getHeartbeatSeeds(ctx context.Context, aeroCluster *asdbv1.AerospikeCluster) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, GetNamespacedNameForSTS(
+		aeroCluster, utils.GetRackIdentifier(asdbv1.DefaultRackID, ""),
+	), configMap); err != nil {
+		return "", err
+	}
+
+	return configMap.Data["peers"], nil
+}
+
+func getPodUIDs(podList *corev1.PodList) map[string]string {
+	podUIDs := make(map[string]string, len(podList.Items))
+	for idx := range podList.Items {
+		podUIDs[podList.Items[idx].Name] = string(podList.Items[idx].UID)
+	}
+
+	return podUIDs
+}
@@ -0,0 +1,101 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
+)
+
+var _ = Describe(
+	"NetworkIsolation", func() {
+		ctx := context.TODO()
+		clusterName := fmt.Sprintf("network-isolation-%d", GinkgoParallelProcess())
+		clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+		AfterEach(func() {
+			aeroCluster := &asdbv1.AerospikeCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName,
+					Namespace: namespace,
+				},
+			}
+
+			Expect(DeleteCluster(k8sClient, ctx, aeroCluster)).NotTo(HaveOccurred())
+			Expect(CleanupPVC(k8sClient, aeroCluster.Namespace, aeroCluster.Name)).ToNot(HaveOccurred())
+		})
+
+		It("Should create, update and delete the NetworkPolicy of the cluster", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			aeroCluster.Spec.NetworkIsolation = &asdbv1.NetworkIsolationSpec{
+				// The operator is deployed in the test namespace
+				OperatorNamespace: namespace,
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			networkPolicy, err := getNetworkPolicy(ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networkPolicy.Spec.Ingress).To(HaveLen(3))
+			Expect(getNetworkPolicyRulePorts(&networkPolicy.Spec.Ingress[0])).To(ConsistOf(
+				*asdbv1.GetHeartbeatPort(aeroCluster.Spec.AerospikeConfig),
+				*asdbv1.GetFabricPort(aeroCluster.Spec.AerospikeConfig),
+			))
+			Expect(getNetworkPolicyRulePorts(&networkPolicy.Spec.Ingress[1])).To(ConsistOf(
+				*asdbv1.GetServicePort(aeroCluster.Spec.AerospikeConfig),
+			))
+
+			By("Adding monitoring namespaces")
+
+			aeroCluster, err = getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			aeroCluster.Spec.NetworkIsolation.MonitoringNamespaces = []string{"monitoring"}
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			networkPolicy, err = getNetworkPolicy(ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networkPolicy.Spec.Ingress).To(HaveLen(4))
+			Expect(getNetworkPolicyRulePorts(&networkPolicy.Spec.Ingress[3])).To(ConsistOf(int32(9145)))
+
+			By("Removing networkIsolation")
+
+			aeroCluster, err = getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			aeroCluster.Spec.NetworkIsolation = nil
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			_, err = getNetworkPolicy(ctx, aeroCluster)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	},
+)
+
+func getNetworkPolicy(ctx context.Context, aeroCluster *asdbv1.AerospikeCluster) (*networkingv1.NetworkPolicy, error) {
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	err := k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: aeroCluster.Namespace,
+		Name:      aeroCluster.Name,
+	}, networkPolicy)
+
+	return networkPolicy, err
+}
+
+func getNetworkPolicyRulePorts(rule *networkingv1.NetworkPolicyIngressRule) []int32 {
+	ports := make([]int32, 0, len(rule.Ports))
+
+	for _, port := range rule.Ports {
+		ports = append(ports, port.Port.IntVal)
+	}
+
+	return ports
+}
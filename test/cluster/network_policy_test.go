@@ -22,6 +22,7 @@ import (
 
 	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
 	aerospikecluster "github.com/aerospike/aerospike-kubernetes-operator/v4/internal/controller/cluster"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/utils"
 	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
 	"github.com/aerospike/aerospike-management-lib/deployment"
 )
@@ -344,6 +345,120 @@ func negativeDeployNetworkPolicyTest(ctx goctx.Context, multiPodPerHost, enableT
 			)
 		},
 	)
+
+	Context(
+		"Negative cases for pod services", func() {
+			clusterName := fmt.Sprintf("np-pod-service-%d", GinkgoParallelProcess())
+			clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+			It(
+				"PodLoadBalancerAndPodNodePort: should fail when 'podLoadBalancer' and 'podNodePort' are used together",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.AerospikeNetworkPolicy.AccessType = asdbv1.AerospikeNetworkTypePodLoadBalancer
+					aeroCluster.Spec.AerospikeNetworkPolicy.AlternateAccessType = asdbv1.AerospikeNetworkTypePodNodePort
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"ExternalAddressWithoutPodNodePort: should fail when externalAddress is given without 'podNodePort'",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.PodService.ExternalAddress = "{{.PodName}}.example.com"
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"SourceRangesWithoutPodLoadBalancer: should fail when loadBalancerSourceRanges is given without "+
+					"'podLoadBalancer'",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.AerospikeNetworkPolicy.AccessType = asdbv1.AerospikeNetworkTypePodNodePort
+					aeroCluster.Spec.PodService.LoadBalancerSourceRanges = []string{"10.0.0.0/8"}
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"MissingGatewayTLSRoute: should fail when tlsAlternateAccess is 'gatewayTLSRoute' and "+
+					"gatewayTLSRoute is not given",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.AerospikeNetworkPolicy.TLSAlternateAccessType =
+						asdbv1.AerospikeNetworkTypeGatewayTLSRoute
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"NonUniqueTLSRouteHostname: should fail when gatewayTLSRoute hostname is not unique per pod",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.AerospikeNetworkPolicy.TLSAlternateAccessType =
+						asdbv1.AerospikeNetworkTypeGatewayTLSRoute
+					aeroCluster.Spec.GatewayTLSRoute = &asdbv1.GatewayTLSRouteSpec{
+						ParentRefs: []asdbv1.GatewayParentReference{{Name: "aerospike-gateway"}},
+						Hostname:   "aerospike.example.com",
+					}
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"InvalidTemplate: should fail when podService annotations use an unknown template variable",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.AerospikeNetworkPolicy.AccessType = asdbv1.AerospikeNetworkTypePodNodePort
+					aeroCluster.Spec.PodService.Metadata.Annotations = map[string]string{
+						"external-dns.alpha.kubernetes.io/hostname": "{{.NodeName}}.example.com",
+					}
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+		},
+	)
+
+	Context(
+		"Negative cases for IP families", func() {
+			clusterName := fmt.Sprintf("np-ip-family-%d", GinkgoParallelProcess())
+			clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+			It(
+				"IPFamilyWithConfiguredIP: should fail when accessIPFamily is given with 'configuredIP' access",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.AerospikeNetworkPolicy.AccessType = asdbv1.AerospikeNetworkTypeConfigured
+					aeroCluster.Spec.AerospikeNetworkPolicy.AccessIPFamily = corev1.IPv6Protocol
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"DuplicateServiceIPFamilies: should fail when serviceIPFamily ipFamilies are not unique",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.ServiceIPFamily = &asdbv1.ServiceIPFamilySpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv4Protocol},
+					}
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+
+			It(
+				"SingleStackWithTwoIPFamilies: should fail when 'SingleStack' ipFamilyPolicy has two ipFamilies",
+				func() {
+					aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+					aeroCluster.Spec.ServiceIPFamily = &asdbv1.ServiceIPFamilySpec{
+						IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack),
+						IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+					}
+					Expect(DeployCluster(k8sClient, ctx, aeroCluster)).Should(HaveOccurred())
+				},
+			)
+		},
+	)
 }
 
 func negativeUpdateNetworkPolicyTest(ctx goctx.Context) {
@@ -698,6 +813,48 @@ func doTestNetworkPolicy(
 		},
 	)
 
+	It(
+		"PodNodePort: should expose each pod through its own NodePort service", func() {
+			clusterName := fmt.Sprintf("np-pod-nodeport-%d", GinkgoParallelProcess())
+			clusterNamespacedName := test.GetNamespacedName(
+				clusterName, test.MultiClusterNs1,
+			)
+
+			networkPolicy := asdbv1.AerospikeNetworkPolicy{
+				AccessType:             asdbv1.AerospikeNetworkTypePod,
+				AlternateAccessType:    asdbv1.AerospikeNetworkTypePodNodePort,
+				TLSAccessType:          asdbv1.AerospikeNetworkTypePod,
+				TLSAlternateAccessType: asdbv1.AerospikeNetworkTypePodNodePort,
+			}
+			aeroCluster = getAerospikeClusterSpecWithNetworkPolicy(
+				clusterNamespacedName, &networkPolicy, multiPodPerHost,
+				enableTLS,
+			)
+			aeroCluster.Spec.PodService.Metadata.Annotations = map[string]string{
+				"test-rack-annotation": "rack-{{.RackID}}",
+			}
+
+			err := aerospikeClusterCreateUpdate(k8sClient, aeroCluster, ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = validateNetworkPolicy(ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			podList, err := getPodList(aeroCluster, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(podList.Items).ToNot(BeEmpty())
+
+			for idx := range podList.Items {
+				svc, err := getServiceForPod(&podList.Items[idx], k8sClient)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+				Expect(svc.Annotations).To(HaveKeyWithValue(
+					"test-rack-annotation", "rack-"+podList.Items[idx].Labels[asdbv1.AerospikeRackIDLabel],
+				))
+			}
+		},
+	)
+
 	It("PodOnlyNetwork: Should not set the hostPort", func() {
 		clusterName := fmt.Sprintf("pod-network-cluster-%d", GinkgoParallelProcess())
 		clusterNamespacedName := test.GetNamespacedName(
@@ -1239,6 +1396,60 @@ func validatePodEndpoint(
 				"expected host configured IP %v got %v", configuredIP, hostIPList[0],
 			)
 		}
+	case asdbv1.AerospikeNetworkTypePodLoadBalancer:
+		svc, err := getServiceForPod(pod, k8sClient)
+		if err != nil {
+			return err
+		}
+
+		if len(svc.Status.LoadBalancer.Ingress) == 0 {
+			return fmt.Errorf("load balancer ingress not assigned to service %s", svc.Name)
+		}
+
+		ingress := svc.Status.LoadBalancer.Ingress[0]
+		if ingress.IP != hostIPList[0] && ingress.Hostname != hostIPList[0] {
+			return fmt.Errorf(
+				"expected load balancer ingress %v got %v", ingress, hostIPList[0],
+			)
+		}
+	case asdbv1.AerospikeNetworkTypePodNodePort:
+		svc, err := getServiceForPod(pod, k8sClient)
+		if err != nil {
+			return err
+		}
+
+		expectedAddress := svc.Annotations[asdbv1.PodServiceExternalAddressAnnotation]
+		if expectedAddress == "" {
+			expectedAddress = hostExternalIP
+		}
+
+		if expectedAddress != hostIPList[0] {
+			return fmt.Errorf(
+				"expected pod service external address %v got %v", expectedAddress, hostIPList[0],
+			)
+		}
+	case asdbv1.AerospikeNetworkTypeGatewayTLSRoute:
+		rackID, _, err := utils.GetRackIDAndRevisionFromPodName(aeroCluster.Name, pod.Name)
+		if err != nil {
+			return err
+		}
+
+		expectedHostname, err := utils.ExpandPodTemplate(aeroCluster.Spec.GatewayTLSRoute.Hostname,
+			&utils.PodTemplateVars{
+				PodName:     pod.Name,
+				ClusterName: aeroCluster.Name,
+				Namespace:   aeroCluster.Namespace,
+				RackID:      rackID,
+			})
+		if err != nil {
+			return err
+		}
+
+		if expectedHostname != hostIPList[0] {
+			return fmt.Errorf(
+				"expected TLSRoute hostname %v got %v", expectedHostname, hostIPList[0],
+			)
+		}
 	case asdbv1.AerospikeNetworkTypeCustomInterface:
 		if !reflect.DeepEqual(customNetIP, hostIPList) {
 			return fmt.Errorf(
@@ -1278,8 +1489,18 @@ func getExpectedServicePortForPod(
 ) (int32, error) {
 	var port int32
 
-	if (networkType != asdbv1.AerospikeNetworkTypePod && networkType != asdbv1.AerospikeNetworkTypeCustomInterface) &&
-		asdbv1.GetBool(aeroCluster.Spec.PodSpec.MultiPodPerHost) {
+	if networkType == asdbv1.AerospikeNetworkTypeGatewayTLSRoute {
+		if aeroCluster.Spec.GatewayTLSRoute.Port != 0 {
+			return aeroCluster.Spec.GatewayTLSRoute.Port, nil
+		}
+
+		return asdbv1.DefaultGatewayTLSRoutePort, nil
+	}
+
+	if networkType == asdbv1.AerospikeNetworkTypePodNodePort ||
+		(networkType != asdbv1.AerospikeNetworkTypePod && networkType != asdbv1.AerospikeNetworkTypeCustomInterface &&
+			networkType != asdbv1.AerospikeNetworkTypePodLoadBalancer &&
+			asdbv1.GetBool(aeroCluster.Spec.PodSpec.MultiPodPerHost)) {
 		svc, err := getServiceForPod(pod, k8sClient)
 		if err != nil {
 			return 0, fmt.Errorf("error getting service port: %v", err)
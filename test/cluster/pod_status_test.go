@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
+)
+
+var _ = Describe(
+	"PodStatus", func() {
+		ctx := context.TODO()
+		clusterName := fmt.Sprintf("pod-status-%d", GinkgoParallelProcess())
+		clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+		AfterEach(func() {
+			aeroCluster := &asdbv1.AerospikeCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName,
+					Namespace: namespace,
+				},
+			}
+
+			Expect(DeleteCluster(k8sClient, ctx, aeroCluster)).NotTo(HaveOccurred())
+			Expect(CleanupPVC(k8sClient, aeroCluster.Namespace, aeroCluster.Name)).ToNot(HaveOccurred())
+		})
+
+		It("Should fold the published pod status into the cluster status", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			aeroCluster, err := getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			podList, err := getClusterPodList(k8sClient, ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(podList.Items).To(HaveLen(2))
+
+			for idx := range podList.Items {
+				pod := &podList.Items[idx]
+
+				podStatus, ok := aeroCluster.Status.Pods[pod.Name]
+				Expect(ok).To(BeTrue(), "pod %s not found in status", pod.Name)
+				Expect(podStatus.PodIP).To(Equal(pod.Status.PodIP))
+
+				confMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(
+					ctx, test.GetNamespacedName(asdbv1.GetPodStatusConfigMapName(pod.Name), namespace), confMap,
+				)).ToNot(HaveOccurred())
+				Expect(confMap.Data).ToNot(HaveKey(asdbv1.PodStatusConfigMapKey))
+				Expect(confMap.Data).ToNot(HaveKey(asdbv1.VolumeOperationsConfigMapKey))
+			}
+
+			Expect(aeroCluster.Status.VolumeOperations).To(BeEmpty())
+
+			By("Allowing the pods to patch only their pod status ConfigMaps")
+
+			role := &rbacv1.Role{}
+			Expect(k8sClient.Get(
+				ctx, test.GetNamespacedName(clusterName+asdbv1.PodStatusConfigMapSuffix, namespace), role,
+			)).ToNot(HaveOccurred())
+			Expect(role.Rules).To(HaveLen(1))
+			Expect(role.Rules[0].ResourceNames).To(ConsistOf(
+				asdbv1.GetPodStatusConfigMapName(podList.Items[0].Name),
+				asdbv1.GetPodStatusConfigMapName(podList.Items[1].Name),
+			))
+
+			By("Deleting the pod status ConfigMap of the scaled down pod")
+
+			aeroCluster.Spec.Size = 1
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			removedPodName := aeroCluster.Name + "-0-1"
+			confMap := &corev1.ConfigMap{}
+			err = k8sClient.Get(
+				ctx, test.GetNamespacedName(asdbv1.GetPodStatusConfigMapName(removedPodName), namespace), confMap,
+			)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	},
+)
@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	asdbv1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1"
+	"github.com/aerospike/aerospike-kubernetes-operator/v4/test"
+)
+
+var _ = Describe(
+	"ServiceMesh", func() {
+		ctx := context.TODO()
+		clusterName := fmt.Sprintf("service-mesh-%d", GinkgoParallelProcess())
+		clusterNamespacedName := test.GetNamespacedName(clusterName, namespace)
+
+		AfterEach(func() {
+			aeroCluster := &asdbv1.AerospikeCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName,
+					Namespace: namespace,
+				},
+			}
+
+			Expect(DeleteCluster(k8sClient, ctx, aeroCluster)).NotTo(HaveOccurred())
+			Expect(CleanupPVC(k8sClient, aeroCluster.Namespace, aeroCluster.Name)).ToNot(HaveOccurred())
+		})
+
+		It("Should add and remove the service mesh annotations on the pods", func() {
+			aeroCluster := createDummyAerospikeCluster(clusterNamespacedName, 2)
+			aeroCluster.Spec.PodSpec.ServiceMesh = &asdbv1.ServiceMeshSpec{
+				Provider: asdbv1.ServiceMeshLinkerd,
+			}
+			aeroCluster.Spec.PodSpec.AerospikeObjectMeta.Annotations = map[string]string{
+				"config.linkerd.io/skip-outbound-ports": "443",
+			}
+
+			Expect(DeployCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			inboundPorts := fmt.Sprintf("%d,%d", *asdbv1.GetFabricPort(aeroCluster.Spec.AerospikeConfig),
+				*asdbv1.GetHeartbeatPort(aeroCluster.Spec.AerospikeConfig))
+
+			podList, err := getClusterPodList(k8sClient, ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			for idx := range podList.Items {
+				annotations := podList.Items[idx].Annotations
+				Expect(annotations).To(HaveKeyWithValue("config.linkerd.io/skip-inbound-ports", inboundPorts))
+				// Annotations given by the user take precedence
+				Expect(annotations).To(HaveKeyWithValue("config.linkerd.io/skip-outbound-ports", "443"))
+			}
+
+			By("Removing serviceMesh")
+
+			aeroCluster, err = getCluster(k8sClient, ctx, clusterNamespacedName)
+			Expect(err).ToNot(HaveOccurred())
+
+			aeroCluster.Spec.PodSpec.ServiceMesh = nil
+			Expect(updateCluster(k8sClient, ctx, aeroCluster)).ToNot(HaveOccurred())
+
+			podList, err = getClusterPodList(k8sClient, ctx, aeroCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			for idx := range podList.Items {
+				Expect(podList.Items[idx].Annotations).ToNot(HaveKey("config.linkerd.io/skip-inbound-ports"))
+			}
+		})
+	},
+)
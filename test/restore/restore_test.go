@@ -8,9 +8,12 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	asdbv1beta1 "github.com/aerospike/aerospike-kubernetes-operator/v4/api/v1beta1"
+	backup_service "github.com/aerospike/aerospike-kubernetes-operator/v4/pkg/backup-service"
 )
 
 var _ = Describe(
@@ -87,6 +90,97 @@ var _ = Describe(
 					Expect(err).To(HaveOccurred())
 				})
 
+				It("Should not retry restore failed with a non transient error", func() {
+					config := getRestoreConfigWithWrongPassword(backupDataPath)
+
+					configBytes, mErr := json.Marshal(config)
+					Expect(mErr).ToNot(HaveOccurred())
+
+					restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Full, configBytes)
+					restore.Spec.RetryPolicy = &asdbv1beta1.RestoreRetryPolicy{
+						MaxAttempts:         2,
+						Backoff:             metav1.Duration{Duration: 5 * time.Second},
+						SkipExistingRecords: true,
+					}
+
+					err = k8sClient.Create(testCtx, restore)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = waitForRestorePhase(k8sClient, restore, asdbv1beta1.AerospikeRestoreFailed, 2*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+
+					// The wrong password fails the restore with an authentication error, which is not retried.
+					Expect(restore.Status.Attempts).To(HaveLen(1))
+					Expect(restore.Status.Attempts[0].Phase).To(Equal(asdbv1beta1.AerospikeRestoreFailed))
+					Expect(restore.Status.Attempts[0].SkippedExistingRecords).To(BeFalse())
+					Expect(restore.Status.NextAttemptTime).To(BeNil())
+				})
+
+				It("Should cancel restore and keep it", func() {
+					config := getRestoreConfigWithWrongPassword(backupDataPath)
+
+					configBytes, mErr := json.Marshal(config)
+					Expect(mErr).ToNot(HaveOccurred())
+
+					restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Full, configBytes)
+					restore.Spec.RetryPolicy = &asdbv1beta1.RestoreRetryPolicy{
+						MaxAttempts: 5,
+						Backoff:     metav1.Duration{Duration: 10 * time.Minute},
+					}
+
+					err = k8sClient.Create(testCtx, restore)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore, err = getRestoreObj(k8sClient, restoreNsNm)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore.Spec.Cancel = true
+
+					err = k8sClient.Update(testCtx, restore)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = waitForRestorePhase(k8sClient, restore, asdbv1beta1.AerospikeRestoreCancelled, time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore.Spec.Cancel = false
+
+					err = k8sClient.Update(testCtx, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cancel can not be unset once set"))
+				})
+
+				It("Should fail when retry policy skipExistingRecords is given with replace policy", func() {
+					config := getRestoreConfigInMap(backupDataPath)
+					config[asdbv1beta1.PolicyKey] = map[string]interface{}{"replace": true}
+
+					configBytes, mErr := json.Marshal(config)
+					Expect(mErr).ToNot(HaveOccurred())
+
+					restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Full, configBytes)
+					restore.Spec.RetryPolicy = &asdbv1beta1.RestoreRetryPolicy{
+						MaxAttempts:         2,
+						SkipExistingRecords: true,
+					}
+
+					err = k8sClient.Create(testCtx, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("retryPolicy skipExistingRecords is not supported"))
+				})
+
+				It("Should fail when retry policy resume is given without pointInTime", func() {
+					restore, err = newRestore(restoreNsNm, asdbv1beta1.Full)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore.Spec.RetryPolicy = &asdbv1beta1.RestoreRetryPolicy{
+						MaxAttempts: 2,
+						Resume:      true,
+					}
+
+					err = k8sClient.Create(testCtx, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("retryPolicy resume is only allowed along with pointInTime"))
+				})
+
 				It("Should fail when routine/time is not given for Timestamp restore type", func() {
 					// getRestoreConfigInMap returns restore config without a routine, time and with source type
 					restoreConfig := getRestoreConfigInMap(backupDataPath)
@@ -103,6 +197,29 @@ var _ = Describe(
 					Expect(err.Error()).To(ContainSubstring("empty field validation error: \"time\" required"))
 				})
 
+				It("Should fail when pointInTime is given for Full restore type", func() {
+					restore, err = newRestore(restoreNsNm, asdbv1beta1.Full)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore.Spec.PointInTime = getPointInTime()
+
+					err = createRestore(k8sClient, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("pointInTime is only allowed for restore type"))
+				})
+
+				It("Should fail when routine field is given in restore config along with pointInTime", func() {
+					configBytes, mErr := getTimeStampRestoreConfigBytes(getRestoreConfigInMap(backupDataPath))
+					Expect(mErr).ToNot(HaveOccurred())
+
+					restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Timestamp, configBytes)
+					restore.Spec.PointInTime = getPointInTime()
+
+					err = createRestore(k8sClient, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("field is not allowed in restore config along with pointInTime"))
+				})
+
 				It("Should fail when source field is given for Timestamp restore type", func() {
 					restore, err = newRestore(restoreNsNm, asdbv1beta1.Timestamp)
 					Expect(err).ToNot(HaveOccurred())
@@ -139,6 +256,64 @@ var _ = Describe(
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("time field is not allowed in restore config"))
 				})
+
+				It("Should fail when invalid destination cluster spec is given", func() {
+					configBytes, mErr := json.Marshal(getRestoreConfigInMap(backupDataPath))
+					Expect(mErr).ToNot(HaveOccurred())
+
+					restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Full, configBytes)
+					restore.Spec.DestinationCluster = &asdbv1beta1.RestoreDestinationCluster{
+						Name: "restore-destination",
+						Spec: runtime.RawExtension{Raw: []byte(`{"size": "two"}`)},
+					}
+
+					err = createRestore(k8sClient, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("invalid destinationCluster spec"))
+				})
+
+				It("Should fail when destination-name is given with destination cluster", func() {
+					restoreConfig := getRestoreConfigInMap(backupDataPath)
+					delete(restoreConfig, asdbv1beta1.DestinationKey)
+					restoreConfig[asdbv1beta1.DestinationNameKey] = "test-cluster"
+
+					configBytes, mErr := json.Marshal(restoreConfig)
+					Expect(mErr).ToNot(HaveOccurred())
+
+					restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Full, configBytes)
+					restore.Spec.DestinationCluster = &asdbv1beta1.RestoreDestinationCluster{
+						Name: "restore-destination",
+						Spec: runtime.RawExtension{Raw: []byte(`{"size": 2}`)},
+					}
+
+					err = createRestore(k8sClient, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("destination-name field is not allowed"))
+				})
+
+				It("Should fail when verification is given without cluster name", func() {
+					restore, err = newRestore(restoreNsNm, asdbv1beta1.Full)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore.Spec.Verification = &asdbv1beta1.RestoreVerification{}
+
+					err = createRestore(k8sClient, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("verification clusterName is required"))
+				})
+
+				It("Should fail when verification namespaces are not given for Timestamp restore type", func() {
+					restore, err = newRestore(restoreNsNm, asdbv1beta1.Timestamp)
+					Expect(err).ToNot(HaveOccurred())
+
+					restore.Spec.Verification = &asdbv1beta1.RestoreVerification{
+						ClusterName: "aerocluster",
+					}
+
+					err = createRestore(k8sClient, restore)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("verification namespaces are required"))
+				})
 			})
 
 		Context(
@@ -197,6 +372,31 @@ var _ = Describe(
 					},
 				)
 
+				It(
+					"Should complete point in time restore with the planned backup chain", func() {
+						restoreConfig := getRestoreConfigInMap(backupDataPath)
+						delete(restoreConfig, asdbv1beta1.SourceKey)
+						delete(restoreConfig, asdbv1beta1.BackupDataPathKey)
+
+						configBytes, mErr := getRestoreConfBytes(restoreConfig)
+						Expect(mErr).ToNot(HaveOccurred())
+
+						restore = newRestoreWithConfig(restoreNsNm, asdbv1beta1.Timestamp, configBytes)
+						restore.Spec.PointInTime = getPointInTime()
+
+						err = createRestore(k8sClient, restore)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(restore.Status.RestoreChain).ToNot(BeNil())
+						Expect(restore.Status.RestoreChain.FullBackup.Keys).ToNot(BeEmpty())
+						Expect(restore.Status.RestoreChain.RestoredKeys).To(Equal(
+							backup_service.GetRestoreChainKeys(restore.Status.RestoreChain)))
+
+						err = validateRestoredData(k8sClient)
+						Expect(err).ToNot(HaveOccurred())
+					},
+				)
+
 				It(
 					"Should complete restore for Timestamp restore type and with TLS configured", func() {
 						configBytes, err := getTimeStampRestoreConfigBytes(getRestoreConfigWithTLSInMap(backupDataPath))
@@ -229,3 +429,15 @@ func getTimeStampRestoreConfigBytes(restoreConfig map[string]interface{}) (confi
 
 	return getRestoreConfBytes(restoreConfig)
 }
+
+func getPointInTime() *asdbv1beta1.RestorePointInTime {
+	parts := strings.Split(backupDataPath, "/")
+	timeInt, err := strconv.ParseInt(parts[len(parts)-3], 10, 64)
+	Expect(err).ToNot(HaveOccurred())
+
+	// increase time by 1 millisecond to consider the latest backup under time bound
+	return &asdbv1beta1.RestorePointInTime{
+		Routine: parts[len(parts)-5],
+		Time:    metav1.NewTime(time.UnixMilli(timeInt + 1)),
+	}
+}
@@ -211,6 +211,29 @@ func waitForRestore(cl client.Client, restore *asdbv1beta1.AerospikeRestore,
 	return nil
 }
 
+func waitForRestorePhase(cl client.Client, restore *asdbv1beta1.AerospikeRestore,
+	phase asdbv1beta1.AerospikeRestorePhase, timeout time.Duration) error {
+	namespaceName := types.NamespacedName{
+		Name: restore.Name, Namespace: restore.Namespace,
+	}
+
+	return wait.PollUntilContextTimeout(
+		testCtx, 1*time.Second,
+		timeout, true, func(ctx context.Context) (bool, error) {
+			if err := cl.Get(ctx, namespaceName, restore); err != nil {
+				return false, nil
+			}
+
+			if restore.Status.Phase != phase {
+				pkgLog.Info(fmt.Sprintf("Restore is in %s phase, waiting for %s phase", restore.Status.Phase, phase))
+				return false, nil
+			}
+
+			return true, nil
+		},
+	)
+}
+
 func getRestoreConfBytes(restoreConfig map[string]interface{}) ([]byte, error) {
 	configBytes, err := json.Marshal(restoreConfig)
 	if err != nil {
@@ -272,6 +295,22 @@ func getRestoreConfigWithTLSInMap(backupPath string) map[string]interface{} {
 	return restoreConfig
 }
 
+// getRestoreConfigWithWrongPassword returns a restore config which is accepted by the backup service,
+// but the restore job fails. The no-generation policy is removed to allow skipping the existing records on a retry.
+func getRestoreConfigWithWrongPassword(backupPath string) map[string]interface{} {
+	restoreConfig := getRestoreConfigInMap(backupPath)
+
+	restoreDestination := restoreConfig["destination"].(map[string]interface{})
+	restoreDestination["credentials"] = map[string]interface{}{
+		"password": "wrong-password",
+		"user":     "admin",
+	}
+
+	delete(restoreConfig["policy"].(map[string]interface{}), "no-generation")
+
+	return restoreConfig
+}
+
 func validateRestoredData(k8sClient client.Client) error {
 	aeroCluster, err := cluster.GetCluster(k8sClient, testCtx, destinationAerospikeClusterNsNm)
 	if err != nil {
@@ -10,8 +10,9 @@ set -e
 #  test.sh -b aerospike/aerospike-kubernetes-operator-bundle:1.1.0
 #  test.sh -b aerospike/aerospike-kubernetes-operator-bundle:1.1.0 -f ".*RackManagement.*" -a "--connect-through-network-type=hostInternal"
 #  test.sh -b <IMAGE> -f "<GINKGO-FOCUS-REGEXP>" -a "<PASS-THROUGHS>"
+#  test.sh -b <IMAGE> -d <INIT-IMAGE-NAME-TAG-WITH-AEROSPIKE-POD-INIT>
 
-while getopts "b:f:a:r:p:n:i:t:" opt
+while getopts "b:f:a:r:p:n:i:t:d:" opt
 do
    case "$opt" in
       b ) BUNDLE="$OPTARG" ;;
@@ -22,6 +23,7 @@ do
       n ) REGISTRY_NAMESPACE="$OPTARG" ;;
       i ) INIT_IMAGE_NAME_TAG="$OPTARG" ;;
       t ) TEST_TYPE="$OPTARG" ;;
+      d ) POD_INIT_IMAGE_NAME_TAG="$OPTARG" ;;
 
    esac
 done
@@ -68,6 +70,11 @@ export CUSTOM_INIT_REGISTRY_NAMESPACE="$REGISTRY_NAMESPACE"
 export CUSTOM_INIT_NAME_TAG="$INIT_IMAGE_NAME_TAG"
 export IMAGE_PULL_SECRET_NAME="$IMAGE_PULL_SECRET"
 
+# Init image built with Dockerfile.init, the tests needing the aerospike-pod-init binary are skipped if not set.
+if [ -n "$POD_INIT_IMAGE_NAME_TAG" ]; then
+   export POD_INIT_NAME_TAG="$POD_INIT_IMAGE_NAME_TAG"
+fi
+
 if [ "$TEST_TYPE" = "cluster-test" ]; then
    make cluster-test FOCUS="$FOCUS" ARGS="$ARGS"
 elif [ "$TEST_TYPE" = "backup-test" ]; then